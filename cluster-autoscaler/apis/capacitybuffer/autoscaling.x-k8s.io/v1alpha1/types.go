@@ -95,12 +95,14 @@ type ScalableRef struct {
 type ResourceList map[ResourceName]resource.Quantity
 
 // CapacityBufferSpec defines the desired state of CapacityBuffer.
-// +kubebuilder:validation:XValidation:rule="!has(self.podTemplateRef) || has(self.replicas) || has(self.limits)",message="If X is set, replicas or limits must also be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.podTemplateRef) || has(self.replicas) || has(self.limits) || has(self.schedule)",message="If podTemplateRef is set, replicas, limits or schedule must also be set"
 // +kubebuilder:validation:XValidation:rule="!(has(self.podTemplateRef) && has(self.scalableRef))",message="You must define both PodTemplateRef and ScalableRef"
 type CapacityBufferSpec struct {
 	// ProvisioningStrategy defines how the buffer is utilized.
 	// "buffer.x-k8s.io/active-capacity" is the default strategy, where the buffer actively scales up the cluster by creating placeholder pods.
-	// +kubebuilder:validation:Enum=buffer.x-k8s.io/active-capacity
+	// "buffer.x-k8s.io/standby-capacity" keeps the buffer as stopped or hibernated instances which can be resumed quickly,
+	// it requires the cloud provider to support standby instances.
+	// +kubebuilder:validation:Enum=buffer.x-k8s.io/active-capacity;buffer.x-k8s.io/standby-capacity
 	// +kubebuilder:default="buffer.x-k8s.io/active-capacity"
 	// +optional
 	ProvisioningStrategy *string `json:"provisioningStrategy,omitempty" protobuf:"bytes,1,opt,name=provisioningStrategy"`
@@ -145,6 +147,49 @@ type CapacityBufferSpec struct {
 	// this will be used to create as many chunks as fit into these limits.
 	// +optional
 	Limits *ResourceList `json:"limits,omitempty" protobuf:"bytes,6,opt,name=limits"`
+
+	// Schedule, if specified, changes the size of the buffer over time. While one
+	// of its windows is open, its `replicas` and `percentage` are used instead of
+	// the ones of the buffer. If more than one window is open, the first one in
+	// the list is used. While no window is open, the buffer has no capacity
+	// unless its own `replicas` or `percentage` are set. `limits` apply
+	// regardless of the schedule.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Schedule []ScheduleWindow `json:"schedule,omitempty" protobuf:"bytes,7,rep,name=schedule"`
+}
+
+// ScheduleWindow is a recurring period of time with its own buffer size.
+// +kubebuilder:validation:XValidation:rule="has(self.replicas) || has(self.percentage)",message="replicas or percentage must be set"
+type ScheduleWindow struct {
+	// Name of the window, reported in the buffer status while the window applies.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+
+	// Cron is the schedule at which the window opens, as a cron expression in the
+	// standard five field format, e.g. "0 9 * * 1-5" for 9:00 on every weekday.
+	// It's evaluated in UTC, unless prefixed with "CRON_TZ=<time zone>".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Cron string `json:"cron" protobuf:"bytes,2,opt,name=cron"`
+
+	// Duration is how long the window stays open, e.g. "8h".
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration" protobuf:"bytes,3,opt,name=duration"`
+
+	// Replicas defines the desired number of buffer chunks while the window is open.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty" protobuf:"varint,4,opt,name=replicas"`
+
+	// Percentage defines the desired buffer capacity as a percentage of the
+	// `scalableRef`'s current replicas while the window is open.
+	// Windows of buffers using a `podTemplateRef` must set replicas.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Percentage *int32 `json:"percentage,omitempty" protobuf:"varint,5,opt,name=percentage"`
 }
 
 // CapacityBufferStatus defines the observed state of CapacityBuffer.
@@ -177,6 +222,16 @@ type CapacityBufferStatus struct {
 	// ProvisioningStrategy defines how the buffer should be utilized.
 	// +optional
 	ProvisioningStrategy *string `json:"provisioningStrategy,omitempty" protobuf:"bytes,5,opt,name=provisioningStrategy"`
+
+	// ActiveScheduleWindow is the name of the `spec.schedule` window the buffer
+	// size is currently taken from. It isn't set if no window is open.
+	// +optional
+	ActiveScheduleWindow *string `json:"activeScheduleWindow,omitempty" protobuf:"bytes,6,opt,name=activeScheduleWindow"`
+
+	// NextScheduleChangeTime is the time at which the schedule window applying
+	// to the buffer changes next. It isn't set if the buffer has no schedule.
+	// +optional
+	NextScheduleChangeTime *metav1.Time `json:"nextScheduleChangeTime,omitempty" protobuf:"bytes,7,opt,name=nextScheduleChangeTime"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
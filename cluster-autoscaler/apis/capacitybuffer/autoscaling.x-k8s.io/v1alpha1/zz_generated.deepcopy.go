@@ -127,6 +127,13 @@ func (in *CapacityBufferSpec) DeepCopyInto(out *CapacityBufferSpec) {
 			}
 		}
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = make([]ScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -170,6 +177,15 @@ func (in *CapacityBufferStatus) DeepCopyInto(out *CapacityBufferStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveScheduleWindow != nil {
+		in, out := &in.ActiveScheduleWindow, &out.ActiveScheduleWindow
+		*out = new(string)
+		**out = **in
+	}
+	if in.NextScheduleChangeTime != nil {
+		in, out := &in.NextScheduleChangeTime, &out.NextScheduleChangeTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -236,3 +252,30 @@ func (in *ScalableRef) DeepCopy() *ScalableRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
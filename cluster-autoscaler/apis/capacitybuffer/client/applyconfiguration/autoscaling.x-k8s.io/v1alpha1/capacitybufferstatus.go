@@ -19,17 +19,20 @@ limitations under the License.
 package v1alpha1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // CapacityBufferStatusApplyConfiguration represents a declarative configuration of the CapacityBufferStatus type for use
 // with apply.
 type CapacityBufferStatusApplyConfiguration struct {
-	PodTemplateRef        *LocalObjectRefApplyConfiguration `json:"podTemplateRef,omitempty"`
-	Replicas              *int32                            `json:"replicas,omitempty"`
-	PodTemplateGeneration *int64                            `json:"podTemplateGeneration,omitempty"`
-	Conditions            []v1.ConditionApplyConfiguration  `json:"conditions,omitempty"`
-	ProvisioningStrategy  *string                           `json:"provisioningStrategy,omitempty"`
+	PodTemplateRef         *LocalObjectRefApplyConfiguration `json:"podTemplateRef,omitempty"`
+	Replicas               *int32                            `json:"replicas,omitempty"`
+	PodTemplateGeneration  *int64                            `json:"podTemplateGeneration,omitempty"`
+	Conditions             []v1.ConditionApplyConfiguration  `json:"conditions,omitempty"`
+	ProvisioningStrategy   *string                           `json:"provisioningStrategy,omitempty"`
+	ActiveScheduleWindow   *string                           `json:"activeScheduleWindow,omitempty"`
+	NextScheduleChangeTime *metav1.Time                      `json:"nextScheduleChangeTime,omitempty"`
 }
 
 // CapacityBufferStatusApplyConfiguration constructs a declarative configuration of the CapacityBufferStatus type for use with
@@ -82,3 +85,19 @@ func (b *CapacityBufferStatusApplyConfiguration) WithProvisioningStrategy(value
 	b.ProvisioningStrategy = &value
 	return b
 }
+
+// WithActiveScheduleWindow sets the ActiveScheduleWindow field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ActiveScheduleWindow field is set to the value of the last call.
+func (b *CapacityBufferStatusApplyConfiguration) WithActiveScheduleWindow(value string) *CapacityBufferStatusApplyConfiguration {
+	b.ActiveScheduleWindow = &value
+	return b
+}
+
+// WithNextScheduleChangeTime sets the NextScheduleChangeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextScheduleChangeTime field is set to the value of the last call.
+func (b *CapacityBufferStatusApplyConfiguration) WithNextScheduleChangeTime(value metav1.Time) *CapacityBufferStatusApplyConfiguration {
+	b.NextScheduleChangeTime = &value
+	return b
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduleWindowApplyConfiguration represents a declarative configuration of the ScheduleWindow type for use
+// with apply.
+type ScheduleWindowApplyConfiguration struct {
+	Name       *string      `json:"name,omitempty"`
+	Cron       *string      `json:"cron,omitempty"`
+	Duration   *v1.Duration `json:"duration,omitempty"`
+	Replicas   *int32       `json:"replicas,omitempty"`
+	Percentage *int32       `json:"percentage,omitempty"`
+}
+
+// ScheduleWindowApplyConfiguration constructs a declarative configuration of the ScheduleWindow type for use with
+// apply.
+func ScheduleWindow() *ScheduleWindowApplyConfiguration {
+	return &ScheduleWindowApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ScheduleWindowApplyConfiguration) WithName(value string) *ScheduleWindowApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithCron sets the Cron field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cron field is set to the value of the last call.
+func (b *ScheduleWindowApplyConfiguration) WithCron(value string) *ScheduleWindowApplyConfiguration {
+	b.Cron = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *ScheduleWindowApplyConfiguration) WithDuration(value v1.Duration) *ScheduleWindowApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *ScheduleWindowApplyConfiguration) WithReplicas(value int32) *ScheduleWindowApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithPercentage sets the Percentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Percentage field is set to the value of the last call.
+func (b *ScheduleWindowApplyConfiguration) WithPercentage(value int32) *ScheduleWindowApplyConfiguration {
+	b.Percentage = &value
+	return b
+}
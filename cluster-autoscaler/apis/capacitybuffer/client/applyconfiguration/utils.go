@@ -42,6 +42,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &autoscalingxk8siov1alpha1.LocalObjectRefApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ScalableRef"):
 		return &autoscalingxk8siov1alpha1.ScalableRefApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScheduleWindow"):
+		return &autoscalingxk8siov1alpha1.ScheduleWindowApplyConfiguration{}
 
 	}
 	return nil
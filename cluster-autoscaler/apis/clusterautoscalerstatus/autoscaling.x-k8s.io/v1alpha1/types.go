@@ -0,0 +1,344 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clusterautoscalerstatuses,scope=Namespaced,shortName=cas
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.autoscalerState",description="Whether Cluster Autoscaler is initializing or running."
+// +kubebuilder:printcolumn:name="Health",type="string",JSONPath=".status.clusterWide.health.status",description="The health of the cluster."
+// +kubebuilder:printcolumn:name="ScaleUp",type="string",JSONPath=".status.clusterWide.scaleUp.status",description="The scale-up status of the cluster."
+// +kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastUpdateTime",description="The time the status was last updated."
+// +k8s:openapi-gen=true
+
+// ClusterAutoscalerStatus is the status of a Cluster Autoscaler instance and of
+// the node groups it manages. It is written by Cluster Autoscaler, and holds the
+// same information as the cluster-autoscaler-status ConfigMap in a typed form.
+type ClusterAutoscalerStatus struct {
+	// Standard Kubernetes object metadata.
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Status is the status observed by Cluster Autoscaler.
+	// +optional
+	Status ClusterAutoscalerStatusStatus `json:"status,omitempty" protobuf:"bytes,2,opt,name=status"`
+}
+
+// AutoscalerState is the state of Cluster Autoscaler.
+// +kubebuilder:validation:Enum=Initializing;Running
+type AutoscalerState string
+
+const (
+	// AutoscalerInitializing means that Cluster Autoscaler is being initialized.
+	AutoscalerInitializing AutoscalerState = "Initializing"
+	// AutoscalerRunning means that Cluster Autoscaler has been initialized and is running.
+	AutoscalerRunning AutoscalerState = "Running"
+)
+
+// HealthStatus is the health of the cluster or of a node group.
+// +kubebuilder:validation:Enum=Healthy;Unhealthy
+type HealthStatus string
+
+const (
+	// Healthy means that enough nodes are ready for Cluster Autoscaler to operate.
+	Healthy HealthStatus = "Healthy"
+	// Unhealthy means that too many nodes are unready or unregistered, and Cluster
+	// Autoscaler doesn't scale the cluster or the node group.
+	Unhealthy HealthStatus = "Unhealthy"
+)
+
+// ScaleUpStatus is the scale-up status of the cluster or of a node group.
+// +kubebuilder:validation:Enum=Needed;NotNeeded;InProgress;NoActivity;Backoff;Unhealthy
+type ScaleUpStatus string
+
+const (
+	// ScaleUpNeeded means that a scale-up is needed.
+	ScaleUpNeeded ScaleUpStatus = "Needed"
+	// ScaleUpNotNeeded means that no scale-up is needed.
+	ScaleUpNotNeeded ScaleUpStatus = "NotNeeded"
+	// ScaleUpInProgress means that a scale-up is in progress.
+	ScaleUpInProgress ScaleUpStatus = "InProgress"
+	// ScaleUpNoActivity means that there was no recent scale-up activity.
+	ScaleUpNoActivity ScaleUpStatus = "NoActivity"
+	// ScaleUpBackoff means that the node group isn't scaled up for some time due to
+	// a recently failed scale-up.
+	ScaleUpBackoff ScaleUpStatus = "Backoff"
+	// ScaleUpUnhealthy means that the node group isn't scaled up because it's unhealthy.
+	ScaleUpUnhealthy ScaleUpStatus = "Unhealthy"
+)
+
+// ScaleDownStatus is the scale-down status of the cluster or of a node group.
+// +kubebuilder:validation:Enum=CandidatesPresent;NoCandidates
+type ScaleDownStatus string
+
+const (
+	// ScaleDownCandidatesPresent means that there are nodes which can be scaled down.
+	ScaleDownCandidatesPresent ScaleDownStatus = "CandidatesPresent"
+	// ScaleDownNoCandidates means that no node can be scaled down.
+	ScaleDownNoCandidates ScaleDownStatus = "NoCandidates"
+)
+
+// ClusterAutoscalerStatusStatus is the status observed by Cluster Autoscaler.
+type ClusterAutoscalerStatusStatus struct {
+	// AutoscalerState is whether Cluster Autoscaler is initializing or running.
+	// +optional
+	AutoscalerState AutoscalerState `json:"autoscalerState,omitempty" protobuf:"bytes,1,opt,name=autoscalerState,casttype=AutoscalerState"`
+
+	// Message contains extra information about the status, if any.
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
+
+	// LastUpdateTime is the time the status was last updated.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty" protobuf:"bytes,3,opt,name=lastUpdateTime"`
+
+	// ClusterWide is the status of the whole cluster.
+	// +optional
+	ClusterWide ClusterWideStatus `json:"clusterWide,omitempty" protobuf:"bytes,4,opt,name=clusterWide"`
+
+	// NodeGroups is the status of each node group managed by Cluster Autoscaler.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	NodeGroups []NodeGroupStatus `json:"nodeGroups,omitempty" protobuf:"bytes,5,rep,name=nodeGroups"`
+}
+
+// ClusterWideStatus is the status of the whole cluster.
+type ClusterWideStatus struct {
+	// Health is the health of the cluster.
+	// +optional
+	Health ClusterHealth `json:"health,omitempty" protobuf:"bytes,1,opt,name=health"`
+
+	// ScaleUp is the scale-up status of the cluster.
+	// +optional
+	ScaleUp ClusterScaleUp `json:"scaleUp,omitempty" protobuf:"bytes,2,opt,name=scaleUp"`
+
+	// ScaleDown is the scale-down status of the cluster.
+	// +optional
+	ScaleDown ScaleDown `json:"scaleDown,omitempty" protobuf:"bytes,3,opt,name=scaleDown"`
+}
+
+// NodeGroupStatus is the status of a node group.
+type NodeGroupStatus struct {
+	// Name is the name of the node group.
+	// +kubebuilder:validation:Required
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+
+	// Health is the health and size of the node group.
+	// +optional
+	Health NodeGroupHealth `json:"health,omitempty" protobuf:"bytes,2,opt,name=health"`
+
+	// ScaleUp is the scale-up status of the node group.
+	// +optional
+	ScaleUp NodeGroupScaleUp `json:"scaleUp,omitempty" protobuf:"bytes,3,opt,name=scaleUp"`
+
+	// ScaleDown is the scale-down status of the node group.
+	// +optional
+	ScaleDown ScaleDown `json:"scaleDown,omitempty" protobuf:"bytes,4,opt,name=scaleDown"`
+}
+
+// NodeCounts are the numbers of nodes in the cluster or in a node group.
+type NodeCounts struct {
+	// Registered are the numbers of nodes registered in the cluster.
+	// +optional
+	Registered RegisteredNodeCounts `json:"registered,omitempty" protobuf:"bytes,1,opt,name=registered"`
+
+	// LongUnregistered is the number of nodes which failed to register in time.
+	// +optional
+	LongUnregistered int32 `json:"longUnregistered,omitempty" protobuf:"varint,2,opt,name=longUnregistered"`
+
+	// Unregistered is the number of nodes which exist in the cloud provider, but
+	// didn't register in the cluster yet.
+	// +optional
+	Unregistered int32 `json:"unregistered,omitempty" protobuf:"varint,3,opt,name=unregistered"`
+}
+
+// RegisteredNodeCounts are the numbers of nodes registered in the cluster.
+type RegisteredNodeCounts struct {
+	// Total is the number of registered nodes.
+	// +optional
+	Total int32 `json:"total,omitempty" protobuf:"varint,1,opt,name=total"`
+
+	// Ready is the number of ready nodes.
+	// +optional
+	Ready int32 `json:"ready,omitempty" protobuf:"varint,2,opt,name=ready"`
+
+	// NotStarted is the number of nodes which didn't become ready yet.
+	// +optional
+	NotStarted int32 `json:"notStarted,omitempty" protobuf:"varint,3,opt,name=notStarted"`
+
+	// BeingDeleted is the number of nodes being deleted. They aren't included in
+	// the target size of their node group.
+	// +optional
+	BeingDeleted int32 `json:"beingDeleted,omitempty" protobuf:"varint,4,opt,name=beingDeleted"`
+
+	// Unready is the number of unready nodes.
+	// +optional
+	Unready int32 `json:"unready,omitempty" protobuf:"varint,5,opt,name=unready"`
+
+	// ResourceUnready is the number of nodes which are unready because a resource,
+	// e.g. a GPU, isn't available yet.
+	// +optional
+	ResourceUnready int32 `json:"resourceUnready,omitempty" protobuf:"varint,6,opt,name=resourceUnready"`
+}
+
+// ClusterHealth is the health of the cluster.
+type ClusterHealth struct {
+	// Status is the health of the cluster.
+	// +optional
+	Status HealthStatus `json:"status,omitempty" protobuf:"bytes,1,opt,name=status,casttype=HealthStatus"`
+
+	// NodeCounts are the numbers of nodes in the cluster.
+	// +optional
+	NodeCounts NodeCounts `json:"nodeCounts,omitempty" protobuf:"bytes,2,opt,name=nodeCounts"`
+
+	// LastProbeTime is the last time the health was checked.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" protobuf:"bytes,3,opt,name=lastProbeTime"`
+
+	// LastTransitionTime is the last time the health changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
+}
+
+// NodeGroupHealth is the health and size of a node group.
+type NodeGroupHealth struct {
+	// Status is the health of the node group.
+	// +optional
+	Status HealthStatus `json:"status,omitempty" protobuf:"bytes,1,opt,name=status,casttype=HealthStatus"`
+
+	// NodeCounts are the numbers of nodes in the node group.
+	// +optional
+	NodeCounts NodeCounts `json:"nodeCounts,omitempty" protobuf:"bytes,2,opt,name=nodeCounts"`
+
+	// CloudProviderTarget is the target size of the node group in the cloud provider.
+	// +optional
+	CloudProviderTarget int32 `json:"cloudProviderTarget,omitempty" protobuf:"varint,3,opt,name=cloudProviderTarget"`
+
+	// MinSize is the minimum size of the node group.
+	// +optional
+	MinSize int32 `json:"minSize,omitempty" protobuf:"varint,4,opt,name=minSize"`
+
+	// ScheduledMinSize is the minimum size of the node group raised by an active
+	// schedule, if any.
+	// +optional
+	ScheduledMinSize int32 `json:"scheduledMinSize,omitempty" protobuf:"varint,5,opt,name=scheduledMinSize"`
+
+	// MaxSize is the maximum size of the node group.
+	// +optional
+	MaxSize int32 `json:"maxSize,omitempty" protobuf:"varint,6,opt,name=maxSize"`
+
+	// LastProbeTime is the last time the health was checked.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" protobuf:"bytes,7,opt,name=lastProbeTime"`
+
+	// LastTransitionTime is the last time the health changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,8,opt,name=lastTransitionTime"`
+}
+
+// ClusterScaleUp is the scale-up status of the cluster.
+type ClusterScaleUp struct {
+	// Status is the scale-up status of the cluster.
+	// +optional
+	Status ScaleUpStatus `json:"status,omitempty" protobuf:"bytes,1,opt,name=status,casttype=ScaleUpStatus"`
+
+	// LastScaleUpTime is the last time any node group was scaled up.
+	// +optional
+	LastScaleUpTime *metav1.Time `json:"lastScaleUpTime,omitempty" protobuf:"bytes,2,opt,name=lastScaleUpTime"`
+
+	// LastProbeTime is the last time the status was checked.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" protobuf:"bytes,3,opt,name=lastProbeTime"`
+
+	// LastTransitionTime is the last time the status changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
+}
+
+// NodeGroupScaleUp is the scale-up status of a node group.
+type NodeGroupScaleUp struct {
+	// Status is the scale-up status of the node group.
+	// +optional
+	Status ScaleUpStatus `json:"status,omitempty" protobuf:"bytes,1,opt,name=status,casttype=ScaleUpStatus"`
+
+	// Backoff is the error which caused the node group to be backed off, if the
+	// status is Backoff.
+	// +optional
+	Backoff *Backoff `json:"backoff,omitempty" protobuf:"bytes,2,opt,name=backoff"`
+
+	// LastScaleUpTime is the last time the node group was scaled up.
+	// +optional
+	LastScaleUpTime *metav1.Time `json:"lastScaleUpTime,omitempty" protobuf:"bytes,3,opt,name=lastScaleUpTime"`
+
+	// LastProbeTime is the last time the status was checked.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" protobuf:"bytes,4,opt,name=lastProbeTime"`
+
+	// LastTransitionTime is the last time the status changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,5,opt,name=lastTransitionTime"`
+}
+
+// Backoff is the error which caused a node group to be backed off.
+type Backoff struct {
+	// ErrorCode is the code of the error, specific to the cloud provider.
+	// +optional
+	ErrorCode string `json:"errorCode,omitempty" protobuf:"bytes,1,opt,name=errorCode"`
+
+	// ErrorMessage is a human readable description of the error.
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty" protobuf:"bytes,2,opt,name=errorMessage"`
+}
+
+// ScaleDown is the scale-down status of the cluster or of a node group.
+type ScaleDown struct {
+	// Status is the scale-down status.
+	// +optional
+	Status ScaleDownStatus `json:"status,omitempty" protobuf:"bytes,1,opt,name=status,casttype=ScaleDownStatus"`
+
+	// Candidates is the number of nodes which can be scaled down.
+	// +optional
+	Candidates int32 `json:"candidates,omitempty" protobuf:"varint,2,opt,name=candidates"`
+
+	// LastScaleDownTime is the last time a node was scaled down.
+	// +optional
+	LastScaleDownTime *metav1.Time `json:"lastScaleDownTime,omitempty" protobuf:"bytes,3,opt,name=lastScaleDownTime"`
+
+	// LastProbeTime is the last time the status was checked.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" protobuf:"bytes,4,opt,name=lastProbeTime"`
+
+	// LastTransitionTime is the last time the status changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" protobuf:"bytes,5,opt,name=lastTransitionTime"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterAutoscalerStatusList contains a list of ClusterAutoscalerStatus objects.
+type ClusterAutoscalerStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []ClusterAutoscalerStatus `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// BackoffApplyConfiguration represents a declarative configuration of the Backoff type for use
+// with apply.
+type BackoffApplyConfiguration struct {
+	ErrorCode    *string `json:"errorCode,omitempty"`
+	ErrorMessage *string `json:"errorMessage,omitempty"`
+}
+
+// BackoffApplyConfiguration constructs a declarative configuration of the Backoff type for use with
+// apply.
+func Backoff() *BackoffApplyConfiguration {
+	return &BackoffApplyConfiguration{}
+}
+
+// WithErrorCode sets the ErrorCode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ErrorCode field is set to the value of the last call.
+func (b *BackoffApplyConfiguration) WithErrorCode(value string) *BackoffApplyConfiguration {
+	b.ErrorCode = &value
+	return b
+}
+
+// WithErrorMessage sets the ErrorMessage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ErrorMessage field is set to the value of the last call.
+func (b *BackoffApplyConfiguration) WithErrorMessage(value string) *BackoffApplyConfiguration {
+	b.ErrorMessage = &value
+	return b
+}
@@ -0,0 +1,85 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+)
+
+// ClusterAutoscalerStatusStatusApplyConfiguration represents a declarative configuration of the ClusterAutoscalerStatusStatus type for use
+// with apply.
+type ClusterAutoscalerStatusStatusApplyConfiguration struct {
+	AutoscalerState *autoscalingxk8siov1alpha1.AutoscalerState `json:"autoscalerState,omitempty"`
+	Message         *string                                    `json:"message,omitempty"`
+	LastUpdateTime  *v1.Time                                   `json:"lastUpdateTime,omitempty"`
+	ClusterWide     *ClusterWideStatusApplyConfiguration       `json:"clusterWide,omitempty"`
+	NodeGroups      []NodeGroupStatusApplyConfiguration        `json:"nodeGroups,omitempty"`
+}
+
+// ClusterAutoscalerStatusStatusApplyConfiguration constructs a declarative configuration of the ClusterAutoscalerStatusStatus type for use with
+// apply.
+func ClusterAutoscalerStatusStatus() *ClusterAutoscalerStatusStatusApplyConfiguration {
+	return &ClusterAutoscalerStatusStatusApplyConfiguration{}
+}
+
+// WithAutoscalerState sets the AutoscalerState field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutoscalerState field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStatusApplyConfiguration) WithAutoscalerState(value autoscalingxk8siov1alpha1.AutoscalerState) *ClusterAutoscalerStatusStatusApplyConfiguration {
+	b.AutoscalerState = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStatusApplyConfiguration) WithMessage(value string) *ClusterAutoscalerStatusStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithLastUpdateTime sets the LastUpdateTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUpdateTime field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStatusApplyConfiguration) WithLastUpdateTime(value v1.Time) *ClusterAutoscalerStatusStatusApplyConfiguration {
+	b.LastUpdateTime = &value
+	return b
+}
+
+// WithClusterWide sets the ClusterWide field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterWide field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStatusApplyConfiguration) WithClusterWide(value *ClusterWideStatusApplyConfiguration) *ClusterAutoscalerStatusStatusApplyConfiguration {
+	b.ClusterWide = value
+	return b
+}
+
+// WithNodeGroups adds the given value to the NodeGroups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NodeGroups field.
+func (b *ClusterAutoscalerStatusStatusApplyConfiguration) WithNodeGroups(values ...*NodeGroupStatusApplyConfiguration) *ClusterAutoscalerStatusStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNodeGroups")
+		}
+		b.NodeGroups = append(b.NodeGroups, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+)
+
+// ClusterHealthApplyConfiguration represents a declarative configuration of the ClusterHealth type for use
+// with apply.
+type ClusterHealthApplyConfiguration struct {
+	Status             *autoscalingxk8siov1alpha1.HealthStatus `json:"status,omitempty"`
+	NodeCounts         *NodeCountsApplyConfiguration           `json:"nodeCounts,omitempty"`
+	LastProbeTime      *v1.Time                                `json:"lastProbeTime,omitempty"`
+	LastTransitionTime *v1.Time                                `json:"lastTransitionTime,omitempty"`
+}
+
+// ClusterHealthApplyConfiguration constructs a declarative configuration of the ClusterHealth type for use with
+// apply.
+func ClusterHealth() *ClusterHealthApplyConfiguration {
+	return &ClusterHealthApplyConfiguration{}
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *ClusterHealthApplyConfiguration) WithStatus(value autoscalingxk8siov1alpha1.HealthStatus) *ClusterHealthApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithNodeCounts sets the NodeCounts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeCounts field is set to the value of the last call.
+func (b *ClusterHealthApplyConfiguration) WithNodeCounts(value *NodeCountsApplyConfiguration) *ClusterHealthApplyConfiguration {
+	b.NodeCounts = value
+	return b
+}
+
+// WithLastProbeTime sets the LastProbeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastProbeTime field is set to the value of the last call.
+func (b *ClusterHealthApplyConfiguration) WithLastProbeTime(value v1.Time) *ClusterHealthApplyConfiguration {
+	b.LastProbeTime = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *ClusterHealthApplyConfiguration) WithLastTransitionTime(value v1.Time) *ClusterHealthApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
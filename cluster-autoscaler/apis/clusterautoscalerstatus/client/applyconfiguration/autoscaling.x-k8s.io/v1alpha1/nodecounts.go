@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// NodeCountsApplyConfiguration represents a declarative configuration of the NodeCounts type for use
+// with apply.
+type NodeCountsApplyConfiguration struct {
+	Registered       *RegisteredNodeCountsApplyConfiguration `json:"registered,omitempty"`
+	LongUnregistered *int32                                  `json:"longUnregistered,omitempty"`
+	Unregistered     *int32                                  `json:"unregistered,omitempty"`
+}
+
+// NodeCountsApplyConfiguration constructs a declarative configuration of the NodeCounts type for use with
+// apply.
+func NodeCounts() *NodeCountsApplyConfiguration {
+	return &NodeCountsApplyConfiguration{}
+}
+
+// WithRegistered sets the Registered field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Registered field is set to the value of the last call.
+func (b *NodeCountsApplyConfiguration) WithRegistered(value *RegisteredNodeCountsApplyConfiguration) *NodeCountsApplyConfiguration {
+	b.Registered = value
+	return b
+}
+
+// WithLongUnregistered sets the LongUnregistered field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LongUnregistered field is set to the value of the last call.
+func (b *NodeCountsApplyConfiguration) WithLongUnregistered(value int32) *NodeCountsApplyConfiguration {
+	b.LongUnregistered = &value
+	return b
+}
+
+// WithUnregistered sets the Unregistered field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Unregistered field is set to the value of the last call.
+func (b *NodeCountsApplyConfiguration) WithUnregistered(value int32) *NodeCountsApplyConfiguration {
+	b.Unregistered = &value
+	return b
+}
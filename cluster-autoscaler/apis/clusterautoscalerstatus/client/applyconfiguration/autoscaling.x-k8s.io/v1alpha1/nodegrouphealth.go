@@ -0,0 +1,107 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+)
+
+// NodeGroupHealthApplyConfiguration represents a declarative configuration of the NodeGroupHealth type for use
+// with apply.
+type NodeGroupHealthApplyConfiguration struct {
+	Status              *autoscalingxk8siov1alpha1.HealthStatus `json:"status,omitempty"`
+	NodeCounts          *NodeCountsApplyConfiguration           `json:"nodeCounts,omitempty"`
+	CloudProviderTarget *int32                                  `json:"cloudProviderTarget,omitempty"`
+	MinSize             *int32                                  `json:"minSize,omitempty"`
+	ScheduledMinSize    *int32                                  `json:"scheduledMinSize,omitempty"`
+	MaxSize             *int32                                  `json:"maxSize,omitempty"`
+	LastProbeTime       *v1.Time                                `json:"lastProbeTime,omitempty"`
+	LastTransitionTime  *v1.Time                                `json:"lastTransitionTime,omitempty"`
+}
+
+// NodeGroupHealthApplyConfiguration constructs a declarative configuration of the NodeGroupHealth type for use with
+// apply.
+func NodeGroupHealth() *NodeGroupHealthApplyConfiguration {
+	return &NodeGroupHealthApplyConfiguration{}
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *NodeGroupHealthApplyConfiguration) WithStatus(value autoscalingxk8siov1alpha1.HealthStatus) *NodeGroupHealthApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithNodeCounts sets the NodeCounts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeCounts field is set to the value of the last call.
+func (b *NodeGroupHealthApplyConfiguration) WithNodeCounts(value *NodeCountsApplyConfiguration) *NodeGroupHealthApplyConfiguration {
+	b.NodeCounts = value
+	return b
+}
+
+// WithCloudProviderTarget sets the CloudProviderTarget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CloudProviderTarget field is set to the value of the last call.
+func (b *NodeGroupHealthApplyConfiguration) WithCloudProviderTarget(value int32) *NodeGroupHealthApplyConfiguration {
+	b.CloudProviderTarget = &value
+	return b
+}
+
+// WithMinSize sets the MinSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinSize field is set to the value of the last call.
+func (b *NodeGroupHealthApplyConfiguration) WithMinSize(value int32) *NodeGroupHealthApplyConfiguration {
+	b.MinSize = &value
+	return b
+}
+
+// WithScheduledMinSize sets the ScheduledMinSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScheduledMinSize field is set to the value of the last call.
+func (b *NodeGroupHealthApplyConfiguration) WithScheduledMinSize(value int32) *NodeGroupHealthApplyConfiguration {
+	b.ScheduledMinSize = &value
+	return b
+}
+
+// WithMaxSize sets the MaxSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxSize field is set to the value of the last call.
+func (b *NodeGroupHealthApplyConfiguration) WithMaxSize(value int32) *NodeGroupHealthApplyConfiguration {
+	b.MaxSize = &value
+	return b
+}
+
+// WithLastProbeTime sets the LastProbeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastProbeTime field is set to the value of the last call.
+func (b *NodeGroupHealthApplyConfiguration) WithLastProbeTime(value v1.Time) *NodeGroupHealthApplyConfiguration {
+	b.LastProbeTime = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *NodeGroupHealthApplyConfiguration) WithLastTransitionTime(value v1.Time) *NodeGroupHealthApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
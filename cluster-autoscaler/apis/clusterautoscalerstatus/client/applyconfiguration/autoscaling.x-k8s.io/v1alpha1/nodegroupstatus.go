@@ -0,0 +1,66 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// NodeGroupStatusApplyConfiguration represents a declarative configuration of the NodeGroupStatus type for use
+// with apply.
+type NodeGroupStatusApplyConfiguration struct {
+	Name      *string                             `json:"name,omitempty"`
+	Health    *NodeGroupHealthApplyConfiguration  `json:"health,omitempty"`
+	ScaleUp   *NodeGroupScaleUpApplyConfiguration `json:"scaleUp,omitempty"`
+	ScaleDown *ScaleDownApplyConfiguration        `json:"scaleDown,omitempty"`
+}
+
+// NodeGroupStatusApplyConfiguration constructs a declarative configuration of the NodeGroupStatus type for use with
+// apply.
+func NodeGroupStatus() *NodeGroupStatusApplyConfiguration {
+	return &NodeGroupStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *NodeGroupStatusApplyConfiguration) WithName(value string) *NodeGroupStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithHealth sets the Health field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Health field is set to the value of the last call.
+func (b *NodeGroupStatusApplyConfiguration) WithHealth(value *NodeGroupHealthApplyConfiguration) *NodeGroupStatusApplyConfiguration {
+	b.Health = value
+	return b
+}
+
+// WithScaleUp sets the ScaleUp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleUp field is set to the value of the last call.
+func (b *NodeGroupStatusApplyConfiguration) WithScaleUp(value *NodeGroupScaleUpApplyConfiguration) *NodeGroupStatusApplyConfiguration {
+	b.ScaleUp = value
+	return b
+}
+
+// WithScaleDown sets the ScaleDown field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleDown field is set to the value of the last call.
+func (b *NodeGroupStatusApplyConfiguration) WithScaleDown(value *ScaleDownApplyConfiguration) *NodeGroupStatusApplyConfiguration {
+	b.ScaleDown = value
+	return b
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RegisteredNodeCountsApplyConfiguration represents a declarative configuration of the RegisteredNodeCounts type for use
+// with apply.
+type RegisteredNodeCountsApplyConfiguration struct {
+	Total           *int32 `json:"total,omitempty"`
+	Ready           *int32 `json:"ready,omitempty"`
+	NotStarted      *int32 `json:"notStarted,omitempty"`
+	BeingDeleted    *int32 `json:"beingDeleted,omitempty"`
+	Unready         *int32 `json:"unready,omitempty"`
+	ResourceUnready *int32 `json:"resourceUnready,omitempty"`
+}
+
+// RegisteredNodeCountsApplyConfiguration constructs a declarative configuration of the RegisteredNodeCounts type for use with
+// apply.
+func RegisteredNodeCounts() *RegisteredNodeCountsApplyConfiguration {
+	return &RegisteredNodeCountsApplyConfiguration{}
+}
+
+// WithTotal sets the Total field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Total field is set to the value of the last call.
+func (b *RegisteredNodeCountsApplyConfiguration) WithTotal(value int32) *RegisteredNodeCountsApplyConfiguration {
+	b.Total = &value
+	return b
+}
+
+// WithReady sets the Ready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ready field is set to the value of the last call.
+func (b *RegisteredNodeCountsApplyConfiguration) WithReady(value int32) *RegisteredNodeCountsApplyConfiguration {
+	b.Ready = &value
+	return b
+}
+
+// WithNotStarted sets the NotStarted field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotStarted field is set to the value of the last call.
+func (b *RegisteredNodeCountsApplyConfiguration) WithNotStarted(value int32) *RegisteredNodeCountsApplyConfiguration {
+	b.NotStarted = &value
+	return b
+}
+
+// WithBeingDeleted sets the BeingDeleted field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BeingDeleted field is set to the value of the last call.
+func (b *RegisteredNodeCountsApplyConfiguration) WithBeingDeleted(value int32) *RegisteredNodeCountsApplyConfiguration {
+	b.BeingDeleted = &value
+	return b
+}
+
+// WithUnready sets the Unready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Unready field is set to the value of the last call.
+func (b *RegisteredNodeCountsApplyConfiguration) WithUnready(value int32) *RegisteredNodeCountsApplyConfiguration {
+	b.Unready = &value
+	return b
+}
+
+// WithResourceUnready sets the ResourceUnready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceUnready field is set to the value of the last call.
+func (b *RegisteredNodeCountsApplyConfiguration) WithResourceUnready(value int32) *RegisteredNodeCountsApplyConfiguration {
+	b.ResourceUnready = &value
+	return b
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+)
+
+// ScaleDownApplyConfiguration represents a declarative configuration of the ScaleDown type for use
+// with apply.
+type ScaleDownApplyConfiguration struct {
+	Status             *autoscalingxk8siov1alpha1.ScaleDownStatus `json:"status,omitempty"`
+	Candidates         *int32                                     `json:"candidates,omitempty"`
+	LastScaleDownTime  *v1.Time                                   `json:"lastScaleDownTime,omitempty"`
+	LastProbeTime      *v1.Time                                   `json:"lastProbeTime,omitempty"`
+	LastTransitionTime *v1.Time                                   `json:"lastTransitionTime,omitempty"`
+}
+
+// ScaleDownApplyConfiguration constructs a declarative configuration of the ScaleDown type for use with
+// apply.
+func ScaleDown() *ScaleDownApplyConfiguration {
+	return &ScaleDownApplyConfiguration{}
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *ScaleDownApplyConfiguration) WithStatus(value autoscalingxk8siov1alpha1.ScaleDownStatus) *ScaleDownApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithCandidates sets the Candidates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Candidates field is set to the value of the last call.
+func (b *ScaleDownApplyConfiguration) WithCandidates(value int32) *ScaleDownApplyConfiguration {
+	b.Candidates = &value
+	return b
+}
+
+// WithLastScaleDownTime sets the LastScaleDownTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastScaleDownTime field is set to the value of the last call.
+func (b *ScaleDownApplyConfiguration) WithLastScaleDownTime(value v1.Time) *ScaleDownApplyConfiguration {
+	b.LastScaleDownTime = &value
+	return b
+}
+
+// WithLastProbeTime sets the LastProbeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastProbeTime field is set to the value of the last call.
+func (b *ScaleDownApplyConfiguration) WithLastProbeTime(value v1.Time) *ScaleDownApplyConfiguration {
+	b.LastProbeTime = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *ScaleDownApplyConfiguration) WithLastTransitionTime(value v1.Time) *ScaleDownApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
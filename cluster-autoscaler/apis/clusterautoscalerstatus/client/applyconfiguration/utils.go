@@ -0,0 +1,66 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/applyconfiguration/autoscaling.x-k8s.io/v1alpha1"
+	internal "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/applyconfiguration/internal"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=autoscaling.x-k8s.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("Backoff"):
+		return &autoscalingxk8siov1alpha1.BackoffApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterAutoscalerStatus"):
+		return &autoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterAutoscalerStatusStatus"):
+		return &autoscalingxk8siov1alpha1.ClusterAutoscalerStatusStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterHealth"):
+		return &autoscalingxk8siov1alpha1.ClusterHealthApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterScaleUp"):
+		return &autoscalingxk8siov1alpha1.ClusterScaleUpApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterWideStatus"):
+		return &autoscalingxk8siov1alpha1.ClusterWideStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeCounts"):
+		return &autoscalingxk8siov1alpha1.NodeCountsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeGroupHealth"):
+		return &autoscalingxk8siov1alpha1.NodeGroupHealthApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeGroupScaleUp"):
+		return &autoscalingxk8siov1alpha1.NodeGroupScaleUpApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeGroupStatus"):
+		return &autoscalingxk8siov1alpha1.NodeGroupStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RegisteredNodeCounts"):
+		return &autoscalingxk8siov1alpha1.RegisteredNodeCountsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScaleDown"):
+		return &autoscalingxk8siov1alpha1.ScaleDownApplyConfiguration{}
+
+	}
+	return nil
+}
+
+func NewTypeConverter(scheme *runtime.Scheme) managedfields.TypeConverter {
+	return managedfields.NewSchemeTypeConverter(scheme, internal.Parser())
+}
@@ -0,0 +1,78 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	applyconfigurationautoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/applyconfiguration/autoscaling.x-k8s.io/v1alpha1"
+	scheme "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned/scheme"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterAutoscalerStatusesGetter has a method to return a ClusterAutoscalerStatusInterface.
+// A group's client should implement this interface.
+type ClusterAutoscalerStatusesGetter interface {
+	ClusterAutoscalerStatuses(namespace string) ClusterAutoscalerStatusInterface
+}
+
+// ClusterAutoscalerStatusInterface has methods to work with ClusterAutoscalerStatus resources.
+type ClusterAutoscalerStatusInterface interface {
+	Create(ctx context.Context, clusterAutoscalerStatus *autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, opts v1.CreateOptions) (*autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, error)
+	Update(ctx context.Context, clusterAutoscalerStatus *autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, opts v1.UpdateOptions) (*autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterAutoscalerStatus *autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, opts v1.UpdateOptions) (*autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, error)
+	List(ctx context.Context, opts v1.ListOptions) (*autoscalingxk8siov1alpha1.ClusterAutoscalerStatusList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, err error)
+	Apply(ctx context.Context, clusterAutoscalerStatus *applyconfigurationautoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration, opts v1.ApplyOptions) (result *autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, clusterAutoscalerStatus *applyconfigurationautoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration, opts v1.ApplyOptions) (result *autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, err error)
+	ClusterAutoscalerStatusExpansion
+}
+
+// clusterAutoscalerStatuses implements ClusterAutoscalerStatusInterface
+type clusterAutoscalerStatuses struct {
+	*gentype.ClientWithListAndApply[*autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, *autoscalingxk8siov1alpha1.ClusterAutoscalerStatusList, *applyconfigurationautoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration]
+}
+
+// newClusterAutoscalerStatuses returns a ClusterAutoscalerStatuses
+func newClusterAutoscalerStatuses(c *AutoscalingV1alpha1Client, namespace string) *clusterAutoscalerStatuses {
+	return &clusterAutoscalerStatuses{
+		gentype.NewClientWithListAndApply[*autoscalingxk8siov1alpha1.ClusterAutoscalerStatus, *autoscalingxk8siov1alpha1.ClusterAutoscalerStatusList, *applyconfigurationautoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration](
+			"clusterautoscalerstatuses",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *autoscalingxk8siov1alpha1.ClusterAutoscalerStatus {
+				return &autoscalingxk8siov1alpha1.ClusterAutoscalerStatus{}
+			},
+			func() *autoscalingxk8siov1alpha1.ClusterAutoscalerStatusList {
+				return &autoscalingxk8siov1alpha1.ClusterAutoscalerStatusList{}
+			},
+		),
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/applyconfiguration/autoscaling.x-k8s.io/v1alpha1"
+	typedautoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned/typed/autoscaling.x-k8s.io/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterAutoscalerStatuses implements ClusterAutoscalerStatusInterface
+type fakeClusterAutoscalerStatuses struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.ClusterAutoscalerStatus, *v1alpha1.ClusterAutoscalerStatusList, *autoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration]
+	Fake *FakeAutoscalingV1alpha1
+}
+
+func newFakeClusterAutoscalerStatuses(fake *FakeAutoscalingV1alpha1, namespace string) typedautoscalingxk8siov1alpha1.ClusterAutoscalerStatusInterface {
+	return &fakeClusterAutoscalerStatuses{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.ClusterAutoscalerStatus, *v1alpha1.ClusterAutoscalerStatusList, *autoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("clusterautoscalerstatuses"),
+			v1alpha1.SchemeGroupVersion.WithKind("ClusterAutoscalerStatus"),
+			func() *v1alpha1.ClusterAutoscalerStatus { return &v1alpha1.ClusterAutoscalerStatus{} },
+			func() *v1alpha1.ClusterAutoscalerStatusList { return &v1alpha1.ClusterAutoscalerStatusList{} },
+			func(dst, src *v1alpha1.ClusterAutoscalerStatusList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ClusterAutoscalerStatusList) []*v1alpha1.ClusterAutoscalerStatus {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ClusterAutoscalerStatusList, items []*v1alpha1.ClusterAutoscalerStatus) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
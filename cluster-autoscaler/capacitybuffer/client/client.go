@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +41,7 @@ import (
 	autoscalingapi "k8s.io/api/autoscaling/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery/cached/memory"
@@ -50,12 +52,17 @@ import (
 	klog "k8s.io/klog/v2"
 )
 
+// restMapperResetInterval is the minimal interval between resets of the rest mapper,
+// references to kinds which don't exist would otherwise trigger a discovery on every call
+const restMapperResetInterval = 30 * time.Second
+
 // CapacityBufferClient represents client for v1 capacitybuffer CRD.
 type CapacityBufferClient struct {
 	buffersClient         capacitybuffer.Interface
 	kubernetesClient      kubernetes.Interface
 	scaleGetter           scaleclient.ScalesGetter
 	scaleMapper           meta.RESTMapper
+	dynamicClient         dynamic.Interface
 	buffersLister         bufferslisters.CapacityBufferLister
 	podTemplateLister     corev1listers.PodTemplateLister
 	replicaSetsLister     appsv1listers.ReplicaSetLister
@@ -63,13 +70,15 @@ type CapacityBufferClient struct {
 	jobsLister            batchv1lister.JobLister
 	deploymentLister      appsv1listers.DeploymentLister
 	replicationContLister corev1listers.ReplicationControllerLister
+	mapperResetLock       sync.Mutex
+	lastMapperReset       time.Time
 }
 
 // NewCapacityBufferClient returns a capacityBufferClient.
 func NewCapacityBufferClient(buffersClient capacitybuffer.Interface, kubernetesClient kubernetes.Interface, buffersLister bufferslisters.CapacityBufferLister,
 	podTemplateLister corev1listers.PodTemplateLister, replicaSetsLister appsv1listers.ReplicaSetLister, statefulSetsLister appsv1listers.StatefulSetLister,
 	jobsLister batchv1lister.JobLister, deploymentLister appsv1listers.DeploymentLister, replicationContLister corev1listers.ReplicationControllerLister,
-	scaleGetter scaleclient.ScalesGetter, scaleMapper meta.RESTMapper) (*CapacityBufferClient, error) {
+	scaleGetter scaleclient.ScalesGetter, scaleMapper meta.RESTMapper, dynamicClient dynamic.Interface) (*CapacityBufferClient, error) {
 	return &CapacityBufferClient{
 		buffersClient:         buffersClient,
 		kubernetesClient:      kubernetesClient,
@@ -82,6 +91,7 @@ func NewCapacityBufferClient(buffersClient capacitybuffer.Interface, kubernetesC
 		deploymentLister:      deploymentLister,
 		replicationContLister: replicationContLister,
 		scaleMapper:           scaleMapper,
+		dynamicClient:         dynamicClient,
 	}, nil
 }
 
@@ -99,7 +109,11 @@ func NewCapacityBufferClientFromConfig(kubeConfig *rest.Config) (*CapacityBuffer
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create scale getter for capacity buffer: %v", err)
 	}
-	return NewCapacityBufferClientFromClients(buffersClient, kubernetesClient, scaleGetter, scaleMapper)
+	dynamicClient, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create dynamic client for capacity buffer: %v", err)
+	}
+	return NewCapacityBufferClientFromClients(buffersClient, kubernetesClient, scaleGetter, scaleMapper, dynamicClient)
 }
 
 func createScaleSubresourceClientGetter(kubeConfig *rest.Config) (scaleclient.ScalesGetter, meta.RESTMapper, error) {
@@ -117,7 +131,8 @@ func createScaleSubresourceClientGetter(kubeConfig *rest.Config) (scaleclient.Sc
 }
 
 // NewCapacityBufferClientFromClients returns a CapacityBufferClient based on the passed clients
-func NewCapacityBufferClientFromClients(buffersClient capacitybuffer.Interface, kubernetesClient kubernetes.Interface, scaleGetter scaleclient.ScalesGetter, scaleMapper meta.RESTMapper) (*CapacityBufferClient, error) {
+func NewCapacityBufferClientFromClients(buffersClient capacitybuffer.Interface, kubernetesClient kubernetes.Interface, scaleGetter scaleclient.ScalesGetter, scaleMapper meta.RESTMapper,
+	dynamicClient dynamic.Interface) (*CapacityBufferClient, error) {
 	if buffersClient == nil || kubernetesClient == nil {
 		return nil, fmt.Errorf("Couldn't create capacity buffer client")
 	}
@@ -134,6 +149,7 @@ func NewCapacityBufferClientFromClients(buffersClient capacitybuffer.Interface,
 		kubernetesClient:      kubernetesClient,
 		scaleGetter:           scaleGetter,
 		scaleMapper:           scaleMapper,
+		dynamicClient:         dynamicClient,
 		buffersLister:         buffersLister,
 		podTemplateLister:     factory.Core().V1().PodTemplates().Lister(),
 		replicaSetsLister:     factory.Apps().V1().ReplicaSets().Lister(),
@@ -309,11 +325,7 @@ func (c *CapacityBufferClient) GetScaleObject(namespace, group, kind, name strin
 	if c.scaleMapper == nil || c.scaleGetter == nil {
 		return nil, fmt.Errorf("Capacity buffer client is not configured for scale objects")
 	}
-	gvk := schema.GroupKind{
-		Group: group,
-		Kind:  kind,
-	}
-	mapping, err := c.scaleMapper.RESTMapping(gvk, "")
+	mapping, err := c.restMapping(group, kind)
 	if err != nil {
 		return nil, err
 	}
@@ -325,6 +337,51 @@ func (c *CapacityBufferClient) GetScaleObject(namespace, group, kind, name strin
 	return obj, nil
 }
 
+// GetObject resolves the api group and kind to a resource and uses it to get the object with passed name from the passed namespace
+func (c *CapacityBufferClient) GetObject(namespace, group, kind, name string) (*unstructured.Unstructured, error) {
+	if c.scaleMapper == nil || c.dynamicClient == nil {
+		return nil, fmt.Errorf("Capacity buffer client is not configured for arbitrary objects")
+	}
+	mapping, err := c.restMapping(group, kind)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %v: %w", mapping.Resource.GroupResource(), err)
+	}
+	return obj, nil
+}
+
+// restMapping resolves the api group and kind using the discovery, CRDs installed after
+// the discovery was cached are only found after resetting the mapper
+func (c *CapacityBufferClient) restMapping(group, kind string) (*meta.RESTMapping, error) {
+	groupKind := schema.GroupKind{
+		Group: group,
+		Kind:  kind,
+	}
+	mapping, err := c.scaleMapper.RESTMapping(groupKind)
+	if meta.IsNoMatchError(err) {
+		if resettableMapper, ok := c.scaleMapper.(meta.ResettableRESTMapper); ok && c.shouldResetMapper() {
+			resettableMapper.Reset()
+			mapping, err = c.scaleMapper.RESTMapping(groupKind)
+		}
+	}
+	return mapping, err
+}
+
+// shouldResetMapper returns true and records the reset if the mapper
+// wasn't reset within the last restMapperResetInterval
+func (c *CapacityBufferClient) shouldResetMapper() bool {
+	c.mapperResetLock.Lock()
+	defer c.mapperResetLock.Unlock()
+	if time.Since(c.lastMapperReset) < restMapperResetInterval {
+		return false
+	}
+	c.lastMapperReset = time.Now()
+	return true
+}
+
 // GetPodsBySelector resolves the api group and kind to group resource and use it to get the scale sub-resource with passed name from the passed namespace
 func (c *CapacityBufferClient) GetPodsBySelector(namespace, selector string) ([]corev1.Pod, error) {
 	if c.kubernetesClient == nil {
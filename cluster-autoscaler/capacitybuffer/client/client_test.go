@@ -18,11 +18,14 @@ package client
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	buffersfake "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/client/clientset/versioned/fake"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
@@ -69,10 +72,40 @@ func TestClientGetPodTemplate(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			fakeKubernetesClient := fakeclient.NewSimpleClientset(test.objectsInKubernetesClient...)
 			fakeBuffersClient := buffersfake.NewSimpleClientset()
-			fakeCapacityBuffersClient, _ := NewCapacityBufferClientFromClients(fakeBuffersClient, fakeKubernetesClient, nil, nil)
+			fakeCapacityBuffersClient, _ := NewCapacityBufferClientFromClients(fakeBuffersClient, fakeKubernetesClient, nil, nil, nil)
 			pt, err := fakeCapacityBuffersClient.GetPodTemplate("default", test.objectName)
 			assert.Equal(t, err != nil, test.expectError)
 			assert.Equal(t, pt, test.expectedValue)
 		})
 	}
 }
+
+type countingResettableMapper struct {
+	*meta.DefaultRESTMapper
+	resets int
+}
+
+func (m *countingResettableMapper) Reset() {
+	m.resets++
+}
+
+func TestRestMappingResetIsRateLimited(t *testing.T) {
+	mapper := &countingResettableMapper{DefaultRESTMapper: meta.NewDefaultRESTMapper([]schema.GroupVersion{})}
+	client := &CapacityBufferClient{scaleMapper: mapper}
+
+	_, err := client.restMapping("example.com", "Missing")
+	assert.True(t, meta.IsNoMatchError(err))
+	assert.Equal(t, 1, mapper.resets)
+
+	// following misses within the interval don't reset the mapper again
+	_, err = client.restMapping("example.com", "Missing")
+	assert.True(t, meta.IsNoMatchError(err))
+	_, err = client.restMapping("example.com", "OtherMissing")
+	assert.True(t, meta.IsNoMatchError(err))
+	assert.Equal(t, 1, mapper.resets)
+
+	client.lastMapperReset = time.Now().Add(-restMapperResetInterval)
+	_, err = client.restMapping("example.com", "Missing")
+	assert.True(t, meta.IsNoMatchError(err))
+	assert.Equal(t, 2, mapper.resets)
+}
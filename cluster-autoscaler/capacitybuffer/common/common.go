@@ -27,6 +27,7 @@ import (
 // Constants to use in Capacity Buffers objects
 const (
 	ActiveProvisioningStrategy    = "buffer.x-k8s.io/active-capacity"
+	StandbyProvisioningStrategy   = "buffer.x-k8s.io/standby-capacity"
 	CapacityBufferKind            = "CapacityBuffer"
 	CapacityBufferApiVersion      = "autoscaling.x-k8s.io/v1alpha1"
 	ReadyForProvisioningCondition = "ReadyForProvisioning"
@@ -70,14 +70,16 @@ func NewBufferController(
 	}
 }
 
-// NewDefaultBufferController creates bufferController with default configs
+// NewDefaultBufferController creates bufferController with default configs,
+// podTemplatePaths configures where pod templates of custom workloads are read from
 func NewDefaultBufferController(
 	client *cbclient.CapacityBufferClient,
+	podTemplatePaths map[string]string,
 ) BufferController {
 	return &bufferController{
 		client: client,
 		// Accepting empty string as it represents nil value for ProvisioningStrategy
-		strategyFilter: filters.NewStrategyFilter([]string{common.ActiveProvisioningStrategy, ""}),
+		strategyFilter: filters.NewStrategyFilter([]string{common.ActiveProvisioningStrategy, common.StandbyProvisioningStrategy, ""}),
 		statusFilter: filter.NewCombinedAnyFilter(
 			[]filters.Filter{
 				filters.NewStatusFilter(map[string]string{
@@ -85,13 +87,14 @@ func NewDefaultBufferController(
 					common.ProvisioningCondition:         common.ConditionTrue,
 				}),
 				filters.NewBufferGenerationChangedFilter(),
+				filters.NewScheduleChangeFilter(),
 				filters.NewPodTemplateGenerationChangedFilter(client),
 			},
 		),
 		translator: translators.NewCombinedTranslator(
 			[]translators.Translator{
 				translators.NewPodTemplateBufferTranslator(client),
-				translators.NewDefaultScalableObjectsTranslator(client),
+				translators.NewScalableObjectsTranslator(client, podTemplatePaths),
 				translators.NewResourceLimitsTranslator(client),
 			},
 		),
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/utils/clock"
+)
+
+// scheduleChangeFilter filters in buffers whose schedule window applying is due to change
+type scheduleChangeFilter struct {
+	clock clock.PassiveClock
+}
+
+// NewScheduleChangeFilter creates an instance of scheduleChangeFilter that filters the buffers that need to be updated
+// because the schedule window applying to them changed since the last update.
+func NewScheduleChangeFilter() *scheduleChangeFilter {
+	return &scheduleChangeFilter{
+		clock: clock.RealClock{},
+	}
+}
+
+// Filter returns the buffers with a schedule change due as the filtered buffers
+func (f *scheduleChangeFilter) Filter(buffersToFilter []*v1.CapacityBuffer) ([]*v1.CapacityBuffer, []*v1.CapacityBuffer) {
+	var buffers []*v1.CapacityBuffer
+	var filteredOutBuffers []*v1.CapacityBuffer
+
+	now := f.clock.Now()
+	for _, buffer := range buffersToFilter {
+		nextChange := buffer.Status.NextScheduleChangeTime
+		if nextChange != nil && !nextChange.After(now) {
+			buffers = append(buffers, buffer)
+		} else {
+			filteredOutBuffers = append(filteredOutBuffers, buffer)
+		}
+	}
+	return buffers, filteredOutBuffers
+}
+
+// CleanUp cleans up the filter's internal structures.
+func (f *scheduleChangeFilter) CleanUp() {
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
+)
+
+func TestScheduleChangeFilter(t *testing.T) {
+	now := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                       string
+		buffers                    []*v1.CapacityBuffer
+		expectedFilteredBuffers    []*v1.CapacityBuffer
+		expectedFilteredOutBuffers []*v1.CapacityBuffer
+	}{
+		{
+			name: "buffer without schedule",
+			buffers: []*v1.CapacityBuffer{
+				getTestBufferWithNextScheduleChange("someBuffer", nil),
+			},
+			expectedFilteredBuffers: []*v1.CapacityBuffer{},
+			expectedFilteredOutBuffers: []*v1.CapacityBuffer{
+				getTestBufferWithNextScheduleChange("someBuffer", nil),
+			},
+		},
+		{
+			name: "schedule change in the future",
+			buffers: []*v1.CapacityBuffer{
+				getTestBufferWithNextScheduleChange("someBuffer", &metav1.Time{Time: now.Add(time.Minute)}),
+			},
+			expectedFilteredBuffers: []*v1.CapacityBuffer{},
+			expectedFilteredOutBuffers: []*v1.CapacityBuffer{
+				getTestBufferWithNextScheduleChange("someBuffer", &metav1.Time{Time: now.Add(time.Minute)}),
+			},
+		},
+		{
+			name: "schedule change due",
+			buffers: []*v1.CapacityBuffer{
+				getTestBufferWithNextScheduleChange("someBuffer", &metav1.Time{Time: now}),
+				getTestBufferWithNextScheduleChange("anotherBuffer", &metav1.Time{Time: now.Add(-time.Hour)}),
+			},
+			expectedFilteredBuffers: []*v1.CapacityBuffer{
+				getTestBufferWithNextScheduleChange("someBuffer", &metav1.Time{Time: now}),
+				getTestBufferWithNextScheduleChange("anotherBuffer", &metav1.Time{Time: now.Add(-time.Hour)}),
+			},
+			expectedFilteredOutBuffers: []*v1.CapacityBuffer{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheduleChangeFilter := &scheduleChangeFilter{
+				clock: clocktesting.NewFakePassiveClock(now),
+			}
+			filtered, filteredOut := scheduleChangeFilter.Filter(test.buffers)
+			assert.ElementsMatch(t, test.expectedFilteredBuffers, filtered)
+			assert.ElementsMatch(t, test.expectedFilteredOutBuffers, filteredOut)
+		})
+	}
+}
+
+func getTestBufferWithNextScheduleChange(bufferName string, nextScheduleChange *metav1.Time) *v1.CapacityBuffer {
+	return &v1.CapacityBuffer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bufferName,
+			Namespace: "default",
+		},
+		Status: v1.CapacityBufferStatus{
+			NextScheduleChangeTime: nextScheduleChange,
+		},
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
+)
+
+// statusStrategyFilter filters out buffers whose resolved provisioning strategy
+// in status is not defined in strategiesToUse, including buffers which were not
+// translated yet
+type statusStrategyFilter struct {
+	strategiesToUse map[string]bool
+}
+
+// NewStatusStrategyFilter creates an instance of statusStrategyFilter.
+func NewStatusStrategyFilter(strategiesToUse []string) *statusStrategyFilter {
+	strategiesToUseMap := map[string]bool{}
+	for _, strategy := range strategiesToUse {
+		strategiesToUseMap[strategy] = true
+	}
+	return &statusStrategyFilter{
+		strategiesToUse: strategiesToUseMap,
+	}
+}
+
+// Filter filters out buffers with status provisioning strategies not defined in strategiesToUse
+func (f *statusStrategyFilter) Filter(buffers []*v1.CapacityBuffer) ([]*v1.CapacityBuffer, []*v1.CapacityBuffer) {
+	var filteredBuffers []*v1.CapacityBuffer
+	var filteredOutBuffers []*v1.CapacityBuffer
+
+	for _, buffer := range buffers {
+		if buffer.Status.ProvisioningStrategy != nil && f.strategiesToUse[*buffer.Status.ProvisioningStrategy] {
+			filteredBuffers = append(filteredBuffers, buffer)
+		} else {
+			filteredOutBuffers = append(filteredOutBuffers, buffer)
+		}
+	}
+	return filteredBuffers, filteredOutBuffers
+}
+
+// CleanUp cleans up the filter's internal structures.
+func (f *statusStrategyFilter) CleanUp() {
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/common"
+	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/testutil"
+)
+
+func TestStatusStrategyFilter(t *testing.T) {
+	activeStrategy := common.ActiveProvisioningStrategy
+	standbyStrategy := common.StandbyProvisioningStrategy
+	tests := []struct {
+		name                       string
+		buffers                    []*v1.CapacityBuffer
+		strategiesToConsider       []string
+		expectedFilteredBuffers    []*v1.CapacityBuffer
+		expectedFilteredOutBuffers []*v1.CapacityBuffer
+	}{
+		{
+			name: "Single buffer with accepted strategy",
+			buffers: []*v1.CapacityBuffer{
+				getTestBufferWithStatusStrategy(&standbyStrategy),
+			},
+			strategiesToConsider: []string{common.StandbyProvisioningStrategy},
+			expectedFilteredBuffers: []*v1.CapacityBuffer{
+				getTestBufferWithStatusStrategy(&standbyStrategy),
+			},
+			expectedFilteredOutBuffers: []*v1.CapacityBuffer{},
+		},
+		{
+			name: "Buffer not translated yet",
+			buffers: []*v1.CapacityBuffer{
+				getTestBufferWithStatusStrategy(nil),
+			},
+			strategiesToConsider:    []string{common.StandbyProvisioningStrategy},
+			expectedFilteredBuffers: []*v1.CapacityBuffer{},
+			expectedFilteredOutBuffers: []*v1.CapacityBuffer{
+				getTestBufferWithStatusStrategy(nil),
+			},
+		},
+		{
+			name: "Multiple buffers different strategies",
+			buffers: []*v1.CapacityBuffer{
+				getTestBufferWithStatusStrategy(&activeStrategy),
+				getTestBufferWithStatusStrategy(&standbyStrategy),
+			},
+			strategiesToConsider: []string{common.ActiveProvisioningStrategy},
+			expectedFilteredBuffers: []*v1.CapacityBuffer{
+				getTestBufferWithStatusStrategy(&activeStrategy),
+			},
+			expectedFilteredOutBuffers: []*v1.CapacityBuffer{
+				getTestBufferWithStatusStrategy(&standbyStrategy),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statusStrategyFilter := NewStatusStrategyFilter(test.strategiesToConsider)
+			filtered, filteredOut := statusStrategyFilter.Filter(test.buffers)
+			assert.ElementsMatch(t, test.expectedFilteredBuffers, filtered)
+			assert.ElementsMatch(t, test.expectedFilteredOutBuffers, filteredOut)
+		})
+	}
+}
+
+func getTestBufferWithStatusStrategy(provisioningStrategy *string) *v1.CapacityBuffer {
+	buffer := testutil.GetBuffer(nil, nil, nil, nil, nil, nil, nil, nil)
+	buffer.Status.ProvisioningStrategy = provisioningStrategy
+	return buffer
+}
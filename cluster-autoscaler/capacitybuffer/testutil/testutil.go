@@ -24,11 +24,12 @@ import (
 
 // To use their pointers in creating testing capacity buffer objects
 var (
-	ProvisioningStrategy      = common.ActiveProvisioningStrategy
-	SomeNumberOfReplicas      = int32(3)
-	AnotherNumberOfReplicas   = int32(5)
-	SomePodTemplateRefName    = "some-pod-template"
-	AnotherPodTemplateRefName = "another-pod-template"
+	ProvisioningStrategy        = common.ActiveProvisioningStrategy
+	StandbyProvisioningStrategy = common.StandbyProvisioningStrategy
+	SomeNumberOfReplicas        = int32(3)
+	AnotherNumberOfReplicas     = int32(5)
+	SomePodTemplateRefName      = "some-pod-template"
+	AnotherPodTemplateRefName   = "another-pod-template"
 )
 
 // SanitizeBuffersStatus returns a list of the status objects of the passed buffers after sanitizing them for testing comparison
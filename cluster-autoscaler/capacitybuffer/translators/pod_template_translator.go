@@ -22,17 +22,20 @@ import (
 	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
 	cbclient "k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/client"
 	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/common"
+	"k8s.io/utils/clock"
 )
 
 // podTemplateBufferTranslator translates podTemplateRef buffers specs to fill their status.
 type podTemplateBufferTranslator struct {
 	client *cbclient.CapacityBufferClient
+	clock  clock.PassiveClock
 }
 
 // NewPodTemplateBufferTranslator creates an instance of podTemplateBufferTranslator.
 func NewPodTemplateBufferTranslator(client *cbclient.CapacityBufferClient) *podTemplateBufferTranslator {
 	return &podTemplateBufferTranslator{
 		client: client,
+		clock:  clock.RealClock{},
 	}
 }
 
@@ -50,7 +53,13 @@ func (t *podTemplateBufferTranslator) Translate(buffers []*v1.CapacityBuffer) []
 				errors = append(errors, err)
 				continue
 			}
-			numberOfPods = t.getNumberOfReplicas(buffer)
+			replicas, _, err := applySchedule(buffer, t.clock.Now())
+			if err != nil {
+				common.SetBufferAsNotReadyForProvisioning(buffer, podTemplateRef, &podTemplate.Generation, nil, buffer.Spec.ProvisioningStrategy, err)
+				errors = append(errors, err)
+				continue
+			}
+			numberOfPods = t.getNumberOfReplicas(replicas)
 			if numberOfPods == nil {
 				common.SetBufferAsNotReadyForProvisioning(buffer, podTemplateRef, &podTemplate.Generation, nil, buffer.Spec.ProvisioningStrategy, fmt.Errorf("Failed to get buffer's number of pods"))
 				continue
@@ -61,10 +70,10 @@ func (t *podTemplateBufferTranslator) Translate(buffers []*v1.CapacityBuffer) []
 	return errors
 }
 
-func (t *podTemplateBufferTranslator) getNumberOfReplicas(buffer *v1.CapacityBuffer) *int32 {
-	if buffer.Spec.Replicas != nil {
-		replicas := max(0, int32(*buffer.Spec.Replicas))
-		return &replicas
+func (t *podTemplateBufferTranslator) getNumberOfReplicas(replicas *int32) *int32 {
+	if replicas != nil {
+		numberOfPods := max(0, int32(*replicas))
+		return &numberOfPods
 	}
 	return nil
 }
@@ -45,7 +45,7 @@ func TestPodTemplateBufferTranslator(t *testing.T) {
 		},
 	}
 	fakeClient := fakeClient.NewSimpleClientset(registeredPodTemplate, anotherRegisteredPodTemplate)
-	fakeCapacityBuffersClient, _ := cbclient.NewCapacityBufferClient(nil, fakeClient, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	fakeCapacityBuffersClient, _ := cbclient.NewCapacityBufferClient(nil, fakeClient, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	tests := []struct {
 		name                   string
 		buffers                []*v1.CapacityBuffer
@@ -62,6 +62,16 @@ func TestPodTemplateBufferTranslator(t *testing.T) {
 			},
 			expectedNumberOfErrors: 0,
 		},
+		{
+			name: "Test buffer with standby provisioning strategy",
+			buffers: []*v1.CapacityBuffer{
+				testutil.GetBuffer(&testutil.StandbyProvisioningStrategy, &v1.LocalObjectRef{Name: registeredPodTemplate.Name}, &testutil.SomeNumberOfReplicas, nil, nil, nil, nil, nil),
+			},
+			expectedStatus: []*v1.CapacityBufferStatus{
+				testutil.GetBufferStatus(&v1.LocalObjectRef{Name: registeredPodTemplate.Name}, &testutil.SomeNumberOfReplicas, &registeredPodTemplate.Generation, &testutil.StandbyProvisioningStrategy, testutil.GetConditionReady()),
+			},
+			expectedNumberOfErrors: 0,
+		},
 		{
 			name: "Test 2 buffers with pod template ref",
 			buffers: []*v1.CapacityBuffer{
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalableobject
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	cbclient "k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/client"
+)
+
+// DefaultPodTemplatePath is the path of the pod template used by most workloads
+const DefaultPodTemplatePath = "spec.template"
+
+// PodTemplatePathResolver resolves objects of any kind into the pod template found at a path
+// of the object, which is DefaultPodTemplatePath unless configured for the object kind
+type PodTemplatePathResolver struct {
+	client           *cbclient.CapacityBufferClient
+	podTemplatePaths map[string]string
+}
+
+// NewPodTemplatePathResolver returns new PodTemplatePathResolver, podTemplatePaths maps
+// keys returned by GetPodTemplatePathKey to dot separated paths of the pod template
+func NewPodTemplatePathResolver(client *cbclient.CapacityBufferClient, podTemplatePaths map[string]string) *PodTemplatePathResolver {
+	return &PodTemplatePathResolver{
+		client:           client,
+		podTemplatePaths: podTemplatePaths,
+	}
+}
+
+// GetPodTemplatePathKey returns the key of the pod template path for the api group and kind, <kind>.<group>
+func GetPodTemplatePathKey(apiGroup, kind string) string {
+	if apiGroup == "" || apiGroup == ApiGroupCore {
+		return kind
+	}
+	return fmt.Sprintf("%v.%v", kind, apiGroup)
+}
+
+// HasConfiguredPath returns true if the pod template path was configured for the api group and kind
+func (r *PodTemplatePathResolver) HasConfiguredPath(apiGroup, kind string) bool {
+	_, found := r.podTemplatePaths[GetPodTemplatePathKey(apiGroup, kind)]
+	return found
+}
+
+// GetTemplate returns the pod template of the passed object name and namespace
+func (r *PodTemplatePathResolver) GetTemplate(namespace, apiGroup, kind, name string) (*corev1.PodTemplateSpec, error) {
+	path, found := r.podTemplatePaths[GetPodTemplatePathKey(apiGroup, kind)]
+	if !found {
+		path = DefaultPodTemplatePath
+	}
+	obj, err := r.client.GetObject(namespace, apiGroup, kind, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	template, found, err := unstructured.NestedMap(obj.Object, strings.Split(path, ".")...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pod template at %v: %w", path, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("pod template not found at %v", path)
+	}
+	podTemplate := &corev1.PodTemplateSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template, podTemplate); err != nil {
+		return nil, fmt.Errorf("failed to convert pod template at %v: %w", path, err)
+	}
+	return podTemplate, nil
+}
@@ -26,17 +26,27 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiv1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
 	scalableobject "k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/translators/scalable_objects"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 )
 
 // ScalableObjectsTranslator translates buffers processors into pod capacity.
 type ScalableObjectsTranslator struct {
 	client             *cbclient.CapacityBufferClient
 	scaleResolver      *scalableobject.ScaleObjectPodResolver
+	pathResolver       *scalableobject.PodTemplatePathResolver
 	supportedResolvers map[string]scalableobject.ScalableObjectTemplateResolver
+	clock              clock.PassiveClock
 }
 
 // NewDefaultScalableObjectsTranslator creates an instance of ScalableObjectsTranslator.
 func NewDefaultScalableObjectsTranslator(client *cbclient.CapacityBufferClient) *ScalableObjectsTranslator {
+	return NewScalableObjectsTranslator(client, nil)
+}
+
+// NewScalableObjectsTranslator creates an instance of ScalableObjectsTranslator reading pod templates
+// of objects with a scale subresource from the passed paths, see scalableobject.NewPodTemplatePathResolver.
+func NewScalableObjectsTranslator(client *cbclient.CapacityBufferClient, podTemplatePaths map[string]string) *ScalableObjectsTranslator {
 	supportedResolvers := map[string]scalableobject.ScalableObjectTemplateResolver{}
 	for _, scalableObject := range scalableobject.GetSupportedScalableObjectResolvers(client) {
 		supportedResolvers[scalableObject.GetResolverKey()] = scalableObject
@@ -47,6 +57,8 @@ func NewDefaultScalableObjectsTranslator(client *cbclient.CapacityBufferClient)
 		client:             client,
 		supportedResolvers: supportedResolvers,
 		scaleResolver:      scaleResolver,
+		pathResolver:       scalableobject.NewPodTemplatePathResolver(client, podTemplatePaths),
+		clock:              clock.RealClock{},
 	}
 }
 
@@ -65,8 +77,27 @@ func (t *ScalableObjectsTranslator) useSupportedResolvers(buffer *apiv1.Capacity
 	return nil, nil, err
 }
 
+// useScaleResolver resolves objects of any kind with a scale subresource. The pod template is read from
+// the configured path of the object if any, otherwise from the most recent pod and, if there are no pods,
+// from the default path.
 func (t *ScalableObjectsTranslator) useScaleResolver(buffer *apiv1.CapacityBuffer) (*corev1.PodTemplateSpec, *int32, error) {
-	return t.scaleResolver.GetTemplateAndReplicas(buffer.Namespace, buffer.Spec.ScalableRef.APIGroup, buffer.Spec.ScalableRef.Kind, buffer.Spec.ScalableRef.Name)
+	ref := buffer.Spec.ScalableRef
+	podTemplateSpec, replicas, err := t.scaleResolver.GetTemplateAndReplicas(buffer.Namespace, ref.APIGroup, ref.Kind, ref.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t.pathResolver.HasConfiguredPath(ref.APIGroup, ref.Kind) {
+		podTemplateSpec, err = t.pathResolver.GetTemplate(buffer.Namespace, ref.APIGroup, ref.Kind, ref.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if podTemplateSpec == nil {
+		podTemplateSpec, err = t.pathResolver.GetTemplate(buffer.Namespace, ref.APIGroup, ref.Kind, ref.Name)
+		if err != nil {
+			klog.V(4).Infof("Couldn't read pod template of %v %v from the default path: %v", ref.Kind, ref.Name, err)
+		}
+	}
+	return podTemplateSpec, replicas, nil
 }
 
 // Translate translates buffers processors into pod capacity.
@@ -108,7 +139,13 @@ func (t *ScalableObjectsTranslator) Translate(buffers []*apiv1.CapacityBuffer) [
 				errors = append(errors, err)
 				continue
 			}
-			numberOfPods := t.getBufferNumberOfPods(buffer, replicasFromScalable)
+			replicas, percentage, err := applySchedule(buffer, t.clock.Now())
+			if err != nil {
+				common.SetBufferAsNotReadyForProvisioning(buffer, &apiv1.LocalObjectRef{Name: createdPodTemplate.Name}, &createdPodTemplate.Generation, nil, buffer.Spec.ProvisioningStrategy, err)
+				errors = append(errors, err)
+				continue
+			}
+			numberOfPods := t.getBufferNumberOfPods(replicas, percentage, replicasFromScalable)
 			if numberOfPods == nil {
 				common.SetBufferAsNotReadyForProvisioning(buffer, &apiv1.LocalObjectRef{Name: createdPodTemplate.Name}, &createdPodTemplate.Generation, nil, buffer.Spec.ProvisioningStrategy, fmt.Errorf("Couldn't get number of replicas for buffer %v, replicas and percentage are not defined", buffer.Name))
 				continue
@@ -120,20 +157,20 @@ func (t *ScalableObjectsTranslator) Translate(buffers []*apiv1.CapacityBuffer) [
 	return errors
 }
 
-func (t *ScalableObjectsTranslator) getBufferNumberOfPods(buffer *apiv1.CapacityBuffer, scalableReplicas *int32) *int32 {
+func (t *ScalableObjectsTranslator) getBufferNumberOfPods(replicas, percentage, scalableReplicas *int32) *int32 {
 
 	var numberOfPodsFromPercentage *int32
 	var numberOfPodsFromReplicas *int32
 
-	if buffer.Spec.Percentage != nil {
+	if percentage != nil {
 		if scalableReplicas != nil {
-			percentValue := buffer.Spec.Percentage
+			percentValue := percentage
 			numberOfPods := max(0, int32(int32(*percentValue)*(*scalableReplicas)/100.0))
 			numberOfPodsFromPercentage = &numberOfPods
 		}
 	}
-	if buffer.Spec.Replicas != nil {
-		numberOfPods := max(0, int32(*buffer.Spec.Replicas))
+	if replicas != nil {
+		numberOfPods := max(0, int32(*replicas))
 		numberOfPodsFromReplicas = &numberOfPods
 	}
 	if numberOfPodsFromPercentage != nil && numberOfPodsFromReplicas != nil {
@@ -17,17 +17,28 @@ limitations under the License.
 package translator
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
 	buffersfake "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/client/clientset/versioned/fake"
 	cbclient "k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/client"
 	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/testutil"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
 )
 
 const defaultNamespace = "default"
@@ -58,7 +69,7 @@ func TestScalabaleObjectsTranslator(t *testing.T) {
 	}
 	fakeKubernetesClient := fakeclient.NewSimpleClientset(podTemplate1, podTemplate2, replicaSet1)
 	fakeBuffersClient := buffersfake.NewSimpleClientset()
-	fakeCapacityBuffersClient, _ := cbclient.NewCapacityBufferClientFromClients(fakeBuffersClient, fakeKubernetesClient, nil, nil)
+	fakeCapacityBuffersClient, _ := cbclient.NewCapacityBufferClientFromClients(fakeBuffersClient, fakeKubernetesClient, nil, nil, nil)
 	tests := []struct {
 		name                   string
 		buffers                []*v1.CapacityBuffer
@@ -156,6 +167,116 @@ func TestScalabaleObjectsTranslator(t *testing.T) {
 	}
 }
 
+func TestScalableObjectsTranslatorSchedule(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replicaSet1",
+			Namespace: defaultNamespace,
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: pointerToInt32(10),
+		},
+	}
+	fakeCapacityBuffersClient, _ := cbclient.NewCapacityBufferClientFromClients(buffersfake.NewSimpleClientset(), fakeclient.NewSimpleClientset(replicaSet), nil, nil, nil)
+	translator := NewDefaultScalableObjectsTranslator(fakeCapacityBuffersClient)
+	translator.clock = clocktesting.NewFakePassiveClock(mustParseTime(t, "2025-01-06T10:00:00Z"))
+
+	buffer := getTestBufferWithScalableAttributes("buffer1", &v1.ScalableRef{
+		Name:     "replicaSet1",
+		Kind:     "ReplicaSet",
+		APIGroup: "apps",
+	}, pointerToInt32(50), nil)
+	buffer.Spec.Schedule = []v1.ScheduleWindow{{
+		Name:       "business-hours",
+		Cron:       "0 9 * * 1-5",
+		Duration:   metav1.Duration{Duration: 8 * time.Hour},
+		Percentage: pointerToInt32(20),
+	}}
+	errors := translator.Translate([]*v1.CapacityBuffer{buffer})
+	assert.Empty(t, errors)
+	assert.Equal(t, pointerToInt32(2), buffer.Status.Replicas)
+	assert.Equal(t, "business-hours", *buffer.Status.ActiveScheduleWindow)
+
+	translator.clock = clocktesting.NewFakePassiveClock(mustParseTime(t, "2025-01-06T18:00:00Z"))
+	errors = translator.Translate([]*v1.CapacityBuffer{buffer})
+	assert.Empty(t, errors)
+	assert.Equal(t, pointerToInt32(5), buffer.Status.Replicas)
+	assert.Nil(t, buffer.Status.ActiveScheduleWindow)
+}
+
+func TestScalableObjectsTranslatorScaleSubresource(t *testing.T) {
+	lwsGVK := schema.GroupVersionKind{Group: "leaderworkerset.x-k8s.io", Version: "v1", Kind: "LeaderWorkerSet"}
+	lws := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "leaderworkerset.x-k8s.io/v1",
+		"kind":       "LeaderWorkerSet",
+		"metadata":   map[string]interface{}{"name": "lws1", "namespace": defaultNamespace},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "main", "image": "default-path"}}},
+			},
+			"leaderWorkerTemplate": map[string]interface{}{
+				"workerTemplate": map[string]interface{}{
+					"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "main", "image": "worker"}}},
+				},
+			},
+		},
+	}}
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "lws1-0", Namespace: defaultNamespace, Labels: map[string]string{"app": "lws1"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: "running-pod"}}},
+	}
+
+	tests := []struct {
+		name             string
+		pods             []runtime.Object
+		podTemplatePaths map[string]string
+		expectedImage    string
+	}{
+		{
+			name:          "template from the most recent pod",
+			pods:          []runtime.Object{runningPod},
+			expectedImage: "running-pod",
+		},
+		{
+			name:          "template from the default path without pods",
+			expectedImage: "default-path",
+		},
+		{
+			name:             "template from the configured path",
+			pods:             []runtime.Object{runningPod},
+			podTemplatePaths: map[string]string{"LeaderWorkerSet.leaderworkerset.x-k8s.io": "spec.leaderWorkerTemplate.workerTemplate"},
+			expectedImage:    "worker",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{lwsGVK.GroupVersion()})
+			mapper.Add(lwsGVK, meta.RESTScopeNamespace)
+			scaleClient := &fakescale.FakeScaleClient{}
+			scaleClient.AddReactor("get", "leaderworkersets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, &autoscalingv1.Scale{Status: autoscalingv1.ScaleStatus{Replicas: 4, Selector: "app=lws1"}}, nil
+			})
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), lws)
+			fakeKubernetesClient := fakeclient.NewSimpleClientset(test.pods...)
+			fakeCapacityBuffersClient, _ := cbclient.NewCapacityBufferClientFromClients(buffersfake.NewSimpleClientset(), fakeKubernetesClient, scaleClient, mapper, dynamicClient)
+			translator := NewScalableObjectsTranslator(fakeCapacityBuffersClient, test.podTemplatePaths)
+
+			buffer := getTestBufferWithScalableAttributes("buffer1", &v1.ScalableRef{
+				Name:     "lws1",
+				Kind:     "LeaderWorkerSet",
+				APIGroup: "leaderworkerset.x-k8s.io",
+			}, pointerToInt32(50), nil)
+			errors := translator.Translate([]*v1.CapacityBuffer{buffer})
+			assert.Empty(t, errors)
+			assert.Equal(t, pointerToInt32(2), buffer.Status.Replicas)
+
+			podTemplate, err := fakeKubernetesClient.CoreV1().PodTemplates(defaultNamespace).Get(context.TODO(), buffer.Status.PodTemplateRef.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedImage, podTemplate.Template.Spec.Containers[0].Image)
+		})
+	}
+}
+
 func getTestBufferWithScalableAttributes(bufferName string, scalableRef *v1.ScalableRef, percentage *int32, replicas *int32) *v1.CapacityBuffer {
 	buffer := &v1.CapacityBuffer{}
 	buffer.Name = bufferName
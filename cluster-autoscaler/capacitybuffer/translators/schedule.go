@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
+)
+
+// maxScheduleChangeLookups bounds the number of window openings and closings checked
+// when looking for the next change of the window applying to a buffer. It's only
+// reached if windows overlap such that the applying window doesn't change for long.
+const maxScheduleChangeLookups = 100
+
+// applySchedule returns the replicas and percentage applying to the buffer at the given
+// time: the ones of the first open window of the buffer schedule, or the ones of the
+// buffer spec if no window is open. A buffer with a schedule but no replicas or percentage
+// of its own has 0 replicas while no window is open. Windows of pod template buffers must
+// set replicas, a percentage alone can't be applied without a scalable object. It records
+// the applying window and the time of the next change in the buffer status.
+func applySchedule(buffer *v1.CapacityBuffer, now time.Time) (*int32, *int32, error) {
+	buffer.Status.ActiveScheduleWindow = nil
+	buffer.Status.NextScheduleChangeTime = nil
+	if len(buffer.Spec.Schedule) == 0 {
+		return buffer.Spec.Replicas, buffer.Spec.Percentage, nil
+	}
+
+	windows := make([]schedule.Window, 0, len(buffer.Spec.Schedule))
+	for _, scheduleWindow := range buffer.Spec.Schedule {
+		if buffer.Spec.PodTemplateRef != nil && scheduleWindow.Replicas == nil {
+			return nil, nil, fmt.Errorf("Schedule window %v of buffer %v sets no replicas, a percentage alone requires a scalableRef", scheduleWindow.Name, buffer.Name)
+		}
+		window, err := schedule.NewWindow(scheduleWindow.Cron, scheduleWindow.Duration.Duration)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid schedule window %v of buffer %v: %v", scheduleWindow.Name, buffer.Name, err)
+		}
+		windows = append(windows, window)
+	}
+
+	if next := nextScheduleChange(windows, now); !next.IsZero() {
+		buffer.Status.NextScheduleChangeTime = &metav1.Time{Time: next}
+	}
+	active := activeWindow(windows, now)
+	if active < 0 {
+		if buffer.Spec.Replicas == nil && buffer.Spec.Percentage == nil {
+			return pointerToInt32(0), nil, nil
+		}
+		return buffer.Spec.Replicas, buffer.Spec.Percentage, nil
+	}
+	scheduleWindow := buffer.Spec.Schedule[active]
+	buffer.Status.ActiveScheduleWindow = &scheduleWindow.Name
+	return scheduleWindow.Replicas, scheduleWindow.Percentage, nil
+}
+
+// activeWindow returns the index of the first window open at the given time, or -1 if
+// none is open.
+func activeWindow(windows []schedule.Window, t time.Time) int {
+	for i, window := range windows {
+		if window.Active(t) {
+			return i
+		}
+	}
+	return -1
+}
+
+// nextScheduleChange returns the earliest time after the given one at which another
+// window applies, or zero time if it isn't found.
+func nextScheduleChange(windows []schedule.Window, now time.Time) time.Time {
+	current := activeWindow(windows, now)
+	t := now
+	for i := 0; i < maxScheduleChangeLookups; i++ {
+		t = nextWindowEdge(windows, t)
+		if t.IsZero() || activeWindow(windows, t) != current {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// nextWindowEdge returns the earliest time after the given one at which any of the
+// windows opens or closes, or zero time if none does within the next five years.
+func nextWindowEdge(windows []schedule.Window, t time.Time) time.Time {
+	var next time.Time
+	for _, window := range windows {
+		for _, edge := range []time.Time{window.NextStart(t), window.End(t)} {
+			if !edge.IsZero() && (next.IsZero() || edge.Before(next)) {
+				next = edge
+			}
+		}
+	}
+	return next
+}
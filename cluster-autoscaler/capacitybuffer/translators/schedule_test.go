@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeClient "k8s.io/client-go/kubernetes/fake"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
+	buffersfake "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/client/clientset/versioned/fake"
+	cbclient "k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/client"
+	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/common"
+	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/testutil"
+)
+
+var (
+	businessHoursWindow = v1.ScheduleWindow{
+		Name:     "business-hours",
+		Cron:     "0 9 * * 1-5",
+		Duration: metav1.Duration{Duration: 8 * time.Hour},
+		Replicas: pointerToInt32(5),
+	}
+	lunchWindow = v1.ScheduleWindow{
+		Name:       "lunch",
+		Cron:       "0 12 * * 1-5",
+		Duration:   metav1.Duration{Duration: time.Hour},
+		Replicas:   pointerToInt32(10),
+		Percentage: pointerToInt32(50),
+	}
+)
+
+// mustParseTime parses a RFC 3339 time. 2025-01-06 is a Monday.
+func mustParseTime(t *testing.T, value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	assert.NoError(t, err)
+	return parsed
+}
+
+func TestApplySchedule(t *testing.T) {
+	tests := []struct {
+		name                       string
+		schedule                   []v1.ScheduleWindow
+		bufferReplicas             *int32
+		now                        string
+		expectedReplicas           *int32
+		expectedPercentage         *int32
+		expectedActiveWindow       *string
+		expectedNextScheduleChange string
+		expectError                bool
+	}{
+		{
+			name:             "no schedule",
+			bufferReplicas:   pointerToInt32(1),
+			now:              "2025-01-06T10:00:00Z",
+			expectedReplicas: pointerToInt32(1),
+		},
+		{
+			name:                       "window open",
+			schedule:                   []v1.ScheduleWindow{businessHoursWindow},
+			bufferReplicas:             pointerToInt32(1),
+			now:                        "2025-01-06T10:00:00Z",
+			expectedReplicas:           pointerToInt32(5),
+			expectedActiveWindow:       &businessHoursWindow.Name,
+			expectedNextScheduleChange: "2025-01-06T17:00:00Z",
+		},
+		{
+			name:                       "no window open",
+			schedule:                   []v1.ScheduleWindow{businessHoursWindow},
+			bufferReplicas:             pointerToInt32(1),
+			now:                        "2025-01-06T07:00:00Z",
+			expectedReplicas:           pointerToInt32(1),
+			expectedNextScheduleChange: "2025-01-06T09:00:00Z",
+		},
+		{
+			name:                       "no window open without buffer replicas",
+			schedule:                   []v1.ScheduleWindow{businessHoursWindow},
+			now:                        "2025-01-06T07:00:00Z",
+			expectedReplicas:           pointerToInt32(0),
+			expectedNextScheduleChange: "2025-01-06T09:00:00Z",
+		},
+		{
+			name:                       "no window open over the weekend",
+			schedule:                   []v1.ScheduleWindow{businessHoursWindow},
+			bufferReplicas:             pointerToInt32(1),
+			now:                        "2025-01-04T12:00:00Z",
+			expectedReplicas:           pointerToInt32(1),
+			expectedNextScheduleChange: "2025-01-06T09:00:00Z",
+		},
+		{
+			name:                       "first of overlapping windows applies",
+			schedule:                   []v1.ScheduleWindow{lunchWindow, businessHoursWindow},
+			bufferReplicas:             pointerToInt32(1),
+			now:                        "2025-01-06T12:30:00Z",
+			expectedReplicas:           pointerToInt32(10),
+			expectedPercentage:         pointerToInt32(50),
+			expectedActiveWindow:       &lunchWindow.Name,
+			expectedNextScheduleChange: "2025-01-06T13:00:00Z",
+		},
+		{
+			name:                       "next change when a preceding window opens",
+			schedule:                   []v1.ScheduleWindow{lunchWindow, businessHoursWindow},
+			bufferReplicas:             pointerToInt32(1),
+			now:                        "2025-01-06T10:00:00Z",
+			expectedReplicas:           pointerToInt32(5),
+			expectedActiveWindow:       &businessHoursWindow.Name,
+			expectedNextScheduleChange: "2025-01-06T12:00:00Z",
+		},
+		{
+			name:                       "next change skips windows not changing the applying one",
+			schedule:                   []v1.ScheduleWindow{businessHoursWindow, lunchWindow},
+			bufferReplicas:             pointerToInt32(1),
+			now:                        "2025-01-06T10:00:00Z",
+			expectedReplicas:           pointerToInt32(5),
+			expectedActiveWindow:       &businessHoursWindow.Name,
+			expectedNextScheduleChange: "2025-01-06T17:00:00Z",
+		},
+		{
+			name: "invalid cron",
+			schedule: []v1.ScheduleWindow{{
+				Name:     "invalid",
+				Cron:     "0 9 * *",
+				Duration: metav1.Duration{Duration: time.Hour},
+				Replicas: pointerToInt32(5),
+			}},
+			bufferReplicas: pointerToInt32(1),
+			now:            "2025-01-06T10:00:00Z",
+			expectError:    true,
+		},
+		{
+			name: "percentage only window of pod template buffer",
+			schedule: []v1.ScheduleWindow{{
+				Name:       "percentage-only",
+				Cron:       "0 9 * * 1-5",
+				Duration:   metav1.Duration{Duration: 8 * time.Hour},
+				Percentage: pointerToInt32(50),
+			}},
+			bufferReplicas: pointerToInt32(1),
+			now:            "2025-01-06T10:00:00Z",
+			expectError:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := testutil.GetPodTemplateRefBuffer(&v1.LocalObjectRef{Name: testutil.SomePodTemplateRefName}, test.bufferReplicas)
+			buffer.Spec.Schedule = test.schedule
+			replicas, percentage, err := applySchedule(buffer, mustParseTime(t, test.now))
+			if test.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, buffer.Status.ActiveScheduleWindow)
+				assert.Nil(t, buffer.Status.NextScheduleChangeTime)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedReplicas, replicas)
+			assert.Equal(t, test.expectedPercentage, percentage)
+			assert.Equal(t, test.expectedActiveWindow, buffer.Status.ActiveScheduleWindow)
+			if test.expectedNextScheduleChange == "" {
+				assert.Nil(t, buffer.Status.NextScheduleChangeTime)
+			} else if assert.NotNil(t, buffer.Status.NextScheduleChangeTime) {
+				assert.True(t, mustParseTime(t, test.expectedNextScheduleChange).Equal(buffer.Status.NextScheduleChangeTime.Time))
+			}
+		})
+	}
+}
+
+func TestScheduledBufferRoundTrip(t *testing.T) {
+	podTemplate := &corev1.PodTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testutil.SomePodTemplateRefName,
+			Namespace:  "default",
+			Generation: 1,
+		},
+	}
+	buffer := testutil.GetPodTemplateRefBuffer(&v1.LocalObjectRef{Name: podTemplate.Name}, pointerToInt32(1))
+	buffer.Name = "scheduled-buffer"
+	buffer.Spec.Schedule = []v1.ScheduleWindow{businessHoursWindow}
+
+	fakeBuffersClient := buffersfake.NewSimpleClientset(buffer)
+	fakeCapacityBuffersClient, _ := cbclient.NewCapacityBufferClientFromClients(fakeBuffersClient, fakeClient.NewSimpleClientset(podTemplate), nil, nil, nil)
+	fakeClock := clocktesting.NewFakePassiveClock(mustParseTime(t, "2025-01-06T10:00:00Z"))
+	translator := NewPodTemplateBufferTranslator(fakeCapacityBuffersClient)
+	translator.clock = fakeClock
+
+	roundTrip := func() *v1.CapacityBuffer {
+		stored, err := fakeBuffersClient.AutoscalingV1alpha1().CapacityBuffers("default").Get(context.TODO(), buffer.Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, translator.Translate([]*v1.CapacityBuffer{stored}))
+		_, err = fakeCapacityBuffersClient.UpdateCapacityBuffer(stored)
+		assert.NoError(t, err)
+		stored, err = fakeBuffersClient.AutoscalingV1alpha1().CapacityBuffers("default").Get(context.TODO(), buffer.Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		return stored
+	}
+
+	stored := roundTrip()
+	assert.Equal(t, []v1.ScheduleWindow{businessHoursWindow}, stored.Spec.Schedule)
+	assert.Equal(t, pointerToInt32(5), stored.Status.Replicas)
+	assert.Equal(t, &businessHoursWindow.Name, stored.Status.ActiveScheduleWindow)
+	assert.True(t, mustParseTime(t, "2025-01-06T17:00:00Z").Equal(stored.Status.NextScheduleChangeTime.Time))
+	assert.Equal(t, common.ConditionTrue, string(stored.Status.Conditions[0].Status))
+
+	fakeClock.SetTime(stored.Status.NextScheduleChangeTime.Time)
+	stored = roundTrip()
+	assert.Equal(t, pointerToInt32(1), stored.Status.Replicas)
+	assert.Nil(t, stored.Status.ActiveScheduleWindow)
+	assert.True(t, mustParseTime(t, "2025-01-07T09:00:00Z").Equal(stored.Status.NextScheduleChangeTime.Time))
+}
+
+func TestScheduledBufferWithoutReplicasOutsideWindows(t *testing.T) {
+	podTemplate := &corev1.PodTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testutil.SomePodTemplateRefName,
+			Namespace:  "default",
+			Generation: 1,
+		},
+	}
+	buffer := testutil.GetPodTemplateRefBuffer(&v1.LocalObjectRef{Name: podTemplate.Name}, nil)
+	buffer.Spec.Schedule = []v1.ScheduleWindow{businessHoursWindow}
+
+	fakeCapacityBuffersClient, _ := cbclient.NewCapacityBufferClientFromClients(buffersfake.NewSimpleClientset(buffer), fakeClient.NewSimpleClientset(podTemplate), nil, nil, nil)
+	translator := NewPodTemplateBufferTranslator(fakeCapacityBuffersClient)
+	translator.clock = clocktesting.NewFakePassiveClock(mustParseTime(t, "2025-01-06T07:00:00Z"))
+
+	assert.Empty(t, translator.Translate([]*v1.CapacityBuffer{buffer}))
+	assert.Equal(t, pointerToInt32(0), buffer.Status.Replicas)
+	assert.Equal(t, common.ConditionTrue, string(buffer.Status.Conditions[0].Status))
+}
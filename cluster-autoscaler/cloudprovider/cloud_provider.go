@@ -263,6 +263,23 @@ type NodeGroup interface {
 	GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error)
 }
 
+// StandbyNodeGroup is an optional extension of NodeGroup implemented by node groups
+// which can keep pre-created instances stopped or hibernated (in standby) and resume
+// them faster than creating new ones. Standby instances don't count towards the
+// target size of the node group.
+type StandbyNodeGroup interface {
+	NodeGroup
+
+	// StandbySize returns the number of instances currently kept in standby.
+	StandbySize() (int, error)
+
+	// SetStandbySize sets the number of instances that should be kept in standby,
+	// creating and stopping or deleting instances as needed. IncreaseSize is expected
+	// to resume standby instances before creating new ones, after which the standby
+	// pool is replenished up to the requested size.
+	SetStandbySize(size int) error
+}
+
 // Instance represents a cloud-provider node. The node does not necessarily map to k8s node
 // i.e it does not have to be registered in k8s cluster despite being returned by NodeGroup.Nodes()
 // method. Also it is sane to have Instance object for nodes which are being created or deleted.
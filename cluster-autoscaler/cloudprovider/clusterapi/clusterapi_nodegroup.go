@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -33,6 +34,7 @@ import (
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
 )
 
 const (
@@ -454,6 +456,15 @@ func (ng *nodegroup) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*c
 	if opt, ok := getDurationOption(options, ng.Id(), config.DefaultMaxNodeProvisionTimeKey); ok {
 		defaults.MaxNodeProvisionTime = opt
 	}
+	if opt, ok := getDurationOption(options, ng.Id(), config.DefaultMaxNodeLifetimeKey); ok {
+		defaults.MaxNodeLifetime = opt
+	}
+	if opt, ok := getWindowsOption(options, ng.Id(), config.DefaultScaleDownMaintenanceWindowsKey); ok {
+		defaults.ScaleDownMaintenanceWindows = opt
+	}
+	if opt, ok := getWindowsOption(options, ng.Id(), config.DefaultScaleDownBlackoutWindowsKey); ok {
+		defaults.ScaleDownBlackoutWindows = opt
+	}
 
 	return &defaults, nil
 }
@@ -565,3 +576,19 @@ func getDurationOption(options map[string]string, templateName, name string) (ti
 
 	return option, true
 }
+
+// getWindowsOption parses a list of schedule windows separated by semicolons.
+func getWindowsOption(options map[string]string, templateName, name string) ([]schedule.Window, bool) {
+	raw, ok := options[name]
+	if !ok {
+		return nil, false
+	}
+
+	option, err := schedule.ParseWindows(strings.Split(raw, ";"))
+	if err != nil {
+		klog.Warningf("failed to convert autoscaling_options option %q (value %q) for scalable resource %q to schedule windows: %v", name, raw, templateName, err)
+		return nil, false
+	}
+
+	return option, true
+}
@@ -33,6 +33,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	gpuapis "k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -1754,6 +1755,8 @@ func TestNodeGroupGetOptions(t *testing.T) {
 				config.DefaultScaleDownUnneededTimeKey:            "1h",
 				config.DefaultScaleDownUnreadyTimeKey:             "30m",
 				config.DefaultMaxNodeProvisionTimeKey:             "60m",
+				config.DefaultMaxNodeLifetimeKey:                  "720h",
+				config.DefaultScaleDownMaintenanceWindowsKey:      "0 22 * * 1-5 8h;@weekly 24h",
 			},
 			expected: &config.NodeGroupAutoscalingOptions{
 				ScaleDownGpuUtilizationThreshold: 0.6,
@@ -1761,6 +1764,8 @@ func TestNodeGroupGetOptions(t *testing.T) {
 				ScaleDownUnneededTime:            time.Hour,
 				ScaleDownUnreadyTime:             30 * time.Minute,
 				MaxNodeProvisionTime:             60 * time.Minute,
+				MaxNodeLifetime:                  720 * time.Hour,
+				ScaleDownMaintenanceWindows:      mustParseWindows(t, "0 22 * * 1-5 8h", "@weekly 24h"),
 			},
 		},
 		{
@@ -1782,6 +1787,7 @@ func TestNodeGroupGetOptions(t *testing.T) {
 			opts: map[string]string{
 				config.DefaultScaleDownGpuUtilizationThresholdKey: "foo",
 				config.DefaultScaleDownUnneededTimeKey:            "bar",
+				config.DefaultScaleDownBlackoutWindowsKey:         "baz",
 			},
 			expected: &defaultOptions,
 		},
@@ -2045,3 +2051,11 @@ func TestNodeGroupNodesInstancesStatus(t *testing.T) {
 		}
 	})
 }
+
+func mustParseWindows(t *testing.T, specs ...string) []schedule.Window {
+	windows, err := schedule.ParseWindows(specs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return windows
+}
@@ -26,6 +26,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -48,10 +49,16 @@ const (
 
 // externalGrpcCloudProvider implements CloudProvider interface.
 type externalGrpcCloudProvider struct {
+	*providerCache
 	resourceLimiter *cloudprovider.ResourceLimiter
 	client          protos.CloudProviderClient
 	grpcTimeout     time.Duration
+	// ctx is the parent context of gRPC calls, set by WithContext. Can be nil.
+	ctx context.Context
+}
 
+// providerCache caches results of gRPC calls, it's shared by the copies made by WithContext.
+type providerCache struct {
 	mutex                 sync.Mutex
 	nodeGroupForNodeCache map[string]cloudprovider.NodeGroup // used to cache NodeGroupForNode grpc calls. Discarded at each Refresh()
 	nodeGroupsCache       []cloudprovider.NodeGroup          // used to cache NodeGroups grpc calls. Discarded at each Refresh()
@@ -59,6 +66,18 @@ type externalGrpcCloudProvider struct {
 	gpuTypesCache         map[string]struct{}                // used to cache GetAvailableGPUTypes grpc calls
 }
 
+// WithContext returns a copy of the cloud provider making its gRPC calls as children
+// of the span in ctx. Node groups returned by the copy aren't bound to ctx.
+func (e *externalGrpcCloudProvider) WithContext(ctx context.Context) cloudprovider.CloudProvider {
+	return &externalGrpcCloudProvider{
+		providerCache:   e.providerCache,
+		resourceLimiter: e.resourceLimiter,
+		client:          e.client,
+		grpcTimeout:     e.grpcTimeout,
+		ctx:             ctx,
+	}
+}
+
 // Name returns name of the cloud provider.
 func (e *externalGrpcCloudProvider) Name() string {
 	return cloudprovider.ExternalGrpcProviderName
@@ -74,7 +93,7 @@ func (e *externalGrpcCloudProvider) NodeGroups() []cloudprovider.NodeGroup {
 		return e.nodeGroupsCache
 	}
 	nodeGroups := make([]cloudprovider.NodeGroup, 0)
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := callContext(e.ctx, e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call NodeGroups")
 	res, err := e.client.NodeGroups(ctx, &protos.NodeGroupsRequest{})
@@ -114,7 +133,7 @@ func (e *externalGrpcCloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudpro
 		return ng, nil
 	}
 	// perform grpc call
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := callContext(e.ctx, e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupForNode for node %v - %v", node.Name, node.Spec.ProviderID)
 	res, err := e.client.NodeGroupForNode(ctx, &protos.NodeGroupForNodeRequest{
@@ -149,11 +168,12 @@ func (e *externalGrpcCloudProvider) HasInstance(node *apiv1.Node) (bool, error)
 type pricingModel struct {
 	client      protos.CloudProviderClient
 	grpcTimeout time.Duration
+	ctx         context.Context
 }
 
 // NodePrice returns a price of running the given node for a given period of time.
 func (m *pricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), m.grpcTimeout)
+	ctx, cancel := callContext(m.ctx, m.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call PricingNodePrice for node %v", node.Name)
 	start := metav1.NewTime(startTime)
@@ -177,7 +197,7 @@ func (m *pricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime
 // PodPrice returns a theoretical minimum price of running a pod for a given
 // period of time on a perfectly matching machine.
 func (m *pricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), m.grpcTimeout)
+	ctx, cancel := callContext(m.ctx, m.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call PricingPodPrice for pod %v", pod.Name)
 	start := metav1.NewTime(startTime)
@@ -208,6 +228,7 @@ func (e *externalGrpcCloudProvider) Pricing() (cloudprovider.PricingModel, error
 	return &pricingModel{
 		client:      e.client,
 		grpcTimeout: e.grpcTimeout,
+		ctx:         e.ctx,
 	}, nil
 }
 
@@ -239,7 +260,7 @@ func (e *externalGrpcCloudProvider) GPULabel() string {
 		klog.V(5).Info("Returning cached GPULabel")
 		return *e.gpuLabelCache
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := callContext(e.ctx, e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call GPULabel")
 	res, err := e.client.GPULabel(ctx, &protos.GPULabelRequest{})
@@ -261,7 +282,7 @@ func (e *externalGrpcCloudProvider) GetAvailableGPUTypes() map[string]struct{} {
 		klog.V(5).Info("Returning cached GetAvailableGPUTypes")
 		return e.gpuTypesCache
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := callContext(e.ctx, e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call GetAvailableGPUTypes")
 	res, err := e.client.GetAvailableGPUTypes(ctx, &protos.GetAvailableGPUTypesRequest{})
@@ -286,7 +307,7 @@ func (e *externalGrpcCloudProvider) GetNodeGpuConfig(node *apiv1.Node) *cloudpro
 
 // Cleanup cleans up open resources before the cloud provider is destroyed, i.e. go routines etc.
 func (e *externalGrpcCloudProvider) Cleanup() error {
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := callContext(e.ctx, e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call Cleanup")
 	_, err := e.client.Cleanup(ctx, &protos.CleanupRequest{})
@@ -305,7 +326,7 @@ func (e *externalGrpcCloudProvider) Refresh() error {
 	e.nodeGroupForNodeCache = make(map[string]cloudprovider.NodeGroup)
 	e.nodeGroupsCache = nil
 	e.mutex.Unlock()
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := callContext(e.ctx, e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call Refresh")
 	_, err := e.client.Refresh(ctx, &protos.RefreshRequest{})
@@ -389,7 +410,7 @@ func newExternalGrpcCloudProviderClient(config []byte) (protos.CloudProviderClie
 		})
 		dialOpt = grpc.WithTransportCredentials(transportCreds)
 	}
-	conn, err := grpc.Dial(yamlConfig.Address, dialOpt)
+	conn, err := grpc.Dial(yamlConfig.Address, dialOpt, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to dial server: %v", err)
 	}
@@ -404,11 +425,22 @@ func newExternalGrpcCloudProviderClient(config []byte) (protos.CloudProviderClie
 
 func newExternalGrpcCloudProvider(client protos.CloudProviderClient, grpcTimeout time.Duration, rl *cloudprovider.ResourceLimiter) cloudprovider.CloudProvider {
 	return &externalGrpcCloudProvider{
-		resourceLimiter:       rl,
-		client:                client,
-		grpcTimeout:           grpcTimeout,
-		nodeGroupForNodeCache: make(map[string]cloudprovider.NodeGroup),
+		providerCache: &providerCache{
+			nodeGroupForNodeCache: make(map[string]cloudprovider.NodeGroup),
+		},
+		resourceLimiter: rl,
+		client:          client,
+		grpcTimeout:     grpcTimeout,
+	}
+}
+
+// callContext returns the context of a gRPC call, a child of parent or of
+// context.Background() if parent is nil.
+func callContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if parent == nil {
+		parent = context.Background()
 	}
+	return context.WithTimeout(parent, timeout)
 }
 
 // externalGrpcNode converts an apiv1.Node to a protos.ExternalGrpcNode.
@@ -17,18 +17,25 @@ limitations under the License.
 package externalgrpc
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/externalgrpc/protos"
+	"k8s.io/autoscaler/cluster-autoscaler/tracing"
 )
 
 func TestCloudProvider_NodeGroups(t *testing.T) {
@@ -543,3 +550,64 @@ func TestCloudProvider_Refresh(t *testing.T) {
 	err = c.Refresh()
 	assert.Error(t, err)
 }
+
+func TestCloudProvider_RefreshTraceContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	client, m, teardown := setupTracedTest(t)
+	defer teardown()
+	c := newExternalGrpcCloudProvider(client, defaultGRPCTimeout, nil)
+	m.On("Refresh", mock.Anything, mock.Anything).Return(&protos.RefreshResponse{}, nil).Twice()
+
+	loopCtx, loopSpan := tracing.Start(context.Background(), "RunOnce")
+	assert.NoError(t, tracing.Bind(loopCtx, c).Refresh())
+	loopSpan.End()
+	// calls made off the main loop start their own trace
+	assert.NoError(t, c.Refresh())
+
+	serverSpans := func() []sdktrace.ReadOnlySpan {
+		var spans []sdktrace.ReadOnlySpan
+		for _, span := range recorder.Ended() {
+			if span.SpanKind() == trace.SpanKindServer {
+				spans = append(spans, span)
+			}
+		}
+		return spans
+	}
+	assert.Eventually(t, func() bool { return len(serverSpans()) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	spansById := map[trace.SpanID]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spansById[span.SpanContext().SpanID()] = span
+	}
+	loopTraceId := loopSpan.SpanContext().TraceID()
+	var bound, unbound sdktrace.ReadOnlySpan
+	for _, span := range serverSpans() {
+		if span.SpanContext().TraceID() == loopTraceId {
+			bound = span
+		} else {
+			unbound = span
+		}
+	}
+	if assert.NotNil(t, bound) {
+		// the server span is a child of the client span, which is a child of the loop span
+		clientSpan, found := spansById[bound.Parent().SpanID()]
+		if assert.True(t, found) {
+			assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+			assert.Equal(t, loopSpan.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+		}
+	}
+	if assert.NotNil(t, unbound) {
+		clientSpan, found := spansById[unbound.Parent().SpanID()]
+		if assert.True(t, found) {
+			assert.False(t, clientSpan.Parent().IsValid())
+		}
+	}
+}
@@ -42,11 +42,30 @@ type NodeGroup struct {
 	debug       string // cached value
 	client      protos.CloudProviderClient
 	grpcTimeout time.Duration
+	// ctx is the parent context of gRPC calls, set by WithContext. Can be nil.
+	ctx context.Context
 
 	mutex    sync.Mutex
 	nodeInfo **framework.NodeInfo // used to cache NodeGroupTemplateNodeInfo() grpc calls
 }
 
+// WithContext returns a copy of the node group making its gRPC calls as children
+// of the span in ctx.
+func (n *NodeGroup) WithContext(ctx context.Context) cloudprovider.NodeGroup {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return &NodeGroup{
+		id:          n.id,
+		minSize:     n.minSize,
+		maxSize:     n.maxSize,
+		debug:       n.debug,
+		client:      n.client,
+		grpcTimeout: n.grpcTimeout,
+		ctx:         ctx,
+		nodeInfo:    n.nodeInfo,
+	}
+}
+
 // MaxSize returns maximum size of the node group.
 func (n *NodeGroup) MaxSize() int {
 	return n.maxSize
@@ -63,7 +82,7 @@ func (n *NodeGroup) MinSize() int {
 // registration or removed nodes are deleted completely). Implementation
 // required.
 func (n *NodeGroup) TargetSize() (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), n.grpcTimeout)
+	ctx, cancel := callContext(n.ctx, n.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupTargetSize for node group %v", n.id)
 	res, err := n.client.NodeGroupTargetSize(ctx, &protos.NodeGroupTargetSizeRequest{
@@ -80,7 +99,7 @@ func (n *NodeGroup) TargetSize() (int, error) {
 // to explicitly name it and use DeleteNode. This function should wait until
 // node group size is updated. Implementation required.
 func (n *NodeGroup) IncreaseSize(delta int) error {
-	ctx, cancel := context.WithTimeout(context.Background(), n.grpcTimeout)
+	ctx, cancel := callContext(n.ctx, n.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupIncreaseSize for node group %v", n.id)
 	_, err := n.client.NodeGroupIncreaseSize(ctx, &protos.NodeGroupIncreaseSizeRequest{
@@ -108,7 +127,7 @@ func (n *NodeGroup) DeleteNodes(nodes []*apiv1.Node) error {
 	for _, n := range nodes {
 		pbNodes = append(pbNodes, externalGrpcNode(n))
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), n.grpcTimeout)
+	ctx, cancel := callContext(n.ctx, n.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupDeleteNodes for node group %v", n.id)
 	_, err := n.client.NodeGroupDeleteNodes(ctx, &protos.NodeGroupDeleteNodesRequest{
@@ -133,7 +152,7 @@ func (n *NodeGroup) ForceDeleteNodes(nodes []*apiv1.Node) error {
 // It is assumed that cloud provider will not delete the existing nodes when there
 // is an option to just decrease the target. Implementation required.
 func (n *NodeGroup) DecreaseTargetSize(delta int) error {
-	ctx, cancel := context.WithTimeout(context.Background(), n.grpcTimeout)
+	ctx, cancel := callContext(n.ctx, n.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupDecreaseTargetSize for node group %v", n.id)
 	_, err := n.client.NodeGroupDecreaseTargetSize(ctx, &protos.NodeGroupDecreaseTargetSizeRequest{
@@ -161,7 +180,7 @@ func (n *NodeGroup) Debug() string {
 // required that Instance objects returned by this method have Id field set.
 // Other fields are optional.
 func (n *NodeGroup) Nodes() ([]cloudprovider.Instance, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), n.grpcTimeout)
+	ctx, cancel := callContext(n.ctx, n.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupNodes for node group %v", n.id)
 	res, err := n.client.NodeGroupNodes(ctx, &protos.NodeGroupNodesRequest{
@@ -213,7 +232,7 @@ func (n *NodeGroup) TemplateNodeInfo() (*framework.NodeInfo, error) {
 		klog.V(5).Infof("Returning cached nodeInfo for node group %v", n.id)
 		return *n.nodeInfo, nil
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), n.grpcTimeout)
+	ctx, cancel := callContext(n.ctx, n.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupTemplateNodeInfo for node group %v", n.id)
 	res, err := n.client.NodeGroupTemplateNodeInfo(ctx, &protos.NodeGroupTemplateNodeInfoRequest{
@@ -266,7 +285,7 @@ func (n *NodeGroup) Autoprovisioned() bool {
 // GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
 // NodeGroup. Returning a nil will result in using default options.
 func (n *NodeGroup) GetOptions(defaults config.NodeGroupAutoscalingOptions) (*config.NodeGroupAutoscalingOptions, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), n.grpcTimeout)
+	ctx, cancel := callContext(n.ctx, n.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupGetOptions for node group %v", n.id)
 	res, err := n.client.NodeGroupGetOptions(ctx, &protos.NodeGroupAutoscalingOptionsRequest{
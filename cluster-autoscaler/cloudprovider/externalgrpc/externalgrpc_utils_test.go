@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/externalgrpc/protos"
 )
@@ -109,14 +110,27 @@ func (c *cloudProviderServerMock) NodeGroupGetOptions(ctx context.Context, req *
 }
 
 func setupTest(t *testing.T) (protos.CloudProviderClient, *cloudProviderServerMock, func()) {
+	t.Helper()
+	return setupTestWithOptions(t, nil, nil)
+}
+
+// setupTracedTest is setupTest with OpenTelemetry instrumentation of both the client and the server.
+func setupTracedTest(t *testing.T) (protos.CloudProviderClient, *cloudProviderServerMock, func()) {
+	t.Helper()
+	return setupTestWithOptions(t,
+		[]grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler())},
+		[]grpc.DialOption{grpc.WithStatsHandler(otelgrpc.NewClientHandler())})
+}
+
+func setupTestWithOptions(t *testing.T, serverOptions []grpc.ServerOption, dialOptions []grpc.DialOption) (protos.CloudProviderClient, *cloudProviderServerMock, func()) {
 	t.Helper()
 	lis, err := net.Listen("tcp", ":0")
 	require.NoError(t, err)
 
-	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	conn, err := grpc.Dial(lis.Addr().String(), append(dialOptions, grpc.WithInsecure())...)
 	require.NoError(t, err)
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(serverOptions...)
 	m := &cloudProviderServerMock{}
 	protos.RegisterCloudProviderServer(server, m)
 	require.NoError(t, err)
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -149,5 +150,68 @@ func LoadConfigFile(kubeClient kubeclient.Interface) (*KwokProviderConfig, error
 		kwokConfig.Kwok = &KwokConfig{}
 	}
 
+	for ngName, behaviour := range kwokConfig.Kwok.NodeGroups {
+		if err := validateNodeGroupBehaviour(behaviour); err != nil {
+			return nil, fmt.Errorf("invalid value for 'kwok.nodegroups.%s' in kwok config: %v", ngName, err)
+		}
+		sort.SliceStable(behaviour.Scenario, func(i, j int) bool {
+			return behaviour.Scenario[i].After.Duration < behaviour.Scenario[j].After.Duration
+		})
+	}
+
 	return &kwokConfig, nil
 }
+
+func validateNodeGroupBehaviour(b *NodeGroupBehaviourConfig) error {
+	if b == nil {
+		return errors.New("nodegroup behaviour is empty")
+	}
+	if err := validateBehaviourOverrides(b.CreationDelay, b.DeletionDelay, b.Failure); err != nil {
+		return err
+	}
+	for i, step := range b.Scenario {
+		if step.After.Duration < 0 {
+			return fmt.Errorf("scenario[%d].after can't be negative", i)
+		}
+		if err := validateBehaviourOverrides(step.CreationDelay, step.DeletionDelay, step.Failure); err != nil {
+			return fmt.Errorf("scenario[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func validateBehaviourOverrides(creationDelay, deletionDelay *DelayConfig, failure *FailureConfig) error {
+	if err := validateDelay(creationDelay); err != nil {
+		return fmt.Errorf("creationDelay: %v", err)
+	}
+	if err := validateDelay(deletionDelay); err != nil {
+		return fmt.Errorf("deletionDelay: %v", err)
+	}
+	if failure == nil {
+		return nil
+	}
+	if failure.Type != failureTypeOutOfStock && failure.Type != failureTypeQuota {
+		return fmt.Errorf("failure.type is invalid (expected: '%s' or '%s'): %s",
+			failureTypeOutOfStock, failureTypeQuota, failure.Type)
+	}
+	if failure.Probability < 0 || failure.Probability > 1 {
+		return fmt.Errorf("failure.probability has to be between 0 and 1: %v", failure.Probability)
+	}
+	return nil
+}
+
+func validateDelay(dc *DelayConfig) error {
+	if dc == nil {
+		return nil
+	}
+	if dc.Fixed.Duration < 0 || dc.Min.Duration < 0 || dc.Max.Duration < 0 {
+		return errors.New("delays can't be negative")
+	}
+	if dc.Max.Duration < dc.Min.Duration {
+		return fmt.Errorf("max '%v' can't be lesser than min '%v'", dc.Max.Duration, dc.Min.Duration)
+	}
+	if dc.Fixed.Duration > 0 && dc.Max.Duration > 0 {
+		return errors.New("please specify either 'fixed' or 'min' and 'max' (you can't use both)")
+	}
+	return nil
+}
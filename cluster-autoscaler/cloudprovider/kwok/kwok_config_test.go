@@ -18,6 +18,7 @@ package kwok
 
 import (
 	"testing"
+	"time"
 
 	"os"
 
@@ -34,6 +35,7 @@ var testConfigs = map[string]string{
 	"without-kwok":             withoutKwok,
 	"with-static-kwok-release": withStaticKwokRelease,
 	"skip-kwok-install":        skipKwokInstall,
+	"with-behaviour":           withNodeGroupBehaviour,
 }
 
 // with node templates from configmap
@@ -282,4 +284,60 @@ func TestLoadConfigFile(t *testing.T) {
 	assert.NotNil(t, kwokConfig)
 	assert.NotNil(t, kwokConfig.status)
 	assert.NotEmpty(t, kwokConfig.status.gpuLabel)
+
+	os.Setenv("KWOK_PROVIDER_CONFIGMAP", "with-behaviour")
+	kwokConfig, err = LoadConfigFile(fakeClient)
+	assert.Nil(t, err)
+	assert.NotNil(t, kwokConfig)
+	assert.Equal(t, int64(42), kwokConfig.Kwok.Seed)
+	behaviour := kwokConfig.Kwok.NodeGroups["m5.xlarge"]
+	assert.NotNil(t, behaviour)
+	assert.Equal(t, 30*time.Second, behaviour.CreationDelay.Fixed.Duration)
+	assert.Equal(t, 10*time.Second, behaviour.DeletionDelay.Min.Duration)
+	assert.Equal(t, time.Minute, behaviour.DeletionDelay.Max.Duration)
+	assert.Equal(t, failureTypeQuota, behaviour.Failure.Type)
+	// scenario steps are sorted by 'after'
+	assert.Len(t, behaviour.Scenario, 2)
+	assert.Equal(t, 5*time.Minute, behaviour.Scenario[0].After.Duration)
+	assert.Equal(t, failureTypeOutOfStock, behaviour.Scenario[0].Failure.Type)
+	assert.Equal(t, 15*time.Minute, behaviour.Scenario[1].After.Duration)
 }
+
+const withNodeGroupBehaviour = `
+apiVersion: v1alpha1
+readNodesFrom: configmap # possible values: [cluster,configmap]
+nodegroups:
+  fromNodeLabelKey: "node.kubernetes.io/instance-type"
+nodes:
+  gpuConfig:
+    # to tell kwok provider what label should be considered as GPU label
+    gpuLabelKey: "k8s.amazonaws.com/accelerator"
+    availableGPUTypes:
+      "nvidia-tesla-k80": {}
+      "nvidia-tesla-p100": {}
+configmap:
+  name: kwok-provider-templates
+kwok:
+  seed: 42
+  nodegroups:
+    m5.xlarge:
+      creationDelay:
+        fixed: 30s
+      deletionDelay:
+        min: 10s
+        max: 1m
+      failure:
+        type: quota
+        probability: 0.1
+      scenario:
+      - after: 15m
+        failure:
+          type: outOfStock
+          probability: 0
+      - after: 5m
+        creationDelay:
+          fixed: 2m
+        failure:
+          type: outOfStock
+          probability: 1
+`
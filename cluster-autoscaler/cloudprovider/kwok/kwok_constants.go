@@ -43,6 +43,17 @@ const (
 	// for kwok provider config
 	nodeTemplatesFromConfigMap = "configmap"
 	nodeTemplatesFromCluster   = "cluster"
+
+	// failure types which can be injected for new instances
+	failureTypeOutOfStock = "outOfStock"
+	failureTypeQuota      = "quota"
+
+	// ErrorCodeOutOfStock is the error code reported for instances which failed
+	// to be created because of a simulated stockout
+	ErrorCodeOutOfStock = "OUT_OF_STOCK"
+	// ErrorCodeQuotaExceeded is the error code reported for instances which failed
+	// to be created because of a simulated quota error
+	ErrorCodeQuotaExceeded = "QUOTA_EXCEEDED"
 )
 
 const testTemplates = `
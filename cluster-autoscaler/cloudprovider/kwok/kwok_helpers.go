@@ -25,6 +25,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 
@@ -178,6 +179,9 @@ func createNodegroups(nodes []*apiv1.Node, kubeClient kubernetes.Interface, kc *
 
 		ng.kubeClient = kubeClient
 		ng.lister = initCustomLister(allNodeLister, filterFn)
+		if kc.Kwok != nil && kc.Kwok.NodeGroups[ngName] != nil {
+			ng.simulator = newInstanceSimulator(kc.Kwok.NodeGroups[ngName], kc.Kwok.Seed, time.Now)
+		}
 
 		ngs[ngName] = ng
 	}
@@ -36,6 +36,7 @@ const (
 	belowMinSizeErr                 = "can't delete nodes because nodegroup size would go below min size"
 	notManagedByKwokErr             = "can't delete node '%v' because it is not managed by kwok"
 	sizeDecreaseMustBeNegativeErr   = "size decrease must be negative"
+	standbySizeMustNotBeNegativeErr = "standby size must not be negative"
 	attemptToDeleteExistingNodesErr = "attempt to delete existing nodes"
 )
 
@@ -57,6 +58,16 @@ func (nodeGroup *NodeGroup) TargetSize() (int, error) {
 
 // IncreaseSize increases NodeGroup size.
 func (nodeGroup *NodeGroup) IncreaseSize(delta int) error {
+	return nodeGroup.increaseSize(delta, false)
+}
+
+// AtomicIncreaseSize increases NodeGroup size in an all-or-nothing manner:
+// if a failure is injected for the nodegroup, all the new instances fail.
+func (nodeGroup *NodeGroup) AtomicIncreaseSize(delta int) error {
+	return nodeGroup.increaseSize(delta, true)
+}
+
+func (nodeGroup *NodeGroup) increaseSize(delta int, atomic bool) error {
 	if delta <= 0 {
 		return fmt.Errorf(sizeIncreaseMustBePositiveErr)
 	}
@@ -73,14 +84,28 @@ func (nodeGroup *NodeGroup) IncreaseSize(delta int) error {
 		return fmt.Errorf("couldn't create a template node for nodegroup %s", nodeGroup.name)
 	}
 
+	newNodes := make([]*apiv1.Node, 0, delta)
 	for i := 0; i < delta; i++ {
-		node := schedNode.Node()
+		node := schedNode.Node().DeepCopy()
 		node.Name = fmt.Sprintf("%s-%s", nodeGroup.name, rand.String(5))
 		if node.Annotations == nil {
 			node.Annotations = map[string]string{}
 		}
 		node.Annotations["metrics.k8s.io/resource-metrics-path"] = fmt.Sprintf("/metrics/nodes/%s/metrics/resource", node.Name)
 		node.Spec.ProviderID = getProviderID(node.Name)
+		newNodes = append(newNodes, node)
+	}
+
+	createNow := newNodes
+	if nodeGroup.simulator != nil {
+		// standby instances are resumed right away, only the remaining ones
+		// go through the simulated creation delay and failures
+		createNow = nodeGroup.simulator.scheduleCreation(newNodes, min(delta, nodeGroup.standbySize), atomic)
+		// instances with a delay or a failure are part of the target size right away
+		nodeGroup.targetSize += len(newNodes) - len(createNow)
+	}
+
+	for _, node := range createNow {
 		_, err := nodeGroup.kubeClient.CoreV1().Nodes().Create(context.Background(), node, v1.CreateOptions{})
 		if err != nil {
 			return fmt.Errorf("couldn't create new node '%s': %v", node.Name, err)
@@ -91,9 +116,22 @@ func (nodeGroup *NodeGroup) IncreaseSize(delta int) error {
 	return nil
 }
 
-// AtomicIncreaseSize is not implemented.
-func (nodeGroup *NodeGroup) AtomicIncreaseSize(delta int) error {
-	return cloudprovider.ErrNotImplemented
+// StandbySize returns the number of instances kept in standby.
+func (nodeGroup *NodeGroup) StandbySize() (int, error) {
+	return nodeGroup.standbySize, nil
+}
+
+// SetStandbySize sets the number of instances kept in standby. Standby
+// instances aren't backed by nodes, resuming them on scale-up skips the
+// simulated creation delay and failures. The standby pool is replenished
+// right away after instances are resumed.
+func (nodeGroup *NodeGroup) SetStandbySize(size int) error {
+	if size < 0 {
+		return fmt.Errorf(standbySizeMustNotBeNegativeErr)
+	}
+	klog.V(5).Infof("setting standby size of nodegroup '%s' to %v (old size: %v)", nodeGroup.name, size, nodeGroup.standbySize)
+	nodeGroup.standbySize = size
+	return nil
 }
 
 // DeleteNodes deletes the specified nodes from the node group.
@@ -108,11 +146,30 @@ func (nodeGroup *NodeGroup) DeleteNodes(nodes []*apiv1.Node) error {
 	}
 
 	for _, node := range nodes {
+		// instances which don't have a node in the cluster yet (delayed or failed)
+		if nodeGroup.simulator != nil && nodeGroup.simulator.removeCreating(node.Spec.ProviderID) {
+			nodeGroup.targetSize -= 1
+			continue
+		}
+
 		// TODO(vadasambar): check if there's a better way than returning an error here
 		if node.GetAnnotations()[KwokManagedAnnotation] != "fake" {
 			return fmt.Errorf(notManagedByKwokErr, node.GetName())
 		}
 
+		if nodeGroup.simulator != nil {
+			deleteNow, alreadyDeleting := nodeGroup.simulator.scheduleDeletion(node.GetName())
+			if alreadyDeleting {
+				// the node was already removed from the target size
+				continue
+			}
+			if !deleteNow {
+				// node is deleted from the cluster once the deletion delay passes
+				nodeGroup.targetSize -= 1
+				continue
+			}
+		}
+
 		// TODO(vadasambar): proceed to delete the next node if the current node deletion errors
 		// TODO(vadasambar): collect all the errors and return them after attempting to delete all the nodes to be deleted
 		err := nodeGroup.kubeClient.CoreV1().Nodes().Delete(context.Background(), node.GetName(), v1.DeleteOptions{})
@@ -147,11 +204,41 @@ func (nodeGroup *NodeGroup) DecreaseTargetSize(delta int) error {
 			attemptToDeleteExistingNodesErr, size, delta, len(nodes))
 	}
 
+	if nodeGroup.simulator != nil {
+		nodeGroup.simulator.cancelCreating(-delta)
+	}
 	nodeGroup.targetSize = newSize
 
 	return nil
 }
 
+// processSimulatedInstances creates and deletes the nodes whose simulated
+// creation or deletion delay has passed and returns the names of the nodes
+// which were created and deleted
+func (nodeGroup *NodeGroup) processSimulatedInstances() (created []string, deleted []string) {
+	if nodeGroup.simulator == nil {
+		return nil, nil
+	}
+
+	toCreate, toDelete := nodeGroup.simulator.popDue()
+	for _, node := range toCreate {
+		if _, err := nodeGroup.kubeClient.CoreV1().Nodes().Create(context.Background(), node, v1.CreateOptions{}); err != nil {
+			klog.Errorf("couldn't create new node '%s': %v", node.GetName(), err)
+			nodeGroup.simulator.markFailed(node, err)
+			continue
+		}
+		created = append(created, node.GetName())
+	}
+	for _, nodeName := range toDelete {
+		if err := nodeGroup.kubeClient.CoreV1().Nodes().Delete(context.Background(), nodeName, v1.DeleteOptions{}); err != nil {
+			klog.Errorf("couldn't delete node '%s': %v", nodeName, err)
+			continue
+		}
+		deleted = append(deleted, nodeName)
+	}
+	return created, deleted
+}
+
 // getNodeNamesForNodeGroup returns list of nodes belonging to the nodegroup
 func (nodeGroup *NodeGroup) getNodeNamesForNodeGroup() ([]string, error) {
 	names := []string{}
@@ -185,6 +272,9 @@ func (nodeGroup *NodeGroup) Nodes() ([]cloudprovider.Instance, error) {
 	if err != nil {
 		return instances, err
 	}
+	if nodeGroup.simulator != nil {
+		return nodeGroup.simulator.instances(nodeNames), nil
+	}
 	for _, nodeName := range nodeNames {
 		instances = append(instances, cloudprovider.Instance{Id: getProviderID(nodeName), Status: &cloudprovider.InstanceStatus{
 			State:     cloudprovider.InstanceRunning,
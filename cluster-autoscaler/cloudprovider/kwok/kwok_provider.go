@@ -126,6 +126,19 @@ func (kwok *KwokCloudProvider) GetNodeGpuConfig(node *apiv1.Node) *cloudprovider
 // Refresh is called before every main loop and can be used to dynamically update cloud provider state.
 // In particular the list of node groups returned by NodeGroups can change as a result of CloudProvider.Refresh().
 func (kwok *KwokCloudProvider) Refresh() error {
+	// simulated instances are processed first so that the nodes created
+	// and deleted by them are taken into account in the target sizes
+	createdNodes := make(map[string]string)
+	deletedNodes := make(map[string]bool)
+	for _, ng := range kwok.nodeGroups {
+		created, deleted := ng.processSimulatedInstances()
+		for _, nodeName := range created {
+			createdNodes[nodeName] = ng.Id()
+		}
+		for _, nodeName := range deleted {
+			deletedNodes[nodeName] = true
+		}
+	}
 
 	allNodes, err := kwok.allNodesLister.List(labels.Everything())
 	if err != nil {
@@ -137,15 +150,23 @@ func (kwok *KwokCloudProvider) Refresh() error {
 
 	for _, node := range allNodes {
 		ngName := getNGName(node, kwok.config)
-		if ngName == "" {
+		if ngName == "" || deletedNodes[node.GetName()] {
 			continue
 		}
+		delete(createdNodes, node.GetName())
 
 		targetSizeInCluster[ngName] += 1
 	}
+	// the lister might not have observed the nodes created above yet
+	for _, ngName := range createdNodes {
+		targetSizeInCluster[ngName] += 1
+	}
 
 	for _, ng := range kwok.nodeGroups {
 		ng.targetSize = targetSizeInCluster[ng.Id()]
+		if ng.simulator != nil {
+			ng.targetSize += ng.simulator.sizeDelta()
+		}
 	}
 
 	return nil
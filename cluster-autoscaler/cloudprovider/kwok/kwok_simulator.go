@@ -0,0 +1,278 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kwok
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+)
+
+// instanceSimulator simulates the provisioning behaviour of a cloud provider
+// for a single nodegroup: creation and deletion latency, and stockout/quota
+// errors for new instances. Node creation and deletion is only performed
+// once the simulated delay has passed, i.e. on the first Refresh() after that.
+type instanceSimulator struct {
+	sync.Mutex
+	config    *NodeGroupBehaviourConfig
+	startTime time.Time
+	now       func() time.Time
+	rand      *rand.Rand
+	// creating holds instances which don't have a node in the cluster yet
+	// (either waiting for the creation delay to pass or failed), keyed by provider ID
+	creating map[string]*simulatedInstance
+	// deleting holds the time after which a node can be deleted, keyed by node name
+	deleting map[string]time.Time
+}
+
+// simulatedInstance is an instance which doesn't have a node in the cluster yet
+type simulatedInstance struct {
+	node      *apiv1.Node
+	createAt  time.Time
+	errorInfo *cloudprovider.InstanceErrorInfo
+}
+
+func newInstanceSimulator(config *NodeGroupBehaviourConfig, seed int64, now func() time.Time) *instanceSimulator {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &instanceSimulator{
+		config:    config,
+		startTime: now(),
+		now:       now,
+		rand:      rand.New(rand.NewSource(seed)),
+		creating:  map[string]*simulatedInstance{},
+		deleting:  map[string]time.Time{},
+	}
+}
+
+// currentBehaviour returns the behaviour config with all the scenario steps
+// which are already active applied on top of it.
+func (s *instanceSimulator) currentBehaviour() (creationDelay, deletionDelay *DelayConfig, failure *FailureConfig) {
+	creationDelay, deletionDelay, failure = s.config.CreationDelay, s.config.DeletionDelay, s.config.Failure
+	elapsed := s.now().Sub(s.startTime)
+	// scenario steps are sorted by 'after' when the config is loaded
+	for _, step := range s.config.Scenario {
+		if step.After.Duration > elapsed {
+			break
+		}
+		if step.CreationDelay != nil {
+			creationDelay = step.CreationDelay
+		}
+		if step.DeletionDelay != nil {
+			deletionDelay = step.DeletionDelay
+		}
+		if step.Failure != nil {
+			failure = step.Failure
+		}
+	}
+	return creationDelay, deletionDelay, failure
+}
+
+// delay returns a delay based on the config (0 if config is nil)
+func (s *instanceSimulator) delay(dc *DelayConfig) time.Duration {
+	if dc == nil {
+		return 0
+	}
+	if dc.Max.Duration > dc.Min.Duration {
+		return dc.Min.Duration + time.Duration(s.rand.Int63n(int64(dc.Max.Duration-dc.Min.Duration)))
+	}
+	if dc.Min.Duration > 0 {
+		// min and max are equal
+		return dc.Min.Duration
+	}
+	return dc.Fixed.Duration
+}
+
+// failure rolls the dice for a new instance and returns
+// the error info if the instance should fail
+func (s *instanceSimulator) failure(fc *FailureConfig) *cloudprovider.InstanceErrorInfo {
+	if fc == nil || fc.Probability <= 0 || s.rand.Float64() >= fc.Probability {
+		return nil
+	}
+	switch fc.Type {
+	case failureTypeQuota:
+		return &cloudprovider.InstanceErrorInfo{
+			ErrorClass:   cloudprovider.OutOfResourcesErrorClass,
+			ErrorCode:    ErrorCodeQuotaExceeded,
+			ErrorMessage: "simulated quota exceeded error",
+		}
+	default:
+		return &cloudprovider.InstanceErrorInfo{
+			ErrorClass:   cloudprovider.OutOfResourcesErrorClass,
+			ErrorCode:    ErrorCodeOutOfStock,
+			ErrorMessage: "simulated out of stock error",
+		}
+	}
+}
+
+// scheduleCreation registers new instances for the given nodes and returns
+// the nodes which should be created right away. The first resumed nodes are
+// standby instances which skip the creation delay and failures. If atomic is
+// true, either all the instances fail or none of them, the resumed ones included.
+func (s *instanceSimulator) scheduleCreation(nodes []*apiv1.Node, resumed int, atomic bool) []*apiv1.Node {
+	s.Lock()
+	defer s.Unlock()
+
+	creationDelay, _, failureConfig := s.currentBehaviour()
+	var atomicErrorInfo *cloudprovider.InstanceErrorInfo
+	if atomic && resumed < len(nodes) {
+		atomicErrorInfo = s.failure(failureConfig)
+	}
+
+	createNow := []*apiv1.Node{}
+	for i, node := range nodes {
+		errorInfo, delay := atomicErrorInfo, time.Duration(0)
+		if i >= resumed {
+			if !atomic {
+				errorInfo = s.failure(failureConfig)
+			}
+			delay = s.delay(creationDelay)
+		}
+		if errorInfo == nil && delay == 0 {
+			createNow = append(createNow, node)
+			continue
+		}
+		s.creating[node.Spec.ProviderID] = &simulatedInstance{
+			node:      node,
+			createAt:  s.now().Add(delay),
+			errorInfo: errorInfo,
+		}
+	}
+	return createNow
+}
+
+// scheduleDeletion registers the node for deletion and returns true
+// if the node should be deleted right away. alreadyDeleting is true if
+// the node was already registered for deletion by an earlier call.
+func (s *instanceSimulator) scheduleDeletion(nodeName string) (deleteNow bool, alreadyDeleting bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, found := s.deleting[nodeName]; found {
+		return false, true
+	}
+	_, deletionDelay, _ := s.currentBehaviour()
+	delay := s.delay(deletionDelay)
+	if delay == 0 {
+		return true, false
+	}
+	s.deleting[nodeName] = s.now().Add(delay)
+	return false, false
+}
+
+// removeCreating removes an instance which doesn't have a node in the cluster
+// yet and returns true if such an instance was found.
+func (s *instanceSimulator) removeCreating(providerID string) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, found := s.creating[providerID]; !found {
+		return false
+	}
+	delete(s.creating, providerID)
+	return true
+}
+
+// cancelCreating forgets up to count instances which
+// don't have a node in the cluster yet.
+func (s *instanceSimulator) cancelCreating(count int) {
+	s.Lock()
+	defer s.Unlock()
+
+	for providerID := range s.creating {
+		if count <= 0 {
+			return
+		}
+		delete(s.creating, providerID)
+		count--
+	}
+}
+
+// markFailed marks a node creation which failed on the kubernetes side
+// as failed instance, so that it's cleaned up by the autoscaler.
+func (s *instanceSimulator) markFailed(node *apiv1.Node, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.creating[node.Spec.ProviderID] = &simulatedInstance{
+		node: node,
+		errorInfo: &cloudprovider.InstanceErrorInfo{
+			ErrorClass:   cloudprovider.OtherErrorClass,
+			ErrorMessage: fmt.Sprintf("couldn't create node '%s': %v", node.GetName(), err),
+		},
+	}
+}
+
+// popDue returns (and forgets) the nodes whose creation or deletion delay has passed.
+func (s *instanceSimulator) popDue() (toCreate []*apiv1.Node, toDelete []string) {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+	for providerID, instance := range s.creating {
+		if instance.errorInfo == nil && !now.Before(instance.createAt) {
+			toCreate = append(toCreate, instance.node)
+			delete(s.creating, providerID)
+		}
+	}
+	for nodeName, deleteAt := range s.deleting {
+		if !now.Before(deleteAt) {
+			toDelete = append(toDelete, nodeName)
+			delete(s.deleting, nodeName)
+		}
+	}
+	return toCreate, toDelete
+}
+
+// sizeDelta returns the difference between the target size and
+// the number of nodes the nodegroup has in the cluster.
+func (s *instanceSimulator) sizeDelta() int {
+	s.Lock()
+	defer s.Unlock()
+
+	return len(s.creating) - len(s.deleting)
+}
+
+// instances returns the cloud provider view of the given nodegroup nodes
+// together with the instances which don't have a node yet.
+func (s *instanceSimulator) instances(nodeNames []string) []cloudprovider.Instance {
+	s.Lock()
+	defer s.Unlock()
+
+	instances := make([]cloudprovider.Instance, 0, len(nodeNames)+len(s.creating))
+	for _, nodeName := range nodeNames {
+		state := cloudprovider.InstanceRunning
+		if _, found := s.deleting[nodeName]; found {
+			state = cloudprovider.InstanceDeleting
+		}
+		instances = append(instances, cloudprovider.Instance{Id: getProviderID(nodeName), Status: &cloudprovider.InstanceStatus{
+			State: state,
+		}})
+	}
+	for providerID, instance := range s.creating {
+		instances = append(instances, cloudprovider.Instance{Id: providerID, Status: &cloudprovider.InstanceStatus{
+			State:     cloudprovider.InstanceCreating,
+			ErrorInfo: instance.errorInfo,
+		}})
+	}
+	return instances
+}
@@ -0,0 +1,469 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kwok
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newSimulatedNodeGroup(behaviour *NodeGroupBehaviourConfig, clock *fakeClock) (*NodeGroup, map[string]bool, map[string]bool) {
+	fakeClient := &fake.Clientset{}
+	createdNodes := make(map[string]bool)
+	deletedNodes := make(map[string]bool)
+
+	fakeClient.Fake.AddReactor("create", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		createdNodes[action.(core.CreateAction).GetObject().(*apiv1.Node).GetName()] = true
+		return true, nil, nil
+	})
+	fakeClient.Fake.AddReactor("delete", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		deletedNodes[action.(core.DeleteAction).GetName()] = true
+		return true, nil, nil
+	})
+
+	return &NodeGroup{
+		name:       "ng",
+		kubeClient: fakeClient,
+		lister:     kube_util.NewTestNodeLister(nil),
+		nodeTemplate: &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "template-node-ng",
+			},
+		},
+		minSize:    0,
+		targetSize: 0,
+		maxSize:    10,
+		simulator:  newInstanceSimulator(behaviour, 1, clock.Now),
+	}, createdNodes, deletedNodes
+}
+
+func TestSimulatedCreationDelay(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	ng, createdNodes, _ := newSimulatedNodeGroup(&NodeGroupBehaviourConfig{
+		CreationDelay: &DelayConfig{Fixed: metav1.Duration{Duration: time.Minute}},
+	}, clock)
+
+	err := ng.IncreaseSize(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ng.targetSize)
+	assert.Len(t, createdNodes, 0)
+
+	instances, err := ng.Nodes()
+	assert.NoError(t, err)
+	assert.Len(t, instances, 2)
+	for _, instance := range instances {
+		assert.Equal(t, cloudprovider.InstanceCreating, instance.Status.State)
+		assert.Nil(t, instance.Status.ErrorInfo)
+	}
+
+	// delay hasn't passed yet
+	clock.now = clock.now.Add(30 * time.Second)
+	ng.processSimulatedInstances()
+	assert.Len(t, createdNodes, 0)
+	assert.Equal(t, 2, ng.simulator.sizeDelta())
+
+	clock.now = clock.now.Add(30 * time.Second)
+	ng.processSimulatedInstances()
+	assert.Len(t, createdNodes, 2)
+	assert.Equal(t, 0, ng.simulator.sizeDelta())
+}
+
+func TestSimulatedStandbyResume(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	ng, createdNodes, _ := newSimulatedNodeGroup(&NodeGroupBehaviourConfig{
+		CreationDelay: &DelayConfig{Fixed: metav1.Duration{Duration: time.Minute}},
+	}, clock)
+	var standbyNodeGroup cloudprovider.StandbyNodeGroup = ng
+
+	assert.Error(t, standbyNodeGroup.SetStandbySize(-1))
+	assert.NoError(t, standbyNodeGroup.SetStandbySize(2))
+	size, err := standbyNodeGroup.StandbySize()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, size)
+
+	// two standby instances are resumed right away, the third one is delayed
+	err = ng.IncreaseSize(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, ng.targetSize)
+	assert.Len(t, createdNodes, 2)
+	assert.Equal(t, 1, ng.simulator.sizeDelta())
+
+	// the standby pool is replenished
+	size, err = standbyNodeGroup.StandbySize()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, size)
+
+	clock.now = clock.now.Add(time.Minute)
+	ng.processSimulatedInstances()
+	assert.Len(t, createdNodes, 3)
+	assert.Equal(t, 0, ng.simulator.sizeDelta())
+}
+
+func TestSimulatedAtomicStandbyResumeFailure(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	ng, createdNodes, _ := newSimulatedNodeGroup(&NodeGroupBehaviourConfig{
+		Failure: &FailureConfig{Type: failureTypeOutOfStock, Probability: 1},
+	}, clock)
+	assert.NoError(t, ng.SetStandbySize(2))
+
+	// the third instance fails, so the resumed standby instances fail with it
+	err := ng.AtomicIncreaseSize(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, ng.targetSize)
+	assert.Len(t, createdNodes, 0)
+
+	instances, err := ng.Nodes()
+	assert.NoError(t, err)
+	assert.Len(t, instances, 3)
+	for _, instance := range instances {
+		assert.Equal(t, cloudprovider.InstanceCreating, instance.Status.State)
+		assert.Equal(t, ErrorCodeOutOfStock, instance.Status.ErrorInfo.ErrorCode)
+	}
+
+	// standby instances alone are resumed even if atomic
+	ng, createdNodes, _ = newSimulatedNodeGroup(&NodeGroupBehaviourConfig{
+		Failure: &FailureConfig{Type: failureTypeOutOfStock, Probability: 1},
+	}, clock)
+	assert.NoError(t, ng.SetStandbySize(2))
+	assert.NoError(t, ng.AtomicIncreaseSize(2))
+	assert.Equal(t, 2, ng.targetSize)
+	assert.Len(t, createdNodes, 2)
+	assert.Equal(t, 0, ng.simulator.sizeDelta())
+}
+
+func TestSimulatedDeletionDelay(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	ng, _, deletedNodes := newSimulatedNodeGroup(&NodeGroupBehaviourConfig{
+		DeletionDelay: &DelayConfig{Min: metav1.Duration{Duration: time.Minute}, Max: metav1.Duration{Duration: 2 * time.Minute}},
+	}, clock)
+	node := &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node-1",
+			Annotations: map[string]string{KwokManagedAnnotation: "fake"},
+		},
+	}
+	ng.lister = kube_util.NewTestNodeLister([]*apiv1.Node{node})
+	ng.targetSize = 1
+
+	err := ng.DeleteNodes([]*apiv1.Node{node})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ng.targetSize)
+	assert.Len(t, deletedNodes, 0)
+
+	instances, err := ng.Nodes()
+	assert.NoError(t, err)
+	assert.Len(t, instances, 1)
+	assert.Equal(t, cloudprovider.InstanceDeleting, instances[0].Status.State)
+
+	// delay is drawn from [min, max)
+	clock.now = clock.now.Add(59 * time.Second)
+	ng.processSimulatedInstances()
+	assert.Len(t, deletedNodes, 0)
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	ng.processSimulatedInstances()
+	assert.True(t, deletedNodes["node-1"])
+}
+
+func TestSimulatedDeletionRequestedTwice(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	ng, _, deletedNodes := newSimulatedNodeGroup(&NodeGroupBehaviourConfig{
+		DeletionDelay: &DelayConfig{Fixed: metav1.Duration{Duration: time.Minute}},
+	}, clock)
+	nodes := []*apiv1.Node{}
+	for _, name := range []string{"node-1", "node-2"} {
+		nodes = append(nodes, &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{KwokManagedAnnotation: "fake"},
+			},
+		})
+	}
+	ng.lister = kube_util.NewTestNodeLister(nodes)
+	ng.targetSize = 2
+
+	assert.NoError(t, ng.DeleteNodes([]*apiv1.Node{nodes[0]}))
+	assert.Equal(t, 1, ng.targetSize)
+	// the node is already being deleted, so the target size doesn't change
+	assert.NoError(t, ng.DeleteNodes([]*apiv1.Node{nodes[0]}))
+	assert.Equal(t, 1, ng.targetSize)
+
+	clock.now = clock.now.Add(time.Minute)
+	ng.processSimulatedInstances()
+	assert.True(t, deletedNodes["node-1"])
+	assert.Len(t, deletedNodes, 1)
+}
+
+func TestRefreshProcessesSimulatedInstances(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	ng, _, _ := newSimulatedNodeGroup(&NodeGroupBehaviourConfig{
+		CreationDelay: &DelayConfig{Fixed: metav1.Duration{Duration: time.Minute}},
+		DeletionDelay: &DelayConfig{Fixed: metav1.Duration{Duration: time.Minute}},
+	}, clock)
+	existing := &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node-1",
+			Annotations: map[string]string{KwokManagedAnnotation: "fake", NGNameAnnotation: "ng"},
+		},
+	}
+	ng.lister = kube_util.NewTestNodeLister([]*apiv1.Node{existing})
+	ng.targetSize = 1
+	provider := &KwokCloudProvider{
+		nodeGroups:     []*NodeGroup{ng},
+		config:         &KwokProviderConfig{status: &GroupingConfig{}},
+		allNodesLister: newTestAllNodeLister(map[string]*apiv1.Node{"node-1": existing}),
+	}
+
+	assert.NoError(t, ng.IncreaseSize(2))
+	assert.NoError(t, ng.DeleteNodes([]*apiv1.Node{existing}))
+	assert.Equal(t, 2, ng.targetSize)
+
+	// both delays pass, the lister doesn't observe the changes yet
+	clock.now = clock.now.Add(time.Minute)
+	assert.NoError(t, provider.Refresh())
+	assert.Equal(t, 2, ng.targetSize)
+}
+
+func TestSimulatedFailures(t *testing.T) {
+	testCases := []struct {
+		name              string
+		failure           *FailureConfig
+		expectedErrorCode string
+	}{
+		{
+			name:              "out of stock",
+			failure:           &FailureConfig{Type: failureTypeOutOfStock, Probability: 1},
+			expectedErrorCode: ErrorCodeOutOfStock,
+		},
+		{
+			name:              "quota",
+			failure:           &FailureConfig{Type: failureTypeQuota, Probability: 1},
+			expectedErrorCode: ErrorCodeQuotaExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Now()}
+			ng, createdNodes, _ := newSimulatedNodeGroup(&NodeGroupBehaviourConfig{Failure: tc.failure}, clock)
+
+			err := ng.AtomicIncreaseSize(3)
+			assert.NoError(t, err)
+			assert.Equal(t, 3, ng.targetSize)
+
+			clock.now = clock.now.Add(time.Hour)
+			ng.processSimulatedInstances()
+			assert.Len(t, createdNodes, 0)
+
+			instances, err := ng.Nodes()
+			assert.NoError(t, err)
+			assert.Len(t, instances, 3)
+			for _, instance := range instances {
+				assert.Equal(t, cloudprovider.InstanceCreating, instance.Status.State)
+				assert.Equal(t, cloudprovider.OutOfResourcesErrorClass, instance.Status.ErrorInfo.ErrorClass)
+				assert.Equal(t, tc.expectedErrorCode, instance.Status.ErrorInfo.ErrorCode)
+			}
+
+			// failed instances are cleaned up the same way the core autoscaler does it
+			failedNodes := []*apiv1.Node{}
+			for _, instance := range instances {
+				failedNodes = append(failedNodes, &apiv1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: instance.Id},
+					Spec:       apiv1.NodeSpec{ProviderID: instance.Id},
+				})
+			}
+			err = ng.DeleteNodes(failedNodes)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, ng.targetSize)
+			assert.Equal(t, 0, ng.simulator.sizeDelta())
+		})
+	}
+}
+
+func TestSimulatedScenario(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	ng, createdNodes, _ := newSimulatedNodeGroup(&NodeGroupBehaviourConfig{
+		Scenario: []ScenarioStep{
+			{
+				After:   metav1.Duration{Duration: 10 * time.Minute},
+				Failure: &FailureConfig{Type: failureTypeOutOfStock, Probability: 1},
+			},
+			{
+				After:   metav1.Duration{Duration: 20 * time.Minute},
+				Failure: &FailureConfig{Type: failureTypeOutOfStock, Probability: 0},
+			},
+		},
+	}, clock)
+
+	// no step is active yet
+	err := ng.IncreaseSize(1)
+	assert.NoError(t, err)
+	assert.Len(t, createdNodes, 1)
+
+	// stockout
+	clock.now = clock.now.Add(10 * time.Minute)
+	err = ng.IncreaseSize(1)
+	assert.NoError(t, err)
+	assert.Len(t, createdNodes, 1)
+	assert.Equal(t, 1, ng.simulator.sizeDelta())
+
+	// stockout is over
+	clock.now = clock.now.Add(10 * time.Minute)
+	err = ng.IncreaseSize(1)
+	assert.NoError(t, err)
+	assert.Len(t, createdNodes, 2)
+	assert.Equal(t, 3, ng.targetSize)
+}
+
+func TestSimulatedDecreaseTargetSize(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	ng, createdNodes, _ := newSimulatedNodeGroup(&NodeGroupBehaviourConfig{
+		CreationDelay: &DelayConfig{Fixed: metav1.Duration{Duration: time.Minute}},
+	}, clock)
+
+	err := ng.IncreaseSize(3)
+	assert.NoError(t, err)
+	err = ng.DecreaseTargetSize(-2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ng.targetSize)
+	assert.Equal(t, 1, ng.simulator.sizeDelta())
+
+	clock.now = clock.now.Add(time.Minute)
+	ng.processSimulatedInstances()
+	assert.Len(t, createdNodes, 1)
+}
+
+func TestSimulatedDelay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		delay    *DelayConfig
+		minDelay time.Duration
+		maxDelay time.Duration
+	}{
+		{
+			name: "no delay",
+		},
+		{
+			name:     "fixed",
+			delay:    &DelayConfig{Fixed: metav1.Duration{Duration: time.Minute}},
+			minDelay: time.Minute,
+			maxDelay: time.Minute,
+		},
+		{
+			name:     "distributed",
+			delay:    &DelayConfig{Min: metav1.Duration{Duration: time.Second}, Max: metav1.Duration{Duration: time.Minute}},
+			minDelay: time.Second,
+			maxDelay: time.Minute,
+		},
+		{
+			name:     "min equal to max",
+			delay:    &DelayConfig{Min: metav1.Duration{Duration: 5 * time.Minute}, Max: metav1.Duration{Duration: 5 * time.Minute}},
+			minDelay: 5 * time.Minute,
+			maxDelay: 5 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newInstanceSimulator(&NodeGroupBehaviourConfig{}, 1, time.Now)
+			for i := 0; i < 10; i++ {
+				delay := s.delay(tc.delay)
+				assert.GreaterOrEqual(t, delay, tc.minDelay)
+				assert.LessOrEqual(t, delay, tc.maxDelay)
+			}
+		})
+	}
+}
+
+func TestValidateNodeGroupBehaviour(t *testing.T) {
+	testCases := []struct {
+		name      string
+		behaviour *NodeGroupBehaviourConfig
+		wantErr   bool
+	}{
+		{
+			name: "valid",
+			behaviour: &NodeGroupBehaviourConfig{
+				CreationDelay: &DelayConfig{Min: metav1.Duration{Duration: time.Second}, Max: metav1.Duration{Duration: time.Minute}},
+				DeletionDelay: &DelayConfig{Fixed: metav1.Duration{Duration: time.Second}},
+				Failure:       &FailureConfig{Type: failureTypeQuota, Probability: 0.5},
+				Scenario:      []ScenarioStep{{After: metav1.Duration{Duration: time.Minute}}},
+			},
+		},
+		{
+			name:      "empty",
+			behaviour: nil,
+			wantErr:   true,
+		},
+		{
+			name: "max lesser than min",
+			behaviour: &NodeGroupBehaviourConfig{
+				CreationDelay: &DelayConfig{Min: metav1.Duration{Duration: time.Minute}, Max: metav1.Duration{Duration: time.Second}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fixed and distributed",
+			behaviour: &NodeGroupBehaviourConfig{
+				DeletionDelay: &DelayConfig{Fixed: metav1.Duration{Duration: time.Second}, Max: metav1.Duration{Duration: time.Minute}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown failure type",
+			behaviour: &NodeGroupBehaviourConfig{
+				Failure: &FailureConfig{Type: "meteorite", Probability: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid probability in scenario",
+			behaviour: &NodeGroupBehaviourConfig{
+				Scenario: []ScenarioStep{{Failure: &FailureConfig{Type: failureTypeOutOfStock, Probability: 2}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateNodeGroupBehaviour(tc.behaviour)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
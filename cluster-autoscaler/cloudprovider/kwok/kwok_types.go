@@ -18,6 +18,7 @@ package kwok
 
 import (
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	listersv1 "k8s.io/client-go/listers/core/v1"
 
@@ -59,6 +60,13 @@ type NodeGroup struct {
 	minSize      int
 	targetSize   int
 	maxSize      int
+	// simulator delays node creation/deletion and injects provisioning
+	// errors based on the nodegroup's behaviour config (nil means nodes
+	// are created and deleted immediately and never fail)
+	simulator *instanceSimulator
+	// standbySize is the number of simulated stopped instances which are
+	// resumed without the creation delay and failures on scale-up
+	standbySize int
 }
 
 // NodegroupsConfig defines options for creating nodegroups
@@ -86,8 +94,54 @@ type GPUConfig struct {
 }
 
 // KwokConfig is the struct to define kwok specific config
-// (needs to be implemented; currently empty)
 type KwokConfig struct {
+	// Seed is used to seed the random generator for distributed delays and
+	// failure probabilities (random seed if 0)
+	Seed int64 `json:"seed" yaml:"seed"`
+	// NodeGroups maps nodegroup names to the provisioning behaviour
+	// simulated for them. Nodegroups which are not listed here get
+	// their nodes created and deleted immediately.
+	NodeGroups map[string]*NodeGroupBehaviourConfig `json:"nodegroups" yaml:"nodegroups"`
+}
+
+// NodeGroupBehaviourConfig defines how the kwok provider simulates
+// provisioning of nodes for a nodegroup
+type NodeGroupBehaviourConfig struct {
+	// CreationDelay is the time between a scale-up and the node showing up in the cluster
+	CreationDelay *DelayConfig `json:"creationDelay" yaml:"creationDelay"`
+	// DeletionDelay is the time between a scale-down and the node being removed from the cluster
+	DeletionDelay *DelayConfig `json:"deletionDelay" yaml:"deletionDelay"`
+	// Failure injects errors for new instances
+	Failure *FailureConfig `json:"failure" yaml:"failure"`
+	// Scenario is a list of steps which override the config above once
+	// the time since the provider was started passes their 'after' value
+	Scenario []ScenarioStep `json:"scenario" yaml:"scenario"`
+}
+
+// ScenarioStep overrides the nodegroup behaviour starting at a point in time.
+// Fields which are not set keep the value from the previous step (or the base config).
+type ScenarioStep struct {
+	// After is the time since the provider was started after which the step applies
+	After         metav1.Duration `json:"after" yaml:"after"`
+	CreationDelay *DelayConfig    `json:"creationDelay" yaml:"creationDelay"`
+	DeletionDelay *DelayConfig    `json:"deletionDelay" yaml:"deletionDelay"`
+	Failure       *FailureConfig  `json:"failure" yaml:"failure"`
+}
+
+// DelayConfig defines a fixed delay or a delay drawn from
+// a uniform distribution between min and max
+type DelayConfig struct {
+	Fixed metav1.Duration `json:"fixed" yaml:"fixed"`
+	Min   metav1.Duration `json:"min" yaml:"min"`
+	Max   metav1.Duration `json:"max" yaml:"max"`
+}
+
+// FailureConfig defines errors reported for new instances
+type FailureConfig struct {
+	// Type is the kind of error reported (possible values: [outOfStock,quota])
+	Type string `json:"type" yaml:"type"`
+	// Probability of a new instance failing to be created (0 disables the failure)
+	Probability float64 `json:"probability" yaml:"probability"`
 }
 
 // KwokProviderConfig is the struct to hold kwok provider config
@@ -111,6 +111,8 @@ type NodeGroupHealthCondition struct {
 	CloudProviderTarget int `json:"cloudProviderTarget" yaml:"cloudProviderTarget"`
 	// MinSize is the CA max size of a node group.
 	MinSize int `json:"minSize" yaml:"minSize"`
+	// ScheduledMinSize is the minimum size of a node group raised by an active schedule, if any.
+	ScheduledMinSize int `json:"scheduledMinSize,omitempty" yaml:"scheduledMinSize,omitempty"`
 	// MaxSize is the CA max size of a node group.
 	MaxSize int `json:"maxSize" yaml:"maxSize"`
 	// LastProbeTime is the last time we probed the condition.
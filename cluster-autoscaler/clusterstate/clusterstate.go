@@ -30,6 +30,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups/asyncnodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/scheduledcapacity"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
@@ -87,6 +88,8 @@ type ClusterStateRegistryConfig struct {
 	// Minimum number of nodes that must be unready for MaxTotalUnreadyPercentage to apply.
 	// This is to ensure that in very small clusters (e.g. 2 nodes) a single node's failure doesn't disable autoscaling.
 	OkTotalUnreadyCount int
+	// ScheduledMinCapacity raises the minimum size of node groups according to schedules. Can be nil.
+	ScheduledMinCapacity *scheduledcapacity.Provider
 }
 
 // IncorrectNodeGroupSize contains information about how much the current size of the node group
@@ -147,6 +150,11 @@ type ClusterStateRegistry struct {
 	// scaleUpFailures contains information about scale-up failures for each node group. It should be
 	// cleared periodically to avoid unnecessary accumulation.
 	scaleUpFailures map[string][]ScaleUpFailure
+
+	// lastScaleUpTimes and lastScaleDownTimes contain the time of the most recent scale-up and
+	// scale-down of each node group. Unlike scale requests, they are kept after the scaling finishes.
+	lastScaleUpTimes   map[string]time.Time
+	lastScaleDownTimes map[string]time.Time
 }
 
 // NodeGroupScalingSafety contains information about the safety of the node group to scale up/down.
@@ -178,6 +186,8 @@ func NewClusterStateRegistry(cloudProvider cloudprovider.CloudProvider, config C
 		scaleUpFailures:                 make(map[string][]ScaleUpFailure),
 		nodeGroupConfigProcessor:        nodeGroupConfigProcessor,
 		asyncNodeGroupStateChecker:      asyncNodeGroupStateChecker,
+		lastScaleUpTimes:                make(map[string]time.Time),
+		lastScaleDownTimes:              make(map[string]time.Time),
 	}
 }
 
@@ -196,6 +206,9 @@ func (csr *ClusterStateRegistry) RegisterScaleUp(nodeGroup cloudprovider.NodeGro
 	csr.Lock()
 	defer csr.Unlock()
 	csr.registerOrUpdateScaleUpNoLock(nodeGroup, delta, currentTime)
+	if delta > 0 {
+		csr.lastScaleUpTimes[nodeGroup.Id()] = currentTime
+	}
 }
 
 // MaxNodeProvisionTime returns MaxNodeProvisionTime value that should be used for the given NodeGroup.
@@ -268,6 +281,51 @@ func (csr *ClusterStateRegistry) RegisterScaleDown(nodeGroup cloudprovider.NodeG
 	csr.Lock()
 	defer csr.Unlock()
 	csr.scaleDownRequests = append(csr.scaleDownRequests, request)
+	csr.lastScaleDownTimes[nodeGroup.Id()] = currentTime
+}
+
+// LastScaleUpTime returns the time of the most recent scale-up of the given node group,
+// and false if the node group wasn't scaled up since Cluster Autoscaler started.
+func (csr *ClusterStateRegistry) LastScaleUpTime(nodeGroupName string) (time.Time, bool) {
+	csr.Lock()
+	defer csr.Unlock()
+	t, found := csr.lastScaleUpTimes[nodeGroupName]
+	return t, found
+}
+
+// LastScaleDownTime returns the time of the most recent scale-down of the given node group,
+// and false if the node group wasn't scaled down since Cluster Autoscaler started.
+func (csr *ClusterStateRegistry) LastScaleDownTime(nodeGroupName string) (time.Time, bool) {
+	csr.Lock()
+	defer csr.Unlock()
+	t, found := csr.lastScaleDownTimes[nodeGroupName]
+	return t, found
+}
+
+// LastClusterScaleUpTime returns the time of the most recent scale-up of any node group,
+// and false if no node group was scaled up since Cluster Autoscaler started.
+func (csr *ClusterStateRegistry) LastClusterScaleUpTime() (time.Time, bool) {
+	csr.Lock()
+	defer csr.Unlock()
+	return latestTime(csr.lastScaleUpTimes)
+}
+
+// LastClusterScaleDownTime returns the time of the most recent scale-down of any node group,
+// and false if no node group was scaled down since Cluster Autoscaler started.
+func (csr *ClusterStateRegistry) LastClusterScaleDownTime() (time.Time, bool) {
+	csr.Lock()
+	defer csr.Unlock()
+	return latestTime(csr.lastScaleDownTimes)
+}
+
+func latestTime(times map[string]time.Time) (time.Time, bool) {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest, len(times) > 0
 }
 
 // To be executed under a lock.
@@ -807,6 +865,9 @@ func (csr *ClusterStateRegistry) GetStatus(now time.Time) *api.ClusterAutoscaler
 		// Health.
 		nodeGroupStatus.Health = buildHealthStatusNodeGroup(
 			csr.IsNodeGroupHealthy(nodeGroup.Id()), readiness, acceptable, nodeGroup.MinSize(), nodeGroup.MaxSize(), nodeGroupLastStatus.Health)
+		if scheduledMinSize := csr.config.ScheduledMinCapacity.MinSize(nodeGroup); scheduledMinSize > nodeGroup.MinSize() {
+			nodeGroupStatus.Health.ScheduledMinSize = scheduledMinSize
+		}
 
 		// Scale up.
 		nodeGroupStatus.ScaleUp = csr.buildScaleUpStatusNodeGroup(
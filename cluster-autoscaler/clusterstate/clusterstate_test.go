@@ -31,7 +31,9 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups/asyncnodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/scheduledcapacity"
 
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/client-go/kubernetes/fake"
@@ -101,6 +103,51 @@ func TestOKWithScaleUp(t *testing.T) {
 	assert.True(t, ng2Checked)
 }
 
+func TestScheduledMinSizeInStatus(t *testing.T) {
+	now := time.Now()
+
+	ng1_1 := BuildTestNode("ng1-1", 1000, 1000)
+	SetNodeReadyState(ng1_1, true, now.Add(-time.Minute))
+	ng2_1 := BuildTestNode("ng2-1", 1000, 1000)
+	SetNodeReadyState(ng2_1, true, now.Add(-time.Minute))
+
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroup("ng2", 1, 10, 1)
+	provider.AddNode("ng1", ng1_1)
+	provider.AddNode("ng2", ng2_1)
+
+	configMapLister, err := kube_util.NewTestConfigMapLister([]*apiv1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduled-min-capacity", Namespace: "kube-system"},
+		Data: map[string]string{scheduledcapacity.ConfigMapKey: `
+- name: always
+  nodeGroups: ["^ng1$"]
+  window: "* * * * * 1h"
+  minSize: 4
+`},
+	}})
+	assert.NoError(t, err)
+	scheduledMinCapacity := scheduledcapacity.NewProvider(configMapLister, "kube-system", "scheduled-min-capacity")
+	assert.NoError(t, scheduledMinCapacity.Refresh(now))
+
+	fakeLogRecorder, _ := utils.NewStatusMapRecorder(&fake.Clientset{}, "kube-system", kube_record.NewFakeRecorder(5), false, "my-cool-configmap")
+	clusterstate := NewClusterStateRegistry(provider, ClusterStateRegistryConfig{
+		MaxTotalUnreadyPercentage: 10,
+		OkTotalUnreadyCount:       1,
+		ScheduledMinCapacity:      scheduledMinCapacity,
+	}, fakeLogRecorder, newBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+	err = clusterstate.UpdateNodes([]*apiv1.Node{ng1_1, ng2_1}, nil, now)
+	assert.NoError(t, err)
+
+	status := clusterstate.GetStatus(now)
+	scheduledMinSizes := make(map[string]int)
+	for _, nodeGroupStatus := range status.NodeGroups {
+		assert.Equal(t, 1, nodeGroupStatus.Health.MinSize)
+		scheduledMinSizes[nodeGroupStatus.Name] = nodeGroupStatus.Health.ScheduledMinSize
+	}
+	assert.Equal(t, map[string]int{"ng1": 4, "ng2": 0}, scheduledMinSizes)
+}
+
 func TestEmptyOK(t *testing.T) {
 	now := time.Now()
 
@@ -555,6 +602,49 @@ func TestRegisterScaleDown(t *testing.T) {
 	assert.Empty(t, clusterstate.GetScaleUpFailures())
 }
 
+func TestLastScaleTimes(t *testing.T) {
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroup("ng2", 1, 10, 1)
+
+	fakeClient := &fake.Clientset{}
+	fakeLogRecorder, _ := utils.NewStatusMapRecorder(fakeClient, "kube-system", kube_record.NewFakeRecorder(5), false, "my-cool-configmap")
+	clusterstate := NewClusterStateRegistry(provider, ClusterStateRegistryConfig{
+		MaxTotalUnreadyPercentage: 10,
+		OkTotalUnreadyCount:       1,
+	}, fakeLogRecorder, newBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+	now := time.Now()
+
+	_, found := clusterstate.LastClusterScaleUpTime()
+	assert.False(t, found)
+	_, found = clusterstate.LastClusterScaleDownTime()
+	assert.False(t, found)
+
+	clusterstate.RegisterScaleUp(provider.GetNodeGroup("ng1"), 1, now)
+	clusterstate.RegisterScaleUp(provider.GetNodeGroup("ng2"), 2, now.Add(time.Minute))
+	clusterstate.RegisterScaleUp(provider.GetNodeGroup("ng1"), -1, now.Add(2*time.Minute))
+	clusterstate.RegisterScaleDown(provider.GetNodeGroup("ng2"), "ng2-1", now.Add(3*time.Minute), now.Add(4*time.Minute))
+
+	// The requests finishing doesn't clear the last scaling times.
+	clusterstate.updateScaleRequests(now.Add(time.Hour))
+
+	lastScaleUp, found := clusterstate.LastScaleUpTime("ng1")
+	assert.True(t, found)
+	assert.Equal(t, now, lastScaleUp)
+	lastScaleUp, found = clusterstate.LastClusterScaleUpTime()
+	assert.True(t, found)
+	assert.Equal(t, now.Add(time.Minute), lastScaleUp)
+
+	_, found = clusterstate.LastScaleDownTime("ng1")
+	assert.False(t, found)
+	lastScaleDown, found := clusterstate.LastScaleDownTime("ng2")
+	assert.True(t, found)
+	assert.Equal(t, now.Add(3*time.Minute), lastScaleDown)
+	lastScaleDown, found = clusterstate.LastClusterScaleDownTime()
+	assert.True(t, found)
+	assert.Equal(t, now.Add(3*time.Minute), lastScaleDown)
+}
+
 func TestNodeGroupScaleUpTime(t *testing.T) {
 	provider := testprovider.NewTestCloudProviderBuilder().Build()
 	assert.NotNil(t, provider)
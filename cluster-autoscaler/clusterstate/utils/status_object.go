@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	casv1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	casclient "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned"
+	casinformers "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/informers/externalversions"
+	caslisters "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/listers/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+
+	klog "k8s.io/klog/v2"
+)
+
+// ToStatusObjectStatus converts the status written to the status ConfigMap into the status
+// of the ClusterAutoscalerStatus object. Last scale-up and scale-down times aren't part of
+// the ConfigMap status and are left empty.
+func ToStatusObjectStatus(status api.ClusterAutoscalerStatus, currentTime time.Time) casv1alpha1.ClusterAutoscalerStatusStatus {
+	result := casv1alpha1.ClusterAutoscalerStatusStatus{
+		AutoscalerState: casv1alpha1.AutoscalerState(status.AutoscalerStatus),
+		Message:         status.Message,
+		LastUpdateTime:  metav1.NewTime(currentTime),
+		ClusterWide: casv1alpha1.ClusterWideStatus{
+			Health: casv1alpha1.ClusterHealth{
+				Status:             casv1alpha1.HealthStatus(status.ClusterWide.Health.Status),
+				NodeCounts:         toNodeCounts(status.ClusterWide.Health.NodeCounts),
+				LastProbeTime:      status.ClusterWide.Health.LastProbeTime,
+				LastTransitionTime: status.ClusterWide.Health.LastTransitionTime,
+			},
+			ScaleUp: casv1alpha1.ClusterScaleUp{
+				Status:             casv1alpha1.ScaleUpStatus(status.ClusterWide.ScaleUp.Status),
+				LastProbeTime:      status.ClusterWide.ScaleUp.LastProbeTime,
+				LastTransitionTime: status.ClusterWide.ScaleUp.LastTransitionTime,
+			},
+			ScaleDown: toScaleDown(status.ClusterWide.ScaleDown),
+		},
+	}
+	for _, nodeGroup := range status.NodeGroups {
+		nodeGroupStatus := casv1alpha1.NodeGroupStatus{
+			Name: nodeGroup.Name,
+			Health: casv1alpha1.NodeGroupHealth{
+				Status:              casv1alpha1.HealthStatus(nodeGroup.Health.Status),
+				NodeCounts:          toNodeCounts(nodeGroup.Health.NodeCounts),
+				CloudProviderTarget: int32(nodeGroup.Health.CloudProviderTarget),
+				MinSize:             int32(nodeGroup.Health.MinSize),
+				ScheduledMinSize:    int32(nodeGroup.Health.ScheduledMinSize),
+				MaxSize:             int32(nodeGroup.Health.MaxSize),
+				LastProbeTime:       nodeGroup.Health.LastProbeTime,
+				LastTransitionTime:  nodeGroup.Health.LastTransitionTime,
+			},
+			ScaleUp: casv1alpha1.NodeGroupScaleUp{
+				Status:             casv1alpha1.ScaleUpStatus(nodeGroup.ScaleUp.Status),
+				LastProbeTime:      nodeGroup.ScaleUp.LastProbeTime,
+				LastTransitionTime: nodeGroup.ScaleUp.LastTransitionTime,
+			},
+			ScaleDown: toScaleDown(nodeGroup.ScaleDown),
+		}
+		if nodeGroup.ScaleUp.Status == api.ClusterAutoscalerBackoff {
+			nodeGroupStatus.ScaleUp.Backoff = &casv1alpha1.Backoff{
+				ErrorCode:    nodeGroup.ScaleUp.BackoffInfo.ErrorCode,
+				ErrorMessage: nodeGroup.ScaleUp.BackoffInfo.ErrorMessage,
+			}
+		}
+		result.NodeGroups = append(result.NodeGroups, nodeGroupStatus)
+	}
+	return result
+}
+
+func toNodeCounts(counts api.NodeCount) casv1alpha1.NodeCounts {
+	return casv1alpha1.NodeCounts{
+		Registered: casv1alpha1.RegisteredNodeCounts{
+			Total:           int32(counts.Registered.Total),
+			Ready:           int32(counts.Registered.Ready),
+			NotStarted:      int32(counts.Registered.NotStarted),
+			BeingDeleted:    int32(counts.Registered.BeingDeleted),
+			Unready:         int32(counts.Registered.Unready.Total),
+			ResourceUnready: int32(counts.Registered.Unready.ResourceUnready),
+		},
+		LongUnregistered: int32(counts.LongUnregistered),
+		Unregistered:     int32(counts.Unregistered),
+	}
+}
+
+func toScaleDown(condition api.ScaleDownCondition) casv1alpha1.ScaleDown {
+	return casv1alpha1.ScaleDown{
+		Status:             casv1alpha1.ScaleDownStatus(condition.Status),
+		Candidates:         int32(condition.Candidates),
+		LastProbeTime:      condition.LastProbeTime,
+		LastTransitionTime: condition.LastTransitionTime,
+	}
+}
+
+// StatusObjectRefreshInterval is how often the status of the ClusterAutoscalerStatus object
+// is written when nothing but its update and probe times changed.
+const StatusObjectRefreshInterval = time.Minute
+
+// NewStatusObjectLister returns a lister of the ClusterAutoscalerStatus objects in the given
+// namespace, once its cache is synced.
+func NewStatusObjectLister(client casclient.Interface, namespace string, stopChannel <-chan struct{}) (caslisters.ClusterAutoscalerStatusLister, error) {
+	factory := casinformers.NewSharedInformerFactoryWithOptions(client, time.Hour, casinformers.WithNamespace(namespace))
+	lister := factory.Autoscaling().V1alpha1().ClusterAutoscalerStatuses().Lister()
+	factory.Start(stopChannel)
+	for _, synced := range factory.WaitForCacheSync(stopChannel) {
+		if !synced {
+			return nil, fmt.Errorf("can't create ClusterAutoscalerStatus lister")
+		}
+	}
+	klog.V(2).Info("Successful initial ClusterAutoscalerStatus sync")
+	return lister, nil
+}
+
+// WriteStatusObject updates the status of the ClusterAutoscalerStatus object, creating the
+// object first if it doesn't exist. The object is read through the lister, and its status
+// is only written if it changed, or if it wasn't written for StatusObjectRefreshInterval.
+// Returns nil if the status wasn't written.
+func WriteStatusObject(client casclient.Interface, lister caslisters.ClusterAutoscalerStatusLister, namespace, name string, status casv1alpha1.ClusterAutoscalerStatusStatus) (*casv1alpha1.ClusterAutoscalerStatus, error) {
+	objects := client.AutoscalingV1alpha1().ClusterAutoscalerStatuses(namespace)
+	object, err := lister.ClusterAutoscalerStatuses(namespace).Get(name)
+	if kube_errors.IsNotFound(err) {
+		object, err = objects.Create(context.TODO(), &casv1alpha1.ClusterAutoscalerStatus{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+		}, metav1.CreateOptions{})
+		if kube_errors.IsAlreadyExists(err) {
+			// The object was created since the lister was last synced.
+			object, err = objects.Get(context.TODO(), name, metav1.GetOptions{})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create status object %s/%s: %v", namespace, name, err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to retrieve status object %s/%s for update: %v", namespace, name, err)
+	} else if !statusObjectNeedsUpdate(object.Status, status) {
+		klog.V(8).Infof("Status of status object %s/%s didn't change, not writing it", namespace, name)
+		return nil, nil
+	}
+	object = object.DeepCopy()
+	object.Status = status
+	object, err = objects.UpdateStatus(context.TODO(), object, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update status of status object %s/%s: %v", namespace, name, err)
+	}
+	klog.V(8).Infof("Successfully wrote status of status object %s/%s", namespace, name)
+	return object, nil
+}
+
+// statusObjectNeedsUpdate checks whether the status changed other than by its update and
+// probe times, or whether it wasn't written for StatusObjectRefreshInterval.
+func statusObjectNeedsUpdate(written, status casv1alpha1.ClusterAutoscalerStatusStatus) bool {
+	if status.LastUpdateTime.Sub(written.LastUpdateTime.Time) >= StatusObjectRefreshInterval {
+		return true
+	}
+	return !apiequality.Semantic.DeepEqual(withoutProbeTimes(written), withoutProbeTimes(status))
+}
+
+func withoutProbeTimes(status casv1alpha1.ClusterAutoscalerStatusStatus) casv1alpha1.ClusterAutoscalerStatusStatus {
+	status = *status.DeepCopy()
+	status.LastUpdateTime = metav1.Time{}
+	status.ClusterWide.Health.LastProbeTime = metav1.Time{}
+	status.ClusterWide.ScaleUp.LastProbeTime = metav1.Time{}
+	status.ClusterWide.ScaleDown.LastProbeTime = metav1.Time{}
+	for i := range status.NodeGroups {
+		status.NodeGroups[i].Health.LastProbeTime = metav1.Time{}
+		status.NodeGroups[i].ScaleUp.LastProbeTime = metav1.Time{}
+		status.NodeGroups[i].ScaleDown.LastProbeTime = metav1.Time{}
+	}
+	return status
+}
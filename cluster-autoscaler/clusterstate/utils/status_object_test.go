@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	casv1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	casfake "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned/fake"
+	caslisters "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/listers/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestToStatusObjectStatus(t *testing.T) {
+	now := time.Now()
+	probeTime := metav1.NewTime(now.Add(-time.Minute))
+	status := api.ClusterAutoscalerStatus{
+		AutoscalerStatus: api.ClusterAutoscalerRunning,
+		ClusterWide: api.ClusterWideStatus{
+			Health: api.ClusterHealthCondition{
+				Status: api.ClusterAutoscalerHealthy,
+				NodeCounts: api.NodeCount{
+					Registered: api.RegisteredNodeCount{
+						Total: 5,
+						Ready: 3,
+						Unready: api.RegisteredUnreadyNodeCount{
+							Total:           2,
+							ResourceUnready: 1,
+						},
+					},
+					Unregistered: 1,
+				},
+				LastProbeTime: probeTime,
+			},
+			ScaleUp: api.ClusterScaleUpCondition{
+				Status: api.ClusterAutoscalerBackoff,
+			},
+			ScaleDown: api.ScaleDownCondition{
+				Status:     api.ClusterAutoscalerCandidatesPresent,
+				Candidates: 2,
+			},
+		},
+		NodeGroups: []api.NodeGroupStatus{
+			{
+				Name: "ng1",
+				Health: api.NodeGroupHealthCondition{
+					Status:              api.ClusterAutoscalerHealthy,
+					CloudProviderTarget: 3,
+					MinSize:             1,
+					MaxSize:             10,
+				},
+				ScaleUp: api.NodeGroupScaleUpCondition{
+					Status: api.ClusterAutoscalerBackoff,
+					BackoffInfo: api.BackoffInfo{
+						ErrorCode:    "QUOTA_EXCEEDED",
+						ErrorMessage: "out of quota",
+					},
+				},
+			},
+			{
+				Name: "ng2",
+				ScaleUp: api.NodeGroupScaleUpCondition{
+					Status: api.ClusterAutoscalerNoActivity,
+				},
+			},
+		},
+	}
+
+	got := ToStatusObjectStatus(status, now)
+
+	assert.Equal(t, casv1alpha1.AutoscalerRunning, got.AutoscalerState)
+	assert.Equal(t, metav1.NewTime(now), got.LastUpdateTime)
+	assert.Equal(t, casv1alpha1.ClusterHealth{
+		Status: casv1alpha1.Healthy,
+		NodeCounts: casv1alpha1.NodeCounts{
+			Registered: casv1alpha1.RegisteredNodeCounts{
+				Total:           5,
+				Ready:           3,
+				Unready:         2,
+				ResourceUnready: 1,
+			},
+			Unregistered: 1,
+		},
+		LastProbeTime: probeTime,
+	}, got.ClusterWide.Health)
+	assert.Equal(t, casv1alpha1.ScaleUpBackoff, got.ClusterWide.ScaleUp.Status)
+	assert.Equal(t, casv1alpha1.ScaleDown{Status: casv1alpha1.ScaleDownCandidatesPresent, Candidates: 2}, got.ClusterWide.ScaleDown)
+
+	assert.Len(t, got.NodeGroups, 2)
+	assert.Equal(t, "ng1", got.NodeGroups[0].Name)
+	assert.Equal(t, casv1alpha1.NodeGroupHealth{
+		Status:              casv1alpha1.Healthy,
+		CloudProviderTarget: 3,
+		MinSize:             1,
+		MaxSize:             10,
+	}, got.NodeGroups[0].Health)
+	assert.Equal(t, &casv1alpha1.Backoff{ErrorCode: "QUOTA_EXCEEDED", ErrorMessage: "out of quota"}, got.NodeGroups[0].ScaleUp.Backoff)
+	assert.Equal(t, casv1alpha1.ScaleUpNoActivity, got.NodeGroups[1].ScaleUp.Status)
+	assert.Nil(t, got.NodeGroups[1].ScaleUp.Backoff)
+}
+
+func TestWriteStatusObject(t *testing.T) {
+	client := casfake.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := caslisters.NewClusterAutoscalerStatusLister(indexer)
+	now := time.Now().Truncate(time.Second)
+	write := func(status casv1alpha1.ClusterAutoscalerStatusStatus) *casv1alpha1.ClusterAutoscalerStatus {
+		object, err := WriteStatusObject(client, lister, "kube-system", "cluster-autoscaler-status", status)
+		assert.NoError(t, err)
+		if object != nil {
+			assert.NoError(t, indexer.Update(object))
+		}
+		return object
+	}
+	get := func() casv1alpha1.ClusterAutoscalerStatusStatus {
+		object, err := client.AutoscalingV1alpha1().ClusterAutoscalerStatuses("kube-system").Get(context.TODO(), "cluster-autoscaler-status", metav1.GetOptions{})
+		assert.NoError(t, err)
+		return object.Status
+	}
+
+	status := casv1alpha1.ClusterAutoscalerStatusStatus{AutoscalerState: casv1alpha1.AutoscalerInitializing, LastUpdateTime: metav1.NewTime(now)}
+	assert.NotNil(t, write(status))
+	assert.Equal(t, status, get())
+
+	status = casv1alpha1.ClusterAutoscalerStatusStatus{
+		AutoscalerState: casv1alpha1.AutoscalerRunning,
+		LastUpdateTime:  metav1.NewTime(now.Add(10 * time.Second)),
+		NodeGroups:      []casv1alpha1.NodeGroupStatus{{Name: "ng1"}},
+	}
+	assert.NotNil(t, write(status))
+	assert.Equal(t, status, get())
+
+	// Only the update and probe times changed, the status isn't written.
+	unchanged := *status.DeepCopy()
+	unchanged.LastUpdateTime = metav1.NewTime(now.Add(20 * time.Second))
+	unchanged.ClusterWide.Health.LastProbeTime = metav1.NewTime(now.Add(20 * time.Second))
+	unchanged.NodeGroups[0].ScaleUp.LastProbeTime = metav1.NewTime(now.Add(20 * time.Second))
+	actions := len(client.Actions())
+	assert.Nil(t, write(unchanged))
+	assert.Len(t, client.Actions(), actions)
+	assert.Equal(t, status, get())
+
+	// The status is refreshed once per StatusObjectRefreshInterval.
+	unchanged.LastUpdateTime = metav1.NewTime(now.Add(10*time.Second + StatusObjectRefreshInterval))
+	assert.NotNil(t, write(unchanged))
+	assert.Equal(t, unchanged, get())
+}
+
+func TestWriteStatusObjectNotYetListed(t *testing.T) {
+	client := casfake.NewSimpleClientset(&casv1alpha1.ClusterAutoscalerStatus{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "cluster-autoscaler-status"},
+	})
+	lister := caslisters.NewClusterAutoscalerStatusLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}))
+
+	status := casv1alpha1.ClusterAutoscalerStatusStatus{AutoscalerState: casv1alpha1.AutoscalerRunning}
+	object, err := WriteStatusObject(client, lister, "kube-system", "cluster-autoscaler-status", status)
+	assert.NoError(t, err)
+	assert.Equal(t, status, object.Status)
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	gce_localssdsize "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/gce/localssdsize"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
 	kubelet_config "k8s.io/kubernetes/pkg/kubelet/apis/config"
 	scheduler_config "k8s.io/kubernetes/pkg/scheduler/apis/config"
 )
@@ -56,6 +57,14 @@ type NodeGroupAutoscalingOptions struct {
 	AllowNonAtomicScaleUpToMax bool
 	// IgnoreDaemonSetsUtilization sets if daemonsets utilization should be considered during node scale-down
 	IgnoreDaemonSetsUtilization bool
+	// MaxNodeLifetime is the maximum age of a node. Older nodes are considered for scale-down regardless
+	// of their utilization and are replaced ahead of the drain if their pods can't be moved elsewhere. 0 means no limit.
+	MaxNodeLifetime time.Duration
+	// ScaleDownMaintenanceWindows are the periods during which nodes can be scaled down. If empty, nodes
+	// can be scaled down at any time.
+	ScaleDownMaintenanceWindows []schedule.Window
+	// ScaleDownBlackoutWindows are the periods during which nodes are never scaled down, even within a maintenance window.
+	ScaleDownBlackoutWindows []schedule.Window
 }
 
 // GCEOptions contain autoscaling options specific to GCE cloud provider.
@@ -192,6 +201,9 @@ type AutoscalingOptions struct {
 	WriteStatusConfigMap bool
 	// StaticConfigMapName
 	StatusConfigMapName string
+	// WriteStatusCRD tells if the status information should be written to a ClusterAutoscalerStatus object
+	// named StatusConfigMapName, in addition to the ConfigMap
+	WriteStatusCRD bool
 	// BalanceSimilarNodeGroups enables logic that identifies node groups with similar machines and tries to balance node count between them.
 	BalanceSimilarNodeGroups bool
 	// ConfigNamespace is the namespace cluster-autoscaler is running in and all related configmaps live in
@@ -348,7 +360,75 @@ type AutoscalingOptions struct {
 	//CapacitybufferControllerEnabled tells if CA should run default capacity buffer as sub-process or not
 	CapacitybufferControllerEnabled bool
 	// CapacitybufferPodInjectionEnabled tells if CA should injects fake pods for capacity buffers that are ready for provisioning
+	// and keep standby instances for the ones using the standby provisioning strategy
 	CapacitybufferPodInjectionEnabled bool
+	// CapacitybufferPodTemplatePaths maps <kind>.<group> of workloads referenced by capacity buffers to
+	// the dot separated path of their pod template, used for workloads resolved through the scale subresource
+	CapacitybufferPodTemplatePaths map[string]string
+	// ConsolidationEnabled tells if CA should replace sets of underutilized nodes with a single cheaper node
+	ConsolidationEnabled bool
+	// ConsolidationUtilizationThreshold is the utilization below which nodes are considered for consolidation
+	ConsolidationUtilizationThreshold float64
+	// MaxConsolidationNodes is the maximum number of nodes replaced by a single consolidation
+	MaxConsolidationNodes int
+	// MaxRecyclingParallelism is the maximum number of nodes older than MaxNodeLifetime being replaced at the same time
+	MaxRecyclingParallelism int
+	// ScaleDownEmptyOutsideMaintenanceWindows is used to allow CA to scale down empty nodes outside of scale-down maintenance windows
+	ScaleDownEmptyOutsideMaintenanceWindows bool
+	// ScheduledMinCapacityConfigMap is the name of the ConfigMap with schedules raising the minimum size of node groups
+	ScheduledMinCapacityConfigMap string
+	// ScaleDownHooksConfig is a path to the file with hooks called before draining and after deleting nodes
+	ScaleDownHooksConfig string
+	// ScaleDownExplanationAnnotations enables annotating nodes with a summary of why they are or aren't scaled down
+	ScaleDownExplanationAnnotations bool
+	// ScaleUpExplanationPodConditions enables setting a condition with the outcome of scale-up on one pod of every group of equivalent pending pods
+	ScaleUpExplanationPodConditions bool
+	// ExternalDrainabilityRuleAddress is the address of a gRPC service deciding whether pods can be drained
+	ExternalDrainabilityRuleAddress string
+	// ExternalDrainabilityRuleTimeout bounds the time of a single call to the external drainability rule
+	ExternalDrainabilityRuleTimeout time.Duration
+	// ExternalDrainabilityRuleCACert is a path to a CA certificate used to verify the external drainability rule service
+	ExternalDrainabilityRuleCACert string
+	// PodGroupAwareScaleUp enables all-or-nothing scale-up estimation for pod groups (gangs)
+	PodGroupAwareScaleUp bool
+	// PodGroupAnnotation is the annotation with the name of the pod group of a pod
+	PodGroupAnnotation string
+	// PodGroupMinMemberAnnotation is the annotation with the min-member of the pod group of a pod
+	PodGroupMinMemberAnnotation string
+	// CostOptimalScaleUp enables planning scale-ups across multiple node groups at the lowest cost
+	CostOptimalScaleUp bool
+	// ScaleDownSimulationParallelism is the number of scale-down candidates simulated concurrently
+	ScaleDownSimulationParallelism int
+	// NodeInfoCacheConfigMap is the name of the ConfigMap persisting cached template node infos
+	NodeInfoCacheConfigMap string
+	// NodeInfoCacheFile is the path of the file persisting cached template node infos
+	NodeInfoCacheFile string
+	// PredictiveScaleUpEnabled enables injecting placeholder pods for the forecast demand of node groups
+	PredictiveScaleUpEnabled bool
+	// PredictiveScaleUpHorizon is how far ahead the demand of node groups is provisioned
+	PredictiveScaleUpHorizon time.Duration
+	// PredictiveScaleUpSeason is the period over which the demand of node groups is expected to repeat
+	PredictiveScaleUpSeason time.Duration
+	// PredictiveScaleUpSeasons is the number of previous seasons averaged by the forecast
+	PredictiveScaleUpSeasons int
+	// PredictiveScaleUpHistoryConfigMap is the name of the ConfigMap persisting the demand history
+	PredictiveScaleUpHistoryConfigMap string
+	// PredictiveScaleUpHistoryFile is the path of the file persisting the demand history
+	PredictiveScaleUpHistoryFile string
+	// BinpackingCapacityCacheEnabled enables remembering across loops how many equivalent pods fit on a new node
+	BinpackingCapacityCacheEnabled bool
+	// TracingOTLPEndpoint is the address of the OTLP gRPC collector receiving spans; empty disables tracing
+	TracingOTLPEndpoint string
+	// TracingOTLPInsecure disables TLS when connecting to the OTLP collector
+	TracingOTLPInsecure bool
+	// TracingSamplingRatio is the fraction of autoscaler loops traced
+	TracingSamplingRatio float64
+	// AuditLogPath is the file scale-up and scale-down decisions are written to as JSON lines; "-" writes to stdout, empty disables the audit log
+	AuditLogPath string
+	// AuditLogMaxSizeMB is the size in megabytes above which the audit log file is rotated
+	AuditLogMaxSizeMB int
+	// AuditLogMaxBackups is the number of rotated audit log files to keep
+	AuditLogMaxBackups int
 }
 
 // KubeClientOptions specify options for kube client
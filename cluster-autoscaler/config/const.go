@@ -40,6 +40,12 @@ const (
 	DefaultMaxNodeProvisionTimeKey = "maxnodeprovisiontime"
 	// DefaultIgnoreDaemonSetsUtilizationKey identifies IgnoreDaemonSetsUtilization autoscaling option
 	DefaultIgnoreDaemonSetsUtilizationKey = "ignoredaemonsetsutilization"
+	// DefaultMaxNodeLifetimeKey identifies MaxNodeLifetime autoscaling option
+	DefaultMaxNodeLifetimeKey = "maxnodelifetime"
+	// DefaultScaleDownMaintenanceWindowsKey identifies ScaleDownMaintenanceWindows autoscaling option
+	DefaultScaleDownMaintenanceWindowsKey = "scaledownmaintenancewindows"
+	// DefaultScaleDownBlackoutWindowsKey identifies ScaleDownBlackoutWindows autoscaling option
+	DefaultScaleDownBlackoutWindowsKey = "scaledownblackoutwindows"
 
 	// DefaultScaleDownUnneededTime is the default time duration for which CA waits before deleting an unneeded node
 	DefaultScaleDownUnneededTime = 10 * time.Minute
@@ -30,6 +30,8 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/podgroup"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
 	scheduler_util "k8s.io/autoscaler/cluster-autoscaler/utils/scheduler"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
 
@@ -124,6 +126,7 @@ var (
 	scaleUpFromZero           = flag.Bool("scale-up-from-zero", true, "Should CA scale up when there are 0 ready nodes.")
 	parallelScaleUp           = flag.Bool("parallel-scale-up", false, "Whether to allow parallel node groups scale up. Experimental: may not work on some cloud providers, enable at your own risk.")
 	maxNodeProvisionTime      = flag.Duration("max-node-provision-time", 15*time.Minute, "The default maximum time CA waits for node to be provisioned - the value can be overridden per node group")
+	maxNodeLifetime           = flag.Duration("max-node-lifetime", 0, "The default maximum age of a node, after which it is replaced regardless of its utilization - the value can be overridden per node group. 0 means no limit.")
 	maxPodEvictionTime        = flag.Duration("max-pod-eviction-time", 2*time.Minute, "Maximum time CA tries to evict a pod before giving up")
 	nodeGroupsFlag            = multiStringFlag(
 		"nodes",
@@ -152,6 +155,7 @@ var (
 
 	writeStatusConfigMapFlag     = flag.Bool("write-status-configmap", true, "Should CA write status information to a configmap")
 	statusConfigMapName          = flag.String("status-config-map-name", "cluster-autoscaler-status", "Status configmap name")
+	writeStatusCRDFlag           = flag.Bool("write-status-crd", false, "Should CA write status information to a ClusterAutoscalerStatus object, named like the status configmap. Requires the ClusterAutoscalerStatus CRD to be installed.")
 	maxInactivityTimeFlag        = flag.Duration("max-inactivity", 10*time.Minute, "Maximum time from last recorded autoscaler activity before automatic restart")
 	maxBinpackingTimeFlag        = flag.Duration("max-binpacking-time", 5*time.Minute, "Maximum time spend on binpacking for a single scale-up. If binpacking is limited by this, scale-up will continue with the already calculated scale-up options.")
 	maxFailingTimeFlag           = flag.Duration("max-failing-time", 15*time.Minute, "Maximum time from last recorded successful autoscaler run before automatic restart")
@@ -229,7 +233,42 @@ var (
 	checkCapacityProcessorInstance               = flag.String("check-capacity-processor-instance", "", "Name of the processor instance. Only ProvisioningRequests that define this name in their parameters with the key \"processorInstance\" will be processed by this CA instance. It only refers to check capacity ProvisioningRequests, but if not empty, best-effort atomic ProvisioningRequests processing is disabled in this instance. Not recommended: Until CA 1.35, ProvisioningRequests with this name as prefix in their class will be also processed.")
 	nodeDeletionCandidateTTL                     = flag.Duration("node-deletion-candidate-ttl", time.Duration(0), "Maximum time a node can be marked as removable before the marking becomes stale. This sets the TTL of Cluster-Autoscaler's state if the Cluste-Autoscaler deployment becomes inactive")
 	capacitybufferControllerEnabled              = flag.Bool("capacity-buffer-controller-enabled", false, "Whether to enable the default controller for capacity buffers or not")
-	capacitybufferPodInjectionEnabled            = flag.Bool("capacity-buffer-pod-injection-enabled", false, "Whether to enable pod list processor that processes ready capacity buffers and injects fake pods accordingly")
+	capacitybufferPodInjectionEnabled            = flag.Bool("capacity-buffer-pod-injection-enabled", false, "Whether to enable pod list processors that process ready capacity buffers and inject fake pods accordingly, or keep standby instances for buffers using the standby provisioning strategy")
+	capacitybufferPodTemplatePaths               = multiStringFlag("capacity-buffer-pod-template-path", "Path of the pod template in workloads referenced by capacity buffers, in the format <kind>.<group>=<path> (e.g. LeaderWorkerSet.leaderworkerset.x-k8s.io=spec.leaderWorkerTemplate.workerTemplate). Workloads with a scale subresource and no configured path use the most recent pod or spec.template. Can be passed multiple times.")
+	consolidationEnabled                         = flag.Bool("consolidation-enabled", false, "Whether CA should replace sets of underutilized nodes, which can't be removed by scale down, with a single cheaper node from another node group. The replaced nodes are drained once the new node is ready.")
+	consolidationUtilizationThreshold            = flag.Float64("consolidation-utilization-threshold", 0.5, "Nodes with cpu and memory utilization below this threshold are considered for consolidation.")
+	maxConsolidationNodes                        = flag.Int("max-consolidation-nodes", 5, "Maximum number of nodes replaced by a single consolidation.")
+	scaleDownMaintenanceWindows                  = multiStringFlag("scale-down-maintenance-window", "A period during which nodes can be scaled down, as a cron expression followed by the window duration, e.g. '0 22 * * 1-5 8h'. Can be used multiple times. If not set, nodes can be scaled down at any time. The value can be overridden per node group.")
+	scaleDownBlackoutWindows                     = multiStringFlag("scale-down-blackout-window", "A period during which nodes are never scaled down, as a cron expression followed by the window duration. Can be used multiple times. Takes precedence over maintenance windows. The value can be overridden per node group.")
+	scaleDownEmptyOutsideMaintenanceWindows      = flag.Bool("scale-down-empty-outside-maintenance-windows", true, "Should CA scale down empty nodes outside of scale-down maintenance windows. Blackout windows apply to empty nodes regardless.")
+	maxRecyclingParallelism                      = flag.Int("max-recycling-parallelism", 1, "Maximum number of nodes older than max-node-lifetime being replaced at the same time.")
+	scaleDownHooksConfig                         = flag.String("scale-down-hooks-config", "", "Path to a YAML file with HTTP and gRPC hooks called before draining and after deleting nodes during scale-down. Empty disables hooks.")
+	scaleDownExplanationAnnotations              = flag.Bool("scale-down-explanation-annotations", false, "Should CA annotate nodes with a summary of why they are or aren't being scaled down. Full explanations are always served on the /scale-down-explanations endpoint.")
+	scaleUpExplanationPodConditions              = flag.Bool("scale-up-explanation-pod-conditions", false, "Should CA set a condition with the outcome of scale-up on one pod of every group of equivalent pending pods. Full explanations are always served on the /scale-up-explanations endpoint.")
+	externalDrainabilityRuleAddress              = flag.String("external-drainability-rule-address", "", "Address of a gRPC service implementing the DrainabilityRule service, asked whether pods can be drained during scale-down. Empty disables the external rule.")
+	externalDrainabilityRuleTimeout              = flag.Duration("external-drainability-rule-timeout", time.Second, "Timeout of a single call to the external drainability rule, made once per node. Errors and timeouts block the drain of the node.")
+	externalDrainabilityRuleCACert               = flag.String("external-drainability-rule-ca-cert", "", "Path to a CA certificate used to verify the external drainability rule service. If empty, the connection is insecure.")
+	podGroupAwareScaleUp                         = flag.Bool("pod-group-aware-scale-up", false, "Whether pods of a pod group (gang), recognized by the scheduler-plugins pod-group label or --pod-group-annotation, should be scaled up all-or-nothing.")
+	podGroupAnnotation                           = flag.String("pod-group-annotation", podgroup.DefaultAnnotation, "Annotation with the name of the pod group of a pod, in addition to the scheduler-plugins pod-group label.")
+	podGroupMinMemberAnnotation                  = flag.String("pod-group-min-member-annotation", podgroup.DefaultMinMemberAnnotation, "Annotation with the minimum number of pods of a pod group which have to run at the same time. If not set, all pending pods of the pod group are required.")
+	costOptimalScaleUp                           = flag.Bool("cost-optimal-scale-up", false, "Whether scale-up should search for the cheapest combination of node groups helping all pending pods, using the pricing of the cloud provider, instead of picking a single node group with the expander. Combinations spanning multiple node groups are executed as one scale-up.")
+	scaleDownSimulationParallelism               = flag.Int("scale-down-simulation-parallelism", 1, "Number of scale-down candidates whose removal is simulated concurrently, each on its own copy of the cluster snapshot. Results are then confirmed one by one, so the outcome matches the sequential simulation. 1 disables parallel simulation. Ignored when dynamic resource allocation is enabled.")
+	nodeInfoCacheConfigMap                       = flag.String("node-info-cache-config-map", "", "Name of the ConfigMap, in the namespace given by --namespace, in which node templates built from real nodes are saved, so that they survive restarts and scale-up from zero can keep using them. Empty disables saving templates to a ConfigMap.")
+	nodeInfoCacheFile                            = flag.String("node-info-cache-file", "", "Path of a local file in which node templates built from real nodes are saved, so that they survive restarts and scale-up from zero can keep using them. Ignored if --node-info-cache-config-map is set. Empty disables saving templates to a file.")
+	scheduledMinCapacityConfigMap                = flag.String("scheduled-min-capacity-config-map", "", "Name of the ConfigMap, in the namespace given by --namespace, with schedules raising the minimum size of node groups. Scale-down never goes below a scheduled minimum size, and scale-up to it requires --enforce-node-group-min-size. Empty disables scheduled minimum sizes.")
+	predictiveScaleUpEnabled                     = flag.Bool("predictive-scale-up-enabled", false, "Whether to record the number of nodes needed by each node group over time and inject placeholder pods for the demand forecast within --predictive-scale-up-horizon from previous seasons.")
+	predictiveScaleUpHorizon                     = flag.Duration("predictive-scale-up-horizon", 15*time.Minute, "How far ahead predictive scale-up provisions nodes for the forecast demand.")
+	predictiveScaleUpSeason                      = flag.Duration("predictive-scale-up-season", 24*time.Hour, "Length of the period over which the demand of node groups is expected to repeat.")
+	predictiveScaleUpSeasons                     = flag.Int("predictive-scale-up-seasons", 7, "Number of previous seasons averaged by the predictive scale-up forecast.")
+	predictiveScaleUpHistoryConfigMap            = flag.String("predictive-scale-up-history-config-map", "", "Name of the ConfigMap, in the namespace given by --namespace, in which the predictive scale-up demand history is saved. Empty disables saving the history to a ConfigMap.")
+	predictiveScaleUpHistoryFile                 = flag.String("predictive-scale-up-history-file", "", "Path of a local file in which the predictive scale-up demand history is saved. Ignored if --predictive-scale-up-history-config-map is set. Empty disables saving the history to a file.")
+	binpackingCapacityCacheEnabled               = flag.Bool("binpacking-capacity-cache-enabled", false, "Whether to remember across loops how many pods of an equivalence group fit on a new node of a node group, so that binpacking estimation doesn't run scheduler predicates for each pod again.")
+	tracingOTLPEndpoint                          = flag.String("tracing-otlp-endpoint", "", "Address of the OpenTelemetry collector receiving traces of the autoscaler loop over OTLP gRPC. Tracing is disabled if empty.")
+	tracingOTLPInsecure                          = flag.Bool("tracing-otlp-insecure", false, "Whether to connect to the OpenTelemetry collector without TLS.")
+	tracingSamplingRatio                         = flag.Float64("tracing-sampling-ratio", 1.0, "Fraction of autoscaler loops traced, between 0 and 1.")
+	auditLogPath                                 = flag.String("audit-log-path", "", "File to which a JSON record of each scale-up and scale-down decision is written. Set to \"-\" to write to stdout. The audit log is disabled if empty.")
+	auditLogMaxSizeMB                            = flag.Int("audit-log-max-size-mb", 100, "Size in megabytes above which the audit log file is rotated.")
+	auditLogMaxBackups                           = flag.Int("audit-log-max-backups", 10, "Number of rotated audit log files to keep.")
 
 	// Deprecated flags
 	ignoreTaintsFlag = multiStringFlag("ignore-taint", "Specifies a taint to ignore in node templates when considering to scale a node group (Deprecated, use startup-taints instead)")
@@ -264,6 +303,11 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		klog.Fatalf("Failed to parse flags: %v", err)
 	}
 
+	parsedCapacitybufferPodTemplatePaths, err := parseCapacitybufferPodTemplatePaths(*capacitybufferPodTemplatePaths)
+	if err != nil {
+		klog.Fatalf("Failed to parse flags: %v", err)
+	}
+
 	var parsedSchedConfig *scheduler_config.KubeSchedulerConfiguration
 	// if scheduler config flag was set by the user
 	if pflag.CommandLine.Changed(config.SchedulerConfigFileFlag) {
@@ -277,6 +321,15 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		klog.Fatalf("Invalid configuration, could not use --drain-priority-config together with --max-graceful-termination-sec")
 	}
 
+	parsedScaleDownMaintenanceWindows, err := schedule.ParseWindows(*scaleDownMaintenanceWindows)
+	if err != nil {
+		klog.Fatalf("Failed to parse --scale-down-maintenance-window: %v", err)
+	}
+	parsedScaleDownBlackoutWindows, err := schedule.ParseWindows(*scaleDownBlackoutWindows)
+	if err != nil {
+		klog.Fatalf("Failed to parse --scale-down-blackout-window: %v", err)
+	}
+
 	var drainPriorityConfigMap []kubelet_config.ShutdownGracePeriodByPodPriority
 	if pflag.CommandLine.Changed("drain-priority-config") {
 		drainPriorityConfigMap = parseShutdownGracePeriodsAndPriorities(*drainPriorityConfig)
@@ -293,6 +346,9 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 			ScaleDownUnreadyTime:             *scaleDownUnreadyTime,
 			IgnoreDaemonSetsUtilization:      *ignoreDaemonSetsUtilization,
 			MaxNodeProvisionTime:             *maxNodeProvisionTime,
+			MaxNodeLifetime:                  *maxNodeLifetime,
+			ScaleDownMaintenanceWindows:      parsedScaleDownMaintenanceWindows,
+			ScaleDownBlackoutWindows:         parsedScaleDownBlackoutWindows,
 		},
 		CloudConfig:                      *cloudConfig,
 		CloudProviderName:                *cloudProviderFlag,
@@ -331,6 +387,7 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		SchedulerConfig:                  parsedSchedConfig,
 		WriteStatusConfigMap:             *writeStatusConfigMapFlag,
 		StatusConfigMapName:              *statusConfigMapName,
+		WriteStatusCRD:                   *writeStatusCRDFlag,
 		BalanceSimilarNodeGroups:         *balanceSimilarNodeGroupsFlag,
 		ConfigNamespace:                  *namespace,
 		ClusterName:                      *clusterName,
@@ -414,6 +471,39 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		NodeDeletionCandidateTTL:                     *nodeDeletionCandidateTTL,
 		CapacitybufferControllerEnabled:              *capacitybufferControllerEnabled,
 		CapacitybufferPodInjectionEnabled:            *capacitybufferPodInjectionEnabled,
+		CapacitybufferPodTemplatePaths:               parsedCapacitybufferPodTemplatePaths,
+		ConsolidationEnabled:                         *consolidationEnabled,
+		ConsolidationUtilizationThreshold:            *consolidationUtilizationThreshold,
+		MaxConsolidationNodes:                        *maxConsolidationNodes,
+		MaxRecyclingParallelism:                      *maxRecyclingParallelism,
+		ScaleDownEmptyOutsideMaintenanceWindows:      *scaleDownEmptyOutsideMaintenanceWindows,
+		ScheduledMinCapacityConfigMap:                *scheduledMinCapacityConfigMap,
+		ScaleDownHooksConfig:                         *scaleDownHooksConfig,
+		ScaleDownExplanationAnnotations:              *scaleDownExplanationAnnotations,
+		ScaleUpExplanationPodConditions:              *scaleUpExplanationPodConditions,
+		ExternalDrainabilityRuleAddress:              *externalDrainabilityRuleAddress,
+		ExternalDrainabilityRuleTimeout:              *externalDrainabilityRuleTimeout,
+		ExternalDrainabilityRuleCACert:               *externalDrainabilityRuleCACert,
+		PodGroupAwareScaleUp:                         *podGroupAwareScaleUp,
+		PodGroupAnnotation:                           *podGroupAnnotation,
+		PodGroupMinMemberAnnotation:                  *podGroupMinMemberAnnotation,
+		CostOptimalScaleUp:                           *costOptimalScaleUp,
+		ScaleDownSimulationParallelism:               *scaleDownSimulationParallelism,
+		NodeInfoCacheConfigMap:                       *nodeInfoCacheConfigMap,
+		NodeInfoCacheFile:                            *nodeInfoCacheFile,
+		PredictiveScaleUpEnabled:                     *predictiveScaleUpEnabled,
+		PredictiveScaleUpHorizon:                     *predictiveScaleUpHorizon,
+		PredictiveScaleUpSeason:                      *predictiveScaleUpSeason,
+		PredictiveScaleUpSeasons:                     *predictiveScaleUpSeasons,
+		PredictiveScaleUpHistoryConfigMap:            *predictiveScaleUpHistoryConfigMap,
+		PredictiveScaleUpHistoryFile:                 *predictiveScaleUpHistoryFile,
+		BinpackingCapacityCacheEnabled:               *binpackingCapacityCacheEnabled,
+		TracingOTLPEndpoint:                          *tracingOTLPEndpoint,
+		TracingOTLPInsecure:                          *tracingOTLPInsecure,
+		TracingSamplingRatio:                         *tracingSamplingRatio,
+		AuditLogPath:                                 *auditLogPath,
+		AuditLogMaxSizeMB:                            *auditLogMaxSizeMB,
+		AuditLogMaxBackups:                           *auditLogMaxBackups,
 	}
 }
 
@@ -467,6 +557,18 @@ func parseMultipleGpuLimits(flags MultiStringFlag) ([]config.GpuLimits, error) {
 	return parsedFlags, nil
 }
 
+func parseCapacitybufferPodTemplatePaths(flags MultiStringFlag) (map[string]string, error) {
+	podTemplatePaths := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		key, path, found := strings.Cut(flag, "=")
+		if !found || key == "" || path == "" {
+			return nil, fmt.Errorf("incorrect capacity buffer pod template path specification: %v", flag)
+		}
+		podTemplatePaths[key] = path
+	}
+	return podTemplatePaths, nil
+}
+
 func parseSingleGpuLimit(limits string) (config.GpuLimits, error) {
 	parts := strings.Split(limits, ":")
 	if len(parts) != 3 {
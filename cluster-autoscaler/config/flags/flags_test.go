@@ -97,6 +97,44 @@ func TestParseSingleGpuLimit(t *testing.T) {
 	}
 }
 
+func TestParseCapacitybufferPodTemplatePaths(t *testing.T) {
+	testcases := []struct {
+		input                []string
+		expectedPaths        map[string]string
+		expectedErrorMessage string
+	}{
+		{
+			input: []string{"LeaderWorkerSet.leaderworkerset.x-k8s.io=spec.leaderWorkerTemplate.workerTemplate", "Rollout.argoproj.io=spec.template"},
+			expectedPaths: map[string]string{
+				"LeaderWorkerSet.leaderworkerset.x-k8s.io": "spec.leaderWorkerTemplate.workerTemplate",
+				"Rollout.argoproj.io":                      "spec.template",
+			},
+		},
+		{
+			input:         []string{},
+			expectedPaths: map[string]string{},
+		},
+		{
+			input:                []string{"Rollout.argoproj.io"},
+			expectedErrorMessage: "incorrect capacity buffer pod template path specification: Rollout.argoproj.io",
+		},
+		{
+			input:                []string{"Rollout.argoproj.io="},
+			expectedErrorMessage: "incorrect capacity buffer pod template path specification: Rollout.argoproj.io=",
+		},
+	}
+
+	for _, testcase := range testcases {
+		paths, err := parseCapacitybufferPodTemplatePaths(testcase.input)
+		if testcase.expectedErrorMessage != "" {
+			assert.EqualError(t, err, testcase.expectedErrorMessage)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expectedPaths, paths)
+		}
+	}
+}
+
 func TestParseShutdownGracePeriodsAndPriorities(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -17,18 +17,24 @@ limitations under the License.
 package context
 
 import (
+	"context"
+
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/hooks"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
+	"k8s.io/autoscaler/cluster-autoscaler/cost"
 	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	processor_callbacks "k8s.io/autoscaler/cluster-autoscaler/processors/callbacks"
+	"k8s.io/autoscaler/cluster-autoscaler/scheduledcapacity"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
 	draprovider "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/provider"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/templatecache"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	"k8s.io/client-go/informers"
 	kube_client "k8s.io/client-go/kubernetes"
@@ -65,6 +71,23 @@ type AutoscalingContext struct {
 	ProvisioningRequestScaleUpMode bool
 	// DraProvider is the provider for dynamic resources allocation.
 	DraProvider *draprovider.Provider
+	// ScheduledMinCapacity raises the minimum size of node groups according to schedules. Can be nil.
+	ScheduledMinCapacity *scheduledcapacity.Provider
+	// ScaleDownHooks calls external hooks before draining and after deleting nodes. Can be nil.
+	ScaleDownHooks *hooks.Runner
+	// CostTracker attributes costs to scale-up and scale-down decisions. Can be nil.
+	CostTracker *cost.Tracker
+	// NewSimulationSnapshot creates cluster snapshots independent of ClusterSnapshot, which can be
+	// used for simulations running concurrently with the main loop. Can be nil.
+	NewSimulationSnapshot func() (clustersnapshot.ClusterSnapshot, error)
+	// NodeInfoCacheStore persists template node infos built from real nodes across restarts. Can be nil.
+	NodeInfoCacheStore templatecache.Store
+	// TemplateNodeInfos are the sanitized template node infos of node groups, keyed by node group id, built by
+	// TemplateNodeInfoProvider in the current loop. Can be nil before they are built.
+	TemplateNodeInfos map[string]*framework.NodeInfo
+	// TraceContext carries the tracing span of the current main loop phase. It is only valid on the main loop
+	// goroutine, code running elsewhere must not use it. Can be nil.
+	TraceContext context.Context
 }
 
 // AutoscalingKubeClients contains all Kubernetes API clients,
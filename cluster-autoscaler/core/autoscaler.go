@@ -24,6 +24,8 @@ import (
 	cloudBuilder "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/builder"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/explanation"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/hooks"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
 	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
@@ -43,6 +45,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/client-go/informers"
 	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
 )
 
 // AutoscalerOptions is the whole set of options for configuring an autoscaler
@@ -65,6 +68,9 @@ type AutoscalerOptions struct {
 	DeleteOptions          options.NodeDeleteOptions
 	DrainabilityRules      rules.Rules
 	DraProvider            *draprovider.Provider
+	ScaleDownHooks         *hooks.Runner
+	NodeExplainer          *explanation.Explainer
+	SimulationSnapshot     func() (clustersnapshot.ClusterSnapshot, error)
 }
 
 // Autoscaler is the main component of CA which scales up/down node groups according to its configuration
@@ -105,6 +111,9 @@ func NewAutoscaler(opts AutoscalerOptions, informerFactory informers.SharedInfor
 		opts.DeleteOptions,
 		opts.DrainabilityRules,
 		opts.DraProvider,
+		opts.ScaleDownHooks,
+		opts.NodeExplainer,
+		opts.SimulationSnapshot,
 	), nil
 }
 
@@ -129,6 +138,15 @@ func initializeDefaultOptions(opts *AutoscalerOptions, informerFactory informers
 	if opts.ClusterSnapshot == nil {
 		opts.ClusterSnapshot = predicate.NewPredicateSnapshot(store.NewBasicSnapshotStore(), opts.FrameworkHandle, opts.DynamicResourceAllocationEnabled)
 	}
+	if opts.SimulationSnapshot == nil && opts.ScaleDownSimulationParallelism > 1 {
+		opts.SimulationSnapshot = func() (clustersnapshot.ClusterSnapshot, error) {
+			fwHandle, err := framework.NewHandle(opts.InformerFactory, opts.SchedulerConfig, opts.DynamicResourceAllocationEnabled)
+			if err != nil {
+				return nil, err
+			}
+			return predicate.NewPredicateSnapshot(store.NewBasicSnapshotStore(), fwHandle, opts.DynamicResourceAllocationEnabled), nil
+		}
+	}
 	if opts.RemainingPdbTracker == nil {
 		opts.RemainingPdbTracker = pdb.NewBasicRemainingPdbTracker()
 	}
@@ -150,11 +168,21 @@ func initializeDefaultOptions(opts *AutoscalerOptions, informerFactory informers
 			estimator.NewSngCapacityThreshold(),
 			estimator.NewClusterCapacityThreshold(),
 		}
+		var capacityCache *estimator.CapacityCache
+		if opts.BinpackingCapacityCacheEnabled {
+			// Pods placed from the cache skip scheduler predicates, including filter extenders.
+			if opts.FrameworkHandle.HasFilterExtenders() {
+				klog.Warningf("Binpacking capacity cache is disabled, since scheduler filter extenders are configured")
+			} else {
+				capacityCache = estimator.NewCapacityCache(estimator.DefaultCapacityCacheTTL)
+			}
+		}
 		estimatorBuilder, err := estimator.NewEstimatorBuilder(
 			opts.EstimatorName,
 			estimator.NewThresholdBasedEstimationLimiter(thresholds),
 			estimator.NewDecreasingPodOrderer(),
 			/* EstimationAnalyserFunc */ nil,
+			capacityCache,
 		)
 		if err != nil {
 			return err
@@ -168,6 +196,16 @@ func initializeDefaultOptions(opts *AutoscalerOptions, informerFactory informers
 	if opts.DrainabilityRules == nil {
 		opts.DrainabilityRules = rules.Default(opts.DeleteOptions)
 	}
+	if opts.ScaleDownHooks == nil && opts.ScaleDownHooksConfig != "" {
+		configs, err := hooks.LoadConfig(opts.ScaleDownHooksConfig)
+		if err != nil {
+			return err
+		}
+		opts.ScaleDownHooks, err = hooks.NewRunner(configs, opts.AutoscalingKubeClients.Recorder, opts.Processors.ScaleStateNotifier)
+		if err != nil {
+			return err
+		}
+	}
 	if opts.DraProvider == nil && opts.DynamicResourceAllocationEnabled {
 		opts.DraProvider = draprovider.NewProviderFromInformers(informerFactory)
 	}
@@ -18,6 +18,7 @@ package actuation
 
 import (
 	"context"
+	"reflect"
 	"strings"
 	"time"
 
@@ -75,6 +76,7 @@ type Actuator struct {
 type actuatorNodeGroupConfigGetter interface {
 	// GetIgnoreDaemonSetsUtilization returns IgnoreDaemonSetsUtilization value that should be used for a given NodeGroup.
 	GetIgnoreDaemonSetsUtilization(nodeGroup cloudprovider.NodeGroup) (bool, error)
+	scaledown.WindowsGetter
 }
 
 // NewActuator returns a new instance of Actuator.
@@ -135,6 +137,10 @@ func (a *Actuator) startDeletion(empty, drain []*apiv1.Node, force bool) (status
 	defer func() { metrics.UpdateDuration(metrics.ScaleDownNodeDeletion, time.Since(deletionStartTime)) }()
 
 	scaledDownNodes := make([]*status.ScaleDownNode, 0)
+	if !force && a.configGetter != nil {
+		empty = a.filterOutOutsideScaleDownWindow(empty, true, deletionStartTime)
+		drain = a.filterOutOutsideScaleDownWindow(drain, false, deletionStartTime)
+	}
 	emptyToDelete, drainToDelete := a.budgetProcessor.CropNodes(a.nodeDeletionTracker, empty, drain)
 	if len(emptyToDelete) == 0 && len(drainToDelete) == 0 {
 		return status.ScaleDownNoNodeDeleted, nil, nil
@@ -167,6 +173,31 @@ func (a *Actuator) startDeletion(empty, drain []*apiv1.Node, force bool) (status
 	return status.ScaleDownNodeDeleteStarted, scaledDownNodes, nil
 }
 
+// filterOutOutsideScaleDownWindow drops nodes from node groups which can't be scaled down at the given time
+// because of scale-down maintenance or blackout windows.
+func (a *Actuator) filterOutOutsideScaleDownWindow(nodes []*apiv1.Node, empty bool, ts time.Time) []*apiv1.Node {
+	var result []*apiv1.Node
+	for _, node := range nodes {
+		nodeGroup, err := a.autoscalingCtx.CloudProvider.NodeGroupForNode(node)
+		if err != nil || nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+			// Nodes without a node group are reported by the budget processor.
+			result = append(result, node)
+			continue
+		}
+		outsideWindow, err := scaledown.IsOutsideScaleDownWindow(a.configGetter, nodeGroup, empty, a.autoscalingCtx.ScaleDownEmptyOutsideMaintenanceWindows, ts)
+		if err != nil {
+			klog.Errorf("Failed to check scale-down windows of node group %s, not deleting %s: %v", nodeGroup.Id(), node.Name, err)
+			continue
+		}
+		if outsideWindow {
+			klog.V(1).Infof("Not deleting %s - node group %s is outside of a scale-down window", node.Name, nodeGroup.Id())
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
 // deleteAsyncEmpty immediately starts deletions asynchronously.
 // scaledDownNodes return value contains all nodes for which deletion successfully started.
 func (a *Actuator) deleteAsyncEmpty(NodeGroupViews []*budgets.NodeGroupView, nodeDeleteDelayAfterTaint time.Duration, force bool) (reportedSDNodes []*status.ScaleDownNode) {
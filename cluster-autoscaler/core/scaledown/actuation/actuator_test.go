@@ -47,6 +47,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/client-go/kubernetes/fake"
@@ -1694,6 +1695,84 @@ func generateDaemonSet() *appsv1.DaemonSet {
 	}
 }
 
+func TestFilterOutOutsideScaleDownWindow(t *testing.T) {
+	open, err := schedule.ParseWindow("* * * * * 1h")
+	if err != nil {
+		t.Fatalf("Couldn't parse window: %v", err)
+	}
+	closed, err := schedule.ParseWindow("0 0 30 2 * 1h")
+	if err != nil {
+		t.Fatalf("Couldn't parse window: %v", err)
+	}
+
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("default", 0, 10, 2)
+	inWindow := testprovider.NewTestNodeGroup("in-window", 10, 0, 2, true, false, "", nil, nil)
+	inWindow.SetOptions(&config.NodeGroupAutoscalingOptions{ScaleDownMaintenanceWindows: []schedule.Window{open}})
+	provider.InsertNodeGroup(inWindow)
+	blackout := testprovider.NewTestNodeGroup("blackout", 10, 0, 2, true, false, "", nil, nil)
+	blackout.SetOptions(&config.NodeGroupAutoscalingOptions{ScaleDownBlackoutWindows: []schedule.Window{open}})
+	provider.InsertNodeGroup(blackout)
+	for _, ng := range []string{"default", "in-window", "blackout"} {
+		provider.AddNode(ng, generateNode(ng+"-1"))
+		provider.AddNode(ng, generateNode(ng+"-2"))
+	}
+	nodes := func(names ...string) []*apiv1.Node {
+		var result []*apiv1.Node
+		for _, name := range names {
+			result = append(result, generateNode(name))
+		}
+		return result
+	}
+
+	for tn, tc := range map[string]struct {
+		emptyOutsideMaintenanceWindows bool
+		empty                          bool
+		want                           []string
+	}{
+		"nodes needing drain": {
+			want: []string{"in-window-1"},
+		},
+		"empty nodes": {
+			empty: true,
+			want:  []string{"in-window-1"},
+		},
+		"empty nodes allowed outside of maintenance windows": {
+			emptyOutsideMaintenanceWindows: true,
+			empty:                          true,
+			want:                           []string{"default-1", "in-window-1"},
+		},
+		"nodes needing drain with empty nodes allowed outside of maintenance windows": {
+			emptyOutsideMaintenanceWindows: true,
+			want:                           []string{"in-window-1"},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+				NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+					ScaleDownMaintenanceWindows: []schedule.Window{closed},
+				},
+				ScaleDownEmptyOutsideMaintenanceWindows: tc.emptyOutsideMaintenanceWindows,
+			}, nil, nil, provider, nil, nil)
+			if err != nil {
+				t.Fatalf("Couldn't set up autoscaling context: %v", err)
+			}
+			actuator := Actuator{
+				autoscalingCtx: &autoscalingCtx,
+				configGetter:   nodegroupconfig.NewDefaultNodeGroupConfigProcessor(autoscalingCtx.NodeGroupDefaults),
+			}
+			got := actuator.filterOutOutsideScaleDownWindow(nodes("default-1", "in-window-1", "blackout-1"), tc.empty, time.Now())
+			var gotNames []string
+			for _, node := range got {
+				gotNames = append(gotNames, node.Name)
+			}
+			if diff := cmp.Diff(tc.want, gotNames); diff != "" {
+				t.Errorf("filterOutOutsideScaleDownWindow() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func generateUtilInfo(cpuUtil, memUtil float64) utilization.Info {
 	var higherUtilName apiv1.ResourceName
 	var higherUtilVal float64
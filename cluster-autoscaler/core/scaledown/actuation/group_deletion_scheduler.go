@@ -98,6 +98,15 @@ func (ds *GroupDeletionScheduler) scheduleDeletion(nodeInfo *framework.NodeInfo,
 		opts = &config.NodeGroupAutoscalingOptions{}
 	}
 
+	if err := ds.autoscalingCtx.ScaleDownHooks.RunPreDrain(nodeInfo.Node(), nodeGroup, drain); err != nil {
+		if !force {
+			hookResult := status.NodeDeleteResult{ResultType: status.NodeDeleteErrorFailedToDelete, Err: errors.NewAutoscalerErrorf(errors.TransientError, "%v", err)}
+			ds.AbortNodeDeletion(nodeInfo.Node(), nodeGroup.Id(), drain, "pre-drain hook failed", hookResult, true)
+			return
+		}
+		klog.Warningf("Scale-down: proceeding with force deletion of node %s despite pre-drain hook failure: %v", nodeInfo.Node().Name, err)
+	}
+
 	nodeDeleteResult := ds.prepareNodeForDeletion(nodeInfo, drain, force)
 	if nodeDeleteResult.Err != nil {
 		if force {
@@ -18,6 +18,8 @@ package actuation
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/budgets"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/hooks"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
 	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
@@ -199,6 +202,74 @@ func TestScheduleDeletion(t *testing.T) {
 	}
 }
 
+func TestScheduleDeletionPreDrainHook(t *testing.T) {
+	for _, tc := range []struct {
+		name                  string
+		decision              string
+		wantDeleted           int
+		wantNodeDeleteResults map[string]status.NodeDeleteResult
+	}{
+		{
+			name:        "hook allows deletion",
+			decision:    "Allow",
+			wantDeleted: 1,
+		},
+		{
+			name:        "hook denies deletion",
+			decision:    "Deny",
+			wantDeleted: 0,
+			wantNodeDeleteResults: map[string]status.NodeDeleteResult{
+				"test-node-0": {ResultType: status.NodeDeleteErrorFailedToDelete, Err: cmpopts.AnyError},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprintf(w, `{"decision": %q}`, tc.decision)
+			}))
+			defer server.Close()
+
+			provider := testprovider.NewTestCloudProviderBuilder().Build()
+			testNg := testprovider.NewTestNodeGroup("test", 100, 0, 3, true, false, "n1-standard-2", nil, nil)
+			testNg.SetCloudProvider(provider)
+			provider.InsertNodeGroup(testNg)
+			views := generateNodeGroupViewList(testNg, 0, 1)
+			for _, node := range views[0].Nodes {
+				provider.AddNode(testNg.Id(), node)
+			}
+
+			dsLister, err := kube_util.NewTestDaemonSetLister([]*appsv1.DaemonSet{})
+			if err != nil {
+				t.Fatalf("Couldn't create daemonset lister")
+			}
+			registry := kube_util.NewListerRegistry(nil, nil, kube_util.NewTestPodLister(nil), kube_util.NewTestPodDisruptionBudgetLister(nil), dsLister, nil, nil, nil, nil)
+			autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, &fake.Clientset{}, registry, provider, nil, nil)
+			if err != nil {
+				t.Fatalf("Couldn't set up autoscaling context: %v", err)
+			}
+			autoscalingCtx.ScaleDownHooks, err = hooks.NewRunner([]hooks.Config{{Name: "checkpoint", Phase: hooks.PreDrain, URL: server.URL}}, autoscalingCtx.Recorder, nil)
+			if err != nil {
+				t.Fatalf("Couldn't create hooks: %v", err)
+			}
+
+			batcher := &countingBatcher{}
+			tracker := deletiontracker.NewNodeDeletionTracker(0)
+			scheduler := NewGroupDeletionScheduler(&autoscalingCtx, tracker, batcher, Evictor{EvictionRetryTime: 0, PodEvictionHeadroom: DefaultPodEvictionHeadroom})
+			if err := scheduleAll(views, scheduler); err != nil {
+				t.Fatal(err)
+			}
+
+			if batcher.addedNodes != tc.wantDeleted {
+				t.Errorf("Incorrect number of deleted nodes, want %v but got %v", tc.wantDeleted, batcher.addedNodes)
+			}
+			gotDeletionResult, _ := tracker.DeletionResults()
+			if diff := cmp.Diff(tc.wantNodeDeleteResults, gotDeletionResult, cmpopts.EquateEmpty(), cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("NodeDeleteResults diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 type countingBatcher struct {
 	addedNodes int
 }
@@ -182,6 +182,21 @@ func (bp *ScaleDownBudgetProcessor) CropNodes(as scaledown.ActuationStatus, empt
 	return emptyToDelete, drainToDelete
 }
 
+// CropRecycledNodes crops the list of expired nodes to be replaced, so that the number of nodes being
+// recycled at the same time doesn't exceed MaxRecyclingParallelism. Since every recycled node is
+// eventually drained, the list is also cropped to the remaining drain budget.
+func (bp *ScaleDownBudgetProcessor) CropRecycledNodes(as scaledown.ActuationStatus, recyclingInProgress int, nodes []*apiv1.Node) []*apiv1.Node {
+	_, drainInProgress := as.DeletionsInProgress()
+	budget := min(bp.autoscalingCtx.MaxRecyclingParallelism-recyclingInProgress, bp.autoscalingCtx.MaxDrainParallelism-len(drainInProgress))
+	if budget <= 0 {
+		return nil
+	}
+	if len(nodes) > budget {
+		return nodes[:budget]
+	}
+	return nodes
+}
+
 func groupBuckets(buckets []*NodeGroupView) map[string]*NodeGroupView {
 	grouped := map[string]*NodeGroupView{}
 	for _, bucket := range buckets {
@@ -535,3 +535,48 @@ func generateNode(name string) *apiv1.Node {
 		},
 	}
 }
+
+func TestCropRecycledNodes(t *testing.T) {
+	nodes := generateNodes(0, 5, "expired")
+	for tn, tc := range map[string]struct {
+		recyclingInProgress      int
+		drainDeletionsInProgress int
+		want                     []*apiv1.Node
+	}{
+		"no recycling in progress": {
+			want: nodes[:2],
+		},
+		"some recycling in progress": {
+			recyclingInProgress: 1,
+			want:                nodes[:1],
+		},
+		"recycling budget exhausted": {
+			recyclingInProgress: 2,
+		},
+		"drain budget exhausted": {
+			drainDeletionsInProgress: 3,
+		},
+		"drain budget lower than recycling budget": {
+			drainDeletionsInProgress: 2,
+			want:                     nodes[:1],
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			options := config.AutoscalingOptions{
+				MaxScaleDownParallelism: 10,
+				MaxDrainParallelism:     3,
+				MaxRecyclingParallelism: 2,
+			}
+			autoscalingCtx, err := test.NewScaleTestAutoscalingContext(options, &fake.Clientset{}, nil, testprovider.NewTestCloudProviderBuilder().Build(), nil, nil)
+			assert.NoError(t, err)
+			ndt := deletiontracker.NewNodeDeletionTracker(1 * time.Hour)
+			for i := 0; i < tc.drainDeletionsInProgress; i++ {
+				ndt.StartDeletionWithDrain("ng", fmt.Sprintf("drain-node-%d", i))
+			}
+			got := NewScaleDownBudgetProcessor(&autoscalingCtx).CropRecycledNodes(ndt, tc.recyclingInProgress, nodes)
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("CropRecycledNodes() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
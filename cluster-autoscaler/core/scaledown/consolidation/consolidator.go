@@ -0,0 +1,472 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consolidation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/eligibility"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/replacement"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// replacementNodeSuffix is the name suffix of the replacement node injected into the snapshot during simulation.
+	replacementNodeSuffix = "consolidation"
+	// pricingPeriod is the period over which node prices are compared.
+	pricingPeriod = time.Hour
+)
+
+type unneededNodesLister interface {
+	UnneededNodes() []*apiv1.Node
+}
+
+type deletionStarter interface {
+	StartDeletion(empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError)
+}
+
+// plan is a set of nodes which can be replaced with a single node from another node group.
+type plan struct {
+	nodeGroup cloudprovider.NodeGroup
+	nodes     []*apiv1.Node
+	// podsToReschedule are the pods which will be evicted from the nodes.
+	podsToReschedule []*apiv1.Pod
+	savings          float64
+}
+
+// consolidation is a plan which is being carried out: the replacement node was requested
+// and the nodes will be drained once it is ready.
+type consolidation struct {
+	nodeGroupId string
+	// nodes are the replaced nodes whose deletion didn't start yet.
+	nodes []string
+	// existingNodes are the nodes of the node group at the time the replacement was requested.
+	existingNodes map[string]bool
+	// replacement is the name of the replacement node, once it's ready.
+	replacement string
+	startTime   time.Time
+	readyTime   time.Time
+}
+
+// Consolidator replaces sets of underutilized nodes, which can't be removed
+// by the regular scale-down because their pods don't fit on other existing
+// nodes, with a single cheaper node. It simulates removing the nodes together
+// with adding a node from another node group and, if that's cheaper according
+// to the PricingModel, carries it out as a scale-up followed by a drain of the
+// replaced nodes once the new node is ready. If the cloud provider doesn't
+// implement pricing, all nodes are assumed to cost the same. The replacement
+// node is requested within the same limits as a regular scale-up.
+type Consolidator struct {
+	autoscalingCtx    *ca_context.AutoscalingContext
+	unneededNodes     unneededNodesLister
+	actuator          deletionStarter
+	requester         *replacement.Requester
+	configGetter      nodegroupconfig.NodeGroupConfigProcessor
+	deleteOptions     options.NodeDeleteOptions
+	drainabilityRules rules.Rules
+	inProgress        *consolidation
+}
+
+// NewConsolidator creates a new Consolidator object.
+func NewConsolidator(autoscalingCtx *ca_context.AutoscalingContext, unneededNodes unneededNodesLister, actuator deletionStarter, requester *replacement.Requester,
+	configGetter nodegroupconfig.NodeGroupConfigProcessor, deleteOptions options.NodeDeleteOptions, drainabilityRules rules.Rules) *Consolidator {
+	return &Consolidator{
+		autoscalingCtx:    autoscalingCtx,
+		unneededNodes:     unneededNodes,
+		actuator:          actuator,
+		requester:         requester,
+		configGetter:      configGetter,
+		deleteOptions:     deleteOptions,
+		drainabilityRules: drainabilityRules,
+	}
+}
+
+// InProgress returns true if a replacement node was requested and the deletion of the replaced nodes didn't start yet.
+func (c *Consolidator) InProgress() bool {
+	return c.inProgress != nil
+}
+
+// FilterOutReplacements removes the nodes which were added to the node group
+// of the ongoing consolidation from the scale-down candidates. The replacement
+// node stays empty until the replaced nodes are drained, so the regular
+// scale-down would remove it otherwise.
+func (c *Consolidator) FilterOutReplacements(nodes []*apiv1.Node) []*apiv1.Node {
+	if c.inProgress == nil {
+		return nodes
+	}
+	inGroup := replacement.NodesInGroup(c.autoscalingCtx.CloudProvider, nodes, c.inProgress.nodeGroupId)
+	var result []*apiv1.Node
+	for _, node := range nodes {
+		if inGroup[node.Name] && !c.inProgress.existingNodes[node.Name] {
+			klog.V(4).Infof("Consolidation: node %s is a consolidation replacement, not considering it for scale-down", node.Name)
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// RunOnce either continues the ongoing consolidation, draining the replaced nodes
+// if the replacement node is ready, or looks for a new one and requests the
+// replacement node. allNodes are all registered nodes in the cluster.
+func (c *Consolidator) RunOnce(allNodes, scaleDownCandidates, podDestinations []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo, currentTime time.Time) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	if c.inProgress != nil {
+		return c.continueConsolidation(allNodes, podDestinations, currentTime)
+	}
+
+	p := c.findPlan(allNodes, scaleDownCandidates, podDestinations, nodeInfosForGroups, currentTime)
+	if p == nil {
+		return status.ScaleDownNoNodeDeleted, nil, nil
+	}
+	if err := c.requester.Request(p.nodeGroup, nodeInfosForGroups[p.nodeGroup.Id()], currentTime); err != nil {
+		return status.ScaleDownError, nil, err.AddPrefix("failed to request consolidation replacement node in %s: ", p.nodeGroup.Id())
+	}
+	// The replaced nodes will be drained, so their pods use up the remaining PDBs.
+	c.autoscalingCtx.RemainingPdbTracker.RemovePods(p.podsToReschedule)
+
+	c.inProgress = &consolidation{
+		nodeGroupId:   p.nodeGroup.Id(),
+		nodes:         replacement.NodeNames(p.nodes),
+		existingNodes: replacement.NodesInGroup(c.autoscalingCtx.CloudProvider, allNodes, p.nodeGroup.Id()),
+		startTime:     currentTime,
+	}
+	klog.V(0).Infof("Consolidation: replacing nodes %v with a new node from %s, estimated savings %.4g", c.inProgress.nodes, p.nodeGroup.Id(), p.savings)
+	c.autoscalingCtx.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaleDownConsolidation",
+		"Consolidation: replacing nodes %v with a new node from %s", c.inProgress.nodes, p.nodeGroup.Id())
+	return status.ScaleDownNoNodeDeleted, nil, nil
+}
+
+// continueConsolidation drains the replaced nodes once the replacement node
+// is ready, or gives up if it doesn't become ready within MaxNodeProvisionTime.
+// The consolidation stays in progress, keeping the replacement node out of
+// scale-down, until the deletion of all replaced nodes started. If it doesn't
+// start within MaxNodeProvisionTime after the replacement became ready, e.g.
+// because of scale-down windows, the consolidation is abandoned.
+func (c *Consolidator) continueConsolidation(allNodes, podDestinations []*apiv1.Node, currentTime time.Time) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	cons := c.inProgress
+	if cons.replacement == "" {
+		cons.replacement = c.findReplacement(allNodes, cons)
+		if cons.replacement == "" {
+			if currentTime.Sub(cons.startTime) > c.maxNodeProvisionTime(cons.nodeGroupId) {
+				klog.Warningf("Consolidation: replacement node in %s didn't become ready in time, giving up on replacing %v", cons.nodeGroupId, cons.nodes)
+				c.inProgress = nil
+			}
+			return status.ScaleDownInProgress, nil, nil
+		}
+		cons.readyTime = currentTime
+	}
+
+	nodesByName := make(map[string]*apiv1.Node, len(allNodes))
+	for _, node := range allNodes {
+		nodesByName[node.Name] = node
+	}
+	var toDrain []*apiv1.Node
+	for _, name := range cons.nodes {
+		if node, found := nodesByName[name]; found && !actuation.IsNodeBeingDeleted(node, currentTime) {
+			toDrain = append(toDrain, node)
+		}
+	}
+	cons.nodes = replacement.NodeNames(toDrain)
+	if len(toDrain) == 0 {
+		c.inProgress = nil
+		return status.ScaleDownNoNodeDeleted, nil, nil
+	}
+
+	// The cluster might have changed since the replacement was requested, make sure the pods still fit.
+	c.autoscalingCtx.ClusterSnapshot.Fork()
+	_, unremovable := c.simulateRemoval(toDrain, replacement.AsMap(replacement.NodeNames(podDestinations)), currentTime)
+	c.autoscalingCtx.ClusterSnapshot.Revert()
+	if unremovable != nil {
+		klog.Warningf("Consolidation: node %s can no longer be removed (reason %v), giving up on replacing %v", unremovable.Node.Name, unremovable.Reason, cons.nodes)
+		c.inProgress = nil
+		return status.ScaleDownNoNodeDeleted, nil, nil
+	}
+	klog.V(0).Infof("Consolidation: replacement node %s in %s is ready, draining %v", cons.replacement, cons.nodeGroupId, cons.nodes)
+	result, scaledDownNodes, err := c.actuator.StartDeletion(nil, toDrain)
+	started := make(map[string]bool)
+	for _, scaledDown := range scaledDownNodes {
+		scaledDown.Reason = status.ScaleDownConsolidated
+		started[scaledDown.Node.Name] = true
+	}
+	var pending []string
+	for _, name := range cons.nodes {
+		if !started[name] {
+			pending = append(pending, name)
+		}
+	}
+	cons.nodes = pending
+	if len(cons.nodes) == 0 {
+		c.inProgress = nil
+	} else if currentTime.Sub(cons.readyTime) > c.maxNodeProvisionTime(cons.nodeGroupId) {
+		klog.Warningf("Consolidation: deletion of %v didn't start in time, giving up on replacing them", cons.nodes)
+		c.inProgress = nil
+	} else {
+		klog.V(1).Infof("Consolidation: deletion of %v didn't start, will retry", cons.nodes)
+	}
+	return result, scaledDownNodes, err
+}
+
+// findReplacement returns a new, ready node from the node group which appeared since the replacement was requested.
+func (c *Consolidator) findReplacement(allNodes []*apiv1.Node, cons *consolidation) string {
+	for _, node := range allNodes {
+		if cons.existingNodes[node.Name] {
+			continue
+		}
+		nodeGroup, err := c.autoscalingCtx.CloudProvider.NodeGroupForNode(node)
+		if err != nil || !replacement.IsValid(nodeGroup) || nodeGroup.Id() != cons.nodeGroupId {
+			continue
+		}
+		if ready, _, _ := kube_util.GetReadinessState(node); ready {
+			return node.Name
+		}
+	}
+	return ""
+}
+
+func (c *Consolidator) maxNodeProvisionTime(nodeGroupId string) time.Duration {
+	for _, nodeGroup := range c.autoscalingCtx.CloudProvider.NodeGroups() {
+		if nodeGroup.Id() != nodeGroupId {
+			continue
+		}
+		if maxNodeProvisionTime, err := c.configGetter.GetMaxNodeProvisionTime(nodeGroup); err == nil {
+			return maxNodeProvisionTime
+		}
+	}
+	return c.autoscalingCtx.NodeGroupDefaults.MaxNodeProvisionTime
+}
+
+// findPlan returns the consolidation with the highest savings, or nil if there is none.
+func (c *Consolidator) findPlan(allNodes, scaleDownCandidates, podDestinations []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo, currentTime time.Time) *plan {
+	candidates := c.candidates(scaleDownCandidates, currentTime)
+	if len(candidates) == 0 {
+		return nil
+	}
+	pricingModel, err := c.autoscalingCtx.CloudProvider.Pricing()
+	if err != nil {
+		if err != cloudprovider.ErrNotImplemented {
+			klog.Warningf("Consolidation: failed to get pricing model, assuming equal node prices: %v", err)
+		}
+		pricingModel = nil
+	}
+
+	var best *plan
+	for _, nodeGroup := range c.autoscalingCtx.CloudProvider.NodeGroups() {
+		template, found := nodeInfosForGroups[nodeGroup.Id()]
+		if !found {
+			continue
+		}
+		if err := c.requester.CanRequest(nodeGroup, allNodes, nodeInfosForGroups, currentTime); err != nil {
+			klog.V(4).Infof("Consolidation: can't add a node to %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		p, err := c.planForNodeGroup(nodeGroup, template, candidates, podDestinations, pricingModel, currentTime)
+		if err != nil {
+			klog.Warningf("Consolidation: failed to simulate replacement with a node from %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		if p != nil && (best == nil || p.savings > best.savings) {
+			best = p
+		}
+	}
+	return best
+}
+
+// planForNodeGroup greedily picks candidates, starting from the least utilized
+// ones, whose pods fit on the existing nodes plus a single new node from the
+// given node group, and returns the plan if it's cheaper than the picked nodes.
+func (c *Consolidator) planForNodeGroup(nodeGroup cloudprovider.NodeGroup, template *framework.NodeInfo, candidates, podDestinations []*apiv1.Node,
+	pricingModel cloudprovider.PricingModel, currentTime time.Time) (*plan, error) {
+	replacementNode, err := simulator.SanitizedNodeInfo(template, replacementNodeSuffix)
+	if err != nil {
+		return nil, err
+	}
+	replacementPodCount := len(replacementNode.Pods())
+
+	c.autoscalingCtx.ClusterSnapshot.Fork()
+	defer c.autoscalingCtx.ClusterSnapshot.Revert()
+	if err := c.autoscalingCtx.ClusterSnapshot.AddNodeInfo(replacementNode); err != nil {
+		return nil, err
+	}
+	destinations := replacement.AsMap(replacement.NodeNames(podDestinations))
+	destinations[replacementNode.Node().Name] = true
+
+	// Picked nodes use up the PDBs for the following ones. The shared tracker is
+	// only updated for the plan which is carried out.
+	pdbTracker := pdb.NewBasicRemainingPdbTracker()
+	if err := pdbTracker.SetPdbs(c.autoscalingCtx.RemainingPdbTracker.GetPdbs()); err != nil {
+		return nil, err
+	}
+	removalsLeft := c.removalsLeft()
+	rs := simulator.NewRemovalSimulator(c.autoscalingCtx.ListerRegistry, c.autoscalingCtx.ClusterSnapshot, c.deleteOptions, c.drainabilityRules, true)
+	var picked []*apiv1.Node
+	var podsToReschedule []*apiv1.Pod
+	for _, node := range candidates {
+		if len(picked) >= c.autoscalingCtx.MaxConsolidationNodes {
+			break
+		}
+		ng, err := c.autoscalingCtx.CloudProvider.NodeGroupForNode(node)
+		if err != nil || !replacement.IsValid(ng) || removalsLeft[ng.Id()] <= 0 {
+			continue
+		}
+		// Pods shouldn't be moved to nodes which will be removed as well.
+		delete(destinations, node.Name)
+		removable, _ := rs.SimulateNodeRemoval(node.Name, destinations, currentTime, pdbTracker)
+		if removable == nil {
+			destinations[node.Name] = true
+			continue
+		}
+		pdbTracker.RemovePods(removable.PodsToReschedule)
+		picked = append(picked, node)
+		podsToReschedule = append(podsToReschedule, removable.PodsToReschedule...)
+		removalsLeft[ng.Id()]--
+	}
+	if len(picked) == 0 {
+		return nil, nil
+	}
+
+	// If no pod was moved to the replacement node, the regular scale-down can remove the nodes.
+	replacementInfo, err := c.autoscalingCtx.ClusterSnapshot.GetNodeInfo(replacementNode.Node().Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(replacementInfo.Pods()) == replacementPodCount {
+		return nil, nil
+	}
+
+	savings, err := c.savings(picked, replacementNode.Node(), pricingModel, currentTime)
+	if err != nil {
+		return nil, err
+	}
+	if savings <= 0 {
+		klog.V(4).Infof("Consolidation: replacing %v with a node from %s doesn't bring savings (%.4g)", replacement.NodeNames(picked), nodeGroup.Id(), savings)
+		return nil, nil
+	}
+	return &plan{nodeGroup: nodeGroup, nodes: picked, podsToReschedule: podsToReschedule, savings: savings}, nil
+}
+
+// simulateRemoval checks if pods from all the given nodes fit on the destination nodes.
+func (c *Consolidator) simulateRemoval(nodes []*apiv1.Node, destinations map[string]bool, currentTime time.Time) ([]*simulator.NodeToBeRemoved, *simulator.UnremovableNode) {
+	for _, node := range nodes {
+		delete(destinations, node.Name)
+	}
+	rs := simulator.NewRemovalSimulator(c.autoscalingCtx.ListerRegistry, c.autoscalingCtx.ClusterSnapshot, c.deleteOptions, c.drainabilityRules, true)
+	var removable []*simulator.NodeToBeRemoved
+	for _, node := range nodes {
+		r, unremovable := rs.SimulateNodeRemoval(node.Name, destinations, currentTime, c.autoscalingCtx.RemainingPdbTracker)
+		if unremovable != nil {
+			return nil, unremovable
+		}
+		removable = append(removable, r)
+	}
+	return removable, nil
+}
+
+// savings returns the difference between the price of the removed nodes and the
+// replacement node. Without pricing model, all nodes are assumed to cost 1.
+func (c *Consolidator) savings(removed []*apiv1.Node, replacement *apiv1.Node, pricingModel cloudprovider.PricingModel, currentTime time.Time) (float64, error) {
+	if pricingModel == nil {
+		return float64(len(removed) - 1), nil
+	}
+	endTime := currentTime.Add(pricingPeriod)
+	replacementPrice, err := pricingModel.NodePrice(replacement, currentTime, endTime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get price of %s: %v", replacement.Name, err)
+	}
+	removedPrice := 0.0
+	for _, node := range removed {
+		price, err := pricingModel.NodePrice(node, currentTime, endTime)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get price of %s: %v", node.Name, err)
+		}
+		removedPrice += price
+	}
+	return removedPrice - replacementPrice, nil
+}
+
+// candidates returns underutilized scale-down candidates which aren't already
+// unneeded, sorted by utilization, starting from the least utilized ones.
+func (c *Consolidator) candidates(scaleDownCandidates []*apiv1.Node, currentTime time.Time) []*apiv1.Node {
+	unneeded := replacement.AsMap(replacement.NodeNames(c.unneededNodes.UnneededNodes()))
+	utilizationMap := make(map[string]float64)
+	var candidates []*apiv1.Node
+	for _, node := range scaleDownCandidates {
+		if unneeded[node.Name] || eligibility.HasNoScaleDownAnnotation(node) || actuation.IsNodeBeingDeleted(node, currentTime) {
+			continue
+		}
+		if ready, _, _ := kube_util.GetReadinessState(node); !ready {
+			continue
+		}
+		nodeGroup, err := c.autoscalingCtx.CloudProvider.NodeGroupForNode(node)
+		if err != nil || !replacement.IsValid(nodeGroup) {
+			continue
+		}
+		nodeInfo, err := c.autoscalingCtx.ClusterSnapshot.GetNodeInfo(node.Name)
+		if err != nil {
+			klog.Errorf("Consolidation: can't retrieve node %s from snapshot: %v", node.Name, err)
+			continue
+		}
+		ignoreDaemonSetsUtilization, err := c.configGetter.GetIgnoreDaemonSetsUtilization(nodeGroup)
+		if err != nil {
+			klog.Warningf("Couldn't retrieve `IgnoreDaemonSetsUtilization` option for node %v: %v", node.Name, err)
+			continue
+		}
+		gpuConfig := c.autoscalingCtx.CloudProvider.GetNodeGpuConfig(node)
+		utilInfo, err := utilization.Calculate(nodeInfo, ignoreDaemonSetsUtilization, c.autoscalingCtx.IgnoreMirrorPodsUtilization, c.autoscalingCtx.DynamicResourceAllocationEnabled, gpuConfig, currentTime)
+		if err != nil {
+			klog.Warningf("Failed to calculate utilization for %s: %v", node.Name, err)
+			continue
+		}
+		if utilInfo.Utilization >= c.autoscalingCtx.ConsolidationUtilizationThreshold {
+			continue
+		}
+		utilizationMap[node.Name] = utilInfo.Utilization
+		candidates = append(candidates, node)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return utilizationMap[candidates[i].Name] < utilizationMap[candidates[j].Name]
+	})
+	return candidates
+}
+
+// removalsLeft returns the number of nodes which can be removed from each node group without going below its min size.
+func (c *Consolidator) removalsLeft() map[string]int {
+	removalsLeft := make(map[string]int)
+	for _, nodeGroup := range c.autoscalingCtx.CloudProvider.NodeGroups() {
+		targetSize, err := nodeGroup.TargetSize()
+		if err != nil {
+			continue
+		}
+		removalsLeft[nodeGroup.Id()] = targetSize - c.autoscalingCtx.ScheduledMinCapacity.MinSize(nodeGroup)
+	}
+	return removalsLeft
+}
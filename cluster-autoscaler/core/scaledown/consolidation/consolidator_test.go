@@ -0,0 +1,361 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consolidation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/replacement"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	processorstest "k8s.io/autoscaler/cluster-autoscaler/processors/test"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const instanceTypeLabel = "node.kubernetes.io/instance-type"
+
+type testPricingModel struct {
+	prices map[string]float64
+}
+
+func (tpm *testPricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	if price, found := tpm.prices[node.Labels[instanceTypeLabel]]; found {
+		return price, nil
+	}
+	return 0, fmt.Errorf("price for node %v not found", node.Name)
+}
+
+func (tpm *testPricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	return 0, nil
+}
+
+type fakeUnneededNodes struct {
+	nodes []*apiv1.Node
+}
+
+func (f *fakeUnneededNodes) UnneededNodes() []*apiv1.Node {
+	return f.nodes
+}
+
+type fakeActuator struct {
+	drained []string
+	// dropped nodes are skipped by the actuator, e.g. because of scale-down windows.
+	dropped map[string]bool
+}
+
+func (f *fakeActuator) StartDeletion(empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	var scaledDown []*status.ScaleDownNode
+	for _, node := range append(empty, needDrain...) {
+		if f.dropped[node.Name] {
+			continue
+		}
+		f.drained = append(f.drained, node.Name)
+		scaledDown = append(scaledDown, &status.ScaleDownNode{Node: node})
+	}
+	if len(scaledDown) == 0 {
+		return status.ScaleDownNoNodeDeleted, nil, nil
+	}
+	return status.ScaleDownNodeDeleteStarted, scaledDown, nil
+}
+
+type fakeScaleUpSafety struct {
+	backedOff map[string]bool
+}
+
+func (f *fakeScaleUpSafety) NodeGroupScaleUpSafety(nodeGroup cloudprovider.NodeGroup, now time.Time) clusterstate.NodeGroupScalingSafety {
+	return clusterstate.NodeGroupScalingSafety{SafeToScale: !f.backedOff[nodeGroup.Id()], Healthy: true}
+}
+
+func buildNode(name, instanceType string, millicpu int64, now time.Time) *apiv1.Node {
+	node := BuildTestNode(name, millicpu, 8*1024*1024*1024)
+	node.Labels[instanceTypeLabel] = instanceType
+	SetNodeReadyState(node, true, now.Add(-time.Hour))
+	return node
+}
+
+func buildPod(name string, millicpu int64, nodeName string) *apiv1.Pod {
+	pod := BuildScheduledTestPod(name, millicpu, 1024*1024, nodeName)
+	return SetRSPodSpec(pod, "rs")
+}
+
+type consolidatorTest struct {
+	consolidator *Consolidator
+	actuator     *fakeActuator
+	safety       *fakeScaleUpSafety
+	provider     *testprovider.TestCloudProvider
+	scaledUp     map[string]int
+	snapshot     clustersnapshot.ClusterSnapshot
+	nodes        []*apiv1.Node
+	pods         []*apiv1.Pod
+	templates    map[string]*framework.NodeInfo
+}
+
+func newConsolidatorTest(t *testing.T, prices map[string]float64, now time.Time) *consolidatorTest {
+	ct := &consolidatorTest{
+		actuator: &fakeActuator{},
+		safety:   &fakeScaleUpSafety{},
+		scaledUp: make(map[string]int),
+		nodes: []*apiv1.Node{
+			buildNode("large-1", "large", 4000, now),
+			buildNode("large-2", "large", 4000, now),
+		},
+		pods: []*apiv1.Pod{
+			buildPod("p1", 2500, "large-1"),
+			buildPod("p2", 2500, "large-2"),
+		},
+	}
+	ct.templates = map[string]*framework.NodeInfo{
+		"large":  framework.NewTestNodeInfo(buildNode("template-large", "large", 4000, now)),
+		"medium": framework.NewTestNodeInfo(buildNode("template-medium", "medium", 6000, now)),
+	}
+	ct.provider = testprovider.NewTestCloudProviderBuilder().WithOnScaleUp(func(id string, delta int) error {
+		ct.scaledUp[id] += delta
+		return nil
+	}).Build()
+	ct.provider.AddNodeGroup("large", 0, 10, 2)
+	ct.provider.AddNodeGroup("medium", 0, 10, 0)
+	for _, node := range ct.nodes {
+		ct.provider.AddNode("large", node)
+	}
+	if prices != nil {
+		ct.provider.SetPricingModel(&testPricingModel{prices: prices})
+	}
+
+	replicas := int32(2)
+	rsLister, err := kube_util.NewTestReplicaSetLister([]*appsv1.ReplicaSet{{
+		ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "default"},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: &replicas},
+	}})
+	assert.NoError(t, err)
+	registry := kube_util.NewListerRegistry(nil, nil, nil, nil, nil, nil, nil, rsLister, nil)
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+			MaxNodeProvisionTime: 15 * time.Minute,
+		},
+		ConsolidationUtilizationThreshold: 0.7,
+		MaxConsolidationNodes:             5,
+	}, &fake.Clientset{}, registry, ct.provider, nil, nil)
+	assert.NoError(t, err)
+	ct.snapshot = autoscalingCtx.ClusterSnapshot
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, ct.snapshot, ct.nodes, ct.pods)
+
+	processors := processorstest.NewTestProcessors(&autoscalingCtx)
+	ct.consolidator = NewConsolidator(&autoscalingCtx, &fakeUnneededNodes{}, ct.actuator,
+		replacement.NewRequester(&autoscalingCtx, ct.safety, processors.ScaleStateNotifier, processors.CustomResourcesProcessor), processors.NodeGroupConfigProcessor, options.NodeDeleteOptions{}, nil)
+	return ct
+}
+
+func (ct *consolidatorTest) runOnce(t *testing.T, now time.Time) status.ScaleDownResult {
+	result, scaledDownNodes, err := ct.consolidator.RunOnce(ct.nodes, ct.nodes, ct.nodes, ct.templates, now)
+	assert.NoError(t, err)
+	for _, scaledDown := range scaledDownNodes {
+		assert.Equal(t, status.ScaleDownConsolidated, scaledDown.Reason)
+	}
+	return result
+}
+
+func (ct *consolidatorTest) addNode(t *testing.T, nodeGroup string, node *apiv1.Node) {
+	ct.provider.AddNode(nodeGroup, node)
+	ct.nodes = append(ct.nodes, node)
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, ct.snapshot, ct.nodes, ct.pods)
+}
+
+func TestConsolidation(t *testing.T) {
+	now := time.Now()
+	ct := newConsolidatorTest(t, map[string]float64{"large": 10, "medium": 8}, now)
+
+	// Pods don't fit on the other existing node, but both fit on a single medium node.
+	assert.Equal(t, status.ScaleDownNoNodeDeleted, ct.runOnce(t, now))
+	assert.Equal(t, map[string]int{"medium": 1}, ct.scaledUp)
+	assert.True(t, ct.consolidator.InProgress())
+
+	// The replacement node isn't there yet.
+	now = now.Add(time.Minute)
+	assert.Equal(t, status.ScaleDownInProgress, ct.runOnce(t, now))
+	assert.Empty(t, ct.actuator.drained)
+
+	// The replacement node is not ready yet.
+	replacement := buildNode("medium-1", "medium", 6000, now)
+	SetNodeReadyState(replacement, false, now)
+	ct.addNode(t, "medium", replacement)
+	assert.Equal(t, status.ScaleDownInProgress, ct.runOnce(t, now))
+	assert.Empty(t, ct.actuator.drained)
+
+	replacement.Spec.Taints = nil
+	SetNodeReadyState(replacement, true, now)
+	assert.Equal(t, status.ScaleDownNodeDeleteStarted, ct.runOnce(t, now))
+	assert.ElementsMatch(t, []string{"large-1", "large-2"}, ct.actuator.drained)
+	assert.False(t, ct.consolidator.InProgress())
+	assert.Equal(t, map[string]int{"medium": 1}, ct.scaledUp)
+}
+
+func TestConsolidationDeletionNotStarted(t *testing.T) {
+	now := time.Now()
+	ct := newConsolidatorTest(t, map[string]float64{"large": 10, "medium": 8}, now)
+	ct.runOnce(t, now)
+	replacementNode := buildNode("medium-1", "medium", 6000, now)
+	ct.addNode(t, "medium", replacementNode)
+
+	// The replacement node stays out of scale-down until the replaced nodes are being deleted.
+	assert.Equal(t, []string{"large-1", "large-2"}, replacement.NodeNames(ct.consolidator.FilterOutReplacements(ct.nodes)))
+
+	ct.actuator.dropped = map[string]bool{"large-2": true}
+	now = now.Add(time.Minute)
+	assert.Equal(t, status.ScaleDownNodeDeleteStarted, ct.runOnce(t, now))
+	assert.Equal(t, []string{"large-1"}, ct.actuator.drained)
+	assert.True(t, ct.consolidator.InProgress())
+	assert.Equal(t, []string{"large-1", "large-2"}, replacement.NodeNames(ct.consolidator.FilterOutReplacements(ct.nodes)))
+
+	// The deletion is retried for the remaining node.
+	ct.actuator.dropped = nil
+	now = now.Add(time.Minute)
+	assert.Equal(t, status.ScaleDownNodeDeleteStarted, ct.runOnce(t, now))
+	assert.Equal(t, []string{"large-1", "large-2"}, ct.actuator.drained)
+	assert.False(t, ct.consolidator.InProgress())
+	assert.Len(t, ct.consolidator.FilterOutReplacements(ct.nodes), 3)
+}
+
+func TestConsolidationDeletionNotStartedTimeout(t *testing.T) {
+	now := time.Now()
+	ct := newConsolidatorTest(t, map[string]float64{"large": 10, "medium": 8}, now)
+	ct.runOnce(t, now)
+	ct.addNode(t, "medium", buildNode("medium-1", "medium", 6000, now))
+
+	ct.actuator.dropped = map[string]bool{"large-1": true, "large-2": true}
+	assert.Equal(t, status.ScaleDownNoNodeDeleted, ct.runOnce(t, now))
+	assert.True(t, ct.consolidator.InProgress())
+	ct.runOnce(t, now.Add(16*time.Minute))
+	assert.False(t, ct.consolidator.InProgress())
+	assert.Empty(t, ct.actuator.drained)
+}
+
+func TestConsolidationReplacementTimeout(t *testing.T) {
+	now := time.Now()
+	ct := newConsolidatorTest(t, map[string]float64{"large": 10, "medium": 8}, now)
+
+	ct.runOnce(t, now)
+	assert.True(t, ct.consolidator.InProgress())
+	ct.runOnce(t, now.Add(16*time.Minute))
+	assert.False(t, ct.consolidator.InProgress())
+	assert.Empty(t, ct.actuator.drained)
+}
+
+func TestConsolidationPlanning(t *testing.T) {
+	testCases := []struct {
+		name          string
+		prices        map[string]float64
+		threshold     float64
+		unneeded      []string
+		backedOff     map[string]bool
+		maxNodesTotal int
+		maxCores      int64
+		wantScaledUp  map[string]int
+	}{
+		{
+			name:         "cheaper replacement",
+			prices:       map[string]float64{"large": 10, "medium": 8},
+			wantScaledUp: map[string]int{"medium": 1},
+		},
+		{
+			name:         "replacement of a single node",
+			prices:       map[string]float64{"large": 10, "medium": 15},
+			wantScaledUp: map[string]int{"medium": 1},
+		},
+		{
+			name:         "replacement more expensive",
+			prices:       map[string]float64{"large": 10, "medium": 25},
+			wantScaledUp: map[string]int{},
+		},
+		{
+			name:         "no pricing, fewer nodes",
+			wantScaledUp: map[string]int{"medium": 1},
+		},
+		{
+			name:         "nodes above utilization threshold",
+			prices:       map[string]float64{"large": 10, "medium": 8},
+			threshold:    0.5,
+			wantScaledUp: map[string]int{},
+		},
+		{
+			name:         "replacement node group backed off",
+			prices:       map[string]float64{"large": 10, "medium": 8},
+			backedOff:    map[string]bool{"medium": true},
+			wantScaledUp: map[string]int{},
+		},
+		{
+			name:          "max nodes total reached",
+			prices:        map[string]float64{"large": 10, "medium": 8},
+			maxNodesTotal: 2,
+			wantScaledUp:  map[string]int{},
+		},
+		{
+			name:         "max cores reached",
+			prices:       map[string]float64{"large": 10, "medium": 8},
+			maxCores:     10,
+			wantScaledUp: map[string]int{},
+		},
+		{
+			name:         "unneeded nodes are left to scale-down",
+			prices:       map[string]float64{"large": 10, "medium": 15},
+			unneeded:     []string{"large-1"},
+			wantScaledUp: map[string]int{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+			ct := newConsolidatorTest(t, tc.prices, now)
+			if tc.threshold != 0 {
+				ct.consolidator.autoscalingCtx.ConsolidationUtilizationThreshold = tc.threshold
+			}
+			ct.consolidator.autoscalingCtx.MaxNodesTotal = tc.maxNodesTotal
+			if tc.maxCores != 0 {
+				ct.provider.SetResourceLimiter(cloudprovider.NewResourceLimiter(nil, map[string]int64{cloudprovider.ResourceNameCores: tc.maxCores}))
+			}
+			ct.safety.backedOff = tc.backedOff
+			unneeded := &fakeUnneededNodes{}
+			for _, node := range ct.nodes {
+				for _, name := range tc.unneeded {
+					if node.Name == name {
+						unneeded.nodes = append(unneeded.nodes, node)
+					}
+				}
+			}
+			ct.consolidator.unneededNodes = unneeded
+
+			ct.runOnce(t, now)
+			assert.Equal(t, tc.wantScaledUp, ct.scaledUp)
+			assert.Equal(t, len(tc.wantScaledUp) > 0, ct.consolidator.InProgress())
+		})
+	}
+}
@@ -51,6 +51,8 @@ type nodeGroupConfigGetter interface {
 	GetScaleDownGpuUtilizationThreshold(nodeGroup cloudprovider.NodeGroup) (float64, error)
 	// GetIgnoreDaemonSetsUtilization returns IgnoreDaemonSetsUtilization value that should be used for a given NodeGroup.
 	GetIgnoreDaemonSetsUtilization(nodeGroup cloudprovider.NodeGroup) (bool, error)
+	// GetMaxNodeLifetime returns MaxNodeLifetime value that should be used for a given NodeGroup.
+	GetMaxNodeLifetime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
 }
 
 // NewChecker creates a new Checker object.
@@ -154,6 +156,16 @@ func (c *Checker) unremovableReasonAndNodeUtilization(autoscalingCtx *ca_context
 		}
 	}
 
+	maxNodeLifetime, err := c.configGetter.GetMaxNodeLifetime(nodeGroup)
+	if err != nil {
+		klog.Warningf("Couldn't retrieve `MaxNodeLifetime` option for node %v: %v", node.Name, err)
+		return simulator.UnexpectedError, nil
+	}
+	if IsNodeExpired(node, maxNodeLifetime, timestamp) {
+		klog.V(4).Infof("Node %s is older than max node lifetime %v, considering it for scale down regardless of utilization", node.Name, maxNodeLifetime)
+		return simulator.NoReason, &utilInfo
+	}
+
 	underutilized, err := c.isNodeBelowUtilizationThreshold(autoscalingCtx, node, nodeGroup, utilInfo)
 	if err != nil {
 		klog.Warningf("Failed to check utilization thresholds for %s: %v", node.Name, err)
@@ -191,6 +203,14 @@ func (c *Checker) isNodeBelowUtilizationThreshold(autoscalingCtx *ca_context.Aut
 	return true, nil
 }
 
+// IsNodeExpired checks whether the node is older than maxNodeLifetime. A zero maxNodeLifetime means no limit.
+func IsNodeExpired(node *apiv1.Node, maxNodeLifetime time.Duration, timestamp time.Time) bool {
+	if maxNodeLifetime <= 0 || node.CreationTimestamp.IsZero() {
+		return false
+	}
+	return !node.CreationTimestamp.Add(maxNodeLifetime).After(timestamp)
+}
+
 // HasNoScaleDownAnnotation checks whether the node has an annotation blocking it from being scaled down.
 func HasNoScaleDownAnnotation(node *apiv1.Node) bool {
 	return node.Annotations[ScaleDownDisabledKey] == "true"
@@ -48,6 +48,7 @@ type testCase struct {
 	wantUnremovable             []*simulator.UnremovableNode
 	scaleDownUnready            bool
 	ignoreDaemonSetsUtilization bool
+	maxNodeLifetime             time.Duration
 }
 
 func getTestCases(ignoreDaemonSetsUtilization bool, suffix string, now time.Time) []testCase {
@@ -62,12 +63,19 @@ func getTestCases(ignoreDaemonSetsUtilization bool, suffix string, now time.Time
 	noScaleDownNode.Annotations = map[string]string{ScaleDownDisabledKey: "true"}
 	SetNodeReadyState(noScaleDownNode, true, time.Time{})
 
+	oldNode := BuildTestNode("old", 1000, 10)
+	oldNode.CreationTimestamp = metav1.NewTime(now.Add(-48 * time.Hour))
+	SetNodeReadyState(oldNode, true, time.Time{})
+
 	unreadyNode := BuildTestNode("unready", 1000, 10)
 	SetNodeReadyState(unreadyNode, false, time.Time{})
 
 	bigPod := BuildTestPod("bigPod", 600, 0)
 	bigPod.Spec.NodeName = "regular"
 
+	bigPodOnOldNode := BuildTestPod("bigPodOnOldNode", 600, 0)
+	bigPodOnOldNode.Spec.NodeName = "old"
+
 	smallPod := BuildTestPod("smallPod", 100, 0)
 	smallPod.Spec.NodeName = "regular"
 
@@ -118,6 +126,24 @@ func getTestCases(ignoreDaemonSetsUtilization bool, suffix string, now time.Time
 			wantUnremovable:  []*simulator.UnremovableNode{{Node: regularNode, Reason: simulator.NotUnderutilized}},
 			scaleDownUnready: true,
 		},
+		{
+			desc:             "highly utilized node older than max node lifetime stays",
+			nodes:            []*apiv1.Node{oldNode},
+			pods:             []*apiv1.Pod{bigPodOnOldNode},
+			wantUnneeded:     []string{"old"},
+			wantUnremovable:  []*simulator.UnremovableNode{},
+			scaleDownUnready: true,
+			maxNodeLifetime:  24 * time.Hour,
+		},
+		{
+			desc:             "highly utilized node younger than max node lifetime is filtered out",
+			nodes:            []*apiv1.Node{oldNode},
+			pods:             []*apiv1.Pod{bigPodOnOldNode},
+			wantUnneeded:     []string{},
+			wantUnremovable:  []*simulator.UnremovableNode{{Node: oldNode, Reason: simulator.NotUnderutilized}},
+			scaleDownUnready: true,
+			maxNodeLifetime:  72 * time.Hour,
+		},
 		{
 			desc:             "underutilized node stays",
 			nodes:            []*apiv1.Node{regularNode},
@@ -220,6 +246,7 @@ func TestFilterOutUnremovable(t *testing.T) {
 					ScaleDownUnneededTime:            config.DefaultScaleDownUnneededTime,
 					ScaleDownUnreadyTime:             config.DefaultScaleDownUnreadyTime,
 					IgnoreDaemonSetsUtilization:      tc.ignoreDaemonSetsUtilization,
+					MaxNodeLifetime:                  tc.maxNodeLifetime,
 				},
 			}
 			s := nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults)
@@ -0,0 +1,376 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explanation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	klog "k8s.io/klog/v2"
+)
+
+// NodeAnnotation is set on nodes to a short summary of their explanation if
+// annotations are enabled.
+const NodeAnnotation = "cluster-autoscaler.kubernetes.io/scale-down-explanation"
+
+const (
+	// annotationWriteQPS and annotationWriteBurst limit the rate of node
+	// patches sent in the background to update NodeAnnotation.
+	annotationWriteQPS   = 5
+	annotationWriteBurst = 10
+)
+
+// NodeExplanation describes why a node is or isn't being scaled down.
+type NodeExplanation struct {
+	Node      string `json:"node"`
+	NodeGroup string `json:"nodeGroup,omitempty"`
+	// Unneeded is set if the node was found to be unneeded.
+	Unneeded bool `json:"unneeded"`
+	// Removable is set if the node is unneeded and nothing prevents its removal.
+	Removable bool `json:"removable"`
+	// Reason is the reason why the node can't be removed. Nodes that weren't
+	// considered for scale-down in the last loop have neither Removable nor Reason set.
+	Reason      string       `json:"reason,omitempty"`
+	BlockingPod *BlockingPod `json:"blockingPod,omitempty"`
+	// UnneededSince is the time since which the node is unneeded.
+	UnneededSince *metav1.Time `json:"unneededSince,omitempty"`
+	// UnneededFor is compared against UnneededThreshold, which is the
+	// scale-down-unneeded-time or scale-down-unready-time of the node group.
+	UnneededFor       *metav1.Duration `json:"unneededFor,omitempty"`
+	UnneededThreshold *metav1.Duration `json:"unneededThreshold,omitempty"`
+	Utilization       *Utilization     `json:"utilization,omitempty"`
+	NodeGroupLimits   *NodeGroupLimits `json:"nodeGroupLimits,omitempty"`
+}
+
+// BlockingPod is a pod which can't be moved off the node.
+type BlockingPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	// PodDisruptionBudgets are the names of PDBs matching the pod.
+	PodDisruptionBudgets []string `json:"podDisruptionBudgets,omitempty"`
+	// BlockedUntil is the time after which the pod stops blocking the node's removal, if known.
+	BlockedUntil *metav1.Time `json:"blockedUntil,omitempty"`
+}
+
+// Utilization of the node compared against the scale-down utilization threshold.
+type Utilization struct {
+	CPU       float64 `json:"cpu"`
+	Memory    float64 `json:"memory"`
+	GPU       float64 `json:"gpu,omitempty"`
+	Resource  string  `json:"resource"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+}
+
+// NodeGroupLimits are the size limits of the node group of the node.
+type NodeGroupLimits struct {
+	MinSize int `json:"minSize"`
+	// EffectiveMinSize includes the scheduled minimum capacity.
+	EffectiveMinSize int `json:"effectiveMinSize"`
+	MaxSize          int `json:"maxSize"`
+	TargetSize       int `json:"targetSize"`
+}
+
+// Summary returns a short, human readable form of the explanation. It
+// doesn't change between loops unless the state of the node does.
+func (e *NodeExplanation) Summary() string {
+	since := ""
+	if e.UnneededSince != nil {
+		since = e.UnneededSince.UTC().Format(time.RFC3339)
+	}
+	switch {
+	case e.Removable:
+		return fmt.Sprintf("Removable, unneeded since %s", since)
+	case e.Unneeded:
+		return fmt.Sprintf("Unneeded since %s, not removable: %s", since, e.Reason)
+	case e.Reason == "":
+		return "Not evaluated"
+	case e.BlockingPod != nil && e.BlockingPod.BlockedUntil != nil:
+		return fmt.Sprintf("Unremovable until %s: %s (pod %s/%s: %s)", e.BlockingPod.BlockedUntil.UTC().Format(time.RFC3339), e.Reason, e.BlockingPod.Namespace, e.BlockingPod.Name, e.BlockingPod.Reason)
+	case e.BlockingPod != nil:
+		return fmt.Sprintf("Unremovable: %s (pod %s/%s: %s)", e.Reason, e.BlockingPod.Namespace, e.BlockingPod.Name, e.BlockingPod.Reason)
+	default:
+		return fmt.Sprintf("Unremovable: %s", e.Reason)
+	}
+}
+
+type nodeGroupConfigGetter interface {
+	// GetScaleDownUnneededTime returns ScaleDownUnneededTime value that should be used for a given NodeGroup.
+	GetScaleDownUnneededTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
+	// GetScaleDownUnreadyTime returns ScaleDownUnreadyTime value that should be used for a given NodeGroup.
+	GetScaleDownUnreadyTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
+	// GetScaleDownUtilizationThreshold returns ScaleDownUtilizationThreshold value that should be used for a given NodeGroup.
+	GetScaleDownUtilizationThreshold(nodeGroup cloudprovider.NodeGroup) (float64, error)
+	// GetScaleDownGpuUtilizationThreshold returns ScaleDownGpuUtilizationThreshold value that should be used for a given NodeGroup.
+	GetScaleDownGpuUtilizationThreshold(nodeGroup cloudprovider.NodeGroup) (float64, error)
+}
+
+// Explainer keeps explanations from the last scale-down loop and serves them
+// over HTTP. A nil Explainer is valid and does nothing.
+type Explainer struct {
+	annotateNodes bool
+	writer        *kube_util.AsyncWriter
+	mutex         sync.RWMutex
+	explanations  map[string]*NodeExplanation
+	lastUpdate    time.Time
+}
+
+// NewExplainer returns a new Explainer. If annotateNodes is set, summaries of
+// explanations are also written to the NodeAnnotation of nodes, otherwise the
+// annotation is removed from nodes which still have it. The nodes are patched
+// in the background, at a limited rate.
+func NewExplainer(annotateNodes bool) *Explainer {
+	writer := kube_util.NewAsyncWriter(annotationWriteQPS, annotationWriteBurst)
+	go writer.Run(wait.NeverStop)
+	return &Explainer{
+		annotateNodes: annotateNodes,
+		writer:        writer,
+		explanations:  make(map[string]*NodeExplanation),
+	}
+}
+
+// Update replaces the stored explanations with ones built from the current
+// state of the scale-down planner.
+func (e *Explainer) Update(autoscalingCtx *ca_context.AutoscalingContext, planner scaledown.Planner, configGetter nodeGroupConfigGetter, nodes []*apiv1.Node, now time.Time) {
+	if e == nil {
+		return
+	}
+	unneeded := make(map[string]bool)
+	for _, node := range planner.UnneededNodes() {
+		unneeded[node.Name] = true
+	}
+	unremovable := make(map[string]*simulator.UnremovableNode)
+	for _, u := range planner.UnremovableNodes() {
+		unremovable[u.Node.Name] = u
+	}
+	utilizationMap := planner.NodeUtilizationMap()
+
+	explanations := make(map[string]*NodeExplanation, len(nodes))
+	for _, node := range nodes {
+		explanation := &NodeExplanation{Node: node.Name, Unneeded: unneeded[node.Name]}
+		if u, found := unremovable[node.Name]; found {
+			explanation.Reason = u.Reason.Name()
+			if u.BlockingPod != nil && u.BlockingPod.Pod != nil {
+				explanation.BlockingPod = blockingPod(autoscalingCtx, u)
+			}
+		}
+		explanation.Removable = explanation.Unneeded && explanation.Reason == ""
+
+		nodeGroup, err := autoscalingCtx.CloudProvider.NodeGroupForNode(node)
+		if err != nil {
+			klog.Warningf("Failed to get node group for %s: %v", node.Name, err)
+			nodeGroup = nil
+		}
+		if nodeGroup != nil && !reflect.ValueOf(nodeGroup).IsNil() {
+			explanation.NodeGroup = nodeGroup.Id()
+			explanation.NodeGroupLimits = nodeGroupLimits(autoscalingCtx, nodeGroup)
+		} else {
+			nodeGroup = nil
+		}
+		if since, found := planner.UnneededSince(node.Name); found {
+			explanation.UnneededSince = &metav1.Time{Time: since}
+			explanation.UnneededFor = &metav1.Duration{Duration: now.Sub(since)}
+			if nodeGroup != nil {
+				explanation.UnneededThreshold = unneededThreshold(node, nodeGroup, configGetter)
+			}
+		}
+		if info, found := utilizationMap[node.Name]; found {
+			explanation.Utilization = &Utilization{
+				CPU:      info.CpuUtil,
+				Memory:   info.MemUtil,
+				GPU:      info.GpuUtil,
+				Resource: string(info.ResourceName),
+				Value:    info.Utilization,
+			}
+			if nodeGroup != nil {
+				explanation.Utilization.Threshold = utilizationThreshold(autoscalingCtx, node, nodeGroup, configGetter)
+			}
+		}
+		explanations[node.Name] = explanation
+	}
+
+	if e.annotateNodes {
+		e.annotate(autoscalingCtx, nodes, explanations)
+	} else {
+		e.clearAnnotations(autoscalingCtx, nodes)
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.explanations = explanations
+	e.lastUpdate = now
+}
+
+// Explanation returns the explanation for a given node.
+func (e *Explainer) Explanation(nodeName string) (*NodeExplanation, bool) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	explanation, found := e.explanations[nodeName]
+	return explanation, found
+}
+
+// Explanations returns explanations for all nodes, sorted by node name.
+func (e *Explainer) Explanations() []*NodeExplanation {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	result := make([]*NodeExplanation, 0, len(e.explanations))
+	for _, explanation := range e.explanations {
+		result = append(result, explanation)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Node < result[j].Node })
+	return result
+}
+
+type response struct {
+	LastUpdateTime metav1.Time        `json:"lastUpdateTime"`
+	Nodes          []*NodeExplanation `json:"nodes"`
+}
+
+// ServeHTTP writes explanations as JSON. The node query parameter limits the
+// response to a single node.
+func (e *Explainer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var body interface{}
+	if nodeName := req.URL.Query().Get("node"); nodeName != "" {
+		explanation, found := e.Explanation(nodeName)
+		if !found {
+			http.Error(w, fmt.Sprintf("node %s not found", nodeName), http.StatusNotFound)
+			return
+		}
+		body = explanation
+	} else {
+		e.mutex.RLock()
+		lastUpdate := e.lastUpdate
+		e.mutex.RUnlock()
+		body = response{LastUpdateTime: metav1.Time{Time: lastUpdate}, Nodes: e.Explanations()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		klog.Errorf("Failed to write scale-down explanations: %v", err)
+	}
+}
+
+func blockingPod(autoscalingCtx *ca_context.AutoscalingContext, u *simulator.UnremovableNode) *BlockingPod {
+	pod := u.BlockingPod.Pod
+	result := &BlockingPod{Namespace: pod.Namespace, Name: pod.Name, Reason: u.BlockingPod.Reason.String()}
+	if !u.BlockingPod.BlockedUntil.IsZero() {
+		result.BlockedUntil = &metav1.Time{Time: u.BlockingPod.BlockedUntil}
+	}
+	if autoscalingCtx.RemainingPdbTracker != nil {
+		for _, pdb := range autoscalingCtx.RemainingPdbTracker.MatchingPdbs(pod) {
+			result.PodDisruptionBudgets = append(result.PodDisruptionBudgets, pdb.Name)
+		}
+	}
+	return result
+}
+
+func nodeGroupLimits(autoscalingCtx *ca_context.AutoscalingContext, nodeGroup cloudprovider.NodeGroup) *NodeGroupLimits {
+	limits := &NodeGroupLimits{
+		MinSize:          nodeGroup.MinSize(),
+		EffectiveMinSize: autoscalingCtx.ScheduledMinCapacity.MinSize(nodeGroup),
+		MaxSize:          nodeGroup.MaxSize(),
+	}
+	if size, err := nodeGroup.TargetSize(); err == nil {
+		limits.TargetSize = size
+	}
+	return limits
+}
+
+func unneededThreshold(node *apiv1.Node, nodeGroup cloudprovider.NodeGroup, configGetter nodeGroupConfigGetter) *metav1.Duration {
+	var threshold time.Duration
+	var err error
+	if ready, _, _ := kube_util.GetReadinessState(node); ready {
+		threshold, err = configGetter.GetScaleDownUnneededTime(nodeGroup)
+	} else {
+		threshold, err = configGetter.GetScaleDownUnreadyTime(nodeGroup)
+	}
+	if err != nil {
+		klog.Warningf("Failed to get unneeded time threshold for %s: %v", node.Name, err)
+		return nil
+	}
+	return &metav1.Duration{Duration: threshold}
+}
+
+func utilizationThreshold(autoscalingCtx *ca_context.AutoscalingContext, node *apiv1.Node, nodeGroup cloudprovider.NodeGroup, configGetter nodeGroupConfigGetter) float64 {
+	var threshold float64
+	var err error
+	if autoscalingCtx.CloudProvider.GetNodeGpuConfig(node) != nil {
+		threshold, err = configGetter.GetScaleDownGpuUtilizationThreshold(nodeGroup)
+	} else {
+		threshold, err = configGetter.GetScaleDownUtilizationThreshold(nodeGroup)
+	}
+	if err != nil {
+		klog.Warningf("Failed to get utilization threshold for %s: %v", node.Name, err)
+	}
+	return threshold
+}
+
+// annotate schedules a patch of the NodeAnnotation of nodes whose summary changed.
+func (e *Explainer) annotate(autoscalingCtx *ca_context.AutoscalingContext, nodes []*apiv1.Node, explanations map[string]*NodeExplanation) {
+	for _, node := range nodes {
+		summary := explanations[node.Name].Summary()
+		if node.Annotations[NodeAnnotation] == summary {
+			continue
+		}
+		e.patchAnnotation(autoscalingCtx, node.Name, &summary)
+	}
+}
+
+// clearAnnotations schedules the removal of NodeAnnotation from nodes which
+// were annotated while annotations were enabled.
+func (e *Explainer) clearAnnotations(autoscalingCtx *ca_context.AutoscalingContext, nodes []*apiv1.Node) {
+	for _, node := range nodes {
+		if _, found := node.Annotations[NodeAnnotation]; found {
+			e.patchAnnotation(autoscalingCtx, node.Name, nil)
+		}
+	}
+}
+
+// patchAnnotation schedules a merge patch setting NodeAnnotation of the node
+// to value, or removing it if value is nil.
+func (e *Explainer) patchAnnotation(autoscalingCtx *ca_context.AutoscalingContext, nodeName string, value *string) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{NodeAnnotation: value},
+		},
+	})
+	if err != nil {
+		klog.Errorf("Failed to build scale-down explanation patch for %s: %v", nodeName, err)
+		return
+	}
+	client := autoscalingCtx.ClientSet
+	e.writer.Write(nodeName, func() {
+		if _, err := client.CoreV1().Nodes().Patch(context.TODO(), nodeName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			klog.Warningf("Failed to update scale-down explanation annotation of node %s: %v", nodeName, err)
+		}
+	})
+}
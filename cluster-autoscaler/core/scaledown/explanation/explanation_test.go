@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explanation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+type fakePlanner struct {
+	unneeded    map[string]time.Time
+	unremovable []*simulator.UnremovableNode
+	utilization map[string]utilization.Info
+}
+
+func (p *fakePlanner) UpdateClusterState(_, _ []*apiv1.Node, _ scaledown.ActuationStatus, _ time.Time) errors.AutoscalerError {
+	return nil
+}
+
+func (p *fakePlanner) CleanUpUnneededNodes() {}
+
+func (p *fakePlanner) NodesToDelete(time.Time) (empty, needDrain []*apiv1.Node) {
+	return nil, nil
+}
+
+func (p *fakePlanner) UnneededNodes() []*apiv1.Node {
+	var nodes []*apiv1.Node
+	for name := range p.unneeded {
+		nodes = append(nodes, BuildTestNode(name, 1000, 1000))
+	}
+	return nodes
+}
+
+func (p *fakePlanner) UnremovableNodes() []*simulator.UnremovableNode {
+	return p.unremovable
+}
+
+func (p *fakePlanner) NodeUtilizationMap() map[string]utilization.Info {
+	return p.utilization
+}
+
+func (p *fakePlanner) UnneededSince(nodeName string) (time.Time, bool) {
+	since, found := p.unneeded[nodeName]
+	return since, found
+}
+
+func (p *fakePlanner) RemovalReason(string) status.ScaleDownReason {
+	return ""
+}
+
+func TestExplainer(t *testing.T) {
+	now := time.Now()
+	removable := BuildTestNode("removable", 1000, 1000)
+	waiting := BuildTestNode("waiting", 1000, 1000)
+	blocked := BuildTestNode("blocked", 1000, 1000)
+	unready := BuildTestNode("unready", 1000, 1000)
+	SetNodeReadyState(unready, false, now.Add(-time.Hour))
+	for _, node := range []*apiv1.Node{removable, waiting, blocked} {
+		SetNodeReadyState(node, true, now.Add(-time.Hour))
+	}
+	notAutoscaled := BuildTestNode("not-autoscaled", 1000, 1000)
+	batch := BuildTestNode("batch", 1000, 1000)
+	nodes := []*apiv1.Node{removable, waiting, blocked, unready, notAutoscaled, batch}
+
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng", 1, 10, 5)
+	for _, node := range []*apiv1.Node{removable, waiting, blocked, unready, batch} {
+		provider.AddNode("ng", node)
+	}
+
+	blockingPod := BuildTestPod("web-1", 100, 100)
+	blockingPod.Namespace = "default"
+	blockingPod.Labels = map[string]string{"app": "web"}
+	batchPod := BuildTestPod("job-1", 100, 100)
+	batchPod.Namespace = "default"
+	options := config.AutoscalingOptions{
+		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+			ScaleDownUnneededTime:         10 * time.Minute,
+			ScaleDownUnreadyTime:          20 * time.Minute,
+			ScaleDownUtilizationThreshold: 0.5,
+		},
+	}
+	client := fake.NewSimpleClientset(removable, waiting, blocked, unready, notAutoscaled, batch)
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(options, client, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, autoscalingCtx.RemainingPdbTracker.SetPdbs([]*policyv1.PodDisruptionBudget{{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-pdb", Namespace: "default"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+	}}))
+
+	planner := &fakePlanner{
+		unneeded: map[string]time.Time{
+			"removable": now.Add(-15 * time.Minute),
+			"waiting":   now.Add(-5 * time.Minute),
+			"unready":   now.Add(-5 * time.Minute),
+		},
+		unremovable: []*simulator.UnremovableNode{
+			{Node: waiting, Reason: simulator.NotUnneededLongEnough},
+			{Node: unready, Reason: simulator.NotUnreadyLongEnough},
+			{Node: blocked, Reason: simulator.BlockedByPod, BlockingPod: &drain.BlockingPod{Pod: blockingPod, Reason: drain.NotEnoughPdb}},
+			{Node: notAutoscaled, Reason: simulator.NotAutoscaled},
+			{Node: batch, Reason: simulator.BlockedByPod, BlockingPod: &drain.BlockingPod{Pod: batchPod, Reason: drain.OutsideDisruptionWindow, BlockedUntil: now.Add(time.Hour)}},
+		},
+		utilization: map[string]utilization.Info{
+			"removable": {CpuUtil: 0.2, MemUtil: 0.3, ResourceName: apiv1.ResourceMemory, Utilization: 0.3},
+		},
+	}
+	explainer := NewExplainer(true)
+	explainer.Update(&autoscalingCtx, planner, nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults), nodes, now)
+
+	limits := &NodeGroupLimits{MinSize: 1, EffectiveMinSize: 1, MaxSize: 10, TargetSize: 5}
+	want := map[string]*NodeExplanation{
+		"removable": {
+			Node:              "removable",
+			NodeGroup:         "ng",
+			Unneeded:          true,
+			Removable:         true,
+			UnneededSince:     &metav1.Time{Time: now.Add(-15 * time.Minute)},
+			UnneededFor:       &metav1.Duration{Duration: 15 * time.Minute},
+			UnneededThreshold: &metav1.Duration{Duration: 10 * time.Minute},
+			Utilization:       &Utilization{CPU: 0.2, Memory: 0.3, Resource: "memory", Value: 0.3, Threshold: 0.5},
+			NodeGroupLimits:   limits,
+		},
+		"waiting": {
+			Node:              "waiting",
+			NodeGroup:         "ng",
+			Unneeded:          true,
+			Reason:            "NotUnneededLongEnough",
+			UnneededSince:     &metav1.Time{Time: now.Add(-5 * time.Minute)},
+			UnneededFor:       &metav1.Duration{Duration: 5 * time.Minute},
+			UnneededThreshold: &metav1.Duration{Duration: 10 * time.Minute},
+			NodeGroupLimits:   limits,
+		},
+		"unready": {
+			Node:              "unready",
+			NodeGroup:         "ng",
+			Unneeded:          true,
+			Reason:            "NotUnreadyLongEnough",
+			UnneededSince:     &metav1.Time{Time: now.Add(-5 * time.Minute)},
+			UnneededFor:       &metav1.Duration{Duration: 5 * time.Minute},
+			UnneededThreshold: &metav1.Duration{Duration: 20 * time.Minute},
+			NodeGroupLimits:   limits,
+		},
+		"blocked": {
+			Node:            "blocked",
+			NodeGroup:       "ng",
+			Reason:          "BlockedByPod",
+			BlockingPod:     &BlockingPod{Namespace: "default", Name: "web-1", Reason: "NotEnoughPdb", PodDisruptionBudgets: []string{"web-pdb"}},
+			NodeGroupLimits: limits,
+		},
+		"not-autoscaled": {
+			Node:   "not-autoscaled",
+			Reason: "NotAutoscaled",
+		},
+		"batch": {
+			Node:            "batch",
+			NodeGroup:       "ng",
+			Reason:          "BlockedByPod",
+			BlockingPod:     &BlockingPod{Namespace: "default", Name: "job-1", Reason: "OutsideDisruptionWindow", BlockedUntil: &metav1.Time{Time: now.Add(time.Hour)}},
+			NodeGroupLimits: limits,
+		},
+	}
+	for name, wantExplanation := range want {
+		got, found := explainer.Explanation(name)
+		assert.True(t, found, name)
+		assert.Equal(t, wantExplanation, got, name)
+	}
+	assert.Len(t, explainer.Explanations(), len(want))
+
+	wantAnnotations := map[string]string{
+		"removable":      "Removable, unneeded since " + now.Add(-15*time.Minute).UTC().Format(time.RFC3339),
+		"waiting":        "Unneeded since " + now.Add(-5*time.Minute).UTC().Format(time.RFC3339) + ", not removable: NotUnneededLongEnough",
+		"blocked":        "Unremovable: BlockedByPod (pod default/web-1: NotEnoughPdb)",
+		"not-autoscaled": "Unremovable: NotAutoscaled",
+		"batch":          "Unremovable until " + now.Add(time.Hour).UTC().Format(time.RFC3339) + ": BlockedByPod (pod default/job-1: OutsideDisruptionWindow)",
+	}
+	assert.Eventually(t, func() bool {
+		for name, wantAnnotation := range wantAnnotations {
+			node, err := client.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil || node.Annotations[NodeAnnotation] != wantAnnotation {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestExplainerClearsAnnotations(t *testing.T) {
+	annotated := BuildTestNode("annotated", 1000, 1000)
+	annotated.Annotations = map[string]string{NodeAnnotation: "Unremovable: NotUnderutilized", "other": "kept"}
+	plain := BuildTestNode("plain", 1000, 1000)
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng", 0, 10, 2)
+	provider.AddNode("ng", annotated)
+	provider.AddNode("ng", plain)
+	client := fake.NewSimpleClientset(annotated, plain)
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, client, nil, provider, nil, nil)
+	assert.NoError(t, err)
+
+	explainer := NewExplainer(false)
+	explainer.Update(&autoscalingCtx, &fakePlanner{}, nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{}), []*apiv1.Node{annotated, plain}, time.Now())
+
+	assert.Eventually(t, func() bool {
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), "annotated", metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+		_, found := node.Annotations[NodeAnnotation]
+		return !found && node.Annotations["other"] == "kept"
+	}, 5*time.Second, 10*time.Millisecond)
+	for _, action := range client.Actions() {
+		if patch, ok := action.(core.PatchAction); ok {
+			assert.Equal(t, "annotated", patch.GetName())
+		}
+	}
+}
+
+func TestExplainerServeHTTP(t *testing.T) {
+	now := time.Now()
+	node := BuildTestNode("n1", 1000, 1000)
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng", 0, 10, 1)
+	provider.AddNode("ng", node)
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, &fake.Clientset{}, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	planner := &fakePlanner{unremovable: []*simulator.UnremovableNode{{Node: node, Reason: simulator.NotUnderutilized}}}
+	explainer := NewExplainer(false)
+	explainer.Update(&autoscalingCtx, planner, nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{}), []*apiv1.Node{node}, now)
+
+	recorder := httptest.NewRecorder()
+	explainer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/scale-down-explanations", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var resp response
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, now.Unix(), resp.LastUpdateTime.Unix())
+	assert.Len(t, resp.Nodes, 1)
+	assert.Equal(t, "NotUnderutilized", resp.Nodes[0].Reason)
+
+	recorder = httptest.NewRecorder()
+	explainer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/scale-down-explanations?node=n1", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var explanation NodeExplanation
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &explanation))
+	assert.Equal(t, "n1", explanation.Node)
+	assert.Equal(t, "ng", explanation.NodeGroup)
+
+	recorder = httptest.NewRecorder()
+	explainer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/scale-down-explanations?node=n2", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestNilExplainer(t *testing.T) {
+	var explainer *Explainer
+	explainer.Update(nil, nil, nil, nil, time.Now())
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/hooks/protos"
+)
+
+// maxResponseSize bounds the size of HTTP response bodies read from hooks.
+const maxResponseSize = 1 << 20
+
+type client interface {
+	call(ctx context.Context, req *Request) (*Response, error)
+}
+
+type httpClient struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPClient(config Config) (*httpClient, error) {
+	httpClient := &httpClient{url: config.URL, client: &http.Client{}}
+	if config.CACert != "" {
+		pool, err := loadCertPool(config.CACert)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}
+	}
+	return httpClient, nil
+}
+
+func (c *httpClient) call(ctx context.Context, req *Request) (*Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %s: %s", httpResp.Status, string(respBody))
+	}
+	resp := &Response{}
+	if len(bytes.TrimSpace(respBody)) > 0 {
+		if err := json.Unmarshal(respBody, resp); err != nil {
+			return nil, fmt.Errorf("invalid response: %v", err)
+		}
+	}
+	return resp, nil
+}
+
+type grpcClient struct {
+	client protos.ScaleDownHookClient
+}
+
+func newGRPCClient(config Config) (*grpcClient, error) {
+	creds := insecure.NewCredentials()
+	if config.CACert != "" {
+		pool, err := loadCertPool(config.CACert)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewClientTLSFromCert(pool, "")
+	}
+	conn, err := grpc.NewClient(config.GRPCAddress, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %v", config.GRPCAddress, err)
+	}
+	return &grpcClient{client: protos.NewScaleDownHookClient(conn)}, nil
+}
+
+func (c *grpcClient) call(ctx context.Context, req *Request) (*Response, error) {
+	out, err := c.client.Call(ctx, &protos.HookRequest{
+		Phase:      string(req.Phase),
+		Node:       req.Node,
+		NodeGroup:  req.NodeGroup,
+		ProviderID: req.ProviderID,
+		Drain:      req.Drain,
+		Attempt:    int32(req.Attempt),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Response{
+		Decision:          Decision(out.GetDecision()),
+		RetryAfterSeconds: int(out.GetRetryAfterSeconds()),
+		Message:           out.GetMessage(),
+	}, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
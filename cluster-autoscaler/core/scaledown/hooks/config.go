@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Phase identifies the point of node deletion at which a hook is called.
+type Phase string
+
+const (
+	// PreDrain hooks are called before a node is drained. They can allow,
+	// deny or delay the deletion of the node.
+	PreDrain Phase = "PreDrain"
+	// PostDelete hooks are called after a node is deleted from the cloud provider.
+	PostDelete Phase = "PostDelete"
+)
+
+// FailurePolicy defines how errors and timeouts of a hook are handled.
+type FailurePolicy string
+
+const (
+	// Fail aborts the deletion of the node if a PreDrain hook fails.
+	Fail FailurePolicy = "Fail"
+	// Ignore proceeds as if the hook succeeded.
+	Ignore FailurePolicy = "Ignore"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Config is the configuration of a single hook.
+type Config struct {
+	// Name identifies the hook in logs, events and metrics.
+	Name string `json:"name"`
+	// Phase is the point of node deletion at which the hook is called.
+	Phase Phase `json:"phase"`
+	// URL of the HTTP endpoint called with a POST request. Exactly one of URL and GRPCAddress has to be set.
+	URL string `json:"url,omitempty"`
+	// GRPCAddress is the address of the gRPC server implementing the ScaleDownHook service.
+	GRPCAddress string `json:"grpcAddress,omitempty"`
+	// CACert is a path to a CA certificate used to verify the hook server.
+	// gRPC connections without it are insecure.
+	CACert string `json:"caCert,omitempty"`
+	// Timeout bounds the time spent in the hook, including delays requested by it. Defaults to 30s.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy defines how errors and timeouts are handled. Defaults to Fail.
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
+	// NodeGroups is a list of regular expressions matched against node group ids.
+	// If empty, the hook is called for nodes from all node groups.
+	NodeGroups []string `json:"nodeGroups,omitempty"`
+}
+
+type configFile struct {
+	Hooks []Config `json:"hooks"`
+}
+
+// LoadConfig reads hook configuration from a YAML file.
+func LoadConfig(path string) ([]Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scale-down hooks config: %v", err)
+	}
+	var file configFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse scale-down hooks config %s: %v", path, err)
+	}
+	return file.Hooks, nil
+}
+
+func (c *Config) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("hook name is required")
+	}
+	if c.Phase != PreDrain && c.Phase != PostDelete {
+		return fmt.Errorf("hook %q: invalid phase %q, expected %s or %s", c.Name, c.Phase, PreDrain, PostDelete)
+	}
+	if (c.URL == "") == (c.GRPCAddress == "") {
+		return fmt.Errorf("hook %q: exactly one of url and grpcAddress has to be set", c.Name)
+	}
+	if c.FailurePolicy == "" {
+		c.FailurePolicy = Fail
+	}
+	if c.FailurePolicy != Fail && c.FailurePolicy != Ignore {
+		return fmt.Errorf("hook %q: invalid failure policy %q, expected %s or %s", c.Name, c.FailurePolicy, Fail, Ignore)
+	}
+	if c.Timeout.Duration < 0 {
+		return fmt.Errorf("hook %q: timeout must not be negative", c.Name)
+	}
+	if c.Timeout.Duration == 0 {
+		c.Timeout.Duration = defaultTimeout
+	}
+	return nil
+}
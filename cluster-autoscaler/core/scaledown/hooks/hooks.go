@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	kube_record "k8s.io/client-go/tools/record"
+	klog "k8s.io/klog/v2"
+)
+
+// Decision is the outcome of a hook call.
+type Decision string
+
+const (
+	// Allow lets the deletion proceed. An empty decision means Allow as well.
+	Allow Decision = "Allow"
+	// Deny vetoes the deletion of the node.
+	Deny Decision = "Deny"
+	// Delay asks to call the hook again after RetryAfterSeconds.
+	Delay Decision = "Delay"
+)
+
+const (
+	// defaultRetryAfter is used if a hook asks for a delay without specifying it.
+	defaultRetryAfter = 10 * time.Second
+	// failedScaleDownReason is reported to scale state observers when a hook fails.
+	failedScaleDownReason = "ScaleDownHookFailed"
+)
+
+// Request is sent to hooks.
+type Request struct {
+	Phase      Phase  `json:"phase"`
+	Node       string `json:"node"`
+	NodeGroup  string `json:"nodeGroup"`
+	ProviderID string `json:"providerID,omitempty"`
+	// Drain is set if the node has pods which are going to be evicted.
+	Drain bool `json:"drain"`
+	// Attempt is the number of the call for this node, starting with 1. It grows when a hook delays the deletion.
+	Attempt int `json:"attempt"`
+}
+
+// Response is returned by hooks. Responses of PostDelete hooks are ignored.
+type Response struct {
+	Decision          Decision `json:"decision,omitempty"`
+	RetryAfterSeconds int      `json:"retryAfterSeconds,omitempty"`
+	Message           string   `json:"message,omitempty"`
+}
+
+type hook struct {
+	Config
+	nodeGroups []*regexp.Regexp
+	client     client
+}
+
+func (h *hook) matches(nodeGroupId string) bool {
+	if len(h.nodeGroups) == 0 {
+		return true
+	}
+	for _, re := range h.nodeGroups {
+		if re.MatchString(nodeGroupId) {
+			return true
+		}
+	}
+	return false
+}
+
+// Runner calls hooks around node deletion. Results of the calls are recorded
+// as events on the node and as metrics, and failures are reported to scale
+// state observers, so the node group is backed off from further scale-down.
+// A nil Runner is valid and doesn't call any hooks.
+type Runner struct {
+	hooks              []*hook
+	recorder           kube_record.EventRecorder
+	scaleStateNotifier nodegroupchange.NodeGroupChangeObserver
+	sleep              func(time.Duration)
+}
+
+// NewRunner validates the hook configuration and creates clients for the hooks.
+func NewRunner(configs []Config, recorder kube_record.EventRecorder, scaleStateNotifier nodegroupchange.NodeGroupChangeObserver) (*Runner, error) {
+	r := &Runner{recorder: recorder, scaleStateNotifier: scaleStateNotifier, sleep: time.Sleep}
+	names := make(map[string]bool)
+	for _, config := range configs {
+		if err := config.validate(); err != nil {
+			return nil, err
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("duplicate hook name %q", config.Name)
+		}
+		names[config.Name] = true
+		h := &hook{Config: config}
+		for _, expr := range config.NodeGroups {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("hook %q: invalid node group expression %q: %v", config.Name, expr, err)
+			}
+			h.nodeGroups = append(h.nodeGroups, re)
+		}
+		var err error
+		if config.URL != "" {
+			h.client, err = newHTTPClient(config)
+		} else {
+			h.client, err = newGRPCClient(config)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("hook %q: %v", config.Name, err)
+		}
+		r.hooks = append(r.hooks, h)
+	}
+	return r, nil
+}
+
+// RunPreDrain calls PreDrain hooks matching the node group of the node. It
+// blocks while hooks delay the deletion and returns an error if any of them
+// denies the deletion or fails with the Fail policy.
+func (r *Runner) RunPreDrain(node *apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool) error {
+	return r.run(PreDrain, node, nodeGroup, drain)
+}
+
+// RunPostDelete calls PostDelete hooks matching the node group of the node.
+// It returns an error if any of them fails with the Fail policy.
+func (r *Runner) RunPostDelete(node *apiv1.Node, nodeGroup cloudprovider.NodeGroup) error {
+	return r.run(PostDelete, node, nodeGroup, false)
+}
+
+func (r *Runner) run(phase Phase, node *apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool) error {
+	if r == nil {
+		return nil
+	}
+	for _, h := range r.hooks {
+		if h.Phase != phase || !h.matches(nodeGroup.Id()) {
+			continue
+		}
+		req := &Request{
+			Phase:      phase,
+			Node:       node.Name,
+			NodeGroup:  nodeGroup.Id(),
+			ProviderID: node.Spec.ProviderID,
+			Drain:      drain,
+		}
+		start := time.Now()
+		err := r.runHook(h, req)
+		metrics.UpdateScaleDownHookDuration(h.Name, string(phase), time.Since(start))
+		if err == nil {
+			r.recorder.Eventf(node, apiv1.EventTypeNormal, "ScaleDownHookSucceeded", "%s hook %s succeeded", phase, h.Name)
+			continue
+		}
+		if _, denied := err.(*deniedError); !denied && h.FailurePolicy == Ignore {
+			klog.Warningf("Scale-down: ignoring failure of %s hook %s for node %s: %v", phase, h.Name, node.Name, err)
+			r.recorder.Eventf(node, apiv1.EventTypeWarning, "ScaleDownHookFailed", "%s hook %s failed, ignoring: %v", phase, h.Name, err)
+			continue
+		}
+		r.recorder.Eventf(node, apiv1.EventTypeWarning, "ScaleDownHookFailed", "%s hook %s failed: %v", phase, h.Name, err)
+		if r.scaleStateNotifier != nil {
+			r.scaleStateNotifier.RegisterFailedScaleDown(nodeGroup, failedScaleDownReason, time.Now())
+		}
+		return fmt.Errorf("%s hook %s failed: %v", phase, h.Name, err)
+	}
+	return nil
+}
+
+type deniedError struct {
+	message string
+}
+
+func (e *deniedError) Error() string {
+	return fmt.Sprintf("deletion denied: %s", e.message)
+}
+
+// runHook calls the hook until it allows or denies the deletion, or until its timeout expires.
+func (r *Runner) runHook(h *hook, req *Request) error {
+	deadline := time.Now().Add(h.Timeout.Duration)
+	for {
+		req.Attempt++
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		resp, err := h.client.call(ctx, req)
+		cancel()
+		if err != nil {
+			result := "error"
+			if !time.Now().Before(deadline) {
+				result = "timeout"
+			}
+			metrics.RegisterScaleDownHookCall(h.Name, string(req.Phase), result)
+			return err
+		}
+		if req.Phase == PostDelete {
+			metrics.RegisterScaleDownHookCall(h.Name, string(req.Phase), "allowed")
+			return nil
+		}
+		switch resp.Decision {
+		case "", Allow:
+			metrics.RegisterScaleDownHookCall(h.Name, string(req.Phase), "allowed")
+			return nil
+		case Deny:
+			metrics.RegisterScaleDownHookCall(h.Name, string(req.Phase), "denied")
+			return &deniedError{message: resp.Message}
+		case Delay:
+			metrics.RegisterScaleDownHookCall(h.Name, string(req.Phase), "delayed")
+			retryAfter := time.Duration(resp.RetryAfterSeconds) * time.Second
+			if retryAfter <= 0 {
+				retryAfter = defaultRetryAfter
+			}
+			if time.Now().Add(retryAfter).After(deadline) {
+				return fmt.Errorf("timed out after %v while the deletion was delayed: %s", h.Timeout.Duration, resp.Message)
+			}
+			klog.V(2).Infof("Scale-down: %s hook %s delayed deletion of node %s by %v: %s", req.Phase, h.Name, req.Node, retryAfter, resp.Message)
+			r.sleep(retryAfter)
+		default:
+			metrics.RegisterScaleDownHookCall(h.Name, string(req.Phase), "error")
+			return fmt.Errorf("unknown decision %q", resp.Decision)
+		}
+	}
+}
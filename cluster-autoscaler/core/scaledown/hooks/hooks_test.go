@@ -0,0 +1,275 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/hooks/protos"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+type fakeNotifier struct {
+	failedScaleDowns []string
+}
+
+func (f *fakeNotifier) RegisterScaleUp(cloudprovider.NodeGroup, int, time.Time) {}
+func (f *fakeNotifier) RegisterScaleDown(cloudprovider.NodeGroup, string, time.Time, time.Time) {
+}
+func (f *fakeNotifier) RegisterFailedScaleUp(cloudprovider.NodeGroup, string, string, string, string, time.Time) {
+}
+func (f *fakeNotifier) RegisterFailedScaleDown(nodeGroup cloudprovider.NodeGroup, reason string, _ time.Time) {
+	f.failedScaleDowns = append(f.failedScaleDowns, nodeGroup.Id())
+}
+
+// newHTTPHook starts a server answering with the given responses in order, repeating the last one.
+func newHTTPHook(t *testing.T, requests *[]Request, responses ...string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, req)
+		resp := responses[len(responses)-1]
+		if len(*requests) <= len(responses) {
+			resp = responses[len(*requests)-1]
+		}
+		if resp == "500" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestRunPreDrain(t *testing.T) {
+	testCases := []struct {
+		name          string
+		responses     []string
+		failurePolicy FailurePolicy
+		timeout       time.Duration
+		wantErr       bool
+		wantAttempts  int
+		wantSleeps    []time.Duration
+	}{
+		{
+			name:         "empty response allows deletion",
+			responses:    []string{""},
+			wantAttempts: 1,
+		},
+		{
+			name:         "allow",
+			responses:    []string{`{"decision": "Allow"}`},
+			wantAttempts: 1,
+		},
+		{
+			name:         "deny",
+			responses:    []string{`{"decision": "Deny", "message": "job running"}`},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:          "deny with ignore policy",
+			responses:     []string{`{"decision": "Deny"}`},
+			failurePolicy: Ignore,
+			wantErr:       true,
+			wantAttempts:  1,
+		},
+		{
+			name:         "delay then allow",
+			responses:    []string{`{"decision": "Delay", "retryAfterSeconds": 5}`, `{"decision": "Delay"}`, `{"decision": "Allow"}`},
+			wantAttempts: 3,
+			wantSleeps:   []time.Duration{5 * time.Second, defaultRetryAfter},
+		},
+		{
+			name:         "delay beyond timeout",
+			responses:    []string{`{"decision": "Delay", "retryAfterSeconds": 60}`},
+			timeout:      time.Minute,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "error",
+			responses:    []string{"500"},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:          "error with ignore policy",
+			responses:     []string{"500"},
+			failurePolicy: Ignore,
+			wantAttempts:  1,
+		},
+		{
+			name:         "unknown decision",
+			responses:    []string{`{"decision": "Maybe"}`},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []Request
+			url := newHTTPHook(t, &requests, tc.responses...)
+			notifier := &fakeNotifier{}
+			runner, err := NewRunner([]Config{{
+				Name:          "checkpoint",
+				Phase:         PreDrain,
+				URL:           url,
+				FailurePolicy: tc.failurePolicy,
+				Timeout:       metav1.Duration{Duration: tc.timeout},
+			}}, kube_record.NewFakeRecorder(10), notifier)
+			assert.NoError(t, err)
+			var sleeps []time.Duration
+			runner.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+			provider := testprovider.NewTestCloudProviderBuilder().Build()
+			provider.AddNodeGroup("ng", 0, 10, 1)
+			node := BuildTestNode("n1", 1000, 1000)
+
+			err = runner.RunPreDrain(node, provider.GetNodeGroup("ng"), true)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, []string{"ng"}, notifier.failedScaleDowns)
+			} else {
+				assert.NoError(t, err)
+				assert.Empty(t, notifier.failedScaleDowns)
+			}
+			assert.Len(t, requests, tc.wantAttempts)
+			assert.Equal(t, tc.wantSleeps, sleeps)
+			for i, req := range requests {
+				assert.Equal(t, Request{Phase: PreDrain, Node: "n1", NodeGroup: "ng", ProviderID: "n1", Drain: true, Attempt: i + 1}, req)
+			}
+			// PostDelete hooks aren't configured.
+			assert.NoError(t, runner.RunPostDelete(node, provider.GetNodeGroup("ng")))
+			assert.Len(t, requests, tc.wantAttempts)
+		})
+	}
+}
+
+func TestNodeGroupSelection(t *testing.T) {
+	var requests []Request
+	url := newHTTPHook(t, &requests, "")
+	runner, err := NewRunner([]Config{{Name: "deregister", Phase: PostDelete, URL: url, NodeGroups: []string{"^gpu-"}}}, kube_record.NewFakeRecorder(10), nil)
+	assert.NoError(t, err)
+
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("gpu-a100", 0, 10, 1)
+	provider.AddNodeGroup("cpu", 0, 10, 1)
+	node := BuildTestNode("n1", 1000, 1000)
+
+	assert.NoError(t, runner.RunPostDelete(node, provider.GetNodeGroup("cpu")))
+	assert.Empty(t, requests)
+	assert.NoError(t, runner.RunPostDelete(node, provider.GetNodeGroup("gpu-a100")))
+	assert.Equal(t, []Request{{Phase: PostDelete, Node: "n1", NodeGroup: "gpu-a100", ProviderID: "n1", Attempt: 1}}, requests)
+}
+
+type fakeHookServer struct {
+	protos.UnimplementedScaleDownHookServer
+	received *protos.HookRequest
+}
+
+func (s *fakeHookServer) Call(_ context.Context, req *protos.HookRequest) (*protos.HookResponse, error) {
+	s.received = req
+	return &protos.HookResponse{Decision: "Deny", Message: "busy"}, nil
+}
+
+func TestGRPCHook(t *testing.T) {
+	hookServer := &fakeHookServer{}
+	server := grpc.NewServer()
+	protos.RegisterScaleDownHookServer(server, hookServer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	runner, err := NewRunner([]Config{{Name: "scheduler", Phase: PreDrain, GRPCAddress: listener.Addr().String()}}, kube_record.NewFakeRecorder(10), nil)
+	assert.NoError(t, err)
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng", 0, 10, 1)
+
+	err = runner.RunPreDrain(BuildTestNode("n1", 1000, 1000), provider.GetNodeGroup("ng"), false)
+	assert.ErrorContains(t, err, "busy")
+	assert.Equal(t, "n1", hookServer.received.GetNode())
+	assert.Equal(t, "PreDrain", hookServer.received.GetPhase())
+	assert.Equal(t, int32(1), hookServer.received.GetAttempt())
+}
+
+func TestNilRunner(t *testing.T) {
+	var runner *Runner
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng", 0, 10, 1)
+	node := BuildTestNode("n1", 1000, 1000)
+	assert.NoError(t, runner.RunPreDrain(node, provider.GetNodeGroup("ng"), true))
+	assert.NoError(t, runner.RunPostDelete(node, provider.GetNodeGroup("ng")))
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hooks.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+hooks:
+- name: checkpoint
+  phase: PreDrain
+  url: http://scheduler.example.com/checkpoint
+  timeout: 10m
+  nodeGroups: ["^gpu-"]
+- name: deregister
+  phase: PostDelete
+  grpcAddress: inventory:9000
+  failurePolicy: Ignore
+`), 0600))
+	configs, err := LoadConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Config{
+		{Name: "checkpoint", Phase: PreDrain, URL: "http://scheduler.example.com/checkpoint", Timeout: metav1.Duration{Duration: 10 * time.Minute}, NodeGroups: []string{"^gpu-"}},
+		{Name: "deregister", Phase: PostDelete, GRPCAddress: "inventory:9000", FailurePolicy: Ignore},
+	}, configs)
+	_, err = NewRunner(configs, kube_record.NewFakeRecorder(10), nil)
+	assert.NoError(t, err)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for _, config := range []Config{
+		{Phase: PreDrain, URL: "http://hook"},
+		{Name: "a", Phase: "PreDelete", URL: "http://hook"},
+		{Name: "a", Phase: PreDrain},
+		{Name: "a", Phase: PreDrain, URL: "http://hook", GRPCAddress: "hook:9000"},
+		{Name: "a", Phase: PreDrain, URL: "http://hook", FailurePolicy: "Retry"},
+		{Name: "a", Phase: PreDrain, URL: "http://hook", Timeout: metav1.Duration{Duration: -time.Second}},
+		{Name: "a", Phase: PreDrain, URL: "http://hook", NodeGroups: []string{"("}},
+		{Name: "a", Phase: PreDrain, URL: "https://hook", CACert: "/nonexistent"},
+	} {
+		_, err := NewRunner([]Config{config}, kube_record.NewFakeRecorder(10), nil)
+		assert.Error(t, err, config.Name)
+	}
+	_, err := NewRunner([]Config{{Name: "a", Phase: PreDrain, URL: "http://hook"}, {Name: "a", Phase: PostDelete, URL: "http://hook"}}, kube_record.NewFakeRecorder(10), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,144 @@
+//
+//Copyright 2025 The Kubernetes Authors.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: core/scaledown/hooks/protos/hooks.proto
+
+package protos
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScaleDownHook_Call_FullMethodName = "/clusterautoscaler.scaledown.hooks.v1.ScaleDownHook/Call"
+)
+
+// ScaleDownHookClient is the client API for ScaleDownHook service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Interface for scale-down hooks.
+type ScaleDownHookClient interface {
+	// Call is invoked for every node deleted by Cluster Autoscaler, in the
+	// phase the hook is configured for.
+	Call(ctx context.Context, in *HookRequest, opts ...grpc.CallOption) (*HookResponse, error)
+}
+
+type scaleDownHookClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScaleDownHookClient(cc grpc.ClientConnInterface) ScaleDownHookClient {
+	return &scaleDownHookClient{cc}
+}
+
+func (c *scaleDownHookClient) Call(ctx context.Context, in *HookRequest, opts ...grpc.CallOption) (*HookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HookResponse)
+	err := c.cc.Invoke(ctx, ScaleDownHook_Call_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScaleDownHookServer is the server API for ScaleDownHook service.
+// All implementations must embed UnimplementedScaleDownHookServer
+// for forward compatibility.
+//
+// Interface for scale-down hooks.
+type ScaleDownHookServer interface {
+	// Call is invoked for every node deleted by Cluster Autoscaler, in the
+	// phase the hook is configured for.
+	Call(context.Context, *HookRequest) (*HookResponse, error)
+	mustEmbedUnimplementedScaleDownHookServer()
+}
+
+// UnimplementedScaleDownHookServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScaleDownHookServer struct{}
+
+func (UnimplementedScaleDownHookServer) Call(context.Context, *HookRequest) (*HookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Call not implemented")
+}
+func (UnimplementedScaleDownHookServer) mustEmbedUnimplementedScaleDownHookServer() {}
+func (UnimplementedScaleDownHookServer) testEmbeddedByValue()                       {}
+
+// UnsafeScaleDownHookServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScaleDownHookServer will
+// result in compilation errors.
+type UnsafeScaleDownHookServer interface {
+	mustEmbedUnimplementedScaleDownHookServer()
+}
+
+func RegisterScaleDownHookServer(s grpc.ServiceRegistrar, srv ScaleDownHookServer) {
+	// If the following call pancis, it indicates UnimplementedScaleDownHookServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScaleDownHook_ServiceDesc, srv)
+}
+
+func _ScaleDownHook_Call_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScaleDownHookServer).Call(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScaleDownHook_Call_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScaleDownHookServer).Call(ctx, req.(*HookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScaleDownHook_ServiceDesc is the grpc.ServiceDesc for ScaleDownHook service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScaleDownHook_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "clusterautoscaler.scaledown.hooks.v1.ScaleDownHook",
+	HandlerType: (*ScaleDownHookServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Call",
+			Handler:    _ScaleDownHook_Call_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "core/scaledown/hooks/protos/hooks.proto",
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/client-go/util/workqueue"
+	klog "k8s.io/klog/v2"
+)
+
+// candidatesPerWorker is the number of candidates each worker simulates in a
+// single batch. Simulating in batches keeps the parallel mode from doing much
+// more work than the sequential one when unneededNodesLimit() is hit early.
+const candidatesPerWorker = 4
+
+// simulationWorker simulates node removals on its own cluster snapshot, so
+// that several candidates can be simulated concurrently.
+type simulationWorker struct {
+	snapshot clustersnapshot.ClusterSnapshot
+	rs       *simulator.RemovalSimulator
+	// loaded is the node and pods of every node loaded into the snapshot, used
+	// to only reload nodes which changed since the previous loop.
+	loaded map[string]loadedNode
+}
+
+// loadedNode is the state of a node loaded into a worker snapshot.
+type loadedNode struct {
+	node *apiv1.Node
+	pods []*apiv1.Pod
+}
+
+// simulationResult is the outcome of simulating the removal of a single node.
+type simulationResult struct {
+	removable   *simulator.NodeToBeRemoved
+	unremovable *simulator.UnremovableNode
+}
+
+// startParallelSimulation enables simulating candidates in parallel during a
+// single categorizeNodes call. Workers are only synced with the cluster
+// snapshot once some candidates actually need to be simulated.
+func (p *Planner) startParallelSimulation(deadline time.Time) {
+	if p.simulationParallelism <= 1 || p.autoscalingCtx.NewSimulationSnapshot == nil {
+		return
+	}
+	p.parallelResults = make(map[string]simulationResult)
+	p.parallelDeadline = deadline
+	p.workersSynced = false
+}
+
+// stopParallelSimulation drops results of the parallel simulation, which are
+// only valid for a single categorizeNodes call.
+func (p *Planner) stopParallelSimulation() {
+	p.parallelResults = nil
+}
+
+// prepareWorkers creates missing workers and syncs all of them with the
+// current state of the cluster snapshot. Nodes which didn't change since the
+// previous sync, i.e. have the same node and pod objects, are kept.
+func (p *Planner) prepareWorkers() error {
+	for len(p.workers) < p.simulationParallelism {
+		snapshot, err := p.autoscalingCtx.NewSimulationSnapshot()
+		if err != nil {
+			return fmt.Errorf("couldn't create a cluster snapshot: %v", err)
+		}
+		p.workers = append(p.workers, &simulationWorker{
+			snapshot: snapshot,
+			rs:       simulator.NewRemovalSimulator(p.autoscalingCtx.ListerRegistry, snapshot, p.deleteOptions, p.drainabilityRules, false),
+			loaded:   make(map[string]loadedNode),
+		})
+	}
+	nodeInfos, err := p.autoscalingCtx.ClusterSnapshot.ListNodeInfos()
+	if err != nil {
+		return fmt.Errorf("couldn't list nodes from the cluster snapshot: %v", err)
+	}
+	current := make(map[string]loadedNode, len(nodeInfos))
+	for _, nodeInfo := range nodeInfos {
+		state := loadedNode{node: nodeInfo.Node()}
+		for _, podInfo := range nodeInfo.Pods() {
+			state.pods = append(state.pods, podInfo.Pod)
+		}
+		current[nodeInfo.Node().Name] = state
+	}
+	errs := make([]error, len(p.workers))
+	workqueue.ParallelizeUntil(context.Background(), len(p.workers), len(p.workers), func(w int) {
+		errs[w] = p.workers[w].sync(nodeInfos, current)
+	})
+	for w, err := range errs {
+		if err != nil {
+			// Start from scratch in the next loop.
+			p.workers[w].loaded = make(map[string]loadedNode)
+			_ = p.workers[w].snapshot.SetClusterState(nil, nil, nil)
+			return fmt.Errorf("couldn't load the cluster snapshot: %v", err)
+		}
+	}
+	return nil
+}
+
+// sync updates the worker snapshot to the given node infos, only touching
+// nodes which changed.
+func (w *simulationWorker) sync(nodeInfos []*framework.NodeInfo, current map[string]loadedNode) error {
+	for name := range w.loaded {
+		if _, found := current[name]; !found {
+			if err := w.snapshot.RemoveNodeInfo(name); err != nil {
+				return err
+			}
+			delete(w.loaded, name)
+		}
+	}
+	for _, nodeInfo := range nodeInfos {
+		name := nodeInfo.Node().Name
+		state := current[name]
+		if loaded, found := w.loaded[name]; found {
+			if loaded.node == state.node && slices.Equal(loaded.pods, state.pods) {
+				continue
+			}
+			if err := w.snapshot.RemoveNodeInfo(name); err != nil {
+				return err
+			}
+			delete(w.loaded, name)
+		}
+		if err := w.snapshot.AddNodeInfo(nodeInfo); err != nil {
+			return err
+		}
+		w.loaded[name] = state
+	}
+	return nil
+}
+
+// simulateNodeRemoval checks whether the node can be removed, taking into
+// account the removals accepted so far. In parallel mode, the node and the
+// upcoming candidates are first simulated concurrently against the cluster
+// state from before any removal. Accepted removals only take capacity away,
+// so nodes found unremovable this way are unremovable anyway. Nodes found
+// removable are confirmed on the main snapshot: their PDBs are checked against
+// the removals accepted so far and their pods are moved there, which is cheap
+// thanks to the scheduling hints gathered by the workers.
+func (p *Planner) simulateNodeRemoval(node string, upcoming []string, podDestinations map[string]bool) (*simulator.NodeToBeRemoved, *simulator.UnremovableNode) {
+	if p.parallelResults != nil {
+		if _, found := p.parallelResults[node]; !found {
+			p.simulateInParallel(upcoming[:min(len(upcoming), p.simulationParallelism*candidatesPerWorker)], podDestinations)
+		}
+		if result, found := p.parallelResults[node]; found {
+			if result.unremovable != nil {
+				return nil, result.unremovable
+			}
+			removable := result.removable
+			if canRemove, _, blockingPod := p.autoscalingCtx.RemainingPdbTracker.CanRemovePods(removable.PodsToReschedule); !canRemove {
+				return nil, &simulator.UnremovableNode{Node: removable.Node, Reason: simulator.BlockedByPod, BlockingPod: blockingPod}
+			}
+			return p.rs.ConfirmNodeRemoval(removable, podDestinations, p.latestUpdate)
+		}
+	}
+	return p.rs.SimulateNodeRemoval(node, podDestinations, p.latestUpdate, p.autoscalingCtx.RemainingPdbTracker)
+}
+
+// simulateInParallel simulates the removal of each of the candidates on one
+// of the workers and stores the results. Workers stop taking candidates once
+// the simulation deadline passes; candidates left out are simulated
+// sequentially if there is still time for them.
+func (p *Planner) simulateInParallel(candidates []string, podDestinations map[string]bool) {
+	if !p.workersSynced {
+		if err := p.prepareWorkers(); err != nil {
+			klog.Errorf("Failed to prepare parallel scale-down simulation, simulating candidates sequentially: %v", err)
+			p.parallelResults = nil
+			return
+		}
+		p.workersSynced = true
+	}
+	results := make([]*simulationResult, len(candidates))
+	workers := len(p.workers)
+	workqueue.ParallelizeUntil(context.Background(), workers, workers, func(w int) {
+		for i := w; i < len(candidates); i += workers {
+			if time.Now().After(p.parallelDeadline) {
+				return
+			}
+			removable, unremovable := p.workers[w].rs.SimulateNodeRemoval(candidates[i], podDestinations, p.latestUpdate, p.autoscalingCtx.RemainingPdbTracker)
+			results[i] = &simulationResult{removable: removable, unremovable: unremovable}
+		}
+	})
+	simulated := 0
+	for i, node := range candidates {
+		if results[i] != nil {
+			p.parallelResults[node] = *results[i]
+			simulated++
+		}
+	}
+	for _, worker := range p.workers {
+		p.rs.MergeHints(worker.rs)
+	}
+	klog.V(4).Infof("Simulated removal of %d out of %d scale-down candidates in parallel", simulated, len(candidates))
+}
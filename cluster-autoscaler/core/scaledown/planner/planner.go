@@ -18,6 +18,7 @@ package planner
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/eligibility"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/unneeded"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/unremovable"
 	"k8s.io/autoscaler/cluster-autoscaler/processors"
@@ -39,6 +41,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
 	klog "k8s.io/klog/v2"
 )
@@ -49,7 +52,13 @@ type eligibilityChecker interface {
 
 type removalSimulator interface {
 	DropOldHints()
+	MergeHints(other *simulator.RemovalSimulator)
 	SimulateNodeRemoval(node string, podDestinations map[string]bool, timestamp time.Time, remainingPdbTracker pdb.RemainingPdbTracker) (*simulator.NodeToBeRemoved, *simulator.UnremovableNode)
+	ConfirmNodeRemoval(removable *simulator.NodeToBeRemoved, podDestinations map[string]bool, timestamp time.Time) (*simulator.NodeToBeRemoved, *simulator.UnremovableNode)
+}
+
+type maxNodeLifetimeGetter interface {
+	GetMaxNodeLifetime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
 }
 
 // controllerReplicasCalculator calculates a number of target and expected replicas for a given controller.
@@ -71,11 +80,20 @@ type Planner struct {
 	latestUpdate          time.Time
 	minUpdateInterval     time.Duration
 	eligibilityChecker    eligibilityChecker
+	maxNodeLifetimeGetter maxNodeLifetimeGetter
+	removalReasons        map[string]status.ScaleDownReason
 	nodeUtilizationMap    map[string]utilization.Info
 	resourceLimitsFinder  *resource.LimitsFinder
 	cc                    controllerReplicasCalculator
 	scaleDownSetProcessor nodes.ScaleDownSetProcessor
 	scaleDownContext      *nodes.ScaleDownContext
+	deleteOptions         options.NodeDeleteOptions
+	drainabilityRules     rules.Rules
+	simulationParallelism int
+	workers               []*simulationWorker
+	parallelResults       map[string]simulationResult
+	parallelDeadline      time.Time
+	workersSynced         bool
 }
 
 // New creates a new Planner object.
@@ -91,6 +109,12 @@ func New(autoscalingCtx *ca_context.AutoscalingContext, processors *processors.A
 		unneededNodes.LoadFromExistingTaints(autoscalingCtx.ListerRegistry, time.Now(), autoscalingCtx.AutoscalingOptions.NodeDeletionCandidateTTL)
 	}
 
+	simulationParallelism := autoscalingCtx.AutoscalingOptions.ScaleDownSimulationParallelism
+	if simulationParallelism > 1 && autoscalingCtx.AutoscalingOptions.DynamicResourceAllocationEnabled {
+		klog.Warningf("Parallel scale-down simulation is not supported with dynamic resource allocation, simulating candidates sequentially")
+		simulationParallelism = 1
+	}
+
 	return &Planner{
 		autoscalingCtx:        autoscalingCtx,
 		unremovableNodes:      unremovable.NewNodes(),
@@ -98,12 +122,16 @@ func New(autoscalingCtx *ca_context.AutoscalingContext, processors *processors.A
 		rs:                    simulator.NewRemovalSimulator(autoscalingCtx.ListerRegistry, autoscalingCtx.ClusterSnapshot, deleteOptions, drainabilityRules, true),
 		actuationInjector:     scheduling.NewHintingSimulator(),
 		eligibilityChecker:    eligibility.NewChecker(processors.NodeGroupConfigProcessor),
+		maxNodeLifetimeGetter: processors.NodeGroupConfigProcessor,
 		nodeUtilizationMap:    make(map[string]utilization.Info),
 		resourceLimitsFinder:  resourceLimitsFinder,
 		cc:                    newControllerReplicasCalculator(autoscalingCtx.ListerRegistry),
 		scaleDownSetProcessor: processors.ScaleDownSetProcessor,
 		scaleDownContext:      nodes.NewDefaultScaleDownContext(),
 		minUpdateInterval:     minUpdateInterval,
+		deleteOptions:         deleteOptions,
+		drainabilityRules:     drainabilityRules,
+		simulationParallelism: simulationParallelism,
 	}
 }
 
@@ -130,6 +158,9 @@ func (p *Planner) UpdateClusterState(podDestinations, scaleDownCandidates []*api
 	p.categorizeNodes(asMap(nodeNames(podDestinations)), scaleDownCandidates)
 	p.rs.DropOldHints()
 	p.actuationInjector.DropOldHints()
+	for _, worker := range p.workers {
+		worker.rs.DropOldHints()
+	}
 	return nil
 }
 
@@ -164,7 +195,9 @@ func (p *Planner) NodesToDelete(_ time.Time) (empty, needDrain []*apiv1.Node) {
 	nodesToRemove, unremovableNodes := p.scaleDownSetProcessor.FilterUnremovableNodes(p.autoscalingCtx, p.scaleDownContext, candidatesToBeRemoved)
 	p.addUnremovableNodes(unremovableNodes)
 
+	p.removalReasons = make(map[string]status.ScaleDownReason, len(nodesToRemove))
 	for _, nodeToRemove := range nodesToRemove {
+		p.removalReasons[nodeToRemove.Node.Name] = p.removalReason(nodeToRemove)
 		if len(nodeToRemove.PodsToReschedule) > 0 {
 			needDrain = append(needDrain, nodeToRemove.Node)
 		} else {
@@ -175,6 +208,35 @@ func (p *Planner) NodesToDelete(_ time.Time) (empty, needDrain []*apiv1.Node) {
 	return empty, needDrain
 }
 
+// removalReason returns why the planner considers a removable node for
+// deletion.
+func (p *Planner) removalReason(nodeToRemove simulator.NodeToBeRemoved) status.ScaleDownReason {
+	node := nodeToRemove.Node
+	if ready, _, _ := kube_util.GetReadinessState(node); !ready {
+		return status.ScaleDownUnready
+	}
+	if p.isExpired(node) {
+		return status.ScaleDownExpired
+	}
+	if len(nodeToRemove.PodsToReschedule) == 0 {
+		return status.ScaleDownEmpty
+	}
+	return status.ScaleDownUnderutilized
+}
+
+func (p *Planner) isExpired(node *apiv1.Node) bool {
+	nodeGroup, err := p.autoscalingCtx.CloudProvider.NodeGroupForNode(node)
+	if err != nil || nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+		return false
+	}
+	maxNodeLifetime, err := p.maxNodeLifetimeGetter.GetMaxNodeLifetime(nodeGroup)
+	if err != nil {
+		klog.Warningf("Couldn't retrieve `MaxNodeLifetime` option for node %v: %v", node.Name, err)
+		return false
+	}
+	return eligibility.IsNodeExpired(node, maxNodeLifetime, p.latestUpdate)
+}
+
 func (p *Planner) addUnremovableNodes(unremovableNodes []simulator.UnremovableNode) {
 	for _, u := range unremovableNodes {
 		p.unremovableNodes.Add(&u)
@@ -204,6 +266,17 @@ func (p *Planner) UnremovableNodes() []*simulator.UnremovableNode {
 	return p.unremovableNodes.AsList()
 }
 
+// UnneededSince returns the time since which a given node is unneeded.
+func (p *Planner) UnneededSince(nodeName string) (time.Time, bool) {
+	return p.unneededNodes.UnneededSince(nodeName)
+}
+
+// RemovalReason returns why a node returned by the last NodesToDelete call
+// should be removed.
+func (p *Planner) RemovalReason(nodeName string) status.ScaleDownReason {
+	return p.removalReasons[nodeName]
+}
+
 // NodeUtilizationMap returns a map with utilization of nodes.
 func (p *Planner) NodeUtilizationMap() map[string]utilization.Info {
 	return p.nodeUtilizationMap
@@ -277,6 +350,8 @@ func (p *Planner) categorizeNodes(podDestinations map[string]bool, scaleDownCand
 	}
 	p.nodeUtilizationMap = utilizationMap
 	timer := time.NewTimer(p.autoscalingCtx.ScaleDownSimulationTimeout)
+	p.startParallelSimulation(time.Now().Add(p.autoscalingCtx.ScaleDownSimulationTimeout))
+	defer p.stopParallelSimulation()
 
 	for i, node := range currentlyUnneededNodeNames {
 		if timedOut(timer) {
@@ -287,7 +362,7 @@ func (p *Planner) categorizeNodes(podDestinations map[string]bool, scaleDownCand
 			klog.V(4).Infof("%d out of %d nodes skipped in scale down simulation: there are already %d unneeded nodes so no point in looking for more. Total atomic scale down nodes: %d", len(currentlyUnneededNodeNames)-i, len(currentlyUnneededNodeNames), len(removableList), atomicScaleDownNodesCount)
 			break
 		}
-		removable, unremovable := p.rs.SimulateNodeRemoval(node, podDestinations, p.latestUpdate, p.autoscalingCtx.RemainingPdbTracker)
+		removable, unremovable := p.simulateNodeRemoval(node, currentlyUnneededNodeNames[i:], podDestinations)
 		if removable != nil {
 			_, inParallel, _ := p.autoscalingCtx.RemainingPdbTracker.CanRemovePods(removable.PodsToReschedule)
 			if !inParallel {
@@ -303,7 +378,7 @@ func (p *Planner) categorizeNodes(podDestinations map[string]bool, scaleDownCand
 		}
 		if unremovable != nil {
 			unremovableCount += 1
-			p.unremovableNodes.AddTimeout(unremovable, unremovableTimeout)
+			p.unremovableNodes.AddTimeout(unremovable, p.recheckTimeout(unremovable, unremovableTimeout))
 		}
 	}
 	p.unneededNodes.Update(removableList, p.latestUpdate)
@@ -312,6 +387,22 @@ func (p *Planner) categorizeNodes(podDestinations map[string]bool, scaleDownCand
 	}
 }
 
+// recheckTimeout returns the time until which an unremovable node shouldn't
+// be simulated again. Nodes blocked by a pod only until a known time are
+// rechecked as soon as it passes.
+func (p *Planner) recheckTimeout(unremovable *simulator.UnremovableNode, timeout time.Time) time.Time {
+	blockingPod := unremovable.BlockingPod
+	if blockingPod == nil || blockingPod.Pod == nil || blockingPod.BlockedUntil.IsZero() {
+		return timeout
+	}
+	p.autoscalingCtx.Recorder.Eventf(unremovable.Node, apiv1.EventTypeNormal, "ScaleDownBlocked",
+		"node can't be removed until %s: pod %s/%s is blocking it (%s)", blockingPod.BlockedUntil.UTC().Format(time.RFC3339), blockingPod.Pod.Namespace, blockingPod.Pod.Name, blockingPod.Reason)
+	if blockingPod.BlockedUntil.Before(timeout) {
+		return blockingPod.BlockedUntil
+	}
+	return timeout
+}
+
 // atomicScaleDownNode checks if the removable node would be considered for atomic scale down.
 func (p *Planner) atomicScaleDownNode(node *simulator.NodeToBeRemoved) bool {
 	nodeGroup, err := p.autoscalingCtx.CloudProvider.NodeGroupForNode(node.Node)
@@ -40,6 +40,7 @@ import (
 	processorstest "k8s.io/autoscaler/cluster-autoscaler/processors/test"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/testsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
@@ -48,6 +49,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/client-go/kubernetes/fake"
+	kube_record "k8s.io/client-go/tools/record"
 )
 
 func TestUpdateClusterState(t *testing.T) {
@@ -525,6 +527,128 @@ func TestUpdateClusterState(t *testing.T) {
 	}
 }
 
+func TestUpdateClusterStateParallelSimulation(t *testing.T) {
+	for _, parallelism := range []int{1, 3} {
+		t.Run(fmt.Sprintf("parallelism %d", parallelism), func(t *testing.T) {
+			// Each candidate alone fits on the destination node, but not both
+			// of them together.
+			nodes := []*apiv1.Node{
+				BuildTestNode("c1", 1000, 10),
+				BuildTestNode("c2", 1000, 10),
+				BuildTestNode("c3", 1000, 10),
+				BuildTestNode("d1", 1000, 10),
+			}
+			pods := []*apiv1.Pod{
+				SetRSPodSpec(BuildScheduledTestPod("p1", 600, 1, "c1"), "rs"),
+				SetRSPodSpec(BuildScheduledTestPod("p2", 600, 1, "c2"), "rs"),
+				SetRSPodSpec(BuildScheduledTestPod("p3", 1000, 1, "c3"), "rs"),
+			}
+			rsLister, err := kube_util.NewTestReplicaSetLister(generateReplicaSets("rs", 5))
+			assert.NoError(t, err)
+			registry := kube_util.NewListerRegistry(nil, nil, nil, nil, nil, nil, nil, rsLister, nil)
+			provider := testprovider.NewTestCloudProviderBuilder().Build()
+			provider.AddNodeGroup("ng1", 0, 0, 0)
+			for _, node := range nodes {
+				provider.AddNode("ng1", node)
+			}
+			autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+				NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+					ScaleDownUnneededTime: 10 * time.Minute,
+				},
+				ScaleDownSimulationTimeout:     10 * time.Second,
+				MaxScaleDownParallelism:        10,
+				ScaleDownSimulationParallelism: parallelism,
+			}, &fake.Clientset{}, registry, provider, nil, nil)
+			assert.NoError(t, err)
+			autoscalingCtx.NewSimulationSnapshot = func() (clustersnapshot.ClusterSnapshot, error) {
+				snapshot, _, err := testsnapshot.NewTestSnapshotAndHandle()
+				return snapshot, err
+			}
+			clustersnapshot.InitializeClusterSnapshotOrDie(t, autoscalingCtx.ClusterSnapshot, nodes, pods)
+			p := New(&autoscalingCtx, processorstest.NewTestProcessors(&autoscalingCtx), options.NodeDeleteOptions{}, nil)
+			p.eligibilityChecker = &fakeEligibilityChecker{eligible: asMap([]string{"c1", "c2", "c3"})}
+
+			assert.NoError(t, p.UpdateClusterState(nodes, nodes, &fakeActuationStatus{}, time.Now()))
+			assert.True(t, p.unneededNodes.Contains("c1"))
+			assert.False(t, p.unneededNodes.Contains("c2"))
+			assert.True(t, p.unremovableNodes.Contains("c2"))
+			assert.True(t, p.unremovableNodes.Contains("c3"))
+			if parallelism > 1 {
+				assert.Len(t, p.workers, parallelism)
+			} else {
+				assert.Empty(t, p.workers)
+			}
+			// Simulations done on the main snapshot must not leak to it.
+			nodeInfo, err := autoscalingCtx.ClusterSnapshot.GetNodeInfo("d1")
+			assert.NoError(t, err)
+			assert.Empty(t, nodeInfo.Pods())
+		})
+	}
+}
+
+func TestPrepareWorkersSyncsChangedNodes(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 10)
+	n2 := BuildTestNode("n2", 1000, 10)
+	n3 := BuildTestNode("n3", 1000, 10)
+	p1 := BuildScheduledTestPod("p1", 100, 1, "n1")
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+		ScaleDownSimulationParallelism: 2,
+	}, &fake.Clientset{}, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	autoscalingCtx.NewSimulationSnapshot = func() (clustersnapshot.ClusterSnapshot, error) {
+		snapshot, _, err := testsnapshot.NewTestSnapshotAndHandle()
+		return snapshot, err
+	}
+	p := New(&autoscalingCtx, processorstest.NewTestProcessors(&autoscalingCtx), options.NodeDeleteOptions{}, nil)
+
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, autoscalingCtx.ClusterSnapshot, []*apiv1.Node{n1, n2}, []*apiv1.Pod{p1})
+	assert.NoError(t, p.prepareWorkers())
+	assert.Len(t, p.workers, 2)
+
+	// n1 is unchanged, a pod moves to n2, n3 is added.
+	p2 := BuildScheduledTestPod("p2", 100, 1, "n2")
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, autoscalingCtx.ClusterSnapshot, []*apiv1.Node{n1, n2, n3}, []*apiv1.Pod{p1, p2})
+	assert.NoError(t, p.prepareWorkers())
+	for _, worker := range p.workers {
+		nodeInfos, err := worker.snapshot.ListNodeInfos()
+		assert.NoError(t, err)
+		assert.Len(t, nodeInfos, 3)
+		for _, name := range []string{"n1", "n2"} {
+			nodeInfo, err := worker.snapshot.GetNodeInfo(name)
+			assert.NoError(t, err)
+			assert.Len(t, nodeInfo.Pods(), 1)
+		}
+	}
+
+	// n2 is removed.
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, autoscalingCtx.ClusterSnapshot, []*apiv1.Node{n1, n3}, []*apiv1.Pod{p1})
+	assert.NoError(t, p.prepareWorkers())
+	for _, worker := range p.workers {
+		_, err := worker.snapshot.GetNodeInfo("n2")
+		assert.Error(t, err)
+	}
+}
+
+func TestSimulateInParallelStopsAtDeadline(t *testing.T) {
+	nodes := []*apiv1.Node{BuildTestNode("n1", 1000, 10), BuildTestNode("n2", 1000, 10)}
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+		ScaleDownSimulationParallelism: 2,
+	}, &fake.Clientset{}, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	autoscalingCtx.NewSimulationSnapshot = func() (clustersnapshot.ClusterSnapshot, error) {
+		snapshot, _, err := testsnapshot.NewTestSnapshotAndHandle()
+		return snapshot, err
+	}
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, autoscalingCtx.ClusterSnapshot, nodes, nil)
+	p := New(&autoscalingCtx, processorstest.NewTestProcessors(&autoscalingCtx), options.NodeDeleteOptions{}, nil)
+
+	p.startParallelSimulation(time.Now().Add(-time.Second))
+	p.simulateInParallel([]string{"n1", "n2"}, map[string]bool{"n1": true, "n2": true})
+	assert.Empty(t, p.parallelResults)
+}
+
 func TestUpdateClusterStatUnneededNodesLimit(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -709,6 +833,51 @@ func TestUpdateClusterStatUnneededNodesLimit(t *testing.T) {
 	}
 }
 
+func TestUpdateClusterStateBlockedUntil(t *testing.T) {
+	now := time.Now()
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n1, true, now.Add(-time.Hour))
+	SetNodeReadyState(n2, true, now.Add(-time.Hour))
+	pod := BuildTestPod("p1", 100, 100)
+	pod.Spec.NodeName = "n1"
+	pod.OwnerReferences = GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+	pod.Annotations = map[string]string{drain.PodDoNotDisruptUntilKey: now.Add(time.Minute).UTC().Format(time.RFC3339)}
+
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 0, 10, 2)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+		ScaleDownSimulationTimeout:    time.Hour,
+		UnremovableNodeRecheckTimeout: 5 * time.Minute,
+		MaxScaleDownParallelism:       10,
+	}, &fake.Clientset{}, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	nodes := []*apiv1.Node{n1, n2}
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, autoscalingCtx.ClusterSnapshot, nodes, []*apiv1.Pod{pod})
+	p := New(&autoscalingCtx, processorstest.NewTestProcessors(&autoscalingCtx), options.NodeDeleteOptions{}, nil)
+	p.eligibilityChecker = &fakeEligibilityChecker{eligible: asMap([]string{"n1"})}
+	assert.NoError(t, p.UpdateClusterState(nodes, nodes, &fakeActuationStatus{}, now))
+
+	var blocked *simulator.UnremovableNode
+	for _, u := range p.UnremovableNodes() {
+		if u.Node.Name == "n1" {
+			blocked = u
+		}
+	}
+	if assert.NotNil(t, blocked) && assert.NotNil(t, blocked.BlockingPod) {
+		assert.Equal(t, drain.OutsideDisruptionWindow, blocked.BlockingPod.Reason)
+	}
+	assert.Contains(t, <-autoscalingCtx.Recorder.(*kube_record.FakeRecorder).Events, "ScaleDownBlocked")
+
+	// The node is rechecked as soon as the pod stops blocking it, rather than after UnremovableNodeRecheckTimeout.
+	p.unremovableNodes.Update(autoscalingCtx.ClusterSnapshot, now.Add(30*time.Second))
+	assert.True(t, p.unremovableNodes.IsRecent("n1"))
+	p.unremovableNodes.Update(autoscalingCtx.ClusterSnapshot, now.Add(2*time.Minute))
+	assert.False(t, p.unremovableNodes.IsRecent("n1"))
+}
+
 // TestNewPlannerWithExistingDeletionCandidateNodes tests that the newPlanner correctly handles existing deletion candidate taints on nodes.
 func TestNewPlannerWithExistingDeletionCandidateNodes(t *testing.T) {
 	// Use a table-driven approach where each test case includes its own set of nodes and expected behavior
@@ -1035,6 +1204,52 @@ func TestNodesToDelete(t *testing.T) {
 	}
 }
 
+func TestNodesToDeleteRemovalReasons(t *testing.T) {
+	now := time.Now()
+	empty := buildRemovableNode("empty", 0)
+	SetNodeReadyState(empty.Node, true, now.Add(-time.Hour))
+	underutilized := buildRemovableNode("underutilized", 1)
+	SetNodeReadyState(underutilized.Node, true, now.Add(-time.Hour))
+	unready := buildRemovableNode("unready", 1)
+	SetNodeReadyState(unready.Node, false, now.Add(-time.Hour))
+	expired := buildRemovableNode("expired", 1)
+	SetNodeReadyState(expired.Node, true, now.Add(-time.Hour))
+	expired.Node.CreationTimestamp = metav1.NewTime(now.Add(-48 * time.Hour))
+	removables := []simulator.NodeToBeRemoved{empty, underutilized, unready, expired}
+
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	ng := testprovider.NewTestNodeGroup("ng", 10000, 0, 4, true, false, "n1-standard-2", nil, nil)
+	ng.SetCloudProvider(provider)
+	provider.InsertNodeGroup(ng)
+	var allNodes []*apiv1.Node
+	for _, removable := range removables {
+		allNodes = append(allNodes, removable.Node)
+		provider.AddNode(ng.Id(), removable.Node)
+	}
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+			ScaleDownUnneededTime: 10 * time.Minute,
+			ScaleDownUnreadyTime:  10 * time.Minute,
+			MaxNodeLifetime:       24 * time.Hour,
+		},
+	}, &fake.Clientset{}, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, autoscalingCtx.ClusterSnapshot, allNodes, nil)
+	p := New(&autoscalingCtx, processorstest.NewTestProcessors(&autoscalingCtx), options.NodeDeleteOptions{}, nil)
+	p.latestUpdate = now
+	p.scaleDownContext.ActuationStatus = deletiontracker.NewNodeDeletionTracker(0 * time.Second)
+	p.unneededNodes.Update(removables, now.Add(-1*time.Hour))
+
+	empties, drain := p.NodesToDelete(now)
+	assert.Len(t, empties, 1)
+	assert.Len(t, drain, 3)
+	assert.Equal(t, status.ScaleDownEmpty, p.RemovalReason("empty"))
+	assert.Equal(t, status.ScaleDownUnderutilized, p.RemovalReason("underutilized"))
+	assert.Equal(t, status.ScaleDownUnready, p.RemovalReason("unready"))
+	assert.Equal(t, status.ScaleDownExpired, p.RemovalReason("expired"))
+	assert.Equal(t, status.ScaleDownReason(""), p.RemovalReason("unknown"))
+}
+
 func sizedNodeGroup(id string, size int, atomic bool) cloudprovider.NodeGroup {
 	ng := testprovider.NewTestNodeGroup(id, 10000, 0, size, true, false, "n1-standard-2", nil, nil)
 	ng.SetOptions(&config.NodeGroupAutoscalingOptions{
@@ -1144,6 +1359,8 @@ type fakeRemovalSimulator struct {
 
 func (r *fakeRemovalSimulator) DropOldHints() {}
 
+func (r *fakeRemovalSimulator) MergeHints(_ *simulator.RemovalSimulator) {}
+
 func (r *fakeRemovalSimulator) SimulateNodeRemoval(name string, _ map[string]bool, _ time.Time, _ pdb.RemainingPdbTracker) (*simulator.NodeToBeRemoved, *simulator.UnremovableNode) {
 	time.Sleep(r.sleep)
 	node := &apiv1.Node{}
@@ -1154,3 +1371,7 @@ func (r *fakeRemovalSimulator) SimulateNodeRemoval(name string, _ map[string]boo
 	}
 	return &simulator.NodeToBeRemoved{Node: node}, nil
 }
+
+func (r *fakeRemovalSimulator) ConfirmNodeRemoval(removable *simulator.NodeToBeRemoved, _ map[string]bool, _ time.Time) (*simulator.NodeToBeRemoved, *simulator.UnremovableNode) {
+	return removable, nil
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recycling
+
+import (
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/budgets"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/eligibility"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/replacement"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	klog "k8s.io/klog/v2"
+)
+
+type unneededNodesLister interface {
+	UnneededNodes() []*apiv1.Node
+}
+
+type actuator interface {
+	StartDeletion(empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError)
+	CheckStatus() scaledown.ActuationStatus
+}
+
+// recycling is an expired node being replaced. The node is drained once a
+// replacement node is ready, or right away if no replacement was needed.
+type recycling struct {
+	nodeGroupId string
+	// existingNodes are the nodes of the node group at the time the replacement was requested.
+	existingNodes map[string]bool
+	// replacement is the name of the node which replaced the recycled one, if any.
+	replacement string
+	startTime   time.Time
+	// readyTime is the time at which the replacement node was found ready.
+	readyTime time.Time
+	draining  bool
+}
+
+// Recycler replaces nodes older than MaxNodeLifetime of their node group.
+// Expired nodes are eligible for the regular scale-down regardless of their
+// utilization, so the ones whose pods fit on other nodes are removed by it.
+// For the remaining ones, whose pods can't be placed elsewhere, the Recycler
+// brings up a replacement node first and drains the expired node once the
+// replacement is ready. The number of nodes recycled at the same time is
+// limited by MaxRecyclingParallelism. Replacement nodes are requested within
+// the same limits as a regular scale-up.
+type Recycler struct {
+	autoscalingCtx    *ca_context.AutoscalingContext
+	unneededNodes     unneededNodesLister
+	actuator          actuator
+	budgetProcessor   *budgets.ScaleDownBudgetProcessor
+	requester         *replacement.Requester
+	configGetter      nodegroupconfig.NodeGroupConfigProcessor
+	deleteOptions     options.NodeDeleteOptions
+	drainabilityRules rules.Rules
+	inFlight          map[string]*recycling
+}
+
+// NewRecycler creates a new Recycler object.
+func NewRecycler(autoscalingCtx *ca_context.AutoscalingContext, unneededNodes unneededNodesLister, actuator actuator, requester *replacement.Requester,
+	configGetter nodegroupconfig.NodeGroupConfigProcessor, deleteOptions options.NodeDeleteOptions, drainabilityRules rules.Rules) *Recycler {
+	return &Recycler{
+		autoscalingCtx:    autoscalingCtx,
+		unneededNodes:     unneededNodes,
+		actuator:          actuator,
+		budgetProcessor:   budgets.NewScaleDownBudgetProcessor(autoscalingCtx),
+		requester:         requester,
+		configGetter:      configGetter,
+		deleteOptions:     deleteOptions,
+		drainabilityRules: drainabilityRules,
+		inFlight:          make(map[string]*recycling),
+	}
+}
+
+// InFlight returns the names of nodes which are being recycled.
+func (r *Recycler) InFlight() []string {
+	names := make([]string, 0, len(r.inFlight))
+	for name := range r.inFlight {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FilterOutReplacements removes the replacement nodes of the ongoing recyclings
+// from the scale-down candidates. A replacement node stays empty until the
+// recycled node is drained, so the regular scale-down would remove it otherwise.
+// Until the replacement is found, all the nodes added to its node group are kept.
+func (r *Recycler) FilterOutReplacements(nodes []*apiv1.Node) []*apiv1.Node {
+	if len(r.inFlight) == 0 {
+		return nodes
+	}
+	inGroups := make(map[string]map[string]bool)
+	var result []*apiv1.Node
+	for _, node := range nodes {
+		if r.isReplacement(node, nodes, inGroups) {
+			klog.V(4).Infof("Recycling: node %s is a recycling replacement, not considering it for scale-down", node.Name)
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// isReplacement checks if the node is the replacement of an ongoing recycling, or
+// a node added to the node group of a recycling whose replacement wasn't found yet.
+// inGroups caches the nodes of each node group.
+func (r *Recycler) isReplacement(node *apiv1.Node, nodes []*apiv1.Node, inGroups map[string]map[string]bool) bool {
+	for _, rec := range r.inFlight {
+		if rec.replacement != "" {
+			if rec.replacement == node.Name {
+				return true
+			}
+			continue
+		}
+		inGroup, found := inGroups[rec.nodeGroupId]
+		if !found {
+			inGroup = replacement.NodesInGroup(r.autoscalingCtx.CloudProvider, nodes, rec.nodeGroupId)
+			inGroups[rec.nodeGroupId] = inGroup
+		}
+		if inGroup[node.Name] && !rec.existingNodes[node.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// RunOnce drains recycled nodes whose replacement is ready and requests
+// replacements for newly expired nodes, as long as the recycling budget allows.
+// allNodes are all registered nodes in the cluster and nodeInfosForGroups are
+// the template node infos of the node groups.
+func (r *Recycler) RunOnce(allNodes, scaleDownCandidates, podDestinations []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo, currentTime time.Time) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	toDrain := r.updateInFlight(allNodes, podDestinations, currentTime)
+
+	result, scaledDownNodes := status.ScaleDownNoNodeDeleted, []*status.ScaleDownNode(nil)
+	if len(toDrain) > 0 {
+		var err errors.AutoscalerError
+		result, scaledDownNodes, err = r.actuator.StartDeletion(nil, toDrain)
+		if err != nil {
+			return result, scaledDownNodes, err
+		}
+		for _, scaledDown := range scaledDownNodes {
+			scaledDown.Reason = status.ScaleDownRecycled
+			if rec, found := r.inFlight[scaledDown.Node.Name]; found {
+				klog.V(0).Infof("Recycling: replacement of node %s is ready, draining it", scaledDown.Node.Name)
+				rec.draining = true
+			}
+		}
+	}
+
+	for _, node := range r.candidates(scaleDownCandidates, podDestinations, currentTime) {
+		if err := r.requestReplacement(node, allNodes, nodeInfosForGroups, currentTime); err != nil {
+			return status.ScaleDownError, scaledDownNodes, err
+		}
+	}
+	return result, scaledDownNodes, nil
+}
+
+// updateInFlight forgets nodes which were removed, whose replacement didn't
+// show up in time or whose pods still don't fit on other nodes long after the
+// replacement became ready, and returns the nodes which are ready to be drained.
+func (r *Recycler) updateInFlight(allNodes, podDestinations []*apiv1.Node, currentTime time.Time) []*apiv1.Node {
+	nodesByName := make(map[string]*apiv1.Node, len(allNodes))
+	for _, node := range allNodes {
+		nodesByName[node.Name] = node
+	}
+	var toDrain []*apiv1.Node
+	for _, name := range r.InFlight() {
+		rec := r.inFlight[name]
+		node, found := nodesByName[name]
+		if !found {
+			delete(r.inFlight, name)
+			continue
+		}
+		if rec.draining {
+			if !actuation.IsNodeBeingDeleted(node, currentTime) {
+				klog.Warningf("Recycling: node %s is no longer being deleted, giving up on recycling it", name)
+				delete(r.inFlight, name)
+			}
+			continue
+		}
+		if rec.replacement != "" && nodesByName[rec.replacement] == nil {
+			klog.Warningf("Recycling: replacement node %s of %s is gone, looking for another one", rec.replacement, name)
+			rec.replacement = ""
+		}
+		if rec.replacement == "" {
+			rec.replacement = r.findReplacement(allNodes, rec)
+			if rec.replacement == "" {
+				if currentTime.Sub(rec.startTime) > r.maxNodeProvisionTime(rec.nodeGroupId) {
+					klog.Warningf("Recycling: replacement node in %s didn't become ready in time, giving up on recycling %s", rec.nodeGroupId, name)
+					delete(r.inFlight, name)
+				}
+				continue
+			}
+			rec.readyTime = currentTime
+		}
+		// The cluster might have changed since the replacement was requested, make sure the pods still fit.
+		if unremovable := r.simulateRemoval(node, podDestinations, currentTime); unremovable != nil {
+			if currentTime.Sub(rec.readyTime) > r.maxNodeProvisionTime(rec.nodeGroupId) {
+				klog.Warningf("Recycling: pods from node %s still don't fit on other nodes (reason %v), giving up on recycling it", name, unremovable.Reason)
+				delete(r.inFlight, name)
+				continue
+			}
+			klog.V(1).Infof("Recycling: pods from node %s don't fit on other nodes yet (reason %v)", name, unremovable.Reason)
+			continue
+		}
+		toDrain = append(toDrain, node)
+	}
+	return toDrain
+}
+
+// findReplacement returns a new, ready node from the node group which isn't replacing another recycled node.
+func (r *Recycler) findReplacement(allNodes []*apiv1.Node, rec *recycling) string {
+	claimed := make(map[string]bool)
+	for _, other := range r.inFlight {
+		if other.replacement != "" {
+			claimed[other.replacement] = true
+		}
+	}
+	for _, node := range allNodes {
+		if rec.existingNodes[node.Name] || claimed[node.Name] {
+			continue
+		}
+		nodeGroup, err := r.autoscalingCtx.CloudProvider.NodeGroupForNode(node)
+		if err != nil || !replacement.IsValid(nodeGroup) || nodeGroup.Id() != rec.nodeGroupId {
+			continue
+		}
+		if ready, _, _ := kube_util.GetReadinessState(node); ready {
+			return node.Name
+		}
+	}
+	return ""
+}
+
+// requestReplacement brings up a new node in the node group of the expired node.
+func (r *Recycler) requestReplacement(node *apiv1.Node, allNodes []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo, currentTime time.Time) errors.AutoscalerError {
+	nodeGroup, err := r.autoscalingCtx.CloudProvider.NodeGroupForNode(node)
+	if err != nil || !replacement.IsValid(nodeGroup) {
+		return nil
+	}
+	if err := r.requester.CanRequest(nodeGroup, allNodes, nodeInfosForGroups, currentTime); err != nil {
+		klog.V(1).Infof("Recycling: can't replace expired node %s: %v", node.Name, err)
+		return nil
+	}
+	if err := r.requester.Request(nodeGroup, nodeInfosForGroups[nodeGroup.Id()], currentTime); err != nil {
+		return err.AddPrefix("failed to request replacement of node %s in %s: ", node.Name, nodeGroup.Id())
+	}
+
+	r.inFlight[node.Name] = &recycling{
+		nodeGroupId:   nodeGroup.Id(),
+		existingNodes: replacement.NodesInGroup(r.autoscalingCtx.CloudProvider, allNodes, nodeGroup.Id()),
+		startTime:     currentTime,
+	}
+	klog.V(0).Infof("Recycling: node %s is older than max node lifetime, requested a replacement node in %s", node.Name, nodeGroup.Id())
+	r.autoscalingCtx.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaleDownRecycling",
+		"Recycling: replacing expired node %s with a new node from %s", node.Name, nodeGroup.Id())
+	return nil
+}
+
+// candidates returns expired nodes, starting from the oldest ones, which need
+// a replacement before they can be drained, cropped to the recycling budget.
+func (r *Recycler) candidates(scaleDownCandidates, podDestinations []*apiv1.Node, currentTime time.Time) []*apiv1.Node {
+	unneeded := replacement.AsMap(replacement.NodeNames(r.unneededNodes.UnneededNodes()))
+	var expired []*apiv1.Node
+	for _, node := range scaleDownCandidates {
+		if r.inFlight[node.Name] != nil || unneeded[node.Name] || eligibility.HasNoScaleDownAnnotation(node) || actuation.IsNodeBeingDeleted(node, currentTime) {
+			continue
+		}
+		if ready, _, _ := kube_util.GetReadinessState(node); !ready {
+			continue
+		}
+		nodeGroup, err := r.autoscalingCtx.CloudProvider.NodeGroupForNode(node)
+		if err != nil || !replacement.IsValid(nodeGroup) {
+			continue
+		}
+		maxNodeLifetime, err := r.configGetter.GetMaxNodeLifetime(nodeGroup)
+		if err != nil {
+			klog.Warningf("Couldn't retrieve `MaxNodeLifetime` option for node %v: %v", node.Name, err)
+			continue
+		}
+		if eligibility.IsNodeExpired(node, maxNodeLifetime, currentTime) {
+			expired = append(expired, node)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].CreationTimestamp.Before(&expired[j].CreationTimestamp)
+	})
+
+	var candidates []*apiv1.Node
+	for _, node := range expired {
+		unremovable := r.simulateRemoval(node, podDestinations, currentTime)
+		if unremovable == nil {
+			// Pods fit on other nodes, the regular scale-down will remove it.
+			continue
+		}
+		if unremovable.Reason != simulator.NoPlaceToMovePods {
+			klog.V(4).Infof("Recycling: expired node %s can't be removed (reason %v)", node.Name, unremovable.Reason)
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+	return r.budgetProcessor.CropRecycledNodes(r.actuator.CheckStatus(), len(r.inFlight), candidates)
+}
+
+// simulateRemoval checks if pods from the node fit on other destination nodes.
+func (r *Recycler) simulateRemoval(node *apiv1.Node, podDestinations []*apiv1.Node, currentTime time.Time) *simulator.UnremovableNode {
+	destinations := replacement.AsMap(replacement.NodeNames(podDestinations))
+	for name := range r.inFlight {
+		delete(destinations, name)
+	}
+	delete(destinations, node.Name)
+	rs := simulator.NewRemovalSimulator(r.autoscalingCtx.ListerRegistry, r.autoscalingCtx.ClusterSnapshot, r.deleteOptions, r.drainabilityRules, false)
+	_, unremovable := rs.SimulateNodeRemoval(node.Name, destinations, currentTime, r.autoscalingCtx.RemainingPdbTracker)
+	return unremovable
+}
+
+func (r *Recycler) maxNodeProvisionTime(nodeGroupId string) time.Duration {
+	for _, nodeGroup := range r.autoscalingCtx.CloudProvider.NodeGroups() {
+		if nodeGroup.Id() != nodeGroupId {
+			continue
+		}
+		if maxNodeProvisionTime, err := r.configGetter.GetMaxNodeProvisionTime(nodeGroup); err == nil {
+			return maxNodeProvisionTime
+		}
+	}
+	return r.autoscalingCtx.NodeGroupDefaults.MaxNodeProvisionTime
+}
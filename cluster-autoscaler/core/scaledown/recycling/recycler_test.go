@@ -0,0 +1,355 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recycling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/replacement"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	processorstest "k8s.io/autoscaler/cluster-autoscaler/processors/test"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeUnneededNodes struct {
+	nodes []*apiv1.Node
+}
+
+func (f *fakeUnneededNodes) UnneededNodes() []*apiv1.Node {
+	return f.nodes
+}
+
+type fakeActuator struct {
+	drained []string
+	tracker *deletiontracker.NodeDeletionTracker
+}
+
+func (f *fakeActuator) StartDeletion(empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	var scaledDown []*status.ScaleDownNode
+	for _, node := range append(empty, needDrain...) {
+		f.drained = append(f.drained, node.Name)
+		scaledDown = append(scaledDown, &status.ScaleDownNode{Node: node})
+	}
+	return status.ScaleDownNodeDeleteStarted, scaledDown, nil
+}
+
+func (f *fakeActuator) CheckStatus() scaledown.ActuationStatus {
+	return f.tracker
+}
+
+type fakeScaleUpSafety struct {
+	backedOff map[string]bool
+}
+
+func (f *fakeScaleUpSafety) NodeGroupScaleUpSafety(nodeGroup cloudprovider.NodeGroup, now time.Time) clusterstate.NodeGroupScalingSafety {
+	return clusterstate.NodeGroupScalingSafety{SafeToScale: !f.backedOff[nodeGroup.Id()], Healthy: true}
+}
+
+func buildNode(name string, age time.Duration, now time.Time) *apiv1.Node {
+	node := BuildTestNode(name, 1000, 8*1024*1024*1024)
+	node.CreationTimestamp = metav1.NewTime(now.Add(-age))
+	SetNodeReadyState(node, true, now.Add(-age))
+	return node
+}
+
+func buildPod(name string, nodeName string) *apiv1.Pod {
+	pod := BuildScheduledTestPod(name, 800, 1024*1024, nodeName)
+	return SetRSPodSpec(pod, "rs")
+}
+
+type recyclerTest struct {
+	recycler  *Recycler
+	actuator  *fakeActuator
+	safety    *fakeScaleUpSafety
+	provider  *testprovider.TestCloudProvider
+	scaledUp  map[string]int
+	snapshot  clustersnapshot.ClusterSnapshot
+	nodes     []*apiv1.Node
+	pods      []*apiv1.Pod
+	templates map[string]*framework.NodeInfo
+}
+
+func newRecyclerTest(t *testing.T, maxRecyclingParallelism int, now time.Time) *recyclerTest {
+	rt := &recyclerTest{
+		actuator: &fakeActuator{tracker: deletiontracker.NewNodeDeletionTracker(time.Hour)},
+		safety:   &fakeScaleUpSafety{},
+		scaledUp: make(map[string]int),
+		nodes: []*apiv1.Node{
+			buildNode("n1", 72*time.Hour, now),
+			buildNode("n2", 48*time.Hour, now),
+			buildNode("n3", time.Hour, now),
+		},
+		pods: []*apiv1.Pod{
+			buildPod("p1", "n1"),
+			buildPod("p2", "n2"),
+			buildPod("p3", "n3"),
+		},
+	}
+	rt.templates = map[string]*framework.NodeInfo{
+		"ng": framework.NewTestNodeInfo(buildNode("template", time.Hour, now)),
+	}
+	rt.provider = testprovider.NewTestCloudProviderBuilder().WithOnScaleUp(func(id string, delta int) error {
+		rt.scaledUp[id] += delta
+		return nil
+	}).Build()
+	rt.provider.AddNodeGroup("ng", 0, 10, 3)
+	for _, node := range rt.nodes {
+		rt.provider.AddNode("ng", node)
+	}
+
+	replicas := int32(3)
+	rsLister, err := kube_util.NewTestReplicaSetLister([]*appsv1.ReplicaSet{{
+		ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "default"},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: &replicas},
+	}})
+	assert.NoError(t, err)
+	registry := kube_util.NewListerRegistry(nil, nil, nil, nil, nil, nil, nil, rsLister, nil)
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+			MaxNodeProvisionTime: 15 * time.Minute,
+			MaxNodeLifetime:      24 * time.Hour,
+		},
+		MaxScaleDownParallelism: 10,
+		MaxDrainParallelism:     10,
+		MaxRecyclingParallelism: maxRecyclingParallelism,
+	}, &fake.Clientset{}, registry, rt.provider, nil, nil)
+	assert.NoError(t, err)
+	rt.snapshot = autoscalingCtx.ClusterSnapshot
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, rt.snapshot, rt.nodes, rt.pods)
+
+	processors := processorstest.NewTestProcessors(&autoscalingCtx)
+	rt.recycler = NewRecycler(&autoscalingCtx, &fakeUnneededNodes{}, rt.actuator,
+		replacement.NewRequester(&autoscalingCtx, rt.safety, processors.ScaleStateNotifier, processors.CustomResourcesProcessor), processors.NodeGroupConfigProcessor, options.NodeDeleteOptions{}, nil)
+	return rt
+}
+
+func (rt *recyclerTest) runOnce(t *testing.T, now time.Time) status.ScaleDownResult {
+	result, scaledDownNodes, err := rt.recycler.RunOnce(rt.nodes, rt.nodes, rt.nodes, rt.templates, now)
+	assert.NoError(t, err)
+	for _, scaledDown := range scaledDownNodes {
+		assert.Equal(t, status.ScaleDownRecycled, scaledDown.Reason)
+	}
+	return result
+}
+
+func (rt *recyclerTest) addNode(t *testing.T, node *apiv1.Node) {
+	rt.provider.AddNode("ng", node)
+	rt.nodes = append(rt.nodes, node)
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, rt.snapshot, rt.nodes, rt.pods)
+}
+
+func TestRecycling(t *testing.T) {
+	now := time.Now()
+	rt := newRecyclerTest(t, 1, now)
+
+	// Only the oldest expired node is recycled because of the budget.
+	assert.Equal(t, status.ScaleDownNoNodeDeleted, rt.runOnce(t, now))
+	assert.Equal(t, map[string]int{"ng": 1}, rt.scaledUp)
+	assert.Equal(t, []string{"n1"}, rt.recycler.InFlight())
+
+	// The replacement node is not ready yet.
+	now = now.Add(time.Minute)
+	replacement := buildNode("n4", 0, now)
+	SetNodeReadyState(replacement, false, now)
+	rt.addNode(t, replacement)
+	assert.Equal(t, status.ScaleDownNoNodeDeleted, rt.runOnce(t, now))
+	assert.Empty(t, rt.actuator.drained)
+	assert.Equal(t, map[string]int{"ng": 1}, rt.scaledUp)
+
+	replacement.Spec.Taints = nil
+	SetNodeReadyState(replacement, true, now)
+	assert.Equal(t, status.ScaleDownNodeDeleteStarted, rt.runOnce(t, now))
+	assert.Equal(t, []string{"n1"}, rt.actuator.drained)
+
+	// Once the recycled node is gone, the next expired node is recycled.
+	rt.nodes = rt.nodes[1:]
+	rt.pods = rt.pods[1:]
+	rt.pods = append(rt.pods, buildPod("p1", "n4"))
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, rt.snapshot, rt.nodes, rt.pods)
+	rt.runOnce(t, now)
+	assert.Equal(t, []string{"n2"}, rt.recycler.InFlight())
+	assert.Equal(t, map[string]int{"ng": 2}, rt.scaledUp)
+}
+
+func TestRecyclingReplacementTimeout(t *testing.T) {
+	now := time.Now()
+	rt := newRecyclerTest(t, 1, now)
+
+	rt.runOnce(t, now)
+	assert.Equal(t, []string{"n1"}, rt.recycler.InFlight())
+	rt.recycler.RunOnce(rt.nodes, nil, rt.nodes, rt.templates, now.Add(16*time.Minute))
+	assert.Empty(t, rt.recycler.InFlight())
+	assert.Empty(t, rt.actuator.drained)
+}
+
+func TestRecyclingPodsDontFitTimeout(t *testing.T) {
+	now := time.Now()
+	rt := newRecyclerTest(t, 1, now)
+
+	rt.runOnce(t, now)
+	assert.Equal(t, []string{"n1"}, rt.recycler.InFlight())
+
+	// The replacement is ready, but pods from the recycled node don't fit on it.
+	rt.pods = append(rt.pods, buildPod("p4", "n4"))
+	rt.addNode(t, buildNode("n4", 0, now))
+	assert.Equal(t, status.ScaleDownNoNodeDeleted, rt.runOnce(t, now.Add(time.Minute)))
+	assert.Equal(t, []string{"n1"}, rt.recycler.InFlight())
+	assert.Equal(t, "n4", rt.recycler.inFlight["n1"].replacement)
+
+	// After giving up, the node is recycled from scratch with a new replacement.
+	rt.runOnce(t, now.Add(17*time.Minute))
+	assert.Empty(t, rt.actuator.drained)
+	assert.Equal(t, map[string]int{"ng": 2}, rt.scaledUp)
+	assert.Equal(t, "", rt.recycler.inFlight["n1"].replacement)
+	assert.True(t, rt.recycler.inFlight["n1"].existingNodes["n4"])
+}
+
+func TestRecyclingReplacementRemoved(t *testing.T) {
+	now := time.Now()
+	rt := newRecyclerTest(t, 1, now)
+
+	rt.runOnce(t, now)
+	rt.pods = append(rt.pods, buildPod("p4", "n4"))
+	rt.addNode(t, buildNode("n4", 0, now))
+	rt.runOnce(t, now)
+	assert.Equal(t, "n4", rt.recycler.inFlight["n1"].replacement)
+
+	// The replacement is removed and another node from the node group shows up.
+	rt.nodes = rt.nodes[:3]
+	rt.pods = rt.pods[:3]
+	rt.addNode(t, buildNode("n5", 0, now))
+	assert.Equal(t, status.ScaleDownNodeDeleteStarted, rt.runOnce(t, now.Add(time.Minute)))
+	assert.Equal(t, []string{"n1"}, rt.actuator.drained)
+	assert.Equal(t, "n5", rt.recycler.inFlight["n1"].replacement)
+}
+
+func TestRecyclingFilterOutReplacements(t *testing.T) {
+	now := time.Now()
+	rt := newRecyclerTest(t, 1, now)
+	assert.Len(t, rt.recycler.FilterOutReplacements(rt.nodes), 3)
+
+	// Until the replacement is found, all nodes added to the node group are kept.
+	rt.runOnce(t, now)
+	notReady := buildNode("n4", 0, now)
+	SetNodeReadyState(notReady, false, now)
+	rt.addNode(t, notReady)
+	assert.Equal(t, []string{"n1", "n2", "n3"}, replacement.NodeNames(rt.recycler.FilterOutReplacements(rt.nodes)))
+
+	// Once it's found, only the replacement is.
+	notReady.Spec.Taints = nil
+	SetNodeReadyState(notReady, true, now)
+	rt.pods = append(rt.pods, buildPod("p4", "n4"))
+	rt.addNode(t, buildNode("n5", 0, now))
+	rt.runOnce(t, now)
+	assert.Equal(t, "n4", rt.recycler.inFlight["n1"].replacement)
+	assert.Equal(t, []string{"n1", "n2", "n3", "n5"}, replacement.NodeNames(rt.recycler.FilterOutReplacements(rt.nodes)))
+}
+
+func TestRecyclingCandidates(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		maxRecyclingParallelism int
+		freeNode                bool
+		unneeded                []string
+		backedOff               bool
+		maxNodesTotal           int
+		wantInFlight            []string
+	}{
+		{
+			name:                    "expired nodes are recycled oldest first",
+			maxRecyclingParallelism: 5,
+			wantInFlight:            []string{"n1", "n2"},
+		},
+		{
+			name:                    "recycling budget is respected",
+			maxRecyclingParallelism: 1,
+			wantInFlight:            []string{"n1"},
+		},
+		{
+			name:                    "recycling disabled",
+			maxRecyclingParallelism: 0,
+			wantInFlight:            []string{},
+		},
+		{
+			name:                    "node group backed off",
+			maxRecyclingParallelism: 5,
+			backedOff:               true,
+			wantInFlight:            []string{},
+		},
+		{
+			name:                    "max nodes total respected",
+			maxRecyclingParallelism: 5,
+			maxNodesTotal:           4,
+			wantInFlight:            []string{"n1"},
+		},
+		{
+			name:                    "unneeded nodes are left to scale-down",
+			maxRecyclingParallelism: 5,
+			unneeded:                []string{"n1"},
+			wantInFlight:            []string{"n2"},
+		},
+		{
+			name:                    "nodes whose pods fit elsewhere are left to scale-down",
+			maxRecyclingParallelism: 5,
+			freeNode:                true,
+			wantInFlight:            []string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+			rt := newRecyclerTest(t, tc.maxRecyclingParallelism, now)
+			if tc.freeNode {
+				rt.addNode(t, buildNode("free-1", time.Hour, now))
+				rt.addNode(t, buildNode("free-2", time.Hour, now))
+			}
+			unneeded := &fakeUnneededNodes{}
+			for _, node := range rt.nodes {
+				for _, name := range tc.unneeded {
+					if node.Name == name {
+						unneeded.nodes = append(unneeded.nodes, node)
+					}
+				}
+			}
+			rt.recycler.unneededNodes = unneeded
+			rt.safety.backedOff = map[string]bool{"ng": tc.backedOff}
+			rt.recycler.autoscalingCtx.MaxNodesTotal = tc.maxNodesTotal
+
+			rt.runOnce(t, now)
+			assert.Equal(t, tc.wantInFlight, rt.recycler.InFlight())
+			assert.Equal(t, len(tc.wantInFlight), rt.scaledUp["ng"])
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replacement contains logic shared by the scale-down components which
+// bring up a new node before draining the nodes it replaces.
+package replacement
+
+import (
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/customresources"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+)
+
+// ScaleUpSafetyChecker tells if a node group can be scaled up, e.g. it isn't backed off.
+type ScaleUpSafetyChecker interface {
+	NodeGroupScaleUpSafety(nodeGroup cloudprovider.NodeGroup, now time.Time) clusterstate.NodeGroupScalingSafety
+}
+
+// Requester requests single replacement nodes, respecting the same limits as
+// the regular scale-up: node group max size, scale-up backoff, MaxNodesTotal
+// and the cluster-wide resource limits.
+type Requester struct {
+	autoscalingCtx     *ca_context.AutoscalingContext
+	scaleUpSafety      ScaleUpSafetyChecker
+	scaleStateNotifier nodegroupchange.NodeGroupChangeObserver
+	resourceManager    *resource.Manager
+}
+
+// NewRequester creates a new Requester object.
+func NewRequester(autoscalingCtx *ca_context.AutoscalingContext, scaleUpSafety ScaleUpSafetyChecker, scaleStateNotifier nodegroupchange.NodeGroupChangeObserver,
+	customResourcesProcessor customresources.CustomResourcesProcessor) *Requester {
+	return &Requester{
+		autoscalingCtx:     autoscalingCtx,
+		scaleUpSafety:      scaleUpSafety,
+		scaleStateNotifier: scaleStateNotifier,
+		resourceManager:    resource.NewManager(customResourcesProcessor),
+	}
+}
+
+// CanRequest returns nil if a replacement node can be added to the node group
+// now, otherwise a reason why it can't. allNodes are all registered nodes in
+// the cluster and nodeInfos are the template node infos of the node groups.
+func (r *Requester) CanRequest(nodeGroup cloudprovider.NodeGroup, allNodes []*apiv1.Node, nodeInfos map[string]*framework.NodeInfo, now time.Time) errors.AutoscalerError {
+	targetSize, err := nodeGroup.TargetSize()
+	if err != nil {
+		return errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to get target size of %s: ", nodeGroup.Id())
+	}
+	if targetSize >= nodeGroup.MaxSize() {
+		return errors.NewAutoscalerErrorf(errors.TransientError, "node group %s is at max size", nodeGroup.Id())
+	}
+	if safety := r.scaleUpSafety.NodeGroupScaleUpSafety(nodeGroup, now); !safety.SafeToScale {
+		if !safety.Healthy {
+			return errors.NewAutoscalerErrorf(errors.TransientError, "node group %s is unhealthy", nodeGroup.Id())
+		}
+		return errors.NewAutoscalerErrorf(errors.TransientError, "node group %s is backed off: %v", nodeGroup.Id(), safety.BackoffStatus.ErrorInfo.ErrorMessage)
+	}
+	if maxNodesTotal := r.autoscalingCtx.MaxNodesTotal; maxNodesTotal > 0 && r.currentNodeCount(allNodes)+1 > maxNodesTotal {
+		return errors.NewAutoscalerErrorf(errors.TransientError, "max node total count %d already reached", maxNodesTotal)
+	}
+	nodeInfo, found := nodeInfos[nodeGroup.Id()]
+	if !found {
+		return errors.NewAutoscalerErrorf(errors.InternalError, "no node info for %s", nodeGroup.Id())
+	}
+	resourcesLeft, aErr := r.resourceManager.ResourcesLeft(r.autoscalingCtx, nodeInfos, allNodes)
+	if aErr != nil {
+		return aErr.AddPrefix("could not compute total resources: ")
+	}
+	delta, aErr := r.resourceManager.DeltaForNode(r.autoscalingCtx, nodeInfo, nodeGroup)
+	if aErr != nil {
+		return aErr.AddPrefix("failed to get node resources of %s: ", nodeGroup.Id())
+	}
+	if check := resource.CheckDeltaWithinLimits(resourcesLeft, delta); check.Exceeded {
+		return errors.NewAutoscalerErrorf(errors.TransientError, "max cluster limits reached for resources %v", check.ExceededResources)
+	}
+	return nil
+}
+
+// Request increases the size of the node group by one node and registers the
+// scale-up, or the failed scale-up so that the node group gets backed off.
+// CanRequest should be checked first.
+func (r *Requester) Request(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, now time.Time) errors.AutoscalerError {
+	var gpuResourceName, gpuType string
+	if nodeInfo != nil {
+		gpuConfig := r.autoscalingCtx.CloudProvider.GetNodeGpuConfig(nodeInfo.Node())
+		gpuResourceName, gpuType = gpu.GetGpuInfoForMetrics(gpuConfig, r.autoscalingCtx.CloudProvider.GetAvailableGPUTypes(), nodeInfo.Node(), nil)
+	}
+	if err := nodeGroup.IncreaseSize(1); err != nil {
+		aErr := errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to increase node group size: ")
+		r.autoscalingCtx.LogRecorder.Eventf(apiv1.EventTypeWarning, "FailedToScaleUpGroup", "Scale-up failed for group %s: %v", nodeGroup.Id(), err)
+		r.scaleStateNotifier.RegisterFailedScaleUp(nodeGroup, string(aErr.Type()), aErr.Error(), gpuResourceName, gpuType, now)
+		return aErr
+	}
+	r.scaleStateNotifier.RegisterScaleUp(nodeGroup, 1, now)
+	metrics.RegisterScaleUp(1, gpuResourceName, gpuType)
+	return nil
+}
+
+// currentNodeCount returns the number of registered nodes plus the nodes which
+// were requested but didn't register yet, the same way the scale-up counts them.
+func (r *Requester) currentNodeCount(allNodes []*apiv1.Node) int {
+	count := len(allNodes)
+	registered := make(map[string]int)
+	for _, node := range allNodes {
+		if nodeGroup, err := r.autoscalingCtx.CloudProvider.NodeGroupForNode(node); err == nil && IsValid(nodeGroup) {
+			registered[nodeGroup.Id()]++
+		}
+	}
+	for _, nodeGroup := range r.autoscalingCtx.CloudProvider.NodeGroups() {
+		if targetSize, err := nodeGroup.TargetSize(); err == nil && targetSize > registered[nodeGroup.Id()] {
+			count += targetSize - registered[nodeGroup.Id()]
+		}
+	}
+	return count
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replacement
+
+import (
+	"reflect"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+)
+
+// IsValid checks if the node group returned by the cloud provider is a non-nil value.
+func IsValid(nodeGroup cloudprovider.NodeGroup) bool {
+	return nodeGroup != nil && !reflect.ValueOf(nodeGroup).IsNil()
+}
+
+// NodesInGroup returns the names of the given nodes which belong to the node group.
+func NodesInGroup(cloudProvider cloudprovider.CloudProvider, nodes []*apiv1.Node, nodeGroupId string) map[string]bool {
+	result := make(map[string]bool)
+	for _, node := range nodes {
+		if nodeGroup, err := cloudProvider.NodeGroupForNode(node); err == nil && IsValid(nodeGroup) && nodeGroup.Id() == nodeGroupId {
+			result[node.Name] = true
+		}
+	}
+	return result
+}
+
+// AsMap converts a list of strings into a set.
+func AsMap(strs []string) map[string]bool {
+	m := make(map[string]bool, len(strs))
+	for _, s := range strs {
+		m[s] = true
+	}
+	return m
+}
+
+// NodeNames returns the names of the nodes.
+func NodeNames(nodes []*apiv1.Node) []string {
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = node.Name
+	}
+	return names
+}
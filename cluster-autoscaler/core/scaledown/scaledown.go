@@ -47,6 +47,12 @@ type Planner interface {
 	// NodeUtilizationMap returns information about utilization of
 	// individual cluster nodes.
 	NodeUtilizationMap() map[string]utilization.Info
+	// UnneededSince returns the time since which a given node is unneeded,
+	// or false if the node isn't unneeded.
+	UnneededSince(nodeName string) (time.Time, bool)
+	// RemovalReason returns why a node returned by the last NodesToDelete
+	// call should be removed.
+	RemovalReason(nodeName string) status.ScaleDownReason
 }
 
 // Actuator is responsible for making changes in the cluster: draining and
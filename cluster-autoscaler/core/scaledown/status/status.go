@@ -24,6 +24,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/klog/v2"
 )
 
@@ -35,6 +36,7 @@ type ScaleDownStatus struct {
 	RemovedNodeGroups     []cloudprovider.NodeGroup
 	NodeDeleteResults     map[string]NodeDeleteResult
 	NodeDeleteResultsAsOf time.Time
+	ScaleDownError        *errors.AutoscalerError
 }
 
 // SetUnremovableNodesInfo sets the status of nodes that were found to be unremovable.
@@ -79,8 +81,27 @@ type ScaleDownNode struct {
 	NodeGroup   cloudprovider.NodeGroup
 	EvictedPods []*apiv1.Pod
 	UtilInfo    utilization.Info
+	Reason      ScaleDownReason
 }
 
+// ScaleDownReason describes why a node is scaled down.
+type ScaleDownReason string
+
+const (
+	// ScaleDownEmpty - the node had no pods to move.
+	ScaleDownEmpty ScaleDownReason = "Empty"
+	// ScaleDownUnderutilized - the node was unneeded and its pods fit on other nodes.
+	ScaleDownUnderutilized ScaleDownReason = "Underutilized"
+	// ScaleDownUnready - the node was unready for long enough and its pods fit on other nodes.
+	ScaleDownUnready ScaleDownReason = "Unready"
+	// ScaleDownExpired - the node was older than its max node lifetime and its pods fit on other nodes.
+	ScaleDownExpired ScaleDownReason = "Expired"
+	// ScaleDownConsolidated - the node was replaced by a cheaper node as part of a consolidation.
+	ScaleDownConsolidated ScaleDownReason = "Consolidated"
+	// ScaleDownRecycled - the node was replaced by a fresh node because it got too old.
+	ScaleDownRecycled ScaleDownReason = "Recycled"
+)
+
 // ScaleDownResult represents the result of scale down.
 type ScaleDownResult int
 
@@ -55,6 +55,7 @@ type scaleDownTimeGetter interface {
 	GetScaleDownUnneededTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
 	// GetScaleDownUnreadyTime returns ScaleDownUnreadyTime value that should be used for a given NodeGroup.
 	GetScaleDownUnreadyTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
+	scaledown.WindowsGetter
 }
 
 // NewNodes returns a new initialized Nodes object.
@@ -166,6 +167,15 @@ func (n *Nodes) Contains(nodeName string) bool {
 	return found
 }
 
+// UnneededSince returns the time since which a given node is unneeded.
+func (n *Nodes) UnneededSince(nodeName string) (time.Time, bool) {
+	v, found := n.byName[nodeName]
+	if !found {
+		return time.Time{}, false
+	}
+	return v.since, true
+}
+
 // AsList returns a slice of unneeded Node objects.
 func (n *Nodes) AsList() []*apiv1.Node {
 	if n.cachedList == nil {
@@ -250,7 +260,18 @@ func (n *Nodes) unremovableReason(autoscalingCtx *ca_context.AutoscalingContext,
 		}
 	}
 
-	if reason := verifyMinSize(node.Name, nodeGroup, nodeGroupSize, scaleDownContext.ActuationStatus); reason != simulator.NoReason {
+	empty := len(v.ntbr.PodsToReschedule) == 0
+	outsideWindow, err := scaledown.IsOutsideScaleDownWindow(n.sdtg, nodeGroup, empty, autoscalingCtx.ScaleDownEmptyOutsideMaintenanceWindows, ts)
+	if err != nil {
+		klog.Errorf("Error trying to get scale-down windows for node %s (in group: %s)", node.Name, nodeGroup.Id())
+		return simulator.UnexpectedError
+	}
+	if outsideWindow {
+		klog.V(4).Infof("Skipping %s - node group %s is outside of a scale-down window", node.Name, nodeGroup.Id())
+		return simulator.OutsideScaleDownWindow
+	}
+
+	if reason := verifyMinSize(node.Name, nodeGroup, autoscalingCtx.ScheduledMinCapacity.MinSize(nodeGroup), nodeGroupSize, scaleDownContext.ActuationStatus); reason != simulator.NoReason {
 		return reason
 	}
 
@@ -293,14 +314,14 @@ func (n *Nodes) splitEmptyAndNonEmptyNodes() (empty, needDrain map[string]*node)
 	return
 }
 
-func verifyMinSize(nodeName string, nodeGroup cloudprovider.NodeGroup, nodeGroupSize map[string]int, as scaledown.ActuationStatus) simulator.UnremovableReason {
+func verifyMinSize(nodeName string, nodeGroup cloudprovider.NodeGroup, minSize int, nodeGroupSize map[string]int, as scaledown.ActuationStatus) simulator.UnremovableReason {
 	size, found := nodeGroupSize[nodeGroup.Id()]
 	if !found {
 		klog.Errorf("Error while checking node group size %s: group size not found in cache", nodeGroup.Id())
 		return simulator.UnexpectedError
 	}
 	deletionsInProgress := as.DeletionsCount(nodeGroup.Id())
-	if size-deletionsInProgress <= nodeGroup.MinSize() {
+	if size-deletionsInProgress <= minSize {
 		klog.V(1).Infof("Skipping %s - node group min size reached", nodeName)
 		return simulator.NodeGroupMinSizeReached
 	}
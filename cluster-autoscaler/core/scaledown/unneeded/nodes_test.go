@@ -34,6 +34,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/client-go/kubernetes/fake"
@@ -139,6 +140,10 @@ func TestRemovableAt(t *testing.T) {
 		numOngoingDeletions int
 		numEmptyToRemove    int
 		numDrainToRemove    int
+		// maintenanceWindow and blackoutWindow are either "open", "closed" or empty.
+		maintenanceWindow              string
+		blackoutWindow                 string
+		emptyOutsideMaintenanceWindows bool
 	}{
 		{
 			name:                "Node group min size is not reached",
@@ -170,6 +175,59 @@ func TestRemovableAt(t *testing.T) {
 			numEmptyToRemove:    2,
 			numDrainToRemove:    0,
 		},
+		{
+			name:              "Inside of maintenance window",
+			numEmpty:          3,
+			numDrain:          2,
+			minSize:           1,
+			targetSize:        10,
+			maintenanceWindow: "open",
+			numEmptyToRemove:  3,
+			numDrainToRemove:  2,
+		},
+		{
+			name:              "Outside of maintenance window",
+			numEmpty:          3,
+			numDrain:          2,
+			minSize:           1,
+			targetSize:        10,
+			maintenanceWindow: "closed",
+			numEmptyToRemove:  0,
+			numDrainToRemove:  0,
+		},
+		{
+			name:                           "Outside of maintenance window, empty nodes allowed",
+			numEmpty:                       3,
+			numDrain:                       2,
+			minSize:                        1,
+			targetSize:                     10,
+			maintenanceWindow:              "closed",
+			emptyOutsideMaintenanceWindows: true,
+			numEmptyToRemove:               3,
+			numDrainToRemove:               0,
+		},
+		{
+			name:                           "Inside of blackout window",
+			numEmpty:                       3,
+			numDrain:                       2,
+			minSize:                        1,
+			targetSize:                     10,
+			maintenanceWindow:              "open",
+			blackoutWindow:                 "open",
+			emptyOutsideMaintenanceWindows: true,
+			numEmptyToRemove:               0,
+			numDrainToRemove:               0,
+		},
+		{
+			name:             "Outside of blackout window",
+			numEmpty:         3,
+			numDrain:         2,
+			minSize:          1,
+			targetSize:       10,
+			blackoutWindow:   "closed",
+			numEmptyToRemove: 3,
+			numDrainToRemove: 2,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -200,12 +258,18 @@ func TestRemovableAt(t *testing.T) {
 			rsLister, err := kube_util.NewTestReplicaSetLister(nil)
 			assert.NoError(t, err)
 			registry := kube_util.NewListerRegistry(nil, nil, nil, nil, nil, nil, nil, rsLister, nil)
-			autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{ScaleDownSimulationTimeout: 5 * time.Minute}, &fake.Clientset{}, registry, provider, nil, nil)
+			autoscalingCtx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+				ScaleDownSimulationTimeout:              5 * time.Minute,
+				ScaleDownEmptyOutsideMaintenanceWindows: tc.emptyOutsideMaintenanceWindows,
+			}, &fake.Clientset{}, registry, provider, nil, nil)
 			assert.NoError(t, err)
 
-			n := NewNodes(&fakeScaleDownTimeGetter{}, &resource.LimitsFinder{})
+			n := NewNodes(&fakeScaleDownTimeGetter{
+				maintenanceWindows: testWindows(t, tc.maintenanceWindow),
+				blackoutWindows:    testWindows(t, tc.blackoutWindow),
+			}, &resource.LimitsFinder{})
 			n.Update(removableNodes, time.Now())
-			gotEmptyToRemove, gotDrainToRemove, _ := n.RemovableAt(&autoscalingCtx, nodeprocessors.ScaleDownContext{
+			gotEmptyToRemove, gotDrainToRemove, gotUnremovable := n.RemovableAt(&autoscalingCtx, nodeprocessors.ScaleDownContext{
 				ActuationStatus:     as,
 				ResourcesLeft:       resource.Limits{},
 				ResourcesWithLimits: []string{},
@@ -213,6 +277,12 @@ func TestRemovableAt(t *testing.T) {
 			if len(gotDrainToRemove) != tc.numDrainToRemove || len(gotEmptyToRemove) != tc.numEmptyToRemove {
 				t.Errorf("%s: getNodesToRemove() return %d, %d, want %d, %d", tc.name, len(gotEmptyToRemove), len(gotDrainToRemove), tc.numEmptyToRemove, tc.numDrainToRemove)
 			}
+			if tc.maintenanceWindow == "closed" || tc.blackoutWindow == "open" {
+				assert.Len(t, gotUnremovable, tc.numEmpty+tc.numDrain-tc.numEmptyToRemove-tc.numDrainToRemove)
+				for _, u := range gotUnremovable {
+					assert.Equal(t, simulator.OutsideScaleDownWindow, u.Reason)
+				}
+			}
 		})
 	}
 }
@@ -330,7 +400,26 @@ func (f *fakeActuationStatus) DeletionsCount(nodeGroup string) int {
 	return f.deletionCount[nodeGroup]
 }
 
-type fakeScaleDownTimeGetter struct{}
+// testWindows returns a window which is always open, a window which is never open, or no windows at all.
+func testWindows(t *testing.T, state string) []schedule.Window {
+	var spec string
+	switch state {
+	case "open":
+		spec = "* * * * * 1h"
+	case "closed":
+		spec = "0 0 30 2 * 1h"
+	default:
+		return nil
+	}
+	w, err := schedule.ParseWindow(spec)
+	assert.NoError(t, err)
+	return []schedule.Window{w}
+}
+
+type fakeScaleDownTimeGetter struct {
+	maintenanceWindows []schedule.Window
+	blackoutWindows    []schedule.Window
+}
 
 func (f *fakeScaleDownTimeGetter) GetScaleDownUnneededTime(cloudprovider.NodeGroup) (time.Duration, error) {
 	return 0 * time.Second, nil
@@ -339,3 +428,11 @@ func (f *fakeScaleDownTimeGetter) GetScaleDownUnneededTime(cloudprovider.NodeGro
 func (f *fakeScaleDownTimeGetter) GetScaleDownUnreadyTime(cloudprovider.NodeGroup) (time.Duration, error) {
 	return 0 * time.Second, nil
 }
+
+func (f *fakeScaleDownTimeGetter) GetScaleDownMaintenanceWindows(cloudprovider.NodeGroup) ([]schedule.Window, error) {
+	return f.maintenanceWindows, nil
+}
+
+func (f *fakeScaleDownTimeGetter) GetScaleDownBlackoutWindows(cloudprovider.NodeGroup) ([]schedule.Window, error) {
+	return f.blackoutWindows, nil
+}
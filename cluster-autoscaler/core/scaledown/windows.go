@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaledown
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
+)
+
+// WindowsGetter provides scale-down maintenance and blackout windows of a NodeGroup.
+type WindowsGetter interface {
+	// GetScaleDownMaintenanceWindows returns ScaleDownMaintenanceWindows value that should be used for a given NodeGroup.
+	GetScaleDownMaintenanceWindows(nodeGroup cloudprovider.NodeGroup) ([]schedule.Window, error)
+	// GetScaleDownBlackoutWindows returns ScaleDownBlackoutWindows value that should be used for a given NodeGroup.
+	GetScaleDownBlackoutWindows(nodeGroup cloudprovider.NodeGroup) ([]schedule.Window, error)
+}
+
+// IsOutsideScaleDownWindow checks whether nodes from the node group can't be
+// scaled down at the given time: either a blackout window is active, or
+// maintenance windows are configured and none of them is active. Empty nodes
+// are only subject to blackout windows if emptyOutsideMaintenanceWindows is set.
+func IsOutsideScaleDownWindow(wg WindowsGetter, nodeGroup cloudprovider.NodeGroup, empty, emptyOutsideMaintenanceWindows bool, ts time.Time) (bool, error) {
+	blackoutWindows, err := wg.GetScaleDownBlackoutWindows(nodeGroup)
+	if err != nil {
+		return false, err
+	}
+	if schedule.AnyActive(blackoutWindows, ts) {
+		return true, nil
+	}
+	if empty && emptyOutsideMaintenanceWindows {
+		return false, nil
+	}
+	maintenanceWindows, err := wg.GetScaleDownMaintenanceWindows(nodeGroup)
+	if err != nil {
+		return false, err
+	}
+	return len(maintenanceWindows) > 0 && !schedule.AnyActive(maintenanceWindows, ts), nil
+}
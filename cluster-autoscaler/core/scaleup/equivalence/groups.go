@@ -26,6 +26,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
 	pod_utils "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/podgroup"
 )
 
 // PodGroup contains a group of pods that are equivalent in terms of schedulability.
@@ -34,6 +35,8 @@ type PodGroup struct {
 	SchedulingErrors  map[string]status.Reasons
 	SchedulableGroups []string
 	Schedulable       bool
+	// PodGroupName is the name of the pod group (gang) of the pods, if any.
+	PodGroupName string
 }
 
 // BuildPodGroups prepares pod groups with equivalent scheduling properties.
@@ -49,6 +52,32 @@ func BuildPodGroups(pods []*apiv1.Pod) []*PodGroup {
 	return podEquivalenceGroups
 }
 
+// SplitByPodGroup splits pod groups so that pods of every resulting group
+// belong to the same pod group (gang), as recognized by the detector.
+func SplitByPodGroup(egs []*PodGroup, detector *podgroup.Detector) []*PodGroup {
+	if detector == nil {
+		return egs
+	}
+	result := make([]*PodGroup, 0, len(egs))
+	for _, eg := range egs {
+		byName := map[string]*PodGroup{}
+		for _, pod := range eg.Pods {
+			name := detector.PodGroup(pod)
+			split, found := byName[name]
+			if !found {
+				split = &PodGroup{
+					SchedulingErrors: map[string]status.Reasons{},
+					PodGroupName:     name,
+				}
+				byName[name] = split
+				result = append(result, split)
+			}
+			split.Pods = append(split.Pods, pod)
+		}
+	}
+	return result
+}
+
 type equivalenceGroupId int
 type equivalenceGroup struct {
 	id           equivalenceGroupId
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	"k8s.io/autoscaler/cluster-autoscaler/utils/podgroup"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -184,3 +185,35 @@ func TestEquivalenceGroupIgnoresDaemonSets(t *testing.T) {
 	podGroups := groupPodsBySchedulingProperties(pods)
 	assert.Equal(t, 2, len(podGroups))
 }
+
+func TestSplitByPodGroup(t *testing.T) {
+	job := apiv1.ReplicationController{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job",
+			Namespace: "default",
+			SelfLink:  "api/v1/namespaces/default/replicationcontrollers/job",
+			UID:       "12345678-1234-1234-1234-123456789012",
+		},
+	}
+	pods := make([]*apiv1.Pod, 0, 5)
+	for i, name := range []string{"a", "a", "b", "", "a"} {
+		p := BuildTestPod(fmt.Sprintf("p%d", i), 3000, 200000)
+		p.OwnerReferences = GenerateOwnerReferences(job.Name, "ReplicationController", "extensions/v1beta1", job.UID)
+		if name != "" {
+			p.Annotations = map[string]string{podgroup.DefaultAnnotation: name}
+		}
+		pods = append(pods, p)
+	}
+	egs := BuildPodGroups(pods)
+	assert.Equal(t, 1, len(egs))
+	assert.Equal(t, egs, SplitByPodGroup(egs, nil))
+
+	split := SplitByPodGroup(egs, podgroup.NewDetector(podgroup.DefaultAnnotation, podgroup.DefaultMinMemberAnnotation))
+	assert.Equal(t, 3, len(split))
+	sizes := map[string]int{}
+	for _, eg := range split {
+		sizes[eg.PodGroupName] = len(eg.Pods)
+		assert.NotNil(t, eg.SchedulingErrors)
+	}
+	assert.Equal(t, map[string]int{"default/a": 3, "default/b": 1, "": 1}, sizes)
+}
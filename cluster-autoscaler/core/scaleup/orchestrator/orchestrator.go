@@ -17,6 +17,7 @@ limitations under the License.
 package orchestrator
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -36,8 +37,10 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/tracing"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/klogx"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/podgroup"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
 	"k8s.io/klog/v2"
 )
@@ -51,6 +54,7 @@ type ScaleUpOrchestrator struct {
 	scaleUpExecutor      *scaleUpExecutor
 	estimatorBuilder     estimator.EstimatorBuilder
 	taintConfig          taints.TaintConfig
+	podGroupDetector     *podgroup.Detector
 	initialized          bool
 }
 
@@ -76,6 +80,9 @@ func (o *ScaleUpOrchestrator) Initialize(
 	o.taintConfig = taintConfig
 	o.resourceManager = resource.NewManager(processors.CustomResourcesProcessor)
 	o.scaleUpExecutor = newScaleUpExecutor(autoscalingCtx, processors.ScaleStateNotifier, o.processors.AsyncNodeGroupStateChecker)
+	if autoscalingCtx.PodGroupAwareScaleUp {
+		o.podGroupDetector = podgroup.NewDetector(autoscalingCtx.PodGroupAnnotation, autoscalingCtx.PodGroupMinMemberAnnotation)
+	}
 	o.initialized = true
 }
 
@@ -100,7 +107,7 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	klogx.V(1).Over(loggingQuota).Infof("%v other pods are also unschedulable", -loggingQuota.Left())
 
 	buildPodEquivalenceGroupsStart := time.Now()
-	podEquivalenceGroups := equivalence.BuildPodGroups(unschedulablePods)
+	podEquivalenceGroups := equivalence.SplitByPodGroup(equivalence.BuildPodGroups(unschedulablePods), o.podGroupDetector)
 	metrics.UpdateDurationFromStart(metrics.BuildPodEquivalenceGroups, buildPodEquivalenceGroupsStart)
 
 	upcomingNodes, aErr := o.UpcomingNodes(nodeInfos)
@@ -147,6 +154,7 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	for _, nodeGroup := range validNodeGroups {
 		option := o.ComputeExpansionOption(nodeGroup, schedulablePodGroups, nodeInfos, len(nodes)+len(upcomingNodes), now, allOrNothing)
 		o.processors.BinpackingLimiter.MarkProcessed(o.autoscalingCtx, nodeGroup.Id())
+		markSkippedPodGroupsAsRejected(podEquivalenceGroups, nodeGroup.Id(), option.Pods)
 
 		if len(option.Pods) == 0 || option.NodeCount == 0 {
 			klog.V(4).Infof("No pod can fit to %s", nodeGroup.Id())
@@ -173,13 +181,38 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 		}, nil
 	}
 
-	// Pick some expansion option.
-	bestOption := o.autoscalingCtx.ExpanderStrategy.BestOption(options, nodeInfos)
+	// Pick some expansion option, or several of them if a cost-optimal plan spans multiple node groups.
+	var bestOption *expander.Option
+	if o.autoscalingCtx.CostOptimalScaleUp {
+		plan := o.ComputeCostOptimalPlan(options, schedulablePodGroups, nodeInfos, resourcesLeft, len(nodes)+len(upcomingNodes), now)
+		planPods := 0
+		for _, option := range plan {
+			planPods += len(option.Pods)
+		}
+		if allOrNothing && planPods < len(unschedulablePods) {
+			klog.V(4).Info("Cost-optimal scale-up plan doesn't help all pods, falling back to the expander due to all-or-nothing scale-up strategy")
+		} else if len(plan) > 1 {
+			scaleUpStatus, aErr := o.executePlan(plan, podEquivalenceGroups, skippedNodeGroups, nodeGroups, nodeInfos, schedulablePodGroups, now, allOrNothing)
+			scaleUpStatus.ExpansionOptions = options
+			return scaleUpStatus, aErr
+		} else if len(plan) == 1 {
+			bestOption = &plan[0]
+		}
+	}
+	if bestOption == nil {
+		expanderCtx, expanderSpan := tracing.Start(o.autoscalingCtx.TraceContext, "Expander.BestOption")
+		bestOption = tracing.Bind(expanderCtx, o.autoscalingCtx.ExpanderStrategy).BestOption(options, nodeInfos)
+		if bestOption != nil && bestOption.NodeGroup != nil {
+			expanderSpan.SetAttributes(tracing.NodeGroupKey.String(bestOption.NodeGroup.Id()))
+		}
+		expanderSpan.End()
+	}
 	if bestOption == nil || bestOption.NodeCount <= 0 {
 		return &status.ScaleUpStatus{
 			Result:                  status.ScaleUpNoOptionsAvailable,
 			PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
 			ConsideredNodeGroups:    nodeGroups,
+			ExpansionOptions:        options,
 		}, nil
 	}
 	klog.V(1).Infof("Best option to resize: %s", bestOption.NodeGroup.Id())
@@ -191,13 +224,13 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	// Cap new nodes to supported number of nodes in the cluster.
 	newNodes, aErr := o.GetCappedNewNodeCount(bestOption.NodeCount, len(nodes)+len(upcomingNodes))
 	if aErr != nil {
-		return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods}, aErr)
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods, ExpansionOptions: options, BestOption: bestOption}, aErr)
 	}
 
 	newNodes, aErr = o.applyLimits(newNodes, resourcesLeft, bestOption.NodeGroup, nodeInfos)
 	if aErr != nil {
 		return status.UpdateScaleUpError(
-			&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods},
+			&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods, ExpansionOptions: options, BestOption: bestOption},
 			aErr)
 	}
 
@@ -238,7 +271,7 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	scaleUpInfos, aErr := o.balanceScaleUps(now, bestOption.NodeGroup, newNodes, nodeInfos, schedulablePodGroups)
 	if aErr != nil {
 		return status.UpdateScaleUpError(
-			&status.ScaleUpStatus{CreateNodeGroupResults: createNodeGroupResults, PodsTriggeredScaleUp: bestOption.Pods},
+			&status.ScaleUpStatus{CreateNodeGroupResults: createNodeGroupResults, PodsTriggeredScaleUp: bestOption.Pods, ExpansionOptions: options, BestOption: bestOption},
 			aErr)
 	}
 
@@ -259,13 +292,17 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 
 	// Execute scale up.
 	klog.V(1).Infof("Final scale-up plan: %v", scaleUpInfos)
-	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(scaleUpInfos, nodeInfos, now, allOrNothing)
+	// Pod groups need all of their nodes at once, so prefer atomic scale-up if the cloud provider supports it.
+	atomic := allOrNothing || containsPodGroups(podEquivalenceGroups, bestOption.Pods)
+	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(o.autoscalingCtx.TraceContext, scaleUpInfos, nodeInfos, now, atomic)
 	if aErr != nil {
 		return status.UpdateScaleUpError(
 			&status.ScaleUpStatus{
 				CreateNodeGroupResults: createNodeGroupResults,
 				FailedResizeNodeGroups: failedNodeGroups,
 				PodsTriggeredScaleUp:   bestOption.Pods,
+				ExpansionOptions:       options,
+				BestOption:             bestOption,
 			},
 			aErr,
 		)
@@ -280,6 +317,8 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 		CreateNodeGroupResults:  createNodeGroupResults,
 		PodsTriggeredScaleUp:    bestOption.Pods,
 		PodsAwaitEvaluation:     GetPodsAwaitingEvaluation(podEquivalenceGroups, bestOption.NodeGroup.Id()),
+		ExpansionOptions:        options,
+		BestOption:              bestOption,
 	}, nil
 }
 
@@ -326,8 +365,9 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 			continue
 		}
 
-		klog.V(4).Infof("ScaleUpToNodeGroupMinSize: NodeGroup %s, TargetSize %d, MinSize %d, MaxSize %d", ng.Id(), targetSize, ng.MinSize(), ng.MaxSize())
-		if targetSize >= ng.MinSize() {
+		minSize := o.autoscalingCtx.ScheduledMinCapacity.MinSize(ng)
+		klog.V(4).Infof("ScaleUpToNodeGroupMinSize: NodeGroup %s, TargetSize %d, MinSize %d, MaxSize %d", ng.Id(), targetSize, minSize, ng.MaxSize())
+		if targetSize >= minSize {
 			continue
 		}
 
@@ -347,7 +387,7 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 			continue
 		}
 
-		newNodeCount := ng.MinSize() - targetSize
+		newNodeCount := minSize - targetSize
 		newNodeCount, err = o.resourceManager.ApplyLimits(o.autoscalingCtx, newNodeCount, resourcesLeft, nodeInfo, ng)
 		if err != nil {
 			klog.Warningf("ScaleUpToNodeGroupMinSize: failed to apply resource limits: %v", err)
@@ -375,7 +415,7 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 	}
 
 	klog.V(1).Infof("ScaleUpToNodeGroupMinSize: final scale-up plan: %v", scaleUpInfos)
-	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */)
+	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(o.autoscalingCtx.TraceContext, scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */)
 	if aErr != nil {
 		return status.UpdateScaleUpError(
 			&status.ScaleUpStatus{
@@ -482,11 +522,14 @@ func (o *ScaleUpOrchestrator) ComputeExpansionOption(
 	}
 
 	estimateStart := time.Now()
+	_, estimateSpan := tracing.Start(o.autoscalingCtx.TraceContext, "Estimate", tracing.NodeGroupKey.String(nodeGroup.Id()), tracing.PodCountKey.Int(countPods(podGroups)))
 	expansionEstimator := o.estimatorBuilder(
 		o.autoscalingCtx.ClusterSnapshot,
 		estimator.NewEstimationContext(o.autoscalingCtx.MaxNodesTotal, option.SimilarNodeGroups, currentNodeCount),
 	)
 	option.NodeCount, option.Pods = expansionEstimator.Estimate(podGroups, nodeInfo, nodeGroup)
+	estimateSpan.SetAttributes(tracing.NodeCountKey.Int(option.NodeCount))
+	estimateSpan.End()
 	metrics.UpdateDurationFromStart(metrics.Estimate, estimateStart)
 
 	autoscalingOptions, err := nodeGroup.GetOptions(o.autoscalingCtx.NodeGroupDefaults)
@@ -607,14 +650,27 @@ func (o *ScaleUpOrchestrator) SchedulablePodGroups(
 		return []estimator.PodEquivalenceGroup{}
 	}
 
+	podGroupPending := make(map[string]int)
+	for _, eg := range podEquivalenceGroups {
+		if eg.PodGroupName != "" {
+			podGroupPending[eg.PodGroupName] += len(eg.Pods)
+		}
+	}
+
 	var schedulablePodGroups []estimator.PodEquivalenceGroup
 	for _, eg := range podEquivalenceGroups {
 		samplePod := eg.Pods[0]
 		if err := o.autoscalingCtx.ClusterSnapshot.CheckPredicates(samplePod, nodeInfo.Node().Name); err == nil {
 			// Add pods to option.
-			schedulablePodGroups = append(schedulablePodGroups, estimator.PodEquivalenceGroup{
+			podGroup := estimator.PodEquivalenceGroup{
 				Pods: eg.Pods,
-			})
+			}
+			if eg.PodGroupName != "" {
+				podGroup.PodGroup = eg.PodGroupName
+				podGroup.PodGroupMinMember = o.podGroupDetector.MinMember(samplePod)
+				podGroup.PodGroupPending = podGroupPending[eg.PodGroupName]
+			}
+			schedulablePodGroups = append(schedulablePodGroups, podGroup)
 			// Mark pod group as (theoretically) schedulable.
 			eg.Schedulable = true
 			eg.SchedulableGroups = append(eg.SchedulableGroups, nodeGroup.Id())
@@ -800,6 +856,37 @@ func matchingSchedulablePodGroups(podGroups []estimator.PodEquivalenceGroup, sim
 	return true
 }
 
+// markSkippedPodGroupsAsRejected rejects a node group for equivalence groups of
+// pod groups which were left out of its expansion option by the estimator.
+func markSkippedPodGroupsAsRejected(egs []*equivalence.PodGroup, nodeGroupId string, optionPods []*apiv1.Pod) {
+	included := make(map[*apiv1.Pod]bool, len(optionPods))
+	for _, pod := range optionPods {
+		included[pod] = true
+	}
+	for _, eg := range egs {
+		if eg.PodGroupName == "" || !slices.Contains(eg.SchedulableGroups, nodeGroupId) || slices.ContainsFunc(eg.Pods, func(pod *apiv1.Pod) bool { return included[pod] }) {
+			continue
+		}
+		eg.SchedulingErrors[nodeGroupId] = PodGroupDoesNotFitReason
+		eg.SchedulableGroups = slices.DeleteFunc(eg.SchedulableGroups, func(id string) bool { return id == nodeGroupId })
+		eg.Schedulable = len(eg.SchedulableGroups) > 0
+	}
+}
+
+// containsPodGroups returns whether any of the pods belongs to a pod group.
+func containsPodGroups(egs []*equivalence.PodGroup, pods []*apiv1.Pod) bool {
+	podGroupPods := make(map[*apiv1.Pod]bool)
+	for _, eg := range egs {
+		if eg.PodGroupName == "" {
+			continue
+		}
+		for _, pod := range eg.Pods {
+			podGroupPods[pod] = true
+		}
+	}
+	return slices.ContainsFunc(pods, func(pod *apiv1.Pod) bool { return podGroupPods[pod] })
+}
+
 func markAllGroupsAsUnschedulable(egs []*equivalence.PodGroup, reason status.Reasons) []*equivalence.PodGroup {
 	for _, eg := range egs {
 		if eg.Schedulable {
@@ -868,3 +955,11 @@ func appendCreatedNodeGroups(nodeGroups []cloudprovider.NodeGroup, bestOptionNod
 	}
 	return nodeGroups
 }
+
+func countPods(podGroups []estimator.PodEquivalenceGroup) int {
+	count := 0
+	for _, podGroup := range podGroups {
+		count += len(podGroup.Pods)
+	}
+	return count
+}
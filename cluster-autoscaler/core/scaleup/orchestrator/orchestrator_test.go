@@ -29,6 +29,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
@@ -46,9 +47,11 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodeinfosprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	processorstest "k8s.io/autoscaler/cluster-autoscaler/processors/test"
+	"k8s.io/autoscaler/cluster-autoscaler/scheduledcapacity"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/podgroup"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
@@ -1688,6 +1691,64 @@ func TestScaleUpToMeetNodeGroupMinSize(t *testing.T) {
 	assert.Equal(t, "ng1", scaleUpStatus.ScaleUpInfos[0].Group.Id())
 }
 
+func TestScaleUpToScheduledMinSize(t *testing.T) {
+	podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
+	listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+	provider := testprovider.NewTestCloudProviderBuilder().WithOnScaleUp(func(nodeGroup string, increase int) error {
+		assert.Equal(t, "ng1", nodeGroup)
+		assert.Equal(t, 2, increase)
+		return nil
+	}).Build()
+
+	// ng1: current size 1, min size 1, scheduled min size 3 => scale up with 2 new nodes.
+	// ng2: current size 1, min size 1, no schedule => no scale up.
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, time.Now())
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n2, true, time.Now())
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNode("ng1", n1)
+	provider.AddNodeGroup("ng2", 1, 10, 1)
+	provider.AddNode("ng2", n2)
+
+	options := config.AutoscalingOptions{
+		EstimatorName:  estimator.BinpackingEstimatorName,
+		MaxCoresTotal:  config.DefaultMaxClusterCores,
+		MaxMemoryTotal: config.DefaultMaxClusterMemory,
+	}
+	autoscalingCtx, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+	assert.NoError(t, err)
+	configMapLister, err := kube_util.NewTestConfigMapLister([]*apiv1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduled-min-capacity", Namespace: "kube-system"},
+		Data: map[string]string{scheduledcapacity.ConfigMapKey: `
+- name: always
+  nodeGroups: ["^ng1$"]
+  window: "* * * * * 1h"
+  minSize: 3
+`},
+	}})
+	assert.NoError(t, err)
+	autoscalingCtx.ScheduledMinCapacity = scheduledcapacity.NewProvider(configMapLister, "kube-system", "scheduled-min-capacity")
+	assert.NoError(t, autoscalingCtx.ScheduledMinCapacity.Refresh(time.Now()))
+
+	nodes := []*apiv1.Node{n1, n2}
+	err = autoscalingCtx.ClusterSnapshot.SetClusterState(nodes, nil, nil)
+	assert.NoError(t, err)
+	nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&autoscalingCtx, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, time.Now())
+	processors := processorstest.NewTestProcessors(&autoscalingCtx)
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, autoscalingCtx.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+	clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+
+	suOrchestrator := New()
+	suOrchestrator.Initialize(&autoscalingCtx, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
+	scaleUpStatus, err := suOrchestrator.ScaleUpToNodeGroupMinSize(nodes, nodeInfos)
+	assert.NoError(t, err)
+	assert.True(t, scaleUpStatus.WasSuccessful())
+	assert.Equal(t, 1, len(scaleUpStatus.ScaleUpInfos))
+	assert.Equal(t, 3, scaleUpStatus.ScaleUpInfos[0].NewSize)
+	assert.Equal(t, "ng1", scaleUpStatus.ScaleUpInfos[0].Group.Id())
+}
+
 func TestScaleupAsyncNodeGroupsEnabled(t *testing.T) {
 	t1 := BuildTestNode("t1", 100, 0)
 	SetNodeReadyState(t1, true, time.Time{})
@@ -1908,7 +1969,120 @@ func newEstimatorBuilder() estimator.EstimatorBuilder {
 		estimator.NewThresholdBasedEstimationLimiter(nil),
 		estimator.NewDecreasingPodOrderer(),
 		nil,
+		nil,
 	)
 
 	return estimatorBuilder
 }
+
+func TestPodGroupScaleUp(t *testing.T) {
+	testCases := []struct {
+		name            string
+		podGroupAware   bool
+		ng2MaxSize      int
+		withLauncher    bool
+		expectScaleUp   string
+		expectIncrease  int
+		expectRejection bool
+	}{
+		{
+			name:           "pod group fits only the larger node group",
+			podGroupAware:  true,
+			ng2MaxSize:     10,
+			expectScaleUp:  "ng2",
+			expectIncrease: 4,
+		},
+		{
+			name:            "pod group fits no node group",
+			podGroupAware:   true,
+			ng2MaxSize:      3,
+			expectRejection: true,
+		},
+		{
+			name:            "pod group with a member fitting no node group",
+			podGroupAware:   true,
+			ng2MaxSize:      10,
+			withLauncher:    true,
+			expectRejection: true,
+		},
+		{
+			name:           "pod groups are ignored if disabled",
+			ng2MaxSize:     1,
+			expectScaleUp:  "ng1",
+			expectIncrease: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+			n1 := BuildTestNode("n1", 1000, 1000)
+			SetNodeReadyState(n1, true, now.Add(-2*time.Minute))
+			n2 := BuildTestNode("n2", 1000, 1000)
+			SetNodeReadyState(n2, true, now.Add(-2*time.Minute))
+			nodes := []*apiv1.Node{n1, n2}
+
+			var scaledUp string
+			var increase int
+			provider := testprovider.NewTestCloudProviderBuilder().WithOnScaleUp(func(nodeGroup string, delta int) error {
+				scaledUp, increase = nodeGroup, delta
+				return nil
+			}).Build()
+			provider.AddNodeGroup("ng1", 1, 3, 1)
+			provider.AddNode("ng1", n1)
+			provider.AddNodeGroup("ng2", 1, tc.ng2MaxSize, 1)
+			provider.AddNode("ng2", n2)
+
+			options := defaultOptions
+			options.PodGroupAwareScaleUp = tc.podGroupAware
+			options.PodGroupAnnotation = podgroup.DefaultAnnotation
+			options.PodGroupMinMemberAnnotation = podgroup.DefaultMinMemberAnnotation
+			podLister := kube_util.NewTestPodLister(nil)
+			listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+			autoscalingCtx, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+			assert.NoError(t, autoscalingCtx.ClusterSnapshot.SetClusterState(nodes, nil, nil))
+			nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&autoscalingCtx, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, autoscalingCtx.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			clusterState.UpdateNodes(nodes, nodeInfos, now)
+
+			var pods []*apiv1.Pod
+			for i := 0; i < 4; i++ {
+				pod := BuildTestPod(fmt.Sprintf("worker-%d", i), 600, 0)
+				pod.Annotations = map[string]string{podgroup.DefaultAnnotation: "job"}
+				pods = append(pods, pod)
+			}
+			if tc.withLauncher {
+				launcher := BuildTestPod("launcher", 100, 0)
+				launcher.Annotations = map[string]string{podgroup.DefaultAnnotation: "job"}
+				launcher.Spec.NodeSelector = map[string]string{"pool": "cpu"}
+				pods = append(pods, launcher)
+			}
+			estimatorBuilder, _ := estimator.NewEstimatorBuilder(
+				estimator.BinpackingEstimatorName,
+				estimator.NewThresholdBasedEstimationLimiter([]estimator.Threshold{estimator.NewSngCapacityThreshold()}),
+				estimator.NewDecreasingPodOrderer(),
+				nil,
+				nil,
+			)
+			processors := processorstest.NewTestProcessors(&autoscalingCtx)
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&autoscalingCtx, processors, clusterState, estimatorBuilder, taints.TaintConfig{})
+			scaleUpStatus, aErr := suOrchestrator.ScaleUp(pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+			assert.NoError(t, aErr)
+
+			assert.Equal(t, tc.expectScaleUp, scaledUp)
+			assert.Equal(t, tc.expectIncrease, increase)
+			if tc.expectRejection {
+				assert.False(t, scaleUpStatus.WasSuccessful())
+				assert.Len(t, scaleUpStatus.PodsRemainUnschedulable, len(pods))
+				for _, noScaleUpInfo := range scaleUpStatus.PodsRemainUnschedulable {
+					if noScaleUpInfo.Pod.Name == "launcher" {
+						continue
+					}
+					assert.Equal(t, PodGroupDoesNotFitReason, noScaleUpInfo.RejectedNodeGroups["ng1"])
+					assert.Equal(t, PodGroupDoesNotFitReason, noScaleUpInfo.RejectedNodeGroups["ng2"])
+				}
+			}
+		})
+	}
+}
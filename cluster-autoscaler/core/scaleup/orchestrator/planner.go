@@ -0,0 +1,383 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orchestrator
+
+import (
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/equivalence"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// maxPlanNodeGroups is the maximum number of node groups in a cost-optimal
+	// scale-up plan.
+	maxPlanNodeGroups = 3
+	// maxPlanCandidates is the maximum number of node groups considered for a
+	// cost-optimal scale-up plan.
+	maxPlanCandidates = 8
+)
+
+// plannedOption is an expansion option of a cost-optimal scale-up plan.
+type plannedOption struct {
+	expander.Option
+	cost float64
+}
+
+// scaleUpPlan is a cost-optimal scale-up spanning one or more node groups.
+type scaleUpPlan struct {
+	options []plannedOption
+	pods    int
+	cost    float64
+}
+
+// with returns a copy of the plan extended with the option.
+func (p *scaleUpPlan) with(option plannedOption) *scaleUpPlan {
+	return &scaleUpPlan{
+		options: append(p.options[:len(p.options):len(p.options)], option),
+		pods:    p.pods + len(option.Pods),
+		cost:    p.cost + option.cost,
+	}
+}
+
+// betterThan returns whether the plan helps more pods, or the same number of
+// pods at a lower cost.
+func (p *scaleUpPlan) betterThan(other *scaleUpPlan) bool {
+	if p.pods != other.pods {
+		return p.pods > other.pods
+	}
+	return p.cost < other.cost
+}
+
+// planSearch is the state of a search for a cost-optimal scale-up plan.
+type planSearch struct {
+	orchestrator         *ScaleUpOrchestrator
+	pricingModel         cloudprovider.PricingModel
+	candidates           []plannedOption
+	schedulablePodGroups map[string][]estimator.PodEquivalenceGroup
+	nodeInfos            map[string]*framework.NodeInfo
+	totalPods            int
+	now                  time.Time
+	deadline             time.Time
+	evaluated            []expander.Option
+	best                 *scaleUpPlan
+}
+
+// ComputeCostOptimalPlan searches for the cheapest combination of up to
+// maxPlanNodeGroups existing node groups helping the most schedulable pods.
+// Candidates are the expansion options computed by binpacking, so node groups
+// left out by the BinpackingLimiter aren't considered. The cheapest
+// maxPlanCandidates of them, by cost per helped pod, are combined depth-first:
+// each node group of a combination is estimated for the pods not helped by the
+// previous ones, and combinations which can't beat the best plan found so far
+// are pruned. The search is cut short by the BinpackingLimiter, or after
+// MaxNodeGroupBinpackingDuration per candidate. Each node group has to fit its
+// part of the plan within its max size, the cluster-wide node count limit and
+// the resources left in the cluster. Returns nil if the cloud provider doesn't
+// provide pricing.
+func (o *ScaleUpOrchestrator) ComputeCostOptimalPlan(
+	options []expander.Option,
+	schedulablePodGroups map[string][]estimator.PodEquivalenceGroup,
+	nodeInfos map[string]*framework.NodeInfo,
+	resourcesLeft resource.Limits,
+	currentNodeCount int,
+	now time.Time,
+) []expander.Option {
+	pricingModel, err := o.autoscalingCtx.CloudProvider.Pricing()
+	if err != nil {
+		klog.V(4).Infof("Cost-optimal scale-up is not possible without pricing: %v", err)
+		return nil
+	}
+
+	search := &planSearch{
+		orchestrator:         o,
+		pricingModel:         pricingModel,
+		schedulablePodGroups: schedulablePodGroups,
+		nodeInfos:            nodeInfos,
+		now:                  now,
+		best:                 &scaleUpPlan{},
+	}
+	pods := make(map[*apiv1.Pod]bool)
+	for _, option := range options {
+		for _, pod := range option.Pods {
+			pods[pod] = true
+		}
+		if !option.NodeGroup.Exist() || o.isZeroOrMaxNodeScaling(option.NodeGroup) {
+			// Node groups to be created and ones scaling only from zero to max are left to the expander.
+			continue
+		}
+		if candidate, ok := search.cost(option, resourcesLeft, currentNodeCount); ok {
+			search.candidates = append(search.candidates, candidate)
+		}
+	}
+	search.totalPods = len(pods)
+	sort.SliceStable(search.candidates, func(i, j int) bool {
+		a, b := search.candidates[i], search.candidates[j]
+		return a.cost*float64(len(b.Pods)) < b.cost*float64(len(a.Pods))
+	})
+	if len(search.candidates) > maxPlanCandidates {
+		search.candidates = search.candidates[:maxPlanCandidates]
+	}
+	if len(search.candidates) == 0 {
+		return nil
+	}
+
+	var candidateNodeGroups []cloudprovider.NodeGroup
+	for _, candidate := range search.candidates {
+		candidateNodeGroups = append(candidateNodeGroups, candidate.NodeGroup)
+	}
+	if o.autoscalingCtx.MaxNodeGroupBinpackingDuration > 0 {
+		search.deadline = time.Now().Add(o.autoscalingCtx.MaxNodeGroupBinpackingDuration * time.Duration(len(search.candidates)))
+	}
+	o.processors.BinpackingLimiter.InitBinpacking(o.autoscalingCtx, candidateNodeGroups)
+	search.search(&scaleUpPlan{}, 0, pods, resourcesLeft, currentNodeCount)
+	o.processors.BinpackingLimiter.FinalizeBinpacking(o.autoscalingCtx, search.evaluated)
+
+	result := make([]expander.Option, 0, len(search.best.options))
+	for _, option := range search.best.options {
+		klog.V(2).Infof("Cost-optimal scale-up plan: %d nodes in %s for %d pods, hourly cost %v", option.NodeCount, option.NodeGroup.Id(), len(option.Pods), option.cost)
+		result = append(result, option.Option)
+	}
+	return result
+}
+
+// search extends the plan with node groups from candidates starting at a given
+// index, for the remaining pods, and records the best plan found.
+func (s *planSearch) search(plan *scaleUpPlan, start int, remaining map[*apiv1.Pod]bool, resourcesLeft resource.Limits, currentNodeCount int) {
+	if plan.betterThan(s.best) {
+		s.best = plan
+	}
+	if len(plan.options) == maxPlanNodeGroups || len(remaining) == 0 {
+		return
+	}
+	for i := start; i < len(s.candidates); i++ {
+		// Extending a plan only adds cost, so it can't beat a cheaper plan helping all pods.
+		if s.best.pods == s.totalPods && plan.cost >= s.best.cost {
+			return
+		}
+		if s.stop() {
+			return
+		}
+		option, ok := s.candidates[i], true
+		if len(plan.options) > 0 {
+			option, ok = s.estimate(s.candidates[i].NodeGroup, remaining, resourcesLeft, currentNodeCount)
+			if !ok {
+				continue
+			}
+		}
+
+		nextRemaining := make(map[*apiv1.Pod]bool, len(remaining))
+		for pod := range remaining {
+			nextRemaining[pod] = true
+		}
+		for _, pod := range option.Pods {
+			delete(nextRemaining, pod)
+		}
+		nextResourcesLeft := make(resource.Limits, len(resourcesLeft))
+		for name, limit := range resourcesLeft {
+			nextResourcesLeft[name] = limit
+		}
+		if delta, err := s.orchestrator.resourceManager.DeltaForNode(s.orchestrator.autoscalingCtx, s.nodeInfos[option.NodeGroup.Id()], option.NodeGroup); err == nil {
+			for name, resourceDelta := range delta {
+				if limit, found := nextResourcesLeft[name]; found {
+					nextResourcesLeft[name] = limit - resourceDelta*int64(option.NodeCount)
+				}
+			}
+		}
+		s.search(plan.with(option), i+1, nextRemaining, nextResourcesLeft, currentNodeCount+option.NodeCount)
+	}
+}
+
+// stop returns whether the search should be cut short.
+func (s *planSearch) stop() bool {
+	if !s.deadline.IsZero() && time.Now().After(s.deadline) {
+		klog.V(4).Info("Cost-optimal scale-up planning is cut short due to exceeding its binpacking time")
+		return true
+	}
+	return s.orchestrator.processors.BinpackingLimiter.StopBinpacking(s.orchestrator.autoscalingCtx, s.evaluated)
+}
+
+// estimate estimates the nodes needed in a node group for the remaining pods
+// and their hourly cost.
+func (s *planSearch) estimate(
+	nodeGroup cloudprovider.NodeGroup,
+	remaining map[*apiv1.Pod]bool,
+	resourcesLeft resource.Limits,
+	currentNodeCount int,
+) (plannedOption, bool) {
+	var podGroups []estimator.PodEquivalenceGroup
+	for _, podGroup := range s.schedulablePodGroups[nodeGroup.Id()] {
+		var pods []*apiv1.Pod
+		for _, pod := range podGroup.Pods {
+			if remaining[pod] {
+				pods = append(pods, pod)
+			}
+		}
+		if len(pods) > 0 {
+			podGroup.Pods = pods
+			podGroups = append(podGroups, podGroup)
+		}
+	}
+	nodeInfo := s.nodeInfos[nodeGroup.Id()]
+	if len(podGroups) == 0 || nodeInfo == nil {
+		return plannedOption{}, false
+	}
+
+	o := s.orchestrator
+	expansionEstimator := o.estimatorBuilder(
+		o.autoscalingCtx.ClusterSnapshot,
+		estimator.NewEstimationContext(o.autoscalingCtx.MaxNodesTotal, nil, currentNodeCount),
+	)
+	option := expander.Option{NodeGroup: nodeGroup}
+	option.NodeCount, option.Pods = expansionEstimator.Estimate(podGroups, nodeInfo, nodeGroup)
+	o.processors.BinpackingLimiter.MarkProcessed(o.autoscalingCtx, nodeGroup.Id())
+	if option.NodeCount == 0 || len(option.Pods) == 0 {
+		return plannedOption{}, false
+	}
+	s.evaluated = append(s.evaluated, option)
+	return s.cost(option, resourcesLeft, currentNodeCount)
+}
+
+// cost checks that the option fits the limits and returns it with its hourly cost.
+func (s *planSearch) cost(option expander.Option, resourcesLeft resource.Limits, currentNodeCount int) (plannedOption, bool) {
+	o := s.orchestrator
+	nodeGroup := option.NodeGroup
+	nodeInfo := s.nodeInfos[nodeGroup.Id()]
+	if option.NodeCount == 0 || len(option.Pods) == 0 || nodeInfo == nil {
+		return plannedOption{}, false
+	}
+	targetSize, err := nodeGroup.TargetSize()
+	if err != nil || targetSize+option.NodeCount > nodeGroup.MaxSize() {
+		return plannedOption{}, false
+	}
+	if o.autoscalingCtx.MaxNodesTotal > 0 && currentNodeCount+option.NodeCount > o.autoscalingCtx.MaxNodesTotal {
+		return plannedOption{}, false
+	}
+	delta, aErr := o.resourceManager.DeltaForNode(o.autoscalingCtx, nodeInfo, nodeGroup)
+	if aErr != nil {
+		return plannedOption{}, false
+	}
+	for name, resourceDelta := range delta {
+		delta[name] = resourceDelta * int64(option.NodeCount)
+	}
+	if resource.CheckDeltaWithinLimits(resourcesLeft, delta).Exceeded {
+		return plannedOption{}, false
+	}
+
+	price, err := s.pricingModel.NodePrice(nodeInfo.Node(), s.now, s.now.Add(time.Hour))
+	if err != nil {
+		klog.V(4).Infof("Failed to get price of node group %s: %v", nodeGroup.Id(), err)
+		return plannedOption{}, false
+	}
+	return plannedOption{Option: option, cost: price * float64(option.NodeCount)}, true
+}
+
+// isZeroOrMaxNodeScaling returns whether the node group only scales from zero to max.
+func (o *ScaleUpOrchestrator) isZeroOrMaxNodeScaling(nodeGroup cloudprovider.NodeGroup) bool {
+	autoscalingOptions, err := nodeGroup.GetOptions(o.autoscalingCtx.NodeGroupDefaults)
+	return err == nil && autoscalingOptions != nil && autoscalingOptions.ZeroOrMaxNodeScaling
+}
+
+// executePlan executes a scale-up plan spanning multiple node groups as one
+// scale-up. Nodes planned for a node group are balanced with its similar node
+// groups, unless they are part of the plan themselves.
+func (o *ScaleUpOrchestrator) executePlan(
+	plan []expander.Option,
+	podEquivalenceGroups []*equivalence.PodGroup,
+	skippedNodeGroups map[string]status.Reasons,
+	nodeGroups []cloudprovider.NodeGroup,
+	nodeInfos map[string]*framework.NodeInfo,
+	schedulablePodGroups map[string][]estimator.PodEquivalenceGroup,
+	now time.Time,
+	allOrNothing bool,
+) (*status.ScaleUpStatus, errors.AutoscalerError) {
+	used := make(map[string]bool, len(plan))
+	for _, option := range plan {
+		used[option.NodeGroup.Id()] = true
+	}
+	var pods []*apiv1.Pod
+	scaleUpInfos := make([]nodegroupset.ScaleUpInfo, 0, len(plan))
+	for _, option := range plan {
+		pods = append(pods, option.Pods...)
+		targetNodeGroups := []cloudprovider.NodeGroup{option.NodeGroup}
+		for _, similarNodeGroup := range o.ComputeSimilarNodeGroups(option.NodeGroup, nodeInfos, schedulablePodGroups, now) {
+			if !used[similarNodeGroup.Id()] {
+				used[similarNodeGroup.Id()] = true
+				targetNodeGroups = append(targetNodeGroups, similarNodeGroup)
+			}
+		}
+		balanced, aErr := o.processors.NodeGroupSetProcessor.BalanceScaleUpBetweenGroups(o.autoscalingCtx, targetNodeGroups, option.NodeCount)
+		if aErr != nil {
+			return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: pods}, aErr)
+		}
+		scaleUpInfos = append(scaleUpInfos, balanced...)
+	}
+
+	klog.V(1).Infof("Final cost-optimal scale-up plan: %v", scaleUpInfos)
+	atomic := allOrNothing || containsPodGroups(podEquivalenceGroups, pods)
+	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(o.autoscalingCtx.TraceContext, scaleUpInfos, nodeInfos, now, atomic)
+	if aErr != nil {
+		return status.UpdateScaleUpError(
+			&status.ScaleUpStatus{
+				FailedResizeNodeGroups: failedNodeGroups,
+				PodsTriggeredScaleUp:   pods,
+			},
+			aErr,
+		)
+	}
+
+	o.clusterStateRegistry.Recalculate()
+	return &status.ScaleUpStatus{
+		Result:                  status.ScaleUpSuccessful,
+		ScaleUpInfos:            scaleUpInfos,
+		PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
+		ConsideredNodeGroups:    nodeGroups,
+		PodsTriggeredScaleUp:    pods,
+		PodsAwaitEvaluation:     podsAwaitingPlan(podEquivalenceGroups, pods),
+	}, nil
+}
+
+// podsAwaitingPlan returns pods which could be helped by a scale-up, but
+// weren't part of the executed plan.
+func podsAwaitingPlan(egs []*equivalence.PodGroup, triggered []*apiv1.Pod) []*apiv1.Pod {
+	planned := make(map[*apiv1.Pod]bool, len(triggered))
+	for _, pod := range triggered {
+		planned[pod] = true
+	}
+	awaitsEvaluation := []*apiv1.Pod{}
+	for _, eg := range egs {
+		if !eg.Schedulable {
+			continue
+		}
+		for _, pod := range eg.Pods {
+			if !planned[pod] {
+				awaitsEvaluation = append(awaitsEvaluation, pod)
+			}
+		}
+	}
+	return awaitsEvaluation
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orchestrator
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups/asyncnodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodeinfosprovider"
+	processorstest "k8s.io/autoscaler/cluster-autoscaler/processors/test"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// gpuPricingModel prices nodes with GPUs at 10 per hour and other nodes at 1 per hour.
+type gpuPricingModel struct{}
+
+func (gpuPricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	price := 1.0
+	if _, found := node.Status.Capacity[gpu.ResourceNvidiaGPU]; found {
+		price = 10.0
+	}
+	return price * endTime.Sub(startTime).Hours(), nil
+}
+
+func (gpuPricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	return 0, nil
+}
+
+func TestCostOptimalScaleUp(t *testing.T) {
+	testCases := []struct {
+		name           string
+		cpuMaxSize     int
+		balance        bool
+		expectIncrease map[string]int
+	}{
+		{
+			name:           "gpu and cpu pods are split between node groups",
+			cpuMaxSize:     10,
+			expectIncrease: map[string]int{"gpu": 2, "cpu": 2},
+		},
+		{
+			name:           "plan is balanced between similar node groups",
+			cpuMaxSize:     10,
+			balance:        true,
+			expectIncrease: map[string]int{"gpu": 2, "cpu": 1, "cpu-2": 1},
+		},
+		{
+			name:           "single node group if the other one is at max size",
+			cpuMaxSize:     1,
+			expectIncrease: map[string]int{"gpu": 6},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+			gpuNode := BuildTestNode("gpu-node", 1500, 1000)
+			AddGpusToNode(gpuNode, 1)
+			// Allow the sidecars to run on GPU nodes too.
+			gpuNode.Spec.Taints = nil
+			SetNodeReadyState(gpuNode, true, now.Add(-2*time.Minute))
+			cpuNode := BuildTestNode("cpu-node", 2000, 1000)
+			SetNodeReadyState(cpuNode, true, now.Add(-2*time.Minute))
+			nodes := []*apiv1.Node{gpuNode, cpuNode}
+			cpuNode2 := BuildTestNode("cpu-node-2", 2000, 1000)
+			SetNodeReadyState(cpuNode2, true, now.Add(-2*time.Minute))
+			if tc.balance {
+				nodes = append(nodes, cpuNode2)
+			}
+
+			var mutex sync.Mutex
+			increases := map[string]int{}
+			provider := testprovider.NewTestCloudProviderBuilder().WithOnScaleUp(func(nodeGroup string, delta int) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				increases[nodeGroup] += delta
+				return nil
+			}).Build()
+			provider.SetPricingModel(gpuPricingModel{})
+			provider.AddNodeGroup("gpu", 1, 10, 1)
+			provider.AddNode("gpu", gpuNode)
+			provider.AddNodeGroup("cpu", 1, tc.cpuMaxSize, 1)
+			provider.AddNode("cpu", cpuNode)
+			if tc.balance {
+				provider.AddNodeGroup("cpu-2", 1, tc.cpuMaxSize, 1)
+				provider.AddNode("cpu-2", cpuNode2)
+			}
+
+			options := defaultOptions
+			options.CostOptimalScaleUp = true
+			options.BalanceSimilarNodeGroups = tc.balance
+			listers := kube_util.NewListerRegistry(nil, nil, kube_util.NewTestPodLister(nil), nil, nil, nil, nil, nil, nil)
+			autoscalingCtx, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+			assert.NoError(t, autoscalingCtx.ClusterSnapshot.SetClusterState(nodes, nil, nil))
+			nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&autoscalingCtx, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, autoscalingCtx.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			clusterState.UpdateNodes(nodes, nodeInfos, now)
+
+			var pods []*apiv1.Pod
+			for i := 0; i < 2; i++ {
+				pod := BuildTestPod(fmt.Sprintf("inference-%d", i), 1000, 0)
+				RequestGpuForPod(pod, 1)
+				pods = append(pods, pod)
+			}
+			for i := 0; i < 4; i++ {
+				pods = append(pods, BuildTestPod(fmt.Sprintf("sidecar-%d", i), 1000, 0))
+			}
+			estimatorBuilder, _ := estimator.NewEstimatorBuilder(
+				estimator.BinpackingEstimatorName,
+				estimator.NewThresholdBasedEstimationLimiter([]estimator.Threshold{estimator.NewSngCapacityThreshold()}),
+				estimator.NewDecreasingPodOrderer(),
+				nil,
+				nil,
+			)
+			processors := processorstest.NewTestProcessors(&autoscalingCtx)
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&autoscalingCtx, processors, clusterState, estimatorBuilder, taints.TaintConfig{})
+			scaleUpStatus, aErr := suOrchestrator.ScaleUp(pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+			assert.NoError(t, aErr)
+			assert.True(t, scaleUpStatus.WasSuccessful())
+			assert.Len(t, scaleUpStatus.PodsTriggeredScaleUp, 6)
+			assert.Equal(t, tc.expectIncrease, increases)
+		})
+	}
+}
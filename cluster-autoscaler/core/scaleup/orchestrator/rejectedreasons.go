@@ -34,4 +34,6 @@ func (sr *RejectedReasons) Reasons() []string {
 var (
 	// AllOrNothingReason means the node group was rejected because not all pods would fit it when using all-or-nothing strategy.
 	AllOrNothingReason = NewRejectedReasons("not all pods would fit and scale-up is using all-or-nothing strategy")
+	// PodGroupDoesNotFitReason means the node group was rejected because not enough pods of a pod group would fit it within its max size.
+	PodGroupDoesNotFitReason = NewRejectedReasons("not enough pods of the pod group would fit in the node group within its max size")
 )
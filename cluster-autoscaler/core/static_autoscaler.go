@@ -17,6 +17,7 @@ limitations under the License.
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -29,13 +30,19 @@ import (
 	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/consolidation"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/explanation"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/hooks"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/planner"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/recycling"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/replacement"
 	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/orchestrator"
 	core_utils "k8s.io/autoscaler/cluster-autoscaler/core/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/cost"
 	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
@@ -43,6 +50,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/observers/loopstart"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/scheduledcapacity"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
@@ -50,6 +58,8 @@ import (
 	drasnapshot "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/snapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/templatecache"
+	"k8s.io/autoscaler/cluster-autoscaler/tracing"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/annotations"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	caerrors "k8s.io/autoscaler/cluster-autoscaler/utils/errors"
@@ -82,12 +92,16 @@ type StaticAutoscaler struct {
 	lastScaleDownFailTime   time.Time
 	scaleDownPlanner        scaledown.Planner
 	scaleDownActuator       scaledown.Actuator
+	consolidator            *consolidation.Consolidator
+	recycler                *recycling.Recycler
 	scaleUpOrchestrator     scaleup.Orchestrator
 	processors              *ca_processors.AutoscalingProcessors
 	loopStartNotifier       *loopstart.ObserversList
 	processorCallbacks      *staticAutoscalerProcessorCallbacks
 	initialized             bool
 	taintConfig             taints.TaintConfig
+	// nodeExplainer keeps explanations of scale-down decisions for each node.
+	nodeExplainer *explanation.Explainer
 }
 
 type staticAutoscalerProcessorCallbacks struct {
@@ -141,13 +155,24 @@ func NewStaticAutoscaler(
 	scaleUpOrchestrator scaleup.Orchestrator,
 	deleteOptions options.NodeDeleteOptions,
 	drainabilityRules rules.Rules,
-	draProvider *draprovider.Provider) *StaticAutoscaler {
+	draProvider *draprovider.Provider,
+	scaleDownHooks *hooks.Runner,
+	nodeExplainer *explanation.Explainer,
+	simulationSnapshot func() (clustersnapshot.ClusterSnapshot, error)) *StaticAutoscaler {
 
 	klog.V(4).Infof("Creating new static autoscaler with opts: %v", opts)
 
+	var scheduledMinCapacity *scheduledcapacity.Provider
+	if opts.ScheduledMinCapacityConfigMap != "" {
+		// Like the priority expander's lister, it never receives the termination signal.
+		stopChannel := make(chan struct{})
+		configMapLister := kube_util.NewConfigMapListerForNamespace(autoscalingKubeClients.ClientSet, stopChannel, opts.ConfigNamespace)
+		scheduledMinCapacity = scheduledcapacity.NewProvider(configMapLister, opts.ConfigNamespace, opts.ScheduledMinCapacityConfigMap)
+	}
 	clusterStateConfig := clusterstate.ClusterStateRegistryConfig{
 		MaxTotalUnreadyPercentage: opts.MaxTotalUnreadyPercentage,
 		OkTotalUnreadyCount:       opts.OkTotalUnreadyCount,
+		ScheduledMinCapacity:      scheduledMinCapacity,
 	}
 	clusterStateRegistry := clusterstate.NewClusterStateRegistry(cloudProvider, clusterStateConfig, autoscalingKubeClients.LogRecorder, backoff, processors.NodeGroupConfigProcessor, processors.AsyncNodeGroupStateChecker)
 	processorCallbacks := newStaticAutoscalerProcessorCallbacks()
@@ -163,6 +188,15 @@ func NewStaticAutoscaler(
 		remainingPdbTracker,
 		clusterStateRegistry,
 		draProvider)
+	autoscalingCtx.ScheduledMinCapacity = scheduledMinCapacity
+	autoscalingCtx.ScaleDownHooks = scaleDownHooks
+	autoscalingCtx.CostTracker = cost.NewTracker(cloudProvider)
+	autoscalingCtx.NewSimulationSnapshot = simulationSnapshot
+	if opts.NodeInfoCacheConfigMap != "" {
+		autoscalingCtx.NodeInfoCacheStore = templatecache.NewConfigMapStore(autoscalingKubeClients.ClientSet, opts.ConfigNamespace, opts.NodeInfoCacheConfigMap)
+	} else if opts.NodeInfoCacheFile != "" {
+		autoscalingCtx.NodeInfoCacheStore = templatecache.NewFileStore(opts.NodeInfoCacheFile)
+	}
 
 	taintConfig := taints.NewTaintConfig(opts)
 	processors.ScaleDownCandidatesNotifier.Register(clusterStateRegistry)
@@ -177,6 +211,14 @@ func NewStaticAutoscaler(
 	scaleDownActuator := actuation.NewActuator(autoscalingCtx, processors.ScaleStateNotifier, ndt, deleteOptions, drainabilityRules, processors.NodeGroupConfigProcessor)
 	autoscalingCtx.ScaleDownActuator = scaleDownActuator
 
+	replacementRequester := replacement.NewRequester(autoscalingCtx, clusterStateRegistry, processors.ScaleStateNotifier, processors.CustomResourcesProcessor)
+	var consolidator *consolidation.Consolidator
+	if opts.ConsolidationEnabled {
+		consolidator = consolidation.NewConsolidator(autoscalingCtx, scaleDownPlanner, scaleDownActuator, replacementRequester, processors.NodeGroupConfigProcessor, deleteOptions, drainabilityRules)
+	}
+
+	recycler := recycling.NewRecycler(autoscalingCtx, scaleDownPlanner, scaleDownActuator, replacementRequester, processors.NodeGroupConfigProcessor, deleteOptions, drainabilityRules)
+
 	if scaleUpOrchestrator == nil {
 		scaleUpOrchestrator = orchestrator.New()
 	}
@@ -192,12 +234,15 @@ func NewStaticAutoscaler(
 		lastScaleDownFailTime:   initialScaleTime,
 		scaleDownPlanner:        scaleDownPlanner,
 		scaleDownActuator:       scaleDownActuator,
+		consolidator:            consolidator,
+		recycler:                recycler,
 		scaleUpOrchestrator:     scaleUpOrchestrator,
 		processors:              processors,
 		loopStartNotifier:       loopStartNotifier,
 		processorCallbacks:      processorCallbacks,
 		clusterStateRegistry:    clusterStateRegistry,
 		taintConfig:             taintConfig,
+		nodeExplainer:           nodeExplainer,
 	}
 }
 
@@ -268,6 +313,10 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	autoscalingCtx := a.AutoscalingContext
 
 	klog.V(4).Info("Starting main loop")
+	loopCtx, loopSpan := tracing.Start(context.Background(), "RunOnce")
+	defer loopSpan.End()
+	autoscalingCtx.TraceContext = loopCtx
+	defer func() { autoscalingCtx.TraceContext = nil }()
 
 	stateUpdateStart := time.Now()
 
@@ -296,6 +345,7 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	if err != nil {
 		return caerrors.ToAutoscalerError(caerrors.ApiCallError, err)
 	}
+	loopSpan.SetAttributes(tracing.NodeCountKey.Int(len(allNodes)), tracing.PodCountKey.Int(len(unschedulablePods)))
 
 	coresTotal, memoryTotal := calculateCoresMemoryTotal(allNodes, currentTime)
 	metrics.UpdateClusterCPUCurrentCores(coresTotal)
@@ -311,7 +361,10 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	scaleDownActuationStatus := a.scaleDownActuator.CheckStatus()
 	// Call CloudProvider.Refresh before any other calls to cloud provider.
 	refreshStart := time.Now()
-	err = a.AutoscalingContext.CloudProvider.Refresh()
+	refreshCtx, refreshSpan := tracing.Start(loopCtx, "CloudProvider.Refresh")
+	err = tracing.Bind(refreshCtx, a.AutoscalingContext.CloudProvider).Refresh()
+	tracing.RecordError(refreshSpan, err)
+	refreshSpan.End()
 	if a.AutoscalingOptions.AsyncNodeGroupsEnabled {
 		// Some node groups might have been created asynchronously, without registering in CSR.
 		a.clusterStateRegistry.Recalculate()
@@ -322,13 +375,16 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		return caerrors.ToAutoscalerError(caerrors.CloudProviderError, err)
 	}
 	a.loopStartNotifier.Refresh()
+	if err := a.ScheduledMinCapacity.Refresh(currentTime); err != nil {
+		klog.Errorf("Failed to refresh scheduled min capacity: %v", err)
+	}
 
 	// Update node groups min/max and maximum number of nodes being set for all node groups after cloud provider refresh
 	maxNodesCount := 0
 	for _, nodeGroup := range a.AutoscalingContext.CloudProvider.NodeGroups() {
 		// Don't report non-existing or upcoming node groups
 		if nodeGroup.Exist() {
-			metrics.UpdateNodeGroupMin(nodeGroup.Id(), nodeGroup.MinSize())
+			metrics.UpdateNodeGroupMin(nodeGroup.Id(), a.ScheduledMinCapacity.MinSize(nodeGroup))
 			metrics.UpdateNodeGroupMax(nodeGroup.Id(), nodeGroup.MaxSize())
 			maxNodesCount += nodeGroup.MaxSize()
 		}
@@ -348,13 +404,20 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		return typedErr.AddPrefix("failed to initialize RemainingPdbTracker: ")
 	}
 
+	_, templatesSpan := tracing.Start(loopCtx, "TemplateNodeInfoProvider.Process", tracing.NodeCountKey.Int(len(readyNodes)))
 	nodeInfosForGroups, autoscalerError := a.processors.TemplateNodeInfoProvider.Process(autoscalingCtx, readyNodes, daemonsets, a.taintConfig, currentTime)
+	tracing.RecordError(templatesSpan, autoscalerError)
+	templatesSpan.End()
 	if autoscalerError != nil {
 		klog.Errorf("Failed to get node infos for groups: %v", autoscalerError)
 		return autoscalerError.AddPrefix("failed to build node infos for node groups: ")
 	}
 
+	autoscalingCtx.TemplateNodeInfos = nodeInfosForGroups
 	a.DebuggingSnapshotter.SetTemplateNodes(nodeInfosForGroups)
+	if a.DebuggingSnapshotter.IsDataCollectionAllowed() {
+		a.DebuggingSnapshotter.SetNodeGroups(a.CloudProvider.NodeGroups())
+	}
 
 	if typedErr := a.updateClusterState(allNodes, nodeInfosForGroups, currentTime); typedErr != nil {
 		klog.Errorf("Failed to update cluster state: %v", typedErr)
@@ -442,6 +505,7 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	metrics.UpdateUnschedulablePodsCount(len(unschedulablePods), len(schedulerUnprocessed))
 	// Treat unknown pods as unschedulable, pod list processor will remove schedulable pods
 	unschedulablePods = append(unschedulablePods, schedulerUnprocessed...)
+	a.DebuggingSnapshotter.SetUnschedulablePods(unschedulablePods)
 	// Upcoming nodes are recently created nodes that haven't registered in the cluster yet, or haven't become ready yet.
 	upcomingCounts, registeredUpcoming := a.clusterStateRegistry.GetUpcomingNodes()
 	// For each upcoming node we inject a placeholder node faked to appear ready into the cluster snapshot, so that we can pack unschedulable pods on
@@ -480,7 +544,10 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		a.AutoscalingContext.DebuggingSnapshotter.SetClusterNodes(l)
 	}
 
+	_, podListSpan := tracing.Start(loopCtx, "PodListProcessor.Process", tracing.PodCountKey.Int(len(unschedulablePods)))
 	unschedulablePodsToHelp, err := a.processors.PodListProcessor.Process(a.AutoscalingContext, unschedulablePods)
+	tracing.RecordError(podListSpan, err)
+	podListSpan.End()
 
 	if err != nil {
 		klog.Warningf("Failed to process unschedulable pods: %v", err)
@@ -549,7 +616,12 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 
 	if shouldScaleUp || a.processors.ScaleUpEnforcer.ShouldForceScaleUp(unschedulablePodsToHelp) {
 		scaleUpStart := preScaleUp()
+		scaleUpCtx, scaleUpSpan := tracing.Start(loopCtx, "ScaleUp", tracing.PodCountKey.Int(len(unschedulablePodsToHelp)))
+		autoscalingCtx.TraceContext = scaleUpCtx
 		scaleUpStatus, typedErr = a.scaleUpOrchestrator.ScaleUp(unschedulablePodsToHelp, readyNodes, daemonsets, nodeInfosForGroups, false)
+		autoscalingCtx.TraceContext = loopCtx
+		tracing.RecordError(scaleUpSpan, typedErr)
+		scaleUpSpan.End()
 		postScaleUp(scaleUpStart)
 	}
 
@@ -585,13 +657,24 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			}
 		}
 
+		if a.consolidator != nil {
+			scaleDownCandidates = a.consolidator.FilterOutReplacements(scaleDownCandidates)
+		}
+		if a.recycler != nil {
+			scaleDownCandidates = a.recycler.FilterOutReplacements(scaleDownCandidates)
+		}
+
+		_, unneededSpan := tracing.Start(loopCtx, "ScaleDown.UpdateClusterState", tracing.NodeCountKey.Int(len(scaleDownCandidates)))
 		typedErr := a.scaleDownPlanner.UpdateClusterState(podDestinations, scaleDownCandidates, scaleDownActuationStatus, currentTime)
+		tracing.RecordError(unneededSpan, typedErr)
+		unneededSpan.End()
 		// Update clusterStateRegistry and metrics regardless of whether ScaleDown was successful or not.
 		unneededNodes := a.scaleDownPlanner.UnneededNodes()
 		a.processors.ScaleDownCandidatesNotifier.Update(unneededNodes, currentTime)
 		metrics.UpdateUnneededNodesCount(len(unneededNodes))
 		if typedErr != nil {
 			scaleDownStatus.Result = scaledownstatus.ScaleDownError
+			scaleDownStatus.ScaleDownError = &typedErr
 			klog.Errorf("Failed to scale down: %v", typedErr)
 			return typedErr
 		}
@@ -620,22 +703,49 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		if scaleDownInCooldown {
 			scaleDownStatus.Result = scaledownstatus.ScaleDownInCooldown
 			a.updateSoftDeletionTaints(allNodes)
+			a.nodeExplainer.Update(autoscalingCtx, a.scaleDownPlanner, a.processors.NodeGroupConfigProcessor, allNodes, currentTime)
+			a.CostTracker.Update(a.scaleDownPlanner.UnneededNodes(), a.scaleDownPlanner.UnremovableNodes(), currentTime)
 		} else if len(scaleDownCandidates) == 0 {
 			klog.V(4).Infof("Starting scale down: no scale down candidates. skipping...")
 			scaleDownStatus.Result = scaledownstatus.ScaleDownNoCandidates
 			metrics.UpdateLastTime(metrics.ScaleDown, time.Now())
 			a.updateSoftDeletionTaints(allNodes)
+			a.nodeExplainer.Update(autoscalingCtx, a.scaleDownPlanner, a.processors.NodeGroupConfigProcessor, allNodes, currentTime)
+			a.CostTracker.Update(a.scaleDownPlanner.UnneededNodes(), a.scaleDownPlanner.UnremovableNodes(), currentTime)
 		} else {
 			klog.V(4).Infof("Starting scale down")
 
 			scaleDownStart := time.Now()
 			metrics.UpdateLastTime(metrics.ScaleDown, scaleDownStart)
+			_, scaleDownSpan := tracing.Start(loopCtx, "ScaleDown")
 			empty, needDrain := a.scaleDownPlanner.NodesToDelete(currentTime)
 			scaleDownResult, scaledDownNodes, typedErr := a.scaleDownActuator.StartDeletion(empty, needDrain)
+			for _, scaledDown := range scaledDownNodes {
+				scaledDown.Reason = a.scaleDownPlanner.RemovalReason(scaledDown.Node.Name)
+			}
+			// Consolidation only runs if the regular scale-down didn't remove anything.
+			if a.consolidator != nil && typedErr == nil && scaleDownResult == scaledownstatus.ScaleDownNoNodeDeleted {
+				scaleDownResult, scaledDownNodes, typedErr = a.consolidator.RunOnce(allNodes, scaleDownCandidates, podDestinations, nodeInfosForGroups, currentTime)
+			}
+			// Nodes older than MaxNodeLifetime whose pods can't be moved elsewhere are replaced before being drained.
+			if a.recycler != nil && typedErr == nil {
+				var recycledNodes []*scaledownstatus.ScaleDownNode
+				var recyclingResult scaledownstatus.ScaleDownResult
+				recyclingResult, recycledNodes, typedErr = a.recycler.RunOnce(allNodes, scaleDownCandidates, podDestinations, nodeInfosForGroups, currentTime)
+				if typedErr != nil || recyclingResult == scaledownstatus.ScaleDownNodeDeleteStarted {
+					scaleDownResult = recyclingResult
+				}
+				scaledDownNodes = append(scaledDownNodes, recycledNodes...)
+			}
 			scaleDownStatus.Result = scaleDownResult
 			scaleDownStatus.ScaledDownNodes = scaledDownNodes
+			scaleDownSpan.SetAttributes(tracing.NodeCountKey.Int(len(scaledDownNodes)))
+			tracing.RecordError(scaleDownSpan, typedErr)
+			scaleDownSpan.End()
 			metrics.UpdateDurationFromStart(metrics.ScaleDown, scaleDownStart)
 			metrics.UpdateUnremovableNodesCount(countsByReason(a.scaleDownPlanner.UnremovableNodes()))
+			a.nodeExplainer.Update(autoscalingCtx, a.scaleDownPlanner, a.processors.NodeGroupConfigProcessor, allNodes, currentTime)
+			a.CostTracker.Update(a.scaleDownPlanner.UnneededNodes(), a.scaleDownPlanner.UnremovableNodes(), currentTime)
 
 			scaleDownStatus.RemovedNodeGroups = removedNodeGroups
 
@@ -645,6 +755,7 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			}
 			a.updateSoftDeletionTaints(allNodes)
 			if typedErr != nil {
+				scaleDownStatus.ScaleDownError = &typedErr
 				klog.Errorf("Failed to scale down: %v", typedErr)
 				a.lastScaleDownFailTime = currentTime
 				return typedErr
@@ -654,7 +765,12 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 
 	if a.EnforceNodeGroupMinSize {
 		scaleUpStart := preScaleUp()
+		scaleUpCtx, scaleUpSpan := tracing.Start(loopCtx, "ScaleUpToNodeGroupMinSize")
+		autoscalingCtx.TraceContext = scaleUpCtx
 		scaleUpStatus, typedErr = a.scaleUpOrchestrator.ScaleUpToNodeGroupMinSize(readyNodes, nodeInfosForGroups)
+		autoscalingCtx.TraceContext = loopCtx
+		tracing.RecordError(scaleUpSpan, typedErr)
+		scaleUpSpan.End()
 		postScaleUp(scaleUpStart)
 	}
 
@@ -772,7 +888,7 @@ func (a *StaticAutoscaler) removeOldUnregisteredNodes(allUnregisteredNodes []clu
 				klog.Warningf("Failed to get node group size; nodeGroup=%v; err=%v", nodeGroup.Id(), err)
 				continue
 			}
-			possibleToDelete := size - nodeGroup.MinSize()
+			possibleToDelete := size - a.ScheduledMinCapacity.MinSize(nodeGroup)
 			if possibleToDelete <= 0 {
 				klog.Warningf("Node group %s min size reached, skipping removal of %v unregistered nodes", nodeGroupId, len(unregisteredNodesToDelete))
 				continue
@@ -2375,6 +2375,14 @@ func (f *candidateTrackingFakePlanner) NodeUtilizationMap() map[string]utilizati
 	return nil
 }
 
+func (f *candidateTrackingFakePlanner) UnneededSince(nodeName string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (f *candidateTrackingFakePlanner) RemovalReason(nodeName string) status.ScaleDownReason {
+	return ""
+}
+
 func assertSnapshotNodeCount(t *testing.T, snapshot clustersnapshot.ClusterSnapshot, wantCount int) {
 	nodeInfos, err := snapshot.ListNodeInfos()
 	assert.NoError(t, err)
@@ -2943,7 +2951,8 @@ func TestStaticAutoscalerRunOnceInvokesScaleDownStatusProcessor(t *testing.T) {
 				Result: status.ScaleDownNodeDeleteStarted,
 				ScaledDownNodes: []*status.ScaleDownNode{
 					{
-						Node: n2,
+						Node:   n2,
+						Reason: status.ScaleDownEmpty,
 					},
 				},
 				UnremovableNodes: []*status.UnremovableNode{
@@ -3154,6 +3163,7 @@ func newEstimatorBuilder() estimator.EstimatorBuilder {
 		estimator.NewThresholdBasedEstimationLimiter(nil),
 		estimator.NewDecreasingPodOrderer(),
 		nil,
+		nil,
 	)
 
 	return estimatorBuilder
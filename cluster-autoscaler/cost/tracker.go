@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	klog "k8s.io/klog/v2"
+)
+
+// Tracker attributes costs to scale-up and scale-down decisions and exports
+// them as metrics. It does nothing if the cloud provider doesn't implement a
+// pricing model. A nil Tracker is valid and does nothing.
+//
+// Scale-down savings of a node group accrue over time at the hourly cost of
+// the nodes removed from it. Nodes added to the node group later offset the
+// savings, so that removing and re-adding a node doesn't count as saving.
+type Tracker struct {
+	cloudProvider cloudprovider.CloudProvider
+
+	mutex sync.Mutex
+	// savingRates are the hourly costs saved by scale-down, by node group.
+	savingRates map[string]float64
+	lastUpdate  time.Time
+	// blockedNodes keeps the reason why nodes underutilized, but blocked in
+	// the last scale-down simulation weren't removed. Nodes aren't simulated
+	// again until UnremovableNodeRecheckTimeout passes, and are reported as
+	// RecentlyUnremovable in the meantime.
+	blockedNodes map[string]metrics.UnneededNodesCostKey
+}
+
+// NewTracker returns a new Tracker.
+func NewTracker(cloudProvider cloudprovider.CloudProvider) *Tracker {
+	return &Tracker{
+		cloudProvider: cloudProvider,
+		savingRates:   make(map[string]float64),
+		blockedNodes:  make(map[string]metrics.UnneededNodesCostKey),
+	}
+}
+
+// RegisterScaleUp records the cost of nodes added to a node group. The node
+// is the template of the node group's nodes.
+func (t *Tracker) RegisterScaleUp(nodeGroup cloudprovider.NodeGroup, node *apiv1.Node, delta int, now time.Time) {
+	if t == nil || delta <= 0 {
+		return
+	}
+	pricingModel := t.pricingModel()
+	if pricingModel == nil {
+		return
+	}
+	hourlyCost, ok := nodeHourlyCost(pricingModel, node, now)
+	if !ok {
+		return
+	}
+	hourlyCost *= float64(delta)
+	metrics.RegisterScaleUpCost(nodeGroup.Id(), hourlyCost)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if rate := t.savingRates[nodeGroup.Id()] - hourlyCost; rate > 0 {
+		t.savingRates[nodeGroup.Id()] = rate
+	} else {
+		delete(t.savingRates, nodeGroup.Id())
+	}
+}
+
+// RegisterScaleDown records the cost of a node removed from a node group.
+func (t *Tracker) RegisterScaleDown(nodeGroup cloudprovider.NodeGroup, node *apiv1.Node, now time.Time) {
+	if t == nil {
+		return
+	}
+	pricingModel := t.pricingModel()
+	if pricingModel == nil {
+		return
+	}
+	hourlyCost, ok := nodeHourlyCost(pricingModel, node, now)
+	if !ok {
+		return
+	}
+	metrics.RegisterScaleDownCost(nodeGroup.Id(), hourlyCost)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.savingRates[nodeGroup.Id()] += hourlyCost
+}
+
+// Update accrues scale-down savings since the last update and records the
+// cost of underutilized nodes which weren't removed yet, attributed to the
+// reason why. These are the unneeded nodes, and the unremovable nodes which
+// were found to be blocked by a pod or to have no place to move their pods to
+// in scale-down simulation.
+func (t *Tracker) Update(unneeded []*apiv1.Node, unremovable []*simulator.UnremovableNode, now time.Time) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.lastUpdate.IsZero() {
+		hours := now.Sub(t.lastUpdate).Hours()
+		for nodeGroup, rate := range t.savingRates {
+			metrics.RegisterScaleDownSavings(nodeGroup, rate*hours)
+		}
+	}
+	t.lastUpdate = now
+
+	if costs, ok := t.unneededCosts(unneeded, unremovable, now); ok {
+		metrics.UpdateUnneededNodesHourlyCost(costs)
+	}
+}
+
+// unneededCosts returns the hourly cost of underutilized nodes which weren't
+// removed yet, by the reason why.
+func (t *Tracker) unneededCosts(unneeded []*apiv1.Node, unremovable []*simulator.UnremovableNode, now time.Time) (map[metrics.UnneededNodesCostKey]float64, bool) {
+	pricingModel := t.pricingModel()
+	if pricingModel == nil {
+		return nil, false
+	}
+	reasons := make(map[string]metrics.UnneededNodesCostKey, len(unremovable))
+	nodes := make(map[string]*apiv1.Node, len(unneeded)+len(unremovable))
+	blockedNodes := make(map[string]metrics.UnneededNodesCostKey)
+	for _, u := range unremovable {
+		key := metrics.UnneededNodesCostKey{Reason: u.Reason.Name()}
+		switch u.Reason {
+		case simulator.BlockedByPod:
+			if u.BlockingPod != nil {
+				key.BlockingPodReason = u.BlockingPod.Reason.String()
+			}
+			blockedNodes[u.Node.Name] = key
+			nodes[u.Node.Name] = u.Node
+		case simulator.NoPlaceToMovePods:
+			blockedNodes[u.Node.Name] = key
+			nodes[u.Node.Name] = u.Node
+		case simulator.RecentlyUnremovable:
+			if blockedKey, found := t.blockedNodes[u.Node.Name]; found {
+				key = blockedKey
+				blockedNodes[u.Node.Name] = key
+				nodes[u.Node.Name] = u.Node
+			}
+		}
+		reasons[u.Node.Name] = key
+	}
+	t.blockedNodes = blockedNodes
+	for _, node := range unneeded {
+		nodes[node.Name] = node
+	}
+
+	costs := make(map[metrics.UnneededNodesCostKey]float64)
+	for name, node := range nodes {
+		key, found := reasons[name]
+		if !found {
+			key = metrics.UnneededNodesCostKey{Reason: simulator.NoReason.Name()}
+		}
+		if hourlyCost, ok := nodeHourlyCost(pricingModel, node, now); ok {
+			costs[key] += hourlyCost
+		}
+	}
+	return costs, true
+}
+
+func nodeHourlyCost(pricingModel cloudprovider.PricingModel, node *apiv1.Node, now time.Time) (float64, bool) {
+	hourlyCost, err := pricingModel.NodePrice(node, now, now.Add(time.Hour))
+	if err != nil {
+		klog.V(4).Infof("Failed to get price of node %s: %v", node.Name, err)
+		return 0, false
+	}
+	return hourlyCost, true
+}
+
+func (t *Tracker) pricingModel() cloudprovider.PricingModel {
+	pricingModel, err := t.cloudProvider.Pricing()
+	if err != nil {
+		return nil
+	}
+	return pricingModel
+}
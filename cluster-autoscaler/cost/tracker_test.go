@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type testPricingModel struct {
+	prices map[string]float64
+}
+
+func (tpm *testPricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	if price, found := tpm.prices[node.Name]; found {
+		return price * endTime.Sub(startTime).Hours(), nil
+	}
+	return 0, fmt.Errorf("price for node %v not found", node.Name)
+}
+
+func (tpm *testPricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	return 0, nil
+}
+
+func TestSavingRates(t *testing.T) {
+	now := time.Now()
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 0, 10, 3)
+	provider.AddNodeGroup("ng2", 0, 10, 3)
+	provider.SetPricingModel(&testPricingModel{prices: map[string]float64{"n1": 1, "n2": 2, "template": 1.5}})
+	ng1, ng2 := provider.GetNodeGroup("ng1"), provider.GetNodeGroup("ng2")
+
+	tracker := NewTracker(provider)
+	tracker.RegisterScaleDown(ng1, BuildTestNode("n1", 1000, 1000), now)
+	tracker.RegisterScaleDown(ng1, BuildTestNode("n2", 1000, 1000), now)
+	tracker.RegisterScaleDown(ng2, BuildTestNode("unknown", 1000, 1000), now)
+	assert.Equal(t, map[string]float64{"ng1": 3}, tracker.savingRates)
+
+	tracker.RegisterScaleUp(ng1, BuildTestNode("template", 1000, 1000), 1, now)
+	assert.Equal(t, map[string]float64{"ng1": 1.5}, tracker.savingRates)
+	tracker.RegisterScaleUp(ng1, BuildTestNode("template", 1000, 1000), 2, now)
+	assert.Empty(t, tracker.savingRates)
+}
+
+func TestUnneededCosts(t *testing.T) {
+	now := time.Now()
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.SetPricingModel(&testPricingModel{prices: map[string]float64{
+		"removable": 1, "waiting": 2, "pdb": 4, "not-safe": 8, "no-place": 16, "recent": 32, "busy": 64,
+	}})
+	nodes := make(map[string]*apiv1.Node)
+	for _, name := range []string{"removable", "waiting", "pdb", "not-safe", "no-place", "recent", "busy"} {
+		nodes[name] = BuildTestNode(name, 1000, 1000)
+	}
+	pod := BuildTestPod("p1", 100, 100)
+
+	tracker := NewTracker(provider)
+	costs, ok := tracker.unneededCosts(
+		[]*apiv1.Node{nodes["removable"], nodes["waiting"]},
+		[]*simulator.UnremovableNode{
+			{Node: nodes["waiting"], Reason: simulator.NotUnneededLongEnough},
+			{Node: nodes["pdb"], Reason: simulator.BlockedByPod, BlockingPod: &drain.BlockingPod{Pod: pod, Reason: drain.NotEnoughPdb}},
+			{Node: nodes["not-safe"], Reason: simulator.BlockedByPod, BlockingPod: &drain.BlockingPod{Pod: pod, Reason: drain.NotSafeToEvictAnnotation}},
+			{Node: nodes["recent"], Reason: simulator.BlockedByPod, BlockingPod: &drain.BlockingPod{Pod: pod, Reason: drain.NotSafeToEvictAnnotation}},
+			{Node: nodes["no-place"], Reason: simulator.NoPlaceToMovePods},
+			{Node: nodes["busy"], Reason: simulator.NotUnderutilized},
+		}, now)
+	assert.True(t, ok)
+	assert.Equal(t, map[metrics.UnneededNodesCostKey]float64{
+		{Reason: "NoReason"}:                                                    1,
+		{Reason: "NotUnneededLongEnough"}:                                       2,
+		{Reason: "BlockedByPod", BlockingPodReason: "NotEnoughPdb"}:             4,
+		{Reason: "BlockedByPod", BlockingPodReason: "NotSafeToEvictAnnotation"}: 40,
+		{Reason: "NoPlaceToMovePods"}:                                           16,
+	}, costs)
+
+	// Nodes which aren't simulated again keep the reason from the last simulation.
+	costs, ok = tracker.unneededCosts(nil, []*simulator.UnremovableNode{
+		{Node: nodes["recent"], Reason: simulator.RecentlyUnremovable},
+		{Node: nodes["busy"], Reason: simulator.RecentlyUnremovable},
+	}, now)
+	assert.True(t, ok)
+	assert.Equal(t, map[metrics.UnneededNodesCostKey]float64{
+		{Reason: "BlockedByPod", BlockingPodReason: "NotSafeToEvictAnnotation"}: 32,
+	}, costs)
+
+	_, ok = NewTracker(testprovider.NewTestCloudProviderBuilder().Build()).unneededCosts(nil, nil, now)
+	assert.False(t, ok)
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	tracker.RegisterScaleUp(nil, nil, 1, time.Now())
+	tracker.RegisterScaleDown(nil, nil, time.Now())
+	tracker.Update(nil, nil, time.Now())
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/klog/v2"
 )
@@ -31,6 +32,14 @@ type ClusterNode struct {
 	Pods []*v1.Pod `json:"Pods"`
 }
 
+// NodeGroup captures the size limits of a node group and the ids of its instances
+type NodeGroup struct {
+	MinSize    int      `json:"MinSize"`
+	MaxSize    int      `json:"MaxSize"`
+	TargetSize int      `json:"TargetSize"`
+	Instances  []string `json:"Instances"`
+}
+
 // DebuggingSnapshot is the interface used to define any debugging snapshot
 // implementation, incl. any custom impl. to be used by DebuggingSnapshotter
 type DebuggingSnapshot interface {
@@ -39,6 +48,11 @@ type DebuggingSnapshot interface {
 	// SetUnscheduledPodsCanBeScheduled is a setter for all pods which are unscheduled,
 	// but they can be scheduled. i.e. pods which aren't triggering scale-up
 	SetUnscheduledPodsCanBeScheduled([]*v1.Pod)
+	// SetUnschedulablePods is a setter for all pods which are unschedulable
+	// at the beginning of the loop, i.e. before any filtering
+	SetUnschedulablePods([]*v1.Pod)
+	// SetNodeGroups is a setter for the node groups present in the cloud provider
+	SetNodeGroups([]cloudprovider.NodeGroup)
 	// SetTemplateNodes is a setter for all the TemplateNodes present in the cluster
 	// incl. templates for which there are no nodes
 	SetTemplateNodes(map[string]*framework.NodeInfo)
@@ -67,6 +81,8 @@ type DebuggingSnapshotImpl struct {
 	StartTimestamp                time.Time               `json:"StartTimestamp"`
 	EndTimestamp                  time.Time               `json:"EndTimestamp"`
 	TemplateNodes                 map[string]*ClusterNode `json:"TemplateNodes"`
+	UnschedulablePods             []*v1.Pod               `json:"UnschedulablePods"`
+	NodeGroups                    map[string]*NodeGroup   `json:"NodeGroups"`
 }
 
 // SetUnscheduledPodsCanBeScheduled is the setter for UnscheduledPodsCanBeScheduled
@@ -81,6 +97,48 @@ func (s *DebuggingSnapshotImpl) SetUnscheduledPodsCanBeScheduled(podList []*v1.P
 	}
 }
 
+// SetUnschedulablePods is the setter for UnschedulablePods
+func (s *DebuggingSnapshotImpl) SetUnschedulablePods(podList []*v1.Pod) {
+	if podList == nil {
+		return
+	}
+
+	s.UnschedulablePods = nil
+	for _, pod := range podList {
+		s.UnschedulablePods = append(s.UnschedulablePods, pod.DeepCopy())
+	}
+}
+
+// SetNodeGroups is the setter for NodeGroups
+func (s *DebuggingSnapshotImpl) SetNodeGroups(nodeGroups []cloudprovider.NodeGroup) {
+	if nodeGroups == nil {
+		return
+	}
+
+	s.NodeGroups = make(map[string]*NodeGroup)
+	for _, ng := range nodeGroups {
+		targetSize, err := ng.TargetSize()
+		if err != nil {
+			klog.Warningf("Unable to get target size of node group %s for the debugging snapshot: %v", ng.Id(), err)
+			continue
+		}
+		instances, err := ng.Nodes()
+		if err != nil {
+			klog.Warningf("Unable to get instances of node group %s for the debugging snapshot: %v", ng.Id(), err)
+			continue
+		}
+		nodeGroup := &NodeGroup{
+			MinSize:    ng.MinSize(),
+			MaxSize:    ng.MaxSize(),
+			TargetSize: targetSize,
+		}
+		for _, instance := range instances {
+			nodeGroup.Instances = append(nodeGroup.Instances, instance.Id)
+		}
+		s.NodeGroups[ng.Id()] = nodeGroup
+	}
+}
+
 // SetTemplateNodes is the setter for TemplateNodes
 func (s *DebuggingSnapshotImpl) SetTemplateNodes(templates map[string]*framework.NodeInfo) {
 	if templates == nil {
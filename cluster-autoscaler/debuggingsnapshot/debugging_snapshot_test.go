@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 )
 
@@ -90,3 +91,24 @@ func TestEmptyDataNoError(t *testing.T) {
 	assert.False(t, err)
 	assert.NotNil(t, op)
 }
+
+func TestSetNodeGroups(t *testing.T) {
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 1, 10, 2)
+	provider.AddNode("ng1", &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}})
+	provider.AddNode("ng1", &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n2"}})
+
+	snapshot := &DebuggingSnapshotImpl{}
+	snapshot.SetNodeGroups(provider.NodeGroups())
+	op, err := snapshot.GetOutputBytes()
+	assert.False(t, err)
+
+	var parsed DebuggingSnapshotImpl
+	assert.NoError(t, json.Unmarshal(op, &parsed))
+	assert.Len(t, parsed.NodeGroups, 1)
+	ng := parsed.NodeGroups["ng1"]
+	assert.Equal(t, 1, ng.MinSize)
+	assert.Equal(t, 10, ng.MaxSize)
+	assert.Equal(t, 2, ng.TargetSize)
+	assert.ElementsMatch(t, []string{"n1", "n2"}, ng.Instances)
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/klog/v2"
 )
@@ -79,6 +80,11 @@ type DebuggingSnapshotter interface {
 	// SetUnscheduledPodsCanBeScheduled is a setter for all pods which are unscheduled
 	// but they can be scheduled. i.e. pods which aren't triggering scale-up
 	SetUnscheduledPodsCanBeScheduled([]*v1.Pod)
+	// SetUnschedulablePods is a setter for all pods which are unschedulable
+	// at the beginning of the loop, i.e. before any filtering
+	SetUnschedulablePods([]*v1.Pod)
+	// SetNodeGroups is a setter for the node groups present in the cloud provider
+	SetNodeGroups([]cloudprovider.NodeGroup)
 	// SetTemplateNodes is a setter for all the TemplateNodes present in the cluster
 	// incl. templates for which there are no nodes
 	SetTemplateNodes(map[string]*framework.NodeInfo)
@@ -240,6 +246,28 @@ func (d *DebuggingSnapshotterImpl) SetUnscheduledPodsCanBeScheduled(podList []*v
 	*d.State = DATA_COLLECTED
 }
 
+// SetUnschedulablePods is the setter for UnschedulablePods
+func (d *DebuggingSnapshotterImpl) SetUnschedulablePods(podList []*v1.Pod) {
+	d.Mutex.Lock()
+	defer d.Mutex.Unlock()
+	if !d.IsDataCollectionAllowedNoLock() {
+		return
+	}
+	klog.V(4).Infof("UnschedulablePods is being set for the debugging snapshot")
+	d.DebuggingSnapshot.SetUnschedulablePods(podList)
+}
+
+// SetNodeGroups is the setter for NodeGroups
+func (d *DebuggingSnapshotterImpl) SetNodeGroups(nodeGroups []cloudprovider.NodeGroup) {
+	d.Mutex.Lock()
+	defer d.Mutex.Unlock()
+	if !d.IsDataCollectionAllowedNoLock() {
+		return
+	}
+	klog.V(4).Infof("NodeGroups is being set for the debugging snapshot")
+	d.DebuggingSnapshot.SetNodeGroups(nodeGroups)
+}
+
 // SetTemplateNodes is the setter for TemplateNodes
 func (d *DebuggingSnapshotterImpl) SetTemplateNodes(templates map[string]*framework.NodeInfo) {
 	d.Mutex.Lock()
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The replay command runs the autoscaler loop against a debugging snapshot
+// and prints the scale-up and scale-down decisions. It accepts all the
+// cluster-autoscaler flags which are mapped to AutoscalingOptions.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"k8s.io/autoscaler/cluster-autoscaler/config/flags"
+	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot/replay"
+	kube_flag "k8s.io/component-base/cli/flag"
+	"k8s.io/klog/v2"
+)
+
+var (
+	snapshotFile = flag.String("snapshot", "", "Path to the debugging snapshot, as returned by the snapshotz endpoint.")
+	loops        = flag.Int("loops", 1, "Number of autoscaler loops to run, scan-interval apart.")
+	jsonOutput   = flag.Bool("json", false, "Print the decisions as JSON.")
+)
+
+func main() {
+	klog.InitFlags(nil)
+	kube_flag.InitFlags()
+
+	if *snapshotFile == "" {
+		klog.Fatalf("--snapshot is required")
+	}
+	snapshot, err := replay.LoadSnapshotFile(*snapshotFile)
+	if err != nil {
+		klog.Fatalf("Failed to load snapshot: %v", err)
+	}
+	result, err := replay.Run(snapshot, flags.AutoscalingOptions(), *loops)
+	if err != nil {
+		klog.Fatalf("Failed to replay snapshot: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			klog.Fatalf("Failed to encode result: %v", err)
+		}
+		return
+	}
+	result.Print(os.Stdout)
+}
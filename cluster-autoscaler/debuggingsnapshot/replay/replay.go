@@ -0,0 +1,414 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replay runs the autoscaler loop offline against the cluster state captured
+// in a debugging snapshot, using a fake cluster and a fake cloud provider.
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testcloudprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core"
+	"k8s.io/autoscaler/cluster-autoscaler/core/podlistprocessor"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/orchestrator"
+	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
+	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/annotations"
+	"k8s.io/client-go/informers"
+	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog/v2"
+)
+
+// defaultMaxSize is the max size used for node groups if the snapshot
+// doesn't contain node group information (snapshots taken by older versions).
+const defaultMaxSize = 1000
+
+// Result contains the decisions taken in each of the replayed loops.
+type Result struct {
+	Loops []*LoopResult `json:"Loops"`
+}
+
+// LoopResult contains the decisions taken in a single replayed loop.
+type LoopResult struct {
+	Time                     time.Time      `json:"Time"`
+	Error                    string         `json:"Error,omitempty"`
+	ScaleUpResult            string         `json:"ScaleUpResult"`
+	ScaleUps                 []*ScaleUp     `json:"ScaleUps,omitempty"`
+	PodsTriggeredScaleUp     []string       `json:"PodsTriggeredScaleUp,omitempty"`
+	PodsRemainUnschedulable  []string       `json:"PodsRemainUnschedulable,omitempty"`
+	ScaleDownResult          string         `json:"ScaleDownResult"`
+	ScaleDowns               []*ScaleDown   `json:"ScaleDowns,omitempty"`
+	UnremovableNodesByReason map[string]int `json:"UnremovableNodesByReason,omitempty"`
+}
+
+// ScaleUp is a scale-up of a single node group.
+type ScaleUp struct {
+	NodeGroup   string `json:"NodeGroup"`
+	CurrentSize int    `json:"CurrentSize"`
+	NewSize     int    `json:"NewSize"`
+}
+
+// ScaleDown is a removal of a single node.
+type ScaleDown struct {
+	NodeGroup   string   `json:"NodeGroup"`
+	Node        string   `json:"Node"`
+	EvictedPods []string `json:"EvictedPods,omitempty"`
+}
+
+// LoadSnapshot decodes a debugging snapshot, as returned by the snapshotz endpoint.
+func LoadSnapshot(r io.Reader) (*debuggingsnapshot.DebuggingSnapshotImpl, error) {
+	snapshot := &debuggingsnapshot.DebuggingSnapshotImpl{}
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode debugging snapshot: %v", err)
+	}
+	if snapshot.Error != "" {
+		return nil, fmt.Errorf("debugging snapshot contains an error: %s", snapshot.Error)
+	}
+	return snapshot, nil
+}
+
+// LoadSnapshotFile decodes a debugging snapshot from the given file.
+func LoadSnapshotFile(path string) (*debuggingsnapshot.DebuggingSnapshotImpl, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadSnapshot(f)
+}
+
+// Run loads the snapshot into a fake cluster and a fake cloud provider and runs
+// the given number of autoscaler loops against it, ScanInterval apart. Node deletion
+// and creation are not reflected in the fake cluster, so subsequent loops only
+// see the changed node group target sizes.
+func Run(snapshot *debuggingsnapshot.DebuggingSnapshotImpl, opts config.AutoscalingOptions, loops int) (*Result, error) {
+	kubeClient, err := buildFakeCluster(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	provider := buildFakeCloudProvider(snapshot)
+
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, 0)
+	recorder := &recorder{}
+	autoscaler, err := buildAutoscaler(opts, kubeClient, informerFactory, provider, recorder)
+	if err != nil {
+		return nil, err
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	informerFactory.Start(stop)
+	for informer, synced := range informerFactory.WaitForCacheSync(stop) {
+		if !synced {
+			return nil, fmt.Errorf("unable to sync informer %v", informer)
+		}
+	}
+	if err := autoscaler.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start autoscaler: %v", err)
+	}
+	defer autoscaler.ExitCleanUp()
+
+	currentTime := snapshot.StartTimestamp
+	if currentTime.IsZero() {
+		currentTime = time.Now()
+	}
+	result := &Result{}
+	for i := 0; i < loops; i++ {
+		loop := &LoopResult{Time: currentTime}
+		recorder.current = loop
+		if err := autoscaler.RunOnce(currentTime); err != nil {
+			loop.Error = err.Error()
+		}
+		result.Loops = append(result.Loops, loop)
+		currentTime = currentTime.Add(opts.ScanInterval)
+	}
+	return result, nil
+}
+
+func buildAutoscaler(opts config.AutoscalingOptions, kubeClient kube_client.Interface, informerFactory informers.SharedInformerFactory,
+	provider cloudprovider.CloudProvider, recorder *recorder) (core.Autoscaler, error) {
+	deleteOptions := options.NewNodeDeleteOptions(opts)
+	processors := ca_processors.DefaultProcessors(opts)
+	processors.PodListProcessor = podlistprocessor.NewDefaultPodListProcessor(scheduling.ScheduleAnywhere)
+	processors.ScaleUpStatusProcessor = status.NewCombinedScaleUpStatusProcessor([]status.ScaleUpStatusProcessor{&scaleUpRecorder{recorder}, processors.ScaleUpStatusProcessor})
+	processors.ScaleDownStatusProcessor = &scaleDownRecorder{recorder}
+
+	autoscaler, err := core.NewAutoscaler(core.AutoscalerOptions{
+		AutoscalingOptions:   opts,
+		KubeClient:           kubeClient,
+		InformerFactory:      informerFactory,
+		CloudProvider:        provider,
+		Processors:           processors,
+		DebuggingSnapshotter: debuggingsnapshot.NewDebuggingSnapshotter(false),
+		DeleteOptions:        deleteOptions,
+		DrainabilityRules:    rules.Default(deleteOptions),
+		ScaleUpOrchestrator:  orchestrator.New(),
+	}, informerFactory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create autoscaler: %v", err)
+	}
+	return autoscaler, nil
+}
+
+// buildFakeCluster creates a fake client with all the nodes and pods from the snapshot.
+func buildFakeCluster(snapshot *debuggingsnapshot.DebuggingSnapshotImpl) (kube_client.Interface, error) {
+	kubeClient := fake.NewClientset()
+	ctx := context.Background()
+
+	for _, clusterNode := range snapshot.NodeList {
+		if clusterNode.Node == nil || isUpcoming(clusterNode.Node) {
+			// Upcoming nodes are injected by the autoscaler itself based on node group target sizes.
+			continue
+		}
+		node := clusterNode.Node.DeepCopy()
+		// The fake cloud provider identifies instances by node names.
+		node.Spec.ProviderID = node.Name
+		if _, err := kubeClient.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to create node %s: %v", node.Name, err)
+		}
+		for _, pod := range clusterNode.Pods {
+			if err := createPod(ctx, kubeClient, pod.DeepCopy()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, pods := range [][]*apiv1.Pod{snapshot.UnschedulablePods, snapshot.UnscheduledPodsCanBeScheduled} {
+		for _, pod := range pods {
+			pod = pod.DeepCopy()
+			pod.Spec.NodeName = ""
+			markUnschedulable(pod)
+			if err := createPod(ctx, kubeClient, pod); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return kubeClient, nil
+}
+
+func createPod(ctx context.Context, kubeClient kube_client.Interface, pod *apiv1.Pod) error {
+	_, err := kubeClient.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// buildFakeCloudProvider creates a test cloud provider with the node groups from the snapshot.
+func buildFakeCloudProvider(snapshot *debuggingsnapshot.DebuggingSnapshotImpl) *testcloudprovider.TestCloudProvider {
+	templates := make(map[string]*framework.NodeInfo)
+	for id, template := range snapshot.TemplateNodes {
+		if template == nil || template.Node == nil {
+			continue
+		}
+		var pods []*framework.PodInfo
+		for _, pod := range template.Pods {
+			pods = append(pods, framework.NewPodInfo(pod, nil))
+		}
+		templates[id] = framework.NewNodeInfo(template.Node, nil, pods...)
+	}
+
+	provider := testcloudprovider.NewTestCloudProviderBuilder().
+		WithOnScaleUp(func(string, int) error { return nil }).
+		WithOnScaleDown(func(string, string) error { return nil }).
+		WithMachineTemplates(templates).
+		Build()
+
+	if len(snapshot.NodeGroups) == 0 {
+		klog.Warningf("Debugging snapshot doesn't contain node groups, nodes won't be assigned to node groups and max size %d will be used", defaultMaxSize)
+		for id := range templates {
+			provider.AddNodeGroup(id, 0, defaultMaxSize, 0)
+		}
+		return provider
+	}
+
+	nodeGroupByInstance := make(map[string]string)
+	for id, ng := range snapshot.NodeGroups {
+		provider.AddNodeGroup(id, ng.MinSize, ng.MaxSize, ng.TargetSize)
+		for _, instance := range ng.Instances {
+			nodeGroupByInstance[instance] = id
+		}
+	}
+	for _, clusterNode := range snapshot.NodeList {
+		if clusterNode.Node == nil {
+			continue
+		}
+		id, found := nodeGroupByInstance[clusterNode.Node.Spec.ProviderID]
+		if !found {
+			id, found = nodeGroupByInstance[clusterNode.Node.Name]
+		}
+		if found {
+			provider.AddNode(id, clusterNode.Node)
+		}
+	}
+	return provider
+}
+
+func isUpcoming(node *apiv1.Node) bool {
+	return node.Annotations[annotations.NodeUpcomingAnnotation] == "true"
+}
+
+// markUnschedulable sets the PodScheduled condition the scheduler sets on unschedulable pods.
+func markUnschedulable(pod *apiv1.Pod) {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == apiv1.PodScheduled {
+			pod.Status.Conditions[i].Status = apiv1.ConditionFalse
+			pod.Status.Conditions[i].Reason = apiv1.PodReasonUnschedulable
+			return
+		}
+	}
+	pod.Status.Conditions = append(pod.Status.Conditions, apiv1.PodCondition{
+		Type:   apiv1.PodScheduled,
+		Status: apiv1.ConditionFalse,
+		Reason: apiv1.PodReasonUnschedulable,
+	})
+}
+
+// recorder holds the result of the loop which is currently being replayed.
+type recorder struct {
+	current *LoopResult
+}
+
+// scaleUpRecorder records scale-up statuses.
+type scaleUpRecorder struct {
+	*recorder
+}
+
+// Process records the scale-up status.
+func (r *scaleUpRecorder) Process(autoscalingCtx *ca_context.AutoscalingContext, scaleUpStatus *status.ScaleUpStatus) {
+	r.current.ScaleUpResult = scaleUpResults[scaleUpStatus.Result]
+	for _, info := range scaleUpStatus.ScaleUpInfos {
+		r.current.ScaleUps = append(r.current.ScaleUps, &ScaleUp{
+			NodeGroup:   info.Group.Id(),
+			CurrentSize: info.CurrentSize,
+			NewSize:     info.NewSize,
+		})
+	}
+	for _, pod := range scaleUpStatus.PodsTriggeredScaleUp {
+		r.current.PodsTriggeredScaleUp = append(r.current.PodsTriggeredScaleUp, podName(pod))
+	}
+	for _, noScaleUp := range scaleUpStatus.PodsRemainUnschedulable {
+		r.current.PodsRemainUnschedulable = append(r.current.PodsRemainUnschedulable, podName(noScaleUp.Pod))
+	}
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (r *scaleUpRecorder) CleanUp() {}
+
+// scaleDownRecorder records scale-down statuses.
+type scaleDownRecorder struct {
+	*recorder
+}
+
+// Process records the scale-down status.
+func (r *scaleDownRecorder) Process(autoscalingCtx *ca_context.AutoscalingContext, scaleDownStatus *scaledownstatus.ScaleDownStatus) {
+	r.current.ScaleDownResult = scaleDownResults[scaleDownStatus.Result]
+	for _, scaledDown := range scaleDownStatus.ScaledDownNodes {
+		sd := &ScaleDown{Node: scaledDown.Node.Name}
+		if scaledDown.NodeGroup != nil {
+			sd.NodeGroup = scaledDown.NodeGroup.Id()
+		}
+		for _, pod := range scaledDown.EvictedPods {
+			sd.EvictedPods = append(sd.EvictedPods, podName(pod))
+		}
+		r.current.ScaleDowns = append(r.current.ScaleDowns, sd)
+	}
+	for _, unremovable := range scaleDownStatus.UnremovableNodes {
+		if r.current.UnremovableNodesByReason == nil {
+			r.current.UnremovableNodesByReason = make(map[string]int)
+		}
+		r.current.UnremovableNodesByReason[unremovable.Reason.Name()]++
+	}
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (r *scaleDownRecorder) CleanUp() {}
+
+var scaleUpResults = map[status.ScaleUpResult]string{
+	status.ScaleUpSuccessful:             "Successful",
+	status.ScaleUpError:                  "Error",
+	status.ScaleUpNoOptionsAvailable:     "NoOptionsAvailable",
+	status.ScaleUpNotNeeded:              "NotNeeded",
+	status.ScaleUpNotTried:               "NotTried",
+	status.ScaleUpInCooldown:             "InCooldown",
+	status.ScaleUpLimitedByMaxNodesTotal: "LimitedByMaxNodesTotal",
+}
+
+var scaleDownResults = map[scaledownstatus.ScaleDownResult]string{
+	scaledownstatus.ScaleDownError:             "Error",
+	scaledownstatus.ScaleDownNoNodeDeleted:     "NoNodeDeleted",
+	scaledownstatus.ScaleDownNodeDeleteStarted: "NodeDeleteStarted",
+	scaledownstatus.ScaleDownNotTried:          "NotTried",
+	scaledownstatus.ScaleDownInCooldown:        "InCooldown",
+	scaledownstatus.ScaleDownInProgress:        "InProgress",
+	scaledownstatus.ScaleDownNoCandidates:      "NoCandidates",
+}
+
+func podName(pod *apiv1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
+
+// Print writes a human readable summary of the result.
+func (r *Result) Print(w io.Writer) {
+	for i, loop := range r.Loops {
+		fmt.Fprintf(w, "Loop %d (%s)\n", i, loop.Time.Format(time.RFC3339))
+		if loop.Error != "" {
+			fmt.Fprintf(w, "  error: %s\n", loop.Error)
+		}
+		fmt.Fprintf(w, "  scale-up: %s\n", loop.ScaleUpResult)
+		for _, su := range loop.ScaleUps {
+			fmt.Fprintf(w, "    %s: %d -> %d\n", su.NodeGroup, su.CurrentSize, su.NewSize)
+		}
+		if len(loop.PodsTriggeredScaleUp) > 0 {
+			fmt.Fprintf(w, "    pods triggered scale-up: %d\n", len(loop.PodsTriggeredScaleUp))
+		}
+		if len(loop.PodsRemainUnschedulable) > 0 {
+			fmt.Fprintf(w, "    pods remain unschedulable: %d\n", len(loop.PodsRemainUnschedulable))
+		}
+		fmt.Fprintf(w, "  scale-down: %s\n", loop.ScaleDownResult)
+		for _, sd := range loop.ScaleDowns {
+			fmt.Fprintf(w, "    %s/%s (%d pods evicted)\n", sd.NodeGroup, sd.Node, len(sd.EvictedPods))
+		}
+		reasons := make([]string, 0, len(loop.UnremovableNodesByReason))
+		for reason := range loop.UnremovableNodesByReason {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+		for _, reason := range reasons {
+			fmt.Fprintf(w, "    unremovable (%s): %d\n", reason, loop.UnremovableNodesByReason[reason])
+		}
+	}
+}
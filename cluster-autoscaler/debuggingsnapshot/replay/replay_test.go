@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func buildTestSnapshot() *debuggingsnapshot.DebuggingSnapshotImpl {
+	node := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(node, true, time.Now().Add(-time.Hour))
+	template := BuildTestNode("template-ng1", 1000, 1000)
+	SetNodeReadyState(template, true, time.Now().Add(-time.Hour))
+
+	return &debuggingsnapshot.DebuggingSnapshotImpl{
+		NodeList: []*debuggingsnapshot.ClusterNode{
+			{Node: node, Pods: []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 800, "n1")}},
+		},
+		UnschedulablePods: []*apiv1.Pod{BuildTestPod("p2", 800, 800)},
+		TemplateNodes: map[string]*debuggingsnapshot.ClusterNode{
+			"ng1": {Node: template},
+		},
+		NodeGroups: map[string]*debuggingsnapshot.NodeGroup{
+			"ng1": {MinSize: 1, MaxSize: 5, TargetSize: 1, Instances: []string{"n1"}},
+		},
+		StartTimestamp: time.Now(),
+	}
+}
+
+func testOptions() config.AutoscalingOptions {
+	return config.AutoscalingOptions{
+		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+			ScaleDownUnneededTime:         time.Minute,
+			ScaleDownUnreadyTime:          time.Minute,
+			ScaleDownUtilizationThreshold: 0.5,
+			MaxNodeProvisionTime:          10 * time.Second,
+		},
+		EstimatorName:                  "binpacking",
+		ExpanderNames:                  "least-waste",
+		MaxNodesTotal:                  10,
+		MaxCoresTotal:                  10,
+		MaxMemoryTotal:                 100000,
+		ScaleDownEnabled:               true,
+		ScanInterval:                   10 * time.Second,
+		MaxBinpackingTime:              10 * time.Second,
+		MaxNodeGroupBinpackingDuration: 10 * time.Second,
+		NodeDeletionBatcherInterval:    0 * time.Second,
+		MaxScaleDownParallelism:        10,
+		MaxDrainParallelism:            1,
+		ScaleUpFromZero:                true,
+		ParallelScaleUp:                false,
+	}
+}
+
+func TestRunScaleUp(t *testing.T) {
+	result, err := Run(buildTestSnapshot(), testOptions(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, result.Loops, 2)
+
+	loop := result.Loops[0]
+	assert.Empty(t, loop.Error)
+	assert.Equal(t, "Successful", loop.ScaleUpResult)
+	assert.Equal(t, []*ScaleUp{{NodeGroup: "ng1", CurrentSize: 1, NewSize: 2}}, loop.ScaleUps)
+	assert.Equal(t, []string{"default/p2"}, loop.PodsTriggeredScaleUp)
+
+	// The new node is upcoming in the second loop, so no further scale-up is needed.
+	assert.Empty(t, result.Loops[1].ScaleUps)
+
+	var out bytes.Buffer
+	result.Print(&out)
+	assert.Contains(t, out.String(), "ng1: 1 -> 2")
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	data, err := json.Marshal(buildTestSnapshot())
+	assert.NoError(t, err)
+	snapshot, err := LoadSnapshot(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.NodeList, 1)
+	assert.Len(t, snapshot.UnschedulablePods, 1)
+	assert.Equal(t, 5, snapshot.NodeGroups["ng1"].MaxSize)
+
+	_, err = LoadSnapshot(bytes.NewReader([]byte(`{"Error": "failed to collect"}`)))
+	assert.Error(t, err)
+}
@@ -18,6 +18,7 @@ package estimator
 
 import (
 	"fmt"
+	"maps"
 	"strconv"
 
 	"slices"
@@ -39,7 +40,7 @@ type BinpackingNodeEstimator struct {
 	podOrderer             EstimationPodOrderer
 	context                EstimationContext
 	estimationAnalyserFunc EstimationAnalyserFunc // optional
-
+	capacityCache          *CapacityCache         // optional
 }
 
 // estimationState contains helper variables to avoid coping them independently in each function.
@@ -49,6 +50,8 @@ type estimationState struct {
 	lastNodeName     string
 	newNodeNames     map[string]bool
 	newNodesWithPods map[string]bool
+	// templateGeneration identifies the node template in capacity cache keys.
+	templateGeneration uint64
 }
 
 func (s *estimationState) trackScheduledPod(pod *apiv1.Pod, nodeName string) {
@@ -56,6 +59,17 @@ func (s *estimationState) trackScheduledPod(pod *apiv1.Pod, nodeName string) {
 	s.scheduledPods = append(s.scheduledPods, pod)
 }
 
+func (s *estimationState) clone() *estimationState {
+	return &estimationState{
+		scheduledPods:      s.scheduledPods[:len(s.scheduledPods):len(s.scheduledPods)],
+		newNodeNameIndex:   s.newNodeNameIndex,
+		lastNodeName:       s.lastNodeName,
+		newNodeNames:       maps.Clone(s.newNodeNames),
+		newNodesWithPods:   maps.Clone(s.newNodesWithPods),
+		templateGeneration: s.templateGeneration,
+	}
+}
+
 // NewBinpackingNodeEstimator builds a new BinpackingNodeEstimator.
 func NewBinpackingNodeEstimator(
 	clusterSnapshot clustersnapshot.ClusterSnapshot,
@@ -63,6 +77,7 @@ func NewBinpackingNodeEstimator(
 	podOrderer EstimationPodOrderer,
 	context EstimationContext,
 	estimationAnalyserFunc EstimationAnalyserFunc,
+	capacityCache *CapacityCache,
 ) *BinpackingNodeEstimator {
 	return &BinpackingNodeEstimator{
 		clusterSnapshot:        clusterSnapshot,
@@ -70,6 +85,7 @@ func NewBinpackingNodeEstimator(
 		podOrderer:             podOrderer,
 		context:                context,
 		estimationAnalyserFunc: estimationAnalyserFunc,
+		capacityCache:          capacityCache,
 	}
 }
 
@@ -92,6 +108,8 @@ func newEstimationState() *estimationState {
 // will be cpu thus the estimated overprovisioning of 11/9 * optimal + 6/9 should be
 // still be maintained.
 // It is assumed that all pods from the given list can fit to nodeTemplate.
+// Pods belonging to a pod group are estimated all-or-nothing: unless at least
+// min-member of them fit, none of them are scheduled.
 // Returns the number of nodes needed to accommodate all pods from the list.
 func (e *BinpackingNodeEstimator) Estimate(
 	podsEquivalenceGroups []PodEquivalenceGroup,
@@ -110,25 +128,31 @@ func (e *BinpackingNodeEstimator) Estimate(
 		e.clusterSnapshot.Revert()
 	}()
 
+	podGroups := make(map[string][]PodEquivalenceGroup)
+	for _, podsEquivalenceGroup := range podsEquivalenceGroups {
+		if podsEquivalenceGroup.PodGroup != "" {
+			podGroups[podsEquivalenceGroup.PodGroup] = append(podGroups[podsEquivalenceGroup.PodGroup], podsEquivalenceGroup)
+		}
+	}
+
 	estimationState := newEstimationState()
+	if e.capacityCache != nil {
+		estimationState.templateGeneration = templateGeneration(nodeTemplate)
+	}
 	newNodesAvailable := true
 	for _, podsEquivalenceGroup := range podsEquivalenceGroups {
 		var err error
-		var remainingPods []*apiv1.Pod
-
-		remainingPods, err = e.tryToScheduleOnExistingNodes(estimationState, podsEquivalenceGroup.Pods)
+		if podsEquivalenceGroup.PodGroup == "" {
+			newNodesAvailable, err = e.tryToSchedule(estimationState, nodeTemplate, podsEquivalenceGroup.Pods, newNodesAvailable)
+		} else if pegs, found := podGroups[podsEquivalenceGroup.PodGroup]; found {
+			// All equivalence groups of a pod group are estimated together, when the first one comes up.
+			delete(podGroups, podsEquivalenceGroup.PodGroup)
+			newNodesAvailable, err = e.tryToSchedulePodGroup(estimationState, nodeTemplate, pegs, newNodesAvailable)
+		}
 		if err != nil {
 			klog.Error(err.Error())
 			return 0, nil
 		}
-
-		if newNodesAvailable {
-			newNodesAvailable, err = e.tryToScheduleOnNewNodes(estimationState, nodeTemplate, remainingPods)
-			if err != nil {
-				klog.Error(err.Error())
-				return 0, nil
-			}
-		}
 	}
 
 	if e.estimationAnalyserFunc != nil {
@@ -137,6 +161,77 @@ func (e *BinpackingNodeEstimator) Estimate(
 	return len(estimationState.newNodesWithPods), estimationState.scheduledPods
 }
 
+// tryToSchedule schedules pods on nodes added in the simulation so far, and
+// then on new nodes if they are still available. Returns whether new nodes are
+// still available.
+func (e *BinpackingNodeEstimator) tryToSchedule(
+	estimationState *estimationState,
+	nodeTemplate *framework.NodeInfo,
+	pods []*apiv1.Pod,
+	newNodesAvailable bool,
+) (bool, error) {
+	remainingPods, err := e.tryToScheduleOnExistingNodes(estimationState, pods)
+	if err != nil {
+		return false, err
+	}
+	if !newNodesAvailable {
+		return false, nil
+	}
+	return e.tryToScheduleOnNewNodes(estimationState, nodeTemplate, remainingPods)
+}
+
+// tryToSchedulePodGroup schedules pods of a single pod group, coming from one
+// or more equivalence groups. If fewer pods than min-member of the pod group
+// can be scheduled, the pod group is reverted from the simulation and the nodes
+// added for it are returned to the limiter.
+func (e *BinpackingNodeEstimator) tryToSchedulePodGroup(
+	estimationState *estimationState,
+	nodeTemplate *framework.NodeInfo,
+	podsEquivalenceGroups []PodEquivalenceGroup,
+	newNodesAvailable bool,
+) (bool, error) {
+	pending, podGroupPending, minMember := 0, 0, 0
+	for _, podsEquivalenceGroup := range podsEquivalenceGroups {
+		pending += len(podsEquivalenceGroup.Pods)
+		podGroupPending = max(podGroupPending, podsEquivalenceGroup.PodGroupPending)
+		minMember = max(minMember, podsEquivalenceGroup.PodGroupMinMember)
+	}
+	// Pods which can't be scheduled on the node group still count, so that the
+	// pod group isn't partially scaled up.
+	pending = max(pending, podGroupPending)
+	required := pending
+	if minMember > 0 && minMember < pending {
+		required = minMember
+	}
+
+	previousState := estimationState.clone()
+	e.clusterSnapshot.Fork()
+	podGroupNewNodesAvailable := newNodesAvailable
+	var err error
+	for _, podsEquivalenceGroup := range podsEquivalenceGroups {
+		podGroupNewNodesAvailable, err = e.tryToSchedule(estimationState, nodeTemplate, podsEquivalenceGroup.Pods, podGroupNewNodesAvailable)
+		if err != nil {
+			e.clusterSnapshot.Revert()
+			return false, err
+		}
+	}
+
+	if scheduled := len(estimationState.scheduledPods) - len(previousState.scheduledPods); scheduled < required {
+		klog.V(4).Infof("Only %d out of %d required pods of pod group %s fit, skipping the pod group", scheduled, required, podsEquivalenceGroups[0].PodGroup)
+		e.clusterSnapshot.Revert()
+		if limiter, ok := e.limiter.(NodeReturningEstimationLimiter); ok {
+			limiter.ReturnNodes(estimationState.newNodeNameIndex - previousState.newNodeNameIndex)
+		}
+		*estimationState = *previousState
+		return newNodesAvailable, nil
+	}
+	if err := e.clusterSnapshot.Commit(); err != nil {
+		e.clusterSnapshot.Revert()
+		return false, fmt.Errorf("Error while committing pod group %s to ClusterSnapshot; %w", podsEquivalenceGroups[0].PodGroup, err)
+	}
+	return podGroupNewNodesAvailable, nil
+}
+
 func (e *BinpackingNodeEstimator) tryToScheduleOnExistingNodes(
 	estimationState *estimationState,
 	pods []*apiv1.Pod,
@@ -169,19 +264,36 @@ func (e *BinpackingNodeEstimator) tryToScheduleOnNewNodes(
 	nodeTemplate *framework.NodeInfo,
 	pods []*apiv1.Pod,
 ) (bool, error) {
-	for _, pod := range pods {
+	cacheable := e.capacityCache != nil && len(pods) > 0 && isCapacityCacheable(pods[0])
+	var cacheKey capacityCacheKey
+	if cacheable {
+		cacheKey = capacityCacheKey{templateGeneration: estimationState.templateGeneration, pod: podHash(pods[0])}
+	}
+	// freshNode is the last node added for these pods while the capacity cache
+	// could be used, and freshPods the number of them scheduled on it so far.
+	freshNode, freshPods, freshNodeFull := "", 0, false
+
+	for i := 0; i < len(pods); i++ {
+		pod := pods[i]
 		found := false
 
-		if estimationState.lastNodeName != "" {
+		if estimationState.lastNodeName != "" && !(freshNodeFull && estimationState.lastNodeName == freshNode) {
 			// Try to schedule the pod on only newly created node.
 			err := e.clusterSnapshot.SchedulePod(pod, estimationState.lastNodeName)
 			if err == nil {
 				// The pod was scheduled on the newly created node.
 				found = true
 				estimationState.trackScheduledPod(pod, estimationState.lastNodeName)
+				if estimationState.lastNodeName == freshNode {
+					freshPods++
+				}
 			} else if err.Type() == clustersnapshot.SchedulingInternalError {
 				// Unexpected error.
 				return false, err
+			} else if estimationState.lastNodeName == freshNode && freshPods > 0 {
+				// The new node is full, remember how many pods it took.
+				e.capacityCache.set(cacheKey, freshPods)
+				freshNode = ""
 			}
 			// The pod can't be scheduled on the newly created node because of scheduling predicates.
 
@@ -227,6 +339,25 @@ func (e *BinpackingNodeEstimator) tryToScheduleOnNewNodes(
 				return false, fmt.Errorf("Error while adding new node for template to ClusterSnapshot; %w", err)
 			}
 
+			freshNode, freshPods, freshNodeFull = "", 0, false
+			if cacheable && !e.haveRequiredAntiAffinity() {
+				freshNode = estimationState.lastNodeName
+				if capacity, found := e.capacityCache.get(cacheKey); found {
+					// Fill the new node without checking predicates, they passed
+					// for the same number of equivalent pods on an equivalent node.
+					scheduled := min(capacity, len(pods)-i)
+					for _, cachedPod := range pods[i : i+scheduled] {
+						if err := e.clusterSnapshot.ForceAddPod(cachedPod, freshNode); err != nil {
+							return false, fmt.Errorf("Error while adding pod to new node in ClusterSnapshot; %w", err)
+						}
+						estimationState.trackScheduledPod(cachedPod, freshNode)
+					}
+					i += scheduled - 1
+					freshPods, freshNodeFull = scheduled, scheduled == capacity
+					continue
+				}
+			}
+
 			// And try to schedule pod to it.
 			// Note that this may still fail (ex. if topology spreading with zonal topologyKey is used);
 			// in this case we can't help the pending pod. We keep the node in clusterSnapshot to avoid
@@ -240,6 +371,9 @@ func (e *BinpackingNodeEstimator) tryToScheduleOnNewNodes(
 			}
 			// The pod got scheduled on the new node.
 			estimationState.trackScheduledPod(pod, estimationState.lastNodeName)
+			if estimationState.lastNodeName == freshNode {
+				freshPods++
+			}
 		}
 	}
 	return true, nil
@@ -262,6 +396,13 @@ func (e *BinpackingNodeEstimator) addNewNodeToSnapshot(
 	return nil
 }
 
+// haveRequiredAntiAffinity returns true if any pod in the snapshot has required
+// anti-affinity, which may keep pods from being scheduled on new nodes.
+func (e *BinpackingNodeEstimator) haveRequiredAntiAffinity() bool {
+	nodeInfos, err := e.clusterSnapshot.NodeInfos().HavePodsWithRequiredAntiAffinityList()
+	return err != nil || len(nodeInfos) > 0
+}
+
 // isTopologyConstraintError determines if an error is related to pod topology spread constraints
 // by checking the predicate name and reasons
 func hasTopologyConstraintError(err clustersnapshot.SchedulingError) bool {
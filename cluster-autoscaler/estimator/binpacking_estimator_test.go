@@ -41,6 +41,13 @@ func makePodEquivalenceGroup(pod *apiv1.Pod, podCount int) PodEquivalenceGroup {
 	}
 }
 
+func makePodGroupEquivalenceGroup(pod *apiv1.Pod, podCount int, podGroup string, minMember int) PodEquivalenceGroup {
+	peg := makePodEquivalenceGroup(pod, podCount)
+	peg.PodGroup = podGroup
+	peg.PodGroupMinMember = minMember
+	return peg
+}
+
 func makeNode(cpu, mem, podCount int64, name string, zone string) *apiv1.Node {
 	node := &apiv1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -222,31 +229,134 @@ func TestBinpackingEstimate(t *testing.T) {
 			expectNodeCount: 3,
 			expectPodCount:  12,
 		},
+		{
+			name:       "pod group exceeding max nodes is skipped",
+			millicores: 1000,
+			memory:     5000,
+			maxNodes:   3,
+			podsEquivalenceGroup: []PodEquivalenceGroup{makePodGroupEquivalenceGroup(
+				BuildTestPod("worker", 600, 1000, WithNamespace("universe")), 4, "universe/job", 0)},
+			expectNodeCount: 0,
+			expectPodCount:  0,
+		},
+		{
+			name:       "pod group with min-member fitting within max nodes",
+			millicores: 1000,
+			memory:     5000,
+			maxNodes:   3,
+			podsEquivalenceGroup: []PodEquivalenceGroup{makePodGroupEquivalenceGroup(
+				BuildTestPod("worker", 600, 1000, WithNamespace("universe")), 4, "universe/job", 3)},
+			expectNodeCount: 3,
+			expectPodCount:  3,
+		},
+		{
+			name:       "pod group with members not fitting the node group is skipped",
+			millicores: 1000,
+			memory:     5000,
+			maxNodes:   10,
+			podsEquivalenceGroup: []PodEquivalenceGroup{func() PodEquivalenceGroup {
+				peg := makePodGroupEquivalenceGroup(BuildTestPod("worker", 600, 1000, WithNamespace("universe")), 3, "universe/job", 0)
+				peg.PodGroupPending = 4
+				return peg
+			}()},
+			expectNodeCount: 0,
+			expectPodCount:  0,
+		},
+		{
+			name:       "pod group split across equivalence groups is estimated together",
+			millicores: 1000,
+			memory:     5000,
+			maxNodes:   2,
+			podsEquivalenceGroup: []PodEquivalenceGroup{
+				makePodGroupEquivalenceGroup(BuildTestPod("launcher", 100, 100, WithNamespace("universe")), 1, "universe/job", 0),
+				makePodEquivalenceGroup(BuildTestPod("web", 300, 1000, WithNamespace("universe")), 2),
+				makePodGroupEquivalenceGroup(BuildTestPod("worker", 600, 1000, WithNamespace("universe")), 3, "universe/job", 0),
+			},
+			expectNodeCount: 1,
+			expectPodCount:  2,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			clusterSnapshot := testsnapshot.NewTestSnapshotOrDie(t)
-			// Add one node in different zone to trigger topology spread constraints
-			err := clusterSnapshot.AddNodeInfo(framework.NewTestNodeInfo(makeNode(100, 100, 10, "oldnode", "zone-jupiter")))
-			assert.NoError(t, err)
+			// The capacity cache is filled by the first estimation and used by
+			// the second one, neither should change the result.
+			capacityCache := NewCapacityCache(DefaultCapacityCacheTTL)
+			for _, cache := range []*CapacityCache{nil, capacityCache, capacityCache} {
+				clusterSnapshot := testsnapshot.NewTestSnapshotOrDie(t)
+				// Add one node in different zone to trigger topology spread constraints
+				err := clusterSnapshot.AddNodeInfo(framework.NewTestNodeInfo(makeNode(100, 100, 10, "oldnode", "zone-jupiter")))
+				assert.NoError(t, err)
 
-			limiter := NewThresholdBasedEstimationLimiter([]Threshold{NewStaticThreshold(tc.maxNodes, time.Duration(0))})
-			processor := NewDecreasingPodOrderer()
-			estimator := NewBinpackingNodeEstimator(clusterSnapshot, limiter, processor, nil /* EstimationContext */, nil /* EstimationAnalyserFunc */)
-			node := makeNode(tc.millicores, tc.memory, 10, "template", "zone-mars")
-			nodeInfo := framework.NewTestNodeInfo(node)
+				limiter := NewThresholdBasedEstimationLimiter([]Threshold{NewStaticThreshold(tc.maxNodes, time.Duration(0))})
+				processor := NewDecreasingPodOrderer()
+				estimator := NewBinpackingNodeEstimator(clusterSnapshot, limiter, processor, nil /* EstimationContext */, nil /* EstimationAnalyserFunc */, cache)
+				node := makeNode(tc.millicores, tc.memory, 10, "template", "zone-mars")
+				nodeInfo := framework.NewTestNodeInfo(node)
 
-			estimatedNodes, estimatedPods := estimator.Estimate(tc.podsEquivalenceGroup, nodeInfo, nil)
-			assert.Equal(t, tc.expectNodeCount, estimatedNodes)
-			assert.Equal(t, tc.expectPodCount, len(estimatedPods))
-			if tc.expectProcessedPods != nil {
-				assert.Equal(t, tc.expectProcessedPods, estimatedPods)
+				estimatedNodes, estimatedPods := estimator.Estimate(tc.podsEquivalenceGroup, nodeInfo, nil)
+				assert.Equal(t, tc.expectNodeCount, estimatedNodes)
+				assert.Equal(t, tc.expectPodCount, len(estimatedPods))
+				if tc.expectProcessedPods != nil {
+					assert.Equal(t, tc.expectProcessedPods, estimatedPods)
+				}
 			}
 		})
 	}
 }
 
+func TestCapacityCache(t *testing.T) {
+	now := time.Now()
+	cache := NewCapacityCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	pod := BuildTestPod("p", 100, 100)
+	key := capacityCacheKey{templateGeneration: templateGeneration(framework.NewTestNodeInfo(makeNode(1000, 1000, 10, "n1", "zone-mars"))), pod: podHash(pod)}
+
+	// Templates differing only by name share entries, any other change of the template makes a new generation.
+	assert.Equal(t, key.templateGeneration, templateGeneration(framework.NewTestNodeInfo(makeNode(1000, 1000, 10, "n2", "zone-mars"))))
+	assert.NotEqual(t, key.templateGeneration, templateGeneration(framework.NewTestNodeInfo(makeNode(2000, 1000, 10, "n1", "zone-mars"))))
+	assert.NotEqual(t, key.templateGeneration, templateGeneration(framework.NewTestNodeInfo(makeNode(1000, 1000, 10, "n1", "zone-mars"), BuildScheduledTestPod("ds", 100, 100, "n1"))))
+	annotated := makeNode(1000, 1000, 10, "n1", "zone-mars")
+	annotated.Annotations = map[string]string{"example.com/gpu-sharing": "time-slicing"}
+	assert.NotEqual(t, key.templateGeneration, templateGeneration(framework.NewTestNodeInfo(annotated)))
+	assert.NotEqual(t, key.pod, podHash(BuildTestPod("p", 200, 100)))
+
+	_, found := cache.get(key)
+	assert.False(t, found)
+	cache.set(key, 7)
+	capacity, found := cache.get(key)
+	assert.True(t, found)
+	assert.Equal(t, 7, capacity)
+
+	// Entries are forgotten once unused for the TTL.
+	now = now.Add(59 * time.Second)
+	_, found = cache.get(key)
+	assert.True(t, found)
+	now = now.Add(time.Minute)
+	_, found = cache.get(key)
+	assert.False(t, found)
+}
+
+func TestIsCapacityCacheable(t *testing.T) {
+	assert.True(t, isCapacityCacheable(BuildTestPod("p", 100, 100)))
+	assert.True(t, isCapacityCacheable(BuildTestPod("p", 100, 100, WithHostPort(8080))))
+	assert.False(t, isCapacityCacheable(BuildTestPod("p", 100, 100, WithMaxSkew(1, "kubernetes.io/hostname", 1))))
+	withAntiAffinity := BuildTestPod("p", 100, 100)
+	withAntiAffinity.Spec.Affinity = &apiv1.Affinity{PodAntiAffinity: &apiv1.PodAntiAffinity{}}
+	assert.False(t, isCapacityCacheable(withAntiAffinity))
+	withPVC := BuildTestPod("p", 100, 100)
+	withPVC.Spec.Volumes = []apiv1.Volume{{Name: "data", VolumeSource: apiv1.VolumeSource{PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}}}
+	assert.False(t, isCapacityCacheable(withPVC))
+}
+
 func BenchmarkBinpackingEstimate(b *testing.B) {
+	benchmarkBinpackingEstimate(b, nil)
+}
+
+func BenchmarkBinpackingEstimateWithCapacityCache(b *testing.B) {
+	benchmarkBinpackingEstimate(b, NewCapacityCache(DefaultCapacityCacheTTL))
+}
+
+func benchmarkBinpackingEstimate(b *testing.B, capacityCache *CapacityCache) {
 	millicores := int64(1000)
 	memory := int64(5000)
 	podsPerNode := int64(100)
@@ -278,14 +388,18 @@ func BenchmarkBinpackingEstimate(b *testing.B) {
 		),
 	}
 
-	for i := 0; i < b.N; i++ {
+	// The first estimation fills the capacity cache, if any.
+	for i := -1; i < b.N; i++ {
+		if i == 0 {
+			b.ResetTimer()
+		}
 		clusterSnapshot := testsnapshot.NewTestSnapshotOrDie(b)
 		err := clusterSnapshot.AddNodeInfo(framework.NewTestNodeInfo(makeNode(100, 100, 10, "oldnode", "zone-jupiter")))
 		assert.NoError(b, err)
 
 		limiter := NewThresholdBasedEstimationLimiter([]Threshold{NewStaticThreshold(maxNodes, time.Duration(0))})
 		processor := NewDecreasingPodOrderer()
-		estimator := NewBinpackingNodeEstimator(clusterSnapshot, limiter, processor, nil /* EstimationContext */, nil /* EstimationAnalyserFunc */)
+		estimator := NewBinpackingNodeEstimator(clusterSnapshot, limiter, processor, nil /* EstimationContext */, nil /* EstimationAnalyserFunc */, capacityCache)
 		node := makeNode(millicores, memory, podsPerNode, "template", "zone-mars")
 		nodeInfo := framework.NewTestNodeInfo(node)
 
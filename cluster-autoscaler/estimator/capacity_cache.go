@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+// DefaultCapacityCacheTTL is the time after which unused capacity cache
+// entries are forgotten.
+const DefaultCapacityCacheTTL = time.Hour
+
+// CapacityCache remembers, across loops, how many pods of an equivalence
+// group fit on a new node created from a template, so that binpacking can
+// fill new nodes without running scheduler predicates for each pod.
+//
+// Entries are keyed by the generation of the template and the hash of the
+// pod. Only pods whose fit depends on nothing but the node and the pod itself
+// are cached, see isCapacityCacheable. The remaining state of the snapshot
+// which could affect them is pods with required anti-affinity, and the cache
+// isn't used while there are any. Cached placements skip scheduler filter
+// extenders, so the cache mustn't be used when any are configured.
+type CapacityCache struct {
+	mutex     sync.Mutex
+	entries   map[capacityCacheKey]*capacityCacheEntry
+	ttl       time.Duration
+	lastPurge time.Time
+	now       func() time.Time
+}
+
+type capacityCacheKey struct {
+	templateGeneration uint64
+	pod                uint64
+}
+
+type capacityCacheEntry struct {
+	pods     int
+	lastUsed time.Time
+}
+
+// NewCapacityCache returns a CapacityCache forgetting entries which weren't
+// used for ttl.
+func NewCapacityCache(ttl time.Duration) *CapacityCache {
+	return &CapacityCache{
+		entries: make(map[capacityCacheKey]*capacityCacheEntry),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// get returns the number of pods fitting on a new node, if known.
+func (c *CapacityCache) get(key capacityCacheKey) (int, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	c.purge(now)
+	entry, found := c.entries[key]
+	metrics.RegisterBinpackingCapacityCacheLookup(found)
+	if !found {
+		return 0, false
+	}
+	entry.lastUsed = now
+	return entry.pods, true
+}
+
+// set remembers the number of pods fitting on a new node.
+func (c *CapacityCache) set(key capacityCacheKey, pods int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[key] = &capacityCacheEntry{pods: pods, lastUsed: c.now()}
+}
+
+// purge drops entries unused for ttl, at most once per ttl.
+func (c *CapacityCache) purge(now time.Time) {
+	if now.Sub(c.lastPurge) < c.ttl {
+		return
+	}
+	c.lastPurge = now
+	for key, entry := range c.entries {
+		if now.Sub(entry.lastUsed) >= c.ttl {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// isCapacityCacheable returns true if whether the pod fits on a node depends
+// only on the node and the pods already running there.
+func isCapacityCacheable(pod *apiv1.Pod) bool {
+	if pod.Spec.NodeName != "" || len(pod.Spec.TopologySpreadConstraints) > 0 || len(pod.Spec.ResourceClaims) > 0 {
+		return false
+	}
+	if affinity := pod.Spec.Affinity; affinity != nil && (affinity.PodAffinity != nil || affinity.PodAntiAffinity != nil) {
+		return false
+	}
+	for _, volume := range pod.Spec.Volumes {
+		// Volumes may be bound to specific topologies and count towards
+		// per-node attach limits.
+		if volume.PersistentVolumeClaim != nil || volume.Ephemeral != nil || volume.CSI != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// templateGeneration identifies the content of a template node. It hashes the
+// labels, annotations, spec and status of the node and the specs of its pods,
+// leaving out only what differs between copies of the same template: object
+// metadata other than labels and annotations, the hostname label and condition
+// timestamps. Any other change of the template, e.g. after it is rebuilt from a
+// different node, results in a new generation.
+func templateGeneration(nodeTemplate *framework.NodeInfo) uint64 {
+	node := nodeTemplate.Node().DeepCopy()
+	node.ObjectMeta = metav1.ObjectMeta{Labels: node.Labels, Annotations: node.Annotations}
+	delete(node.Labels, apiv1.LabelHostname)
+	for i := range node.Status.Conditions {
+		node.Status.Conditions[i].LastHeartbeatTime = metav1.Time{}
+		node.Status.Conditions[i].LastTransitionTime = metav1.Time{}
+	}
+	pods := make([]apiv1.PodSpec, 0, len(nodeTemplate.Pods()))
+	for _, podInfo := range nodeTemplate.Pods() {
+		spec := podInfo.Pod.Spec
+		spec.NodeName = ""
+		pods = append(pods, spec)
+	}
+	return hashJSON(struct {
+		Node           *apiv1.Node
+		Pods           []apiv1.PodSpec
+		ResourceSlices int
+	}{node, pods, len(nodeTemplate.LocalResourceSlices)})
+}
+
+// podHash hashes the spec of a pod.
+func podHash(pod *apiv1.Pod) uint64 {
+	return hashJSON(pod.Spec)
+}
+
+func hashJSON(object interface{}) uint64 {
+	hasher := fnv.New64a()
+	// Errors can't happen when encoding API types.
+	_ = json.NewEncoder(hasher).Encode(object)
+	return hasher.Sum64()
+}
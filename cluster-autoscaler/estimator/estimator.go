@@ -37,6 +37,18 @@ var AvailableEstimators = []string{BinpackingEstimatorName}
 // requirements and are managed by the same controller.
 type PodEquivalenceGroup struct {
 	Pods []*apiv1.Pod
+	// PodGroup is the name of the pod group (gang) the pods belong to, if any.
+	// Pods of a pod group, possibly coming from several equivalence groups,
+	// are estimated all-or-nothing.
+	PodGroup string
+	// PodGroupMinMember is the minimum number of pods of the pod group which
+	// have to be scheduled together. 0 means all pending pods of the pod group.
+	PodGroupMinMember int
+	// PodGroupPending is the number of pending pods of the pod group across
+	// all its equivalence groups, including ones which can't be scheduled on
+	// the estimated node group. 0 means the pods of the equivalence groups
+	// passed to the estimator.
+	PodGroupPending int
 }
 
 // Exemplar returns an example pod from the group.
@@ -61,14 +73,15 @@ type EstimatorBuilder func(clustersnapshot.ClusterSnapshot, EstimationContext) E
 // EstimationAnalyserFunc to be run at the end of the estimation logic.
 type EstimationAnalyserFunc func(clustersnapshot.ClusterSnapshot, cloudprovider.NodeGroup, map[string]bool)
 
-// NewEstimatorBuilder creates a new estimator object from flag.
-func NewEstimatorBuilder(name string, limiter EstimationLimiter, orderer EstimationPodOrderer, estimationAnalyserFunc EstimationAnalyserFunc) (EstimatorBuilder, error) {
+// NewEstimatorBuilder creates a new estimator object from flag. capacityCache
+// is optional, and shared by all estimators built.
+func NewEstimatorBuilder(name string, limiter EstimationLimiter, orderer EstimationPodOrderer, estimationAnalyserFunc EstimationAnalyserFunc, capacityCache *CapacityCache) (EstimatorBuilder, error) {
 	switch name {
 	case BinpackingEstimatorName:
 		return func(
 			clusterSnapshot clustersnapshot.ClusterSnapshot,
 			context EstimationContext) Estimator {
-			return NewBinpackingNodeEstimator(clusterSnapshot, limiter, orderer, context, estimationAnalyserFunc)
+			return NewBinpackingNodeEstimator(clusterSnapshot, limiter, orderer, context, estimationAnalyserFunc, capacityCache)
 		}, nil
 	}
 	return nil, fmt.Errorf("unknown estimator: %s", name)
@@ -90,6 +103,15 @@ type EstimationLimiter interface {
 	PermissionToAddNode() bool
 }
 
+// NodeReturningEstimationLimiter is an optional extension of EstimationLimiter
+// for limiters which can take back permissions to add nodes. Estimators check
+// for it with a type assertion.
+type NodeReturningEstimationLimiter interface {
+	// ReturnNodes is called by an estimator when it removes nodes it was given
+	// permission for from simulation, so that they can be added again.
+	ReturnNodes(count int)
+}
+
 // EstimationPodOrderer is an interface used to determine the order of the pods
 // used while binpacking during scale up estimation
 type EstimationPodOrderer interface {
@@ -68,6 +68,10 @@ func (tbel *thresholdBasedEstimationLimiter) PermissionToAddNode() bool {
 	return true
 }
 
+func (tbel *thresholdBasedEstimationLimiter) ReturnNodes(count int) {
+	tbel.nodes = max(tbel.nodes-count, 0)
+}
+
 // NewThresholdBasedEstimationLimiter returns an EstimationLimiter that will prevent estimation
 // after either a node count of time-based threshold is reached. This is meant to prevent cases
 // where binpacking of hundreds or thousands of nodes takes extremely long time rendering CA
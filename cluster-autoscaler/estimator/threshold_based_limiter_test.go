@@ -34,6 +34,10 @@ func expectAllow(t *testing.T, l EstimationLimiter) {
 	assert.Equal(t, true, l.PermissionToAddNode())
 }
 
+func returnTwoNodes(_ *testing.T, l EstimationLimiter) {
+	l.(NodeReturningEstimationLimiter).ReturnNodes(2)
+}
+
 func resetLimiter(_ *testing.T, l EstimationLimiter) {
 	l.EndEstimation()
 	l.StartEstimation([]PodEquivalenceGroup{}, nil, nil)
@@ -82,6 +86,21 @@ func TestThresholdBasedLimiter(t *testing.T) {
 			expectNodeCount: 0,
 			thresholds:      []Threshold{NewStaticThreshold(20, 5*time.Second)},
 		},
+		{
+			name: "returned nodes can be added again",
+			operations: []limiterOperation{
+				expectAllow,
+				expectAllow,
+				expectAllow,
+				expectDeny,
+				returnTwoNodes,
+				expectAllow,
+				expectAllow,
+				expectDeny,
+			},
+			expectNodeCount: 3,
+			thresholds:      []Threshold{NewStaticThreshold(3, 0)},
+		},
 		{
 			name: "sequence of additions works until the threshold is hit",
 			operations: []limiterOperation{
@@ -17,8 +17,11 @@ limitations under the License.
 package factory
 
 import (
+	"context"
+
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/tracing"
 )
 
 type chainStrategy struct {
@@ -33,6 +36,15 @@ func newChainStrategy(filters []expander.Filter, fallback expander.Strategy) exp
 	}
 }
 
+// WithContext returns a copy of the chain with its filters and fallback bound to ctx.
+func (c *chainStrategy) WithContext(ctx context.Context) expander.Strategy {
+	filters := make([]expander.Filter, 0, len(c.filters))
+	for _, filter := range c.filters {
+		filters = append(filters, tracing.Bind(ctx, filter))
+	}
+	return newChainStrategy(filters, tracing.Bind(ctx, c.fallback))
+}
+
 func (c *chainStrategy) BestOption(options []expander.Option, nodeInfo map[string]*framework.NodeInfo) *expander.Option {
 	filteredOptions := options
 	for _, filter := range c.filters {
@@ -17,12 +17,14 @@ limitations under the License.
 package factory
 
 import (
+	"context"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/tracing"
 )
 
 type substringTestFilterStrategy struct {
@@ -131,3 +133,29 @@ func newOption(debug string) *expander.Option {
 		Debug: debug,
 	}
 }
+
+type contextTestFilter struct {
+	ctx context.Context
+}
+
+func (f *contextTestFilter) WithContext(ctx context.Context) expander.Filter {
+	return &contextTestFilter{ctx: ctx}
+}
+
+func (f *contextTestFilter) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) []expander.Option {
+	return expansionOptions
+}
+
+type testContextKey struct{}
+
+func TestChainStrategy_WithContext(t *testing.T) {
+	filter := &contextTestFilter{}
+	chain := newChainStrategy([]expander.Filter{newSubstringTestFilterStrategy("a"), filter}, newSubstringTestFilterStrategy("a"))
+	ctx := context.WithValue(context.Background(), testContextKey{}, "loop")
+
+	bound := tracing.Bind(ctx, chain).(*chainStrategy)
+	assert.Len(t, bound.filters, 2)
+	assert.Equal(t, ctx, bound.filters[1].(*contextTestFilter).ctx)
+	assert.Nil(t, filter.ctx)
+	assert.Equal(t, newOption("a"), bound.BestOption([]expander.Option{*newOption("a"), *newOption("b")}, nil))
+}
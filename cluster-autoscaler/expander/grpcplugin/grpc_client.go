@@ -27,6 +27,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/klog/v2"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -38,6 +39,8 @@ const (
 
 type grpcclientstrategy struct {
 	grpcClient protos.ExpanderClient
+	// ctx is the parent context of gRPC calls, set by WithContext. Can be nil.
+	ctx context.Context
 }
 
 // NewFilter returns an expansion filter that creates a gRPC client, and calls out to a gRPC server
@@ -62,6 +65,7 @@ func createGRPCClient(expanderCert string, expanderUrl string) protos.ExpanderCl
 	dialOpts := []grpc.DialOption{
 		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(gRPCMaxRecvMsgSize)),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	}
 	klog.V(2).Infof("Dialing: %s with dialopt: %v", expanderUrl, dialOpts)
 	conn, err := grpc.Dial(expanderUrl, dialOpts...)
@@ -72,6 +76,11 @@ func createGRPCClient(expanderCert string, expanderUrl string) protos.ExpanderCl
 	return protos.NewExpanderClient(conn)
 }
 
+// WithContext returns a copy of the filter making its gRPC calls as children of the span in ctx.
+func (g *grpcclientstrategy) WithContext(ctx context.Context) expander.Filter {
+	return &grpcclientstrategy{grpcClient: g.grpcClient, ctx: ctx}
+}
+
 func (g *grpcclientstrategy) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) []expander.Option {
 	if g.grpcClient == nil {
 		klog.Errorf("Incorrect gRPC client config, filtering no options")
@@ -84,7 +93,11 @@ func (g *grpcclientstrategy) BestOptions(expansionOptions []expander.Option, nod
 
 	// call gRPC server to get BestOption
 	klog.V(2).Infof("GPRC call of best options to server with %v options", len(nodeGroupIDOptionMap))
-	ctx, cancel := context.WithTimeout(context.Background(), gRPCTimeout)
+	parent := g.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, gRPCTimeout)
 	defer cancel()
 	bestOptionsResponse, err := g.grpcClient.BestOptions(ctx, &protos.BestOptionsRequest{Options: grpcOptionsSlice, NodeMap: grpcNodeMap})
 	if err != nil {
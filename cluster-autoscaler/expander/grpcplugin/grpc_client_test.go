@@ -175,7 +175,7 @@ func TestBestOptionsValid(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockClient := mocks.NewMockExpanderClient(ctrl)
-	g := &grpcclientstrategy{mockClient}
+	g := &grpcclientstrategy{grpcClient: mockClient}
 
 	nodeInfos := makeFakeNodeInfos()
 	grpcNodeInfoMap := make(map[string]*v1.Node)
@@ -200,7 +200,7 @@ func TestBestOptionsEmpty(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockClient := mocks.NewMockExpanderClient(ctrl)
-	g := grpcclientstrategy{mockClient}
+	g := grpcclientstrategy{grpcClient: mockClient}
 
 	testCases := []struct {
 		desc         string
@@ -238,7 +238,7 @@ func TestBestOptionsErrors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockClient := mocks.NewMockExpanderClient(ctrl)
-	g := grpcclientstrategy{mockClient}
+	g := grpcclientstrategy{grpcClient: mockClient}
 
 	badProtosOption := protos.Option{
 		NodeGroupId: "badID",
@@ -256,7 +256,7 @@ func TestBestOptionsErrors(t *testing.T) {
 	}{
 		{
 			desc:         "Bad gRPC client config",
-			client:       grpcclientstrategy{nil},
+			client:       grpcclientstrategy{grpcClient: nil},
 			nodeInfo:     makeFakeNodeInfos(),
 			mockResponse: protos.BestOptionsResponse{},
 			errResponse:  nil,
@@ -16,7 +16,7 @@
 
 ###
 # This script is to be used when updating the generated clients of 
-# the Provisioning Request CRD.
+# the Provisioning Request and ClusterAutoscalerStatus CRDs.
 ###
 
 set -o errexit
@@ -32,19 +32,23 @@ cd "${CURRENT_DIR}/.."
 # shellcheck source=/dev/null
 source "${CODEGEN_PKG}/kube_codegen.sh"
 
-kube::codegen::gen_helpers \
-    --boilerplate "${REPO_ROOT}/hack/boilerplate/boilerplate.generatego.txt" \
-    "${REPO_ROOT}/cluster-autoscaler/apis/provisioningrequest"
+for api in provisioningrequest clusterautoscalerstatus; do
+  kube::codegen::gen_helpers \
+      --boilerplate "${REPO_ROOT}/hack/boilerplate/boilerplate.generatego.txt" \
+      "${REPO_ROOT}/cluster-autoscaler/apis/${api}"
+done
 
 echo "Ran gen helpers, moving on to generating client code..."
 
-kube::codegen::gen_client \
-    --output-pkg k8s.io/autoscaler/cluster-autoscaler/apis/provisioningrequest/client \
-    --output-dir "${REPO_ROOT}/cluster-autoscaler/apis/provisioningrequest/client" \
-    --boilerplate "${REPO_ROOT}/hack/boilerplate/boilerplate.generatego.txt" \
-    --with-watch \
-    --with-applyconfig \
-    "${REPO_ROOT}/cluster-autoscaler/apis/provisioningrequest"
+for api in provisioningrequest clusterautoscalerstatus; do
+  kube::codegen::gen_client \
+      --output-pkg "k8s.io/autoscaler/cluster-autoscaler/apis/${api}/client" \
+      --output-dir "${REPO_ROOT}/cluster-autoscaler/apis/${api}/client" \
+      --boilerplate "${REPO_ROOT}/hack/boilerplate/boilerplate.generatego.txt" \
+      --with-watch \
+      --with-applyconfig \
+      "${REPO_ROOT}/cluster-autoscaler/apis/${api}"
+done
 
 echo "Generated client code, running `go mod tidy`..."
 
@@ -27,6 +27,7 @@ import (
 
 	"github.com/spf13/pflag"
 
+	casclient "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned"
 	capacityclient "k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/client"
 	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/common"
 	"k8s.io/autoscaler/cluster-autoscaler/config/flags"
@@ -50,16 +51,20 @@ import (
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	capacitybuffer "k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/controller"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	clusterstateutils "k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/core"
 	"k8s.io/autoscaler/cluster-autoscaler/core/podlistprocessor"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/explanation"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/observers/loopstart"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/audit"
 	cbprocessor "k8s.io/autoscaler/cluster-autoscaler/processors/capacitybuffer"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodeinfosprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/podinjection"
 	podinjectionbackoff "k8s.io/autoscaler/cluster-autoscaler/processors/podinjection/backoff"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/podinjection/predictive"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/pods"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/provreq"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates"
@@ -68,7 +73,9 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	provreqorchestrator "k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/orchestrator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/external"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/tracing"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	"k8s.io/autoscaler/cluster-autoscaler/version"
 	"k8s.io/client-go/informers"
@@ -93,13 +100,16 @@ func registerSignalHandlers(autoscaler core.Autoscaler) {
 		<-sigs
 		klog.V(1).Info("Received signal, attempting cleanup")
 		autoscaler.ExitCleanUp()
+		if err := tracing.Shutdown(context.Background()); err != nil {
+			klog.Warningf("Failed to flush traces: %v", err)
+		}
 		klog.V(1).Info("Cleaned up, exiting...")
 		klog.Flush()
 		os.Exit(0)
 	}()
 }
 
-func buildAutoscaler(ctx context.Context, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter) (core.Autoscaler, *loop.LoopTrigger, error) {
+func buildAutoscaler(ctx context.Context, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter, nodeExplainer *explanation.Explainer, scaleUpExplainer *status.ExplainingScaleUpStatusProcessor) (core.Autoscaler, *loop.LoopTrigger, error) {
 	// Get AutoscalingOptions from flags.
 	autoscalingOptions := flags.AutoscalingOptions()
 
@@ -119,7 +129,15 @@ func buildAutoscaler(ctx context.Context, debuggingSnapshotter debuggingsnapshot
 		return nil, nil, err
 	}
 	deleteOptions := options.NewNodeDeleteOptions(autoscalingOptions)
-	drainabilityRules := rules.Default(deleteOptions)
+	var extraDrainabilityRules []rules.Rule
+	if autoscalingOptions.ExternalDrainabilityRuleAddress != "" {
+		externalRule, err := external.New(autoscalingOptions.ExternalDrainabilityRuleAddress, autoscalingOptions.ExternalDrainabilityRuleTimeout, autoscalingOptions.ExternalDrainabilityRuleCACert)
+		if err != nil {
+			return nil, nil, err
+		}
+		extraDrainabilityRules = append(extraDrainabilityRules, externalRule)
+	}
+	drainabilityRules := rules.Default(deleteOptions, extraDrainabilityRules...)
 
 	var snapshotStore clustersnapshot.ClusterSnapshotStore = store.NewDeltaSnapshotStore(autoscalingOptions.ClusterSnapshotParallelism)
 	opts := core.AutoscalerOptions{
@@ -129,6 +147,7 @@ func buildAutoscaler(ctx context.Context, debuggingSnapshotter debuggingsnapshot
 		KubeClient:           kubeClient,
 		InformerFactory:      informerFactory,
 		DebuggingSnapshotter: debuggingSnapshotter,
+		NodeExplainer:        nodeExplainer,
 		DeleteOptions:        deleteOptions,
 		DrainabilityRules:    drainabilityRules,
 		ScaleUpOrchestrator:  orchestrator.New(),
@@ -136,6 +155,7 @@ func buildAutoscaler(ctx context.Context, debuggingSnapshotter debuggingsnapshot
 
 	opts.Processors = ca_processors.DefaultProcessors(autoscalingOptions)
 	opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(&autoscalingOptions.NodeInfoCacheExpireTime, autoscalingOptions.ForceDaemonSets)
+	opts.Processors.ScaleUpStatusProcessor = status.NewCombinedScaleUpStatusProcessor([]status.ScaleUpStatusProcessor{opts.Processors.ScaleUpStatusProcessor, scaleUpExplainer})
 	podListProcessor := podlistprocessor.NewDefaultPodListProcessor(scheduling.ScheduleAnywhere)
 
 	var ProvisioningRequestInjector *provreq.ProvisioningRequestPodsInjector
@@ -181,7 +201,7 @@ func buildAutoscaler(ctx context.Context, debuggingSnapshotter debuggingsnapshot
 		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
 		capacitybufferClient, capacitybufferClientError = capacityclient.NewCapacityBufferClientFromConfig(restConfig)
 		if capacitybufferClientError == nil && capacitybufferClient != nil {
-			nodeBufferController := capacitybuffer.NewDefaultBufferController(capacitybufferClient)
+			nodeBufferController := capacitybuffer.NewDefaultBufferController(capacitybufferClient, autoscalingOptions.CapacitybufferPodTemplatePaths)
 			go nodeBufferController.Run(make(chan struct{}))
 		}
 	}
@@ -193,7 +213,8 @@ func buildAutoscaler(ctx context.Context, debuggingSnapshotter debuggingsnapshot
 		}
 		if capacitybufferClientError == nil && capacitybufferClient != nil {
 			bufferPodInjector := cbprocessor.NewCapacityBufferPodListProcessor(capacitybufferClient, []string{common.ActiveProvisioningStrategy})
-			podListProcessor = pods.NewCombinedPodListProcessor([]pods.PodListProcessor{bufferPodInjector, podListProcessor})
+			bufferStandbyProcessor := cbprocessor.NewCapacityBufferStandbyProcessor(capacitybufferClient)
+			podListProcessor = pods.NewCombinedPodListProcessor([]pods.PodListProcessor{bufferPodInjector, bufferStandbyProcessor, podListProcessor})
 			opts.Processors.ScaleUpStatusProcessor = status.NewCombinedScaleUpStatusProcessor([]status.ScaleUpStatusProcessor{cbprocessor.NewFakePodsScaleUpStatusProcessor(), opts.Processors.ScaleUpStatusProcessor})
 		}
 	}
@@ -211,6 +232,43 @@ func buildAutoscaler(ctx context.Context, debuggingSnapshotter debuggingsnapshot
 		opts.Processors.ScaleUpStatusProcessor = status.NewCombinedScaleUpStatusProcessor([]status.ScaleUpStatusProcessor{podinjection.NewFakePodsScaleUpStatusProcessor(podInjectionBackoffRegistry), opts.Processors.ScaleUpStatusProcessor})
 	}
 
+	if autoscalingOptions.PredictiveScaleUpEnabled {
+		var historyStore predictive.Store
+		if autoscalingOptions.PredictiveScaleUpHistoryConfigMap != "" {
+			historyStore = predictive.NewConfigMapStore(kubeClient, autoscalingOptions.ConfigNamespace, autoscalingOptions.PredictiveScaleUpHistoryConfigMap)
+		} else if autoscalingOptions.PredictiveScaleUpHistoryFile != "" {
+			historyStore = predictive.NewFileStore(autoscalingOptions.PredictiveScaleUpHistoryFile)
+		}
+		predictiveProcessor := predictive.NewPodListProcessor(historyStore, autoscalingOptions.PredictiveScaleUpHorizon, autoscalingOptions.PredictiveScaleUpSeason, autoscalingOptions.PredictiveScaleUpSeasons)
+		// Placeholder pods are injected before the default processor, so that
+		// the ones fitting on existing nodes are filtered out as schedulable.
+		podListProcessor = pods.NewCombinedPodListProcessor([]pods.PodListProcessor{predictiveProcessor, podListProcessor})
+		opts.Processors.ScaleUpStatusProcessor = status.NewCombinedScaleUpStatusProcessor([]status.ScaleUpStatusProcessor{predictive.NewPlaceholderPodsScaleUpStatusProcessor(predictiveProcessor), opts.Processors.ScaleUpStatusProcessor})
+	}
+
+	if autoscalingOptions.AuditLogPath != "" {
+		auditLog := audit.NewFileLog(autoscalingOptions.AuditLogPath, autoscalingOptions.AuditLogMaxSizeMB, autoscalingOptions.AuditLogMaxBackups)
+		// The audit processors go last, so that fake pods are already filtered out of the statuses.
+		opts.Processors.ScaleUpStatusProcessor = status.NewCombinedScaleUpStatusProcessor([]status.ScaleUpStatusProcessor{opts.Processors.ScaleUpStatusProcessor, audit.NewScaleUpStatusProcessor(auditLog)})
+		opts.Processors.ScaleDownStatusProcessor = status.NewCombinedScaleDownStatusProcessor([]status.ScaleDownStatusProcessor{opts.Processors.ScaleDownStatusProcessor, audit.NewScaleDownStatusProcessor(auditLog)})
+	}
+
+	if autoscalingOptions.WriteStatusCRD {
+		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+		statusClient, err := casclient.NewForConfig(restConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		statusLister, err := clusterstateutils.NewStatusObjectLister(statusClient, autoscalingOptions.ConfigNamespace, make(chan struct{}))
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.Processors.AutoscalingStatusProcessor = status.NewCombinedAutoscalingStatusProcessor([]status.AutoscalingStatusProcessor{
+			opts.Processors.AutoscalingStatusProcessor,
+			status.NewStatusObjectAutoscalingStatusProcessor(statusClient, statusLister, autoscalingOptions.ConfigNamespace, autoscalingOptions.StatusConfigMapName),
+		})
+	}
+
 	opts.Processors.PodListProcessor = podListProcessor
 	sdCandidatesSorting := previouscandidates.NewPreviousCandidates()
 	scaleDownCandidatesComparers := []scaledowncandidates.CandidatesComparer{
@@ -287,14 +345,22 @@ func buildAutoscaler(ctx context.Context, debuggingSnapshotter debuggingsnapshot
 	return autoscaler, trigger, nil
 }
 
-func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter) {
+func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter, nodeExplainer *explanation.Explainer, scaleUpExplainer *status.ExplainingScaleUpStatusProcessor) {
 	autoscalingOpts := flags.AutoscalingOptions()
 
 	metrics.RegisterAll(autoscalingOpts.EmitPerNodeGroupMetrics)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	autoscaler, trigger, err := buildAutoscaler(ctx, debuggingSnapshotter)
+	if err := tracing.Setup(ctx, tracing.Options{
+		Endpoint:      autoscalingOpts.TracingOTLPEndpoint,
+		Insecure:      autoscalingOpts.TracingOTLPInsecure,
+		SamplingRatio: autoscalingOpts.TracingSamplingRatio,
+	}); err != nil {
+		klog.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	autoscaler, trigger, err := buildAutoscaler(ctx, debuggingSnapshotter, nodeExplainer, scaleUpExplainer)
 	if err != nil {
 		klog.Fatalf("Failed to create autoscaler: %v", err)
 	}
@@ -369,6 +435,8 @@ func main() {
 	klog.V(1).Infof("Cluster Autoscaler %s", version.ClusterAutoscalerVersion)
 
 	debuggingSnapshotter := debuggingsnapshot.NewDebuggingSnapshotter(autoscalingOpts.DebuggingSnapshotEnabled)
+	nodeExplainer := explanation.NewExplainer(autoscalingOpts.ScaleDownExplanationAnnotations)
+	scaleUpExplainer := status.NewExplainingScaleUpStatusProcessor(autoscalingOpts.ScaleUpExplanationPodConditions)
 
 	go func() {
 		pathRecorderMux := mux.NewPathRecorderMux("cluster-autoscaler")
@@ -380,6 +448,8 @@ func main() {
 			pathRecorderMux.HandleFunc("/snapshotz", debuggingSnapshotter.ResponseHandler)
 		}
 		pathRecorderMux.HandleFunc("/health-check", healthCheck.ServeHTTP)
+		pathRecorderMux.Handle("/scale-down-explanations", nodeExplainer)
+		pathRecorderMux.Handle("/scale-up-explanations", scaleUpExplainer)
 		if autoscalingOpts.EnableProfiling {
 			routes.Profiling{}.Install(pathRecorderMux)
 		}
@@ -388,7 +458,7 @@ func main() {
 	}()
 
 	if !leaderElection.LeaderElect {
-		run(healthCheck, debuggingSnapshotter)
+		run(healthCheck, debuggingSnapshotter, nodeExplainer, scaleUpExplainer)
 	} else {
 		id, err := os.Hostname()
 		if err != nil {
@@ -428,7 +498,7 @@ func main() {
 				OnStartedLeading: func(_ context.Context) {
 					// Since we are committing a suicide after losing
 					// mastership, we can safely ignore the argument.
-					run(healthCheck, debuggingSnapshotter)
+					run(healthCheck, debuggingSnapshotter, nodeExplainer, scaleUpExplainer)
 				},
 				OnStoppedLeading: func() {
 					klog.Fatalf("lost master")
@@ -425,6 +425,88 @@ var (
 			Buckets:   k8smetrics.ExponentialBuckets(1, 2, 6), // 1, 2, 4, ..., 32
 		}, []string{"instance_type", "cpu_count", "namespace_count"},
 	)
+
+	binpackingCapacityCacheLookups = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "binpacking_capacity_cache_lookups_total",
+			Help:      "Number of lookups of how many pods of an equivalence group fit on a new node during binpacking, by result (hit or miss).",
+		}, []string{"result"},
+	)
+
+	scaleDownHookCallsCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "scale_down_hook_calls_total",
+			Help:      "Number of scale-down hook calls, by hook, phase and result.",
+		}, []string{"hook", "phase", "result"},
+	)
+
+	scaleDownHookDuration = k8smetrics.NewHistogramVec(
+		&k8smetrics.HistogramOpts{
+			Namespace: caNamespace,
+			Name:      "scale_down_hook_duration_seconds",
+			Help:      "Time taken by scale-down hooks, including delays requested by the hooks.",
+			Buckets:   k8smetrics.ExponentialBuckets(0.01, 2, 16), // 0.01, 0.02, 0.04, ..., 327.68
+		}, []string{"hook", "phase"},
+	)
+
+	scaledUpNodesHourlyCost = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "scaled_up_nodes_hourly_cost_total",
+			Help:      "Sum of hourly costs of nodes added by CA, by node group. Reported only if per node group metrics are enabled and the cloud provider implements a pricing model.",
+		}, []string{"node_group"},
+	)
+
+	scaledDownNodesHourlyCost = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "scaled_down_nodes_hourly_cost_total",
+			Help:      "Sum of hourly costs of nodes removed by CA, by node group. Reported only if per node group metrics are enabled and the cloud provider implements a pricing model.",
+		}, []string{"node_group"},
+	)
+
+	scaleDownSavings = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "scale_down_savings_total",
+			Help:      "Estimated cost saved by removing nodes, by node group. Reported only if per node group metrics are enabled and the cloud provider implements a pricing model.",
+		}, []string{"node_group"},
+	)
+
+	unneededNodesHourlyCost = k8smetrics.NewGaugeVec(
+		&k8smetrics.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "unneeded_nodes_hourly_cost",
+			Help:      "Hourly cost of underutilized nodes which weren't removed yet, by the reason why. Reported only if the cloud provider implements a pricing model.",
+		}, []string{"reason", "blocking_pod_reason"},
+	)
+
+	predictiveScaleUpForecastNodes = k8smetrics.NewGaugeVec(
+		&k8smetrics.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "predictive_scale_up_forecast_nodes",
+			Help:      "Highest number of nodes a node group is forecast to need within the predictive scale-up horizon.",
+		}, []string{"node_group"},
+	)
+
+	predictiveScaleUpForecastError = k8smetrics.NewHistogramVec(
+		&k8smetrics.HistogramOpts{
+			Namespace: caNamespace,
+			Name:      "predictive_scale_up_forecast_absolute_error",
+			Help:      "Absolute difference between forecast and observed number of nodes needed by a node group.",
+			Buckets:   []float64{0, 0.5, 1, 2, 4, 8, 16, 32, 64, 128},
+		}, []string{"node_group"},
+	)
+
+	predictiveScaleUpPlaceholderPods = k8smetrics.NewGaugeVec(
+		&k8smetrics.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "predictive_scale_up_placeholder_pods",
+			Help:      "Number of placeholder pods injected by predictive scale-up in the last loop, by node group.",
+		}, []string{"node_group"},
+	)
 )
 
 // RegisterAll registers all metrics.
@@ -461,6 +543,13 @@ func RegisterAll(emitPerNodeGroupMetrics bool) {
 	legacyregistry.MustRegister(nodeTaintsCount)
 	legacyregistry.MustRegister(inconsistentInstancesMigsCount)
 	legacyregistry.MustRegister(binpackingHeterogeneity)
+	legacyregistry.MustRegister(binpackingCapacityCacheLookups)
+	legacyregistry.MustRegister(scaleDownHookCallsCount)
+	legacyregistry.MustRegister(scaleDownHookDuration)
+	legacyregistry.MustRegister(unneededNodesHourlyCost)
+	legacyregistry.MustRegister(predictiveScaleUpForecastNodes)
+	legacyregistry.MustRegister(predictiveScaleUpForecastError)
+	legacyregistry.MustRegister(predictiveScaleUpPlaceholderPods)
 
 	if emitPerNodeGroupMetrics {
 		legacyregistry.MustRegister(nodesGroupMinNodes)
@@ -468,6 +557,9 @@ func RegisterAll(emitPerNodeGroupMetrics bool) {
 		legacyregistry.MustRegister(nodesGroupTargetSize)
 		legacyregistry.MustRegister(nodesGroupHealthiness)
 		legacyregistry.MustRegister(nodeGroupBackOffStatus)
+		legacyregistry.MustRegister(scaledUpNodesHourlyCost)
+		legacyregistry.MustRegister(scaledDownNodesHourlyCost)
+		legacyregistry.MustRegister(scaleDownSavings)
 	}
 }
 
@@ -748,3 +840,66 @@ func UpdateInconsistentInstancesMigsCount(migCount int) {
 func ObserveBinpackingHeterogeneity(instanceType, cpuCount, namespaceCount string, pegCount int) {
 	binpackingHeterogeneity.WithLabelValues(instanceType, cpuCount, namespaceCount).Observe(float64(pegCount))
 }
+
+// RegisterBinpackingCapacityCacheLookup records a lookup of the binpacking
+// capacity cache.
+func RegisterBinpackingCapacityCacheLookup(hit bool) {
+	if hit {
+		binpackingCapacityCacheLookups.WithLabelValues("hit").Inc()
+	} else {
+		binpackingCapacityCacheLookups.WithLabelValues("miss").Inc()
+	}
+}
+
+// RegisterScaleDownHookCall records the result of a scale-down hook call.
+func RegisterScaleDownHookCall(hook, phase, result string) {
+	scaleDownHookCallsCount.WithLabelValues(hook, phase, result).Inc()
+}
+
+// UpdateScaleDownHookDuration records the time taken by a scale-down hook.
+func UpdateScaleDownHookDuration(hook, phase string, duration time.Duration) {
+	scaleDownHookDuration.WithLabelValues(hook, phase).Observe(duration.Seconds())
+}
+
+// RegisterScaleUpCost records the hourly cost of nodes added to a node group.
+func RegisterScaleUpCost(nodeGroup string, hourlyCost float64) {
+	scaledUpNodesHourlyCost.WithLabelValues(nodeGroup).Add(hourlyCost)
+}
+
+// RegisterScaleDownCost records the hourly cost of a node removed from a node group.
+func RegisterScaleDownCost(nodeGroup string, hourlyCost float64) {
+	scaledDownNodesHourlyCost.WithLabelValues(nodeGroup).Add(hourlyCost)
+}
+
+// RegisterScaleDownSavings records the cost saved by removing nodes from a node group.
+func RegisterScaleDownSavings(nodeGroup string, savings float64) {
+	scaleDownSavings.WithLabelValues(nodeGroup).Add(savings)
+}
+
+// UnneededNodesCostKey identifies why underutilized nodes weren't removed.
+type UnneededNodesCostKey struct {
+	Reason            string
+	BlockingPodReason string
+}
+
+// UpdateUnneededNodesHourlyCost records the hourly cost of underutilized nodes
+// which weren't removed yet.
+func UpdateUnneededNodesHourlyCost(costs map[UnneededNodesCostKey]float64) {
+	unneededNodesHourlyCost.Reset()
+	for key, cost := range costs {
+		unneededNodesHourlyCost.WithLabelValues(key.Reason, key.BlockingPodReason).Set(cost)
+	}
+}
+
+// UpdatePredictiveScaleUpForecast records the number of nodes a node group is
+// forecast to need and the number of placeholder pods injected for it.
+func UpdatePredictiveScaleUpForecast(nodeGroup string, nodes float64, placeholderPods int) {
+	predictiveScaleUpForecastNodes.WithLabelValues(nodeGroup).Set(nodes)
+	predictiveScaleUpPlaceholderPods.WithLabelValues(nodeGroup).Set(float64(placeholderPods))
+}
+
+// ObservePredictiveScaleUpForecastError records the absolute error of a past
+// forecast of the number of nodes needed by a node group.
+func ObservePredictiveScaleUpForecastError(nodeGroup string, absoluteError float64) {
+	predictiveScaleUpForecastError.WithLabelValues(nodeGroup).Observe(absoluteError)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit writes one structured JSON record per scale-up and scale-down
+// decision, so that past decisions can be looked up without relying on logs
+// and events, which are lossy and rate-limited.
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+	klog "k8s.io/klog/v2"
+)
+
+// StdoutPath is the path making NewFileLog write records to stdout.
+const StdoutPath = "-"
+
+// Log writes audit records as JSON lines.
+type Log struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	closer io.Closer
+	closed bool
+	now    func() time.Time
+}
+
+// NewLog returns a Log writing records to writer.
+func NewLog(writer io.Writer) *Log {
+	return &Log{writer: writer, now: time.Now}
+}
+
+// NewFileLog returns a Log writing records to the file at path, which is
+// rotated once it grows over maxSizeMB megabytes, keeping maxBackups rotated
+// files. If path is StdoutPath, records are written to stdout instead.
+func NewFileLog(path string, maxSizeMB, maxBackups int) *Log {
+	if path == StdoutPath {
+		return NewLog(os.Stdout)
+	}
+	logger := &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    maxSizeMB,
+		MaxBackups: maxBackups,
+	}
+	log := NewLog(logger)
+	log.closer = logger
+	return log
+}
+
+// Write writes record, setting its time if it isn't set yet.
+func (l *Log) Write(record Record) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.closed {
+		return
+	}
+	if record.Time.IsZero() {
+		record.Time = l.now()
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		klog.Errorf("Failed to encode audit record: %v", err)
+		return
+	}
+	if _, err := l.writer.Write(append(line, '\n')); err != nil {
+		klog.Errorf("Failed to write audit record: %v", err)
+	}
+}
+
+// Close stops writing records, and closes the underlying file, if any.
+func (l *Log) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog(t *testing.T) {
+	now := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	var buffer bytes.Buffer
+	log := NewLog(&buffer)
+	log.now = func() time.Time { return now }
+
+	log.Write(Record{Kind: KindScaleDown, ScaleDown: &ScaleDownRecord{Node: "n1", Result: "Deleted"}})
+	log.Write(Record{Time: now.Add(time.Minute), Kind: KindScaleUpFailure, Error: &ErrorRecord{Class: "cloudProviderError", Message: "quota exceeded"}})
+	assert.NoError(t, log.Close())
+	log.Write(Record{Kind: KindScaleUp})
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		`{"time":"2025-03-04T10:00:00Z","kind":"ScaleDown","scaleDown":{"node":"n1","result":"Deleted"}}`,
+		`{"time":"2025-03-04T10:01:00Z","kind":"ScaleUpFailure","error":{"class":"cloudProviderError","message":"quota exceeded"}}`,
+	}, lines)
+}
+
+func TestFileLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log := NewFileLog(path, 1, 1)
+	log.Write(Record{Kind: KindScaleUp, ScaleUp: &ScaleUpRecord{ChosenNodeGroup: "ng1"}})
+	assert.NoError(t, log.Close())
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"chosenNodeGroup":"ng1"`)
+	assert.Equal(t, os.Stdout, NewFileLog(StdoutPath, 1, 1).writer)
+}
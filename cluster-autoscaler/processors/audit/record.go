@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"time"
+)
+
+// Kind is the kind of decision an audit record describes.
+type Kind string
+
+const (
+	// KindScaleUp - node groups were scaled up.
+	KindScaleUp Kind = "ScaleUp"
+	// KindScaleUpFailure - a scale-up was attempted, but failed.
+	KindScaleUpFailure Kind = "ScaleUpFailure"
+	// KindScaleDown - the deletion of a node started or completed.
+	KindScaleDown Kind = "ScaleDown"
+	// KindScaleDownFailure - a scale-down or the deletion of a node failed.
+	KindScaleDownFailure Kind = "ScaleDownFailure"
+)
+
+// Record is a single audit record.
+type Record struct {
+	Time      time.Time        `json:"time"`
+	Kind      Kind             `json:"kind"`
+	ScaleUp   *ScaleUpRecord   `json:"scaleUp,omitempty"`
+	ScaleDown *ScaleDownRecord `json:"scaleDown,omitempty"`
+	Error     *ErrorRecord     `json:"error,omitempty"`
+}
+
+// ScaleUpRecord describes a scale-up decision.
+type ScaleUpRecord struct {
+	// TriggeringPods are the pods the scale-up was made for, as namespace/name.
+	TriggeringPods []string `json:"triggeringPods,omitempty"`
+	// Options are the expansion options considered.
+	Options []OptionRecord `json:"options,omitempty"`
+	// Expander is the chain of expanders choosing between options.
+	Expander string `json:"expander,omitempty"`
+	// ChosenNodeGroup is the node group of the option chosen by the expander.
+	ChosenNodeGroup string `json:"chosenNodeGroup,omitempty"`
+	// Increases are the size changes of scaled up node groups.
+	Increases []IncreaseRecord `json:"increases,omitempty"`
+	// CreatedNodeGroups are the node groups created for the scale-up.
+	CreatedNodeGroups []string `json:"createdNodeGroups,omitempty"`
+	// FailedNodeGroups are the node groups which failed to scale up.
+	FailedNodeGroups []string `json:"failedNodeGroups,omitempty"`
+}
+
+// OptionRecord describes an expansion option.
+type OptionRecord struct {
+	NodeGroup string `json:"nodeGroup"`
+	NodeCount int    `json:"nodeCount"`
+	PodCount  int    `json:"podCount"`
+}
+
+// IncreaseRecord describes a size change of a node group.
+type IncreaseRecord struct {
+	NodeGroup   string `json:"nodeGroup"`
+	CurrentSize int    `json:"currentSize"`
+	NewSize     int    `json:"newSize"`
+	Delta       int    `json:"delta"`
+}
+
+// ScaleDownRecord describes the deletion of a node.
+type ScaleDownRecord struct {
+	Node      string `json:"node"`
+	NodeGroup string `json:"nodeGroup,omitempty"`
+	// Reason is why the node was scaled down, e.g. Empty or Underutilized.
+	Reason string `json:"reason,omitempty"`
+	// EvictedPods are the pods evicted from the node, as namespace/name.
+	EvictedPods []string `json:"evictedPods,omitempty"`
+	// Result is DeletionStarted when the deletion starts, and the outcome of
+	// the deletion once it's done, e.g. Deleted or FailedToEvictPods.
+	Result string `json:"result"`
+}
+
+// ErrorRecord describes the error a decision failed with.
+type ErrorRecord struct {
+	// Class is the type of the error, e.g. cloudProviderError.
+	Class   string `json:"class"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	klog "k8s.io/klog/v2"
+)
+
+const deletionStartedResult = "DeletionStarted"
+
+// ScaleUpStatusProcessor writes an audit record for each scale-up attempt.
+type ScaleUpStatusProcessor struct {
+	log *Log
+}
+
+// NewScaleUpStatusProcessor returns a ScaleUpStatusProcessor writing to log.
+func NewScaleUpStatusProcessor(log *Log) *ScaleUpStatusProcessor {
+	return &ScaleUpStatusProcessor{log: log}
+}
+
+// Process writes a record if a scale-up was attempted.
+func (p *ScaleUpStatusProcessor) Process(autoscalingCtx *ca_context.AutoscalingContext, scaleUpStatus *status.ScaleUpStatus) {
+	switch scaleUpStatus.Result {
+	case status.ScaleUpSuccessful:
+		p.log.Write(Record{Kind: KindScaleUp, ScaleUp: scaleUpRecord(autoscalingCtx, scaleUpStatus)})
+	case status.ScaleUpError:
+		record := Record{Kind: KindScaleUpFailure, ScaleUp: scaleUpRecord(autoscalingCtx, scaleUpStatus)}
+		if scaleUpStatus.ScaleUpError != nil {
+			record.Error = errorRecord(*scaleUpStatus.ScaleUpError)
+		}
+		p.log.Write(record)
+	}
+}
+
+// CleanUp closes the log.
+func (p *ScaleUpStatusProcessor) CleanUp() {
+	if err := p.log.Close(); err != nil {
+		klog.Warningf("Failed to close audit log: %v", err)
+	}
+}
+
+func scaleUpRecord(autoscalingCtx *ca_context.AutoscalingContext, scaleUpStatus *status.ScaleUpStatus) *ScaleUpRecord {
+	record := &ScaleUpRecord{
+		TriggeringPods: podNames(scaleUpStatus.PodsTriggeredScaleUp),
+	}
+	if autoscalingCtx != nil {
+		record.Expander = autoscalingCtx.ExpanderNames
+	}
+	for _, option := range scaleUpStatus.ExpansionOptions {
+		record.Options = append(record.Options, OptionRecord{
+			NodeGroup: option.NodeGroup.Id(),
+			NodeCount: option.NodeCount,
+			PodCount:  len(option.Pods),
+		})
+	}
+	if scaleUpStatus.BestOption != nil && scaleUpStatus.BestOption.NodeGroup != nil {
+		record.ChosenNodeGroup = scaleUpStatus.BestOption.NodeGroup.Id()
+	}
+	for _, info := range scaleUpStatus.ScaleUpInfos {
+		record.Increases = append(record.Increases, IncreaseRecord{
+			NodeGroup:   info.Group.Id(),
+			CurrentSize: info.CurrentSize,
+			NewSize:     info.NewSize,
+			Delta:       info.NewSize - info.CurrentSize,
+		})
+	}
+	for _, result := range scaleUpStatus.CreateNodeGroupResults {
+		if result.MainCreatedNodeGroup != nil {
+			record.CreatedNodeGroups = append(record.CreatedNodeGroups, result.MainCreatedNodeGroup.Id())
+		}
+	}
+	for _, nodeGroup := range scaleUpStatus.FailedResizeNodeGroups {
+		record.FailedNodeGroups = append(record.FailedNodeGroups, nodeGroup.Id())
+	}
+	return record
+}
+
+// ScaleDownStatusProcessor writes an audit record when the deletion of a node
+// starts, and another one with its outcome once it's done.
+type ScaleDownStatusProcessor struct {
+	log *Log
+	// deleting holds records of nodes whose deletion started, by node name.
+	deleting map[string]ScaleDownRecord
+}
+
+// NewScaleDownStatusProcessor returns a ScaleDownStatusProcessor writing to log.
+func NewScaleDownStatusProcessor(log *Log) *ScaleDownStatusProcessor {
+	return &ScaleDownStatusProcessor{log: log, deleting: map[string]ScaleDownRecord{}}
+}
+
+// Process writes records of started and completed node deletions, and of
+// scale-down failures.
+func (p *ScaleDownStatusProcessor) Process(_ *ca_context.AutoscalingContext, scaleDownStatus *scaledownstatus.ScaleDownStatus) {
+	for _, scaledDown := range scaleDownStatus.ScaledDownNodes {
+		record := ScaleDownRecord{
+			Node:        scaledDown.Node.Name,
+			Reason:      string(scaledDown.Reason),
+			EvictedPods: podNames(scaledDown.EvictedPods),
+			Result:      deletionStartedResult,
+		}
+		if scaledDown.NodeGroup != nil {
+			record.NodeGroup = scaledDown.NodeGroup.Id()
+		}
+		p.deleting[record.Node] = record
+		p.log.Write(Record{Kind: KindScaleDown, ScaleDown: &record})
+	}
+
+	nodeNames := make([]string, 0, len(scaleDownStatus.NodeDeleteResults))
+	for nodeName := range scaleDownStatus.NodeDeleteResults {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	for _, nodeName := range nodeNames {
+		result := scaleDownStatus.NodeDeleteResults[nodeName]
+		record, found := p.deleting[nodeName]
+		if !found {
+			record = ScaleDownRecord{Node: nodeName}
+		}
+		delete(p.deleting, nodeName)
+		record.Result = deleteResult(result.ResultType)
+		if result.Err != nil {
+			p.log.Write(Record{Kind: KindScaleDownFailure, ScaleDown: &record, Error: errorRecord(result.Err)})
+		} else {
+			p.log.Write(Record{Kind: KindScaleDown, ScaleDown: &record})
+		}
+	}
+
+	if scaleDownStatus.Result == scaledownstatus.ScaleDownError && scaleDownStatus.ScaleDownError != nil {
+		p.log.Write(Record{Kind: KindScaleDownFailure, Error: errorRecord(*scaleDownStatus.ScaleDownError)})
+	}
+}
+
+// CleanUp closes the log.
+func (p *ScaleDownStatusProcessor) CleanUp() {
+	if err := p.log.Close(); err != nil {
+		klog.Warningf("Failed to close audit log: %v", err)
+	}
+}
+
+func deleteResult(resultType scaledownstatus.NodeDeleteResultType) string {
+	switch resultType {
+	case scaledownstatus.NodeDeleteOk:
+		return "Deleted"
+	case scaledownstatus.NodeDeleteErrorFailedToMarkToBeDeleted:
+		return "FailedToMarkToBeDeleted"
+	case scaledownstatus.NodeDeleteErrorFailedToEvictPods:
+		return "FailedToEvictPods"
+	case scaledownstatus.NodeDeleteErrorFailedToDelete:
+		return "FailedToDelete"
+	default:
+		return "InternalError"
+	}
+}
+
+func errorRecord(err error) *ErrorRecord {
+	autoscalerErr := errors.ToAutoscalerError(errors.InternalError, err)
+	if autoscalerErr == nil {
+		return nil
+	}
+	return &ErrorRecord{Class: string(autoscalerErr.Type()), Message: autoscalerErr.Error()}
+}
+
+func podNames(pods []*apiv1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	return names
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func readRecords(t *testing.T, buffer *bytes.Buffer) []Record {
+	var records []Record
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record Record
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		record.Time = time.Time{}
+		records = append(records, record)
+	}
+	buffer.Reset()
+	return records
+}
+
+func TestScaleUpStatusProcessor(t *testing.T) {
+	ng1 := testprovider.NewTestNodeGroup("ng1", 10, 0, 1, true, false, "", nil, nil)
+	ng2 := testprovider.NewTestNodeGroup("ng2", 10, 0, 1, true, false, "", nil, nil)
+	p1 := BuildTestPod("p1", 100, 100)
+	p2 := BuildTestPod("p2", 100, 100)
+	autoscalingCtx := &ca_context.AutoscalingContext{AutoscalingOptions: config.AutoscalingOptions{ExpanderNames: "least-waste"}}
+	options := []expander.Option{
+		{NodeGroup: ng1, NodeCount: 2, Pods: []*apiv1.Pod{p1, p2}},
+		{NodeGroup: ng2, NodeCount: 1, Pods: []*apiv1.Pod{p1}},
+	}
+
+	var buffer bytes.Buffer
+	processor := NewScaleUpStatusProcessor(NewLog(&buffer))
+
+	processor.Process(autoscalingCtx, &status.ScaleUpStatus{Result: status.ScaleUpNotNeeded})
+	processor.Process(autoscalingCtx, &status.ScaleUpStatus{Result: status.ScaleUpNoOptionsAvailable})
+	assert.Empty(t, readRecords(t, &buffer))
+
+	processor.Process(autoscalingCtx, &status.ScaleUpStatus{
+		Result:               status.ScaleUpSuccessful,
+		PodsTriggeredScaleUp: []*apiv1.Pod{p1, p2},
+		ExpansionOptions:     options,
+		BestOption:           &options[0],
+		ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: ng1, CurrentSize: 1, NewSize: 3, MaxSize: 10}},
+	})
+	assert.Equal(t, []Record{{
+		Kind: KindScaleUp,
+		ScaleUp: &ScaleUpRecord{
+			TriggeringPods:  []string{"default/p1", "default/p2"},
+			Options:         []OptionRecord{{NodeGroup: "ng1", NodeCount: 2, PodCount: 2}, {NodeGroup: "ng2", NodeCount: 1, PodCount: 1}},
+			Expander:        "least-waste",
+			ChosenNodeGroup: "ng1",
+			Increases:       []IncreaseRecord{{NodeGroup: "ng1", CurrentSize: 1, NewSize: 3, Delta: 2}},
+		},
+	}}, readRecords(t, &buffer))
+
+	scaleUpStatus, _ := status.UpdateScaleUpError(&status.ScaleUpStatus{
+		PodsTriggeredScaleUp:   []*apiv1.Pod{p1},
+		ExpansionOptions:       options,
+		BestOption:             &options[1],
+		FailedResizeNodeGroups: []cloudprovider.NodeGroup{ng2},
+	}, errors.NewAutoscalerError(errors.CloudProviderError, "quota exceeded"))
+	processor.Process(autoscalingCtx, scaleUpStatus)
+	assert.Equal(t, []Record{{
+		Kind: KindScaleUpFailure,
+		ScaleUp: &ScaleUpRecord{
+			TriggeringPods:   []string{"default/p1"},
+			Options:          []OptionRecord{{NodeGroup: "ng1", NodeCount: 2, PodCount: 2}, {NodeGroup: "ng2", NodeCount: 1, PodCount: 1}},
+			Expander:         "least-waste",
+			ChosenNodeGroup:  "ng2",
+			FailedNodeGroups: []string{"ng2"},
+		},
+		Error: &ErrorRecord{Class: "cloudProviderError", Message: "quota exceeded"},
+	}}, readRecords(t, &buffer))
+}
+
+func TestScaleDownStatusProcessor(t *testing.T) {
+	ng1 := testprovider.NewTestNodeGroup("ng1", 10, 0, 3, true, false, "", nil, nil)
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	p1 := BuildTestPod("p1", 100, 100)
+
+	var buffer bytes.Buffer
+	processor := NewScaleDownStatusProcessor(NewLog(&buffer))
+
+	processor.Process(nil, &scaledownstatus.ScaleDownStatus{
+		Result: scaledownstatus.ScaleDownNodeDeleteStarted,
+		ScaledDownNodes: []*scaledownstatus.ScaleDownNode{
+			{Node: n1, NodeGroup: ng1, EvictedPods: []*apiv1.Pod{p1}, Reason: scaledownstatus.ScaleDownUnderutilized},
+			{Node: n2, NodeGroup: ng1, Reason: scaledownstatus.ScaleDownEmpty},
+		},
+	})
+	n1Record := ScaleDownRecord{Node: "n1", NodeGroup: "ng1", Reason: "Underutilized", EvictedPods: []string{"default/p1"}, Result: "DeletionStarted"}
+	n2Record := ScaleDownRecord{Node: "n2", NodeGroup: "ng1", Reason: "Empty", Result: "DeletionStarted"}
+	assert.Equal(t, []Record{
+		{Kind: KindScaleDown, ScaleDown: &n1Record},
+		{Kind: KindScaleDown, ScaleDown: &n2Record},
+	}, readRecords(t, &buffer))
+
+	processor.Process(nil, &scaledownstatus.ScaleDownStatus{
+		Result: scaledownstatus.ScaleDownError,
+		NodeDeleteResults: map[string]scaledownstatus.NodeDeleteResult{
+			"n2": {ResultType: scaledownstatus.NodeDeleteOk},
+			"n1": {ResultType: scaledownstatus.NodeDeleteErrorFailedToEvictPods, Err: errors.NewAutoscalerError(errors.TransientError, "eviction timed out")},
+		},
+		ScaleDownError: func() *errors.AutoscalerError {
+			err := errors.NewAutoscalerError(errors.ApiCallError, "list failed")
+			return &err
+		}(),
+	})
+	n1Record.Result = "FailedToEvictPods"
+	n2Record.Result = "Deleted"
+	assert.Equal(t, []Record{
+		{Kind: KindScaleDownFailure, ScaleDown: &n1Record, Error: &ErrorRecord{Class: "transientError", Message: "eviction timed out"}},
+		{Kind: KindScaleDown, ScaleDown: &n2Record},
+		{Kind: KindScaleDownFailure, Error: &ErrorRecord{Class: "apiCallError", Message: "list failed"}},
+	}, readRecords(t, &buffer))
+	assert.Empty(t, processor.deleting)
+}
@@ -45,15 +45,11 @@ type CapacityBufferPodListProcessor struct {
 	client               *client.CapacityBufferClient
 	statusFilter         buffersfilter.Filter
 	podTemplateGenFilter buffersfilter.Filter
-	provStrategies       map[string]bool
+	strategyFilter       buffersfilter.Filter
 }
 
 // NewCapacityBufferPodListProcessor creates a new CapacityRequestPodListProcessor.
 func NewCapacityBufferPodListProcessor(client *client.CapacityBufferClient, provStrategies []string) *CapacityBufferPodListProcessor {
-	provStrategiesMap := map[string]bool{}
-	for _, ps := range provStrategies {
-		provStrategiesMap[ps] = true
-	}
 	return &CapacityBufferPodListProcessor{
 		client: client,
 		statusFilter: buffersfilter.NewStatusFilter(map[string]string{
@@ -61,7 +57,7 @@ func NewCapacityBufferPodListProcessor(client *client.CapacityBufferClient, prov
 			common.ProvisioningCondition:         common.ConditionTrue,
 		}),
 		podTemplateGenFilter: buffersfilter.NewPodTemplateGenerationChangedFilter(client),
-		provStrategies:       provStrategiesMap,
+		strategyFilter:       buffersfilter.NewStatusStrategyFilter(provStrategies),
 	}
 }
 
@@ -72,7 +68,7 @@ func (p *CapacityBufferPodListProcessor) Process(autoscalingCtx *ca_context.Auto
 		klog.Errorf("CapacityBufferPodListProcessor failed to list buffers with error: %v", err.Error())
 		return unschedulablePods, nil
 	}
-	buffers = p.filterBuffersProvStrategy(buffers)
+	buffers, _ = p.strategyFilter.Filter(buffers)
 	_, buffers = p.statusFilter.Filter(buffers)
 	_, buffers = p.podTemplateGenFilter.Filter(buffers)
 
@@ -113,17 +109,6 @@ func (p *CapacityBufferPodListProcessor) provision(buffer *api_v1.CapacityBuffer
 	return fakePods
 }
 
-func (p *CapacityBufferPodListProcessor) filterBuffersProvStrategy(buffers []*api_v1.CapacityBuffer) []*api_v1.CapacityBuffer {
-	var filteredBuffers []*api_v1.CapacityBuffer
-	for _, buffer := range buffers {
-
-		if buffer.Status.ProvisioningStrategy != nil && p.provStrategies[*buffer.Status.ProvisioningStrategy] {
-			filteredBuffers = append(filteredBuffers, buffer)
-		}
-	}
-	return filteredBuffers
-}
-
 func (p *CapacityBufferPodListProcessor) updateBufferStatus(buffer *api_v1.CapacityBuffer) {
 	_, err := p.client.UpdateCapacityBuffer(buffer)
 	if err != nil {
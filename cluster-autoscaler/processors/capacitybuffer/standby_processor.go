@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitybufferpodlister
+
+import (
+	"fmt"
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	api_v1 "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/autoscaling.x-k8s.io/v1alpha1"
+	client "k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/client"
+	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/common"
+	buffersfilter "k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/filters"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+// CapacityBufferStandbyProcessor provisions buffers using the standby provisioning
+// strategy. Instead of injecting fake pods, it keeps enough stopped instances in
+// node groups supporting standby to run the buffer's pods once resumed.
+//
+// The processor owns the standby size of all standby node groups: node groups
+// not chosen for any standby buffer have their standby size set to 0. Buffers
+// waiting to be translated again keep the standby nodes they were last given.
+type CapacityBufferStandbyProcessor struct {
+	client               *client.CapacityBufferClient
+	strategyFilter       buffersfilter.Filter
+	statusFilter         buffersfilter.Filter
+	podTemplateGenFilter buffersfilter.Filter
+	// provisioned holds the node group and standby nodes chosen for each buffer
+	// in the last loop.
+	provisioned map[types.NamespacedName]standbyNodes
+}
+
+// standbyNodes is a number of standby nodes in a node group.
+type standbyNodes struct {
+	nodeGroupId string
+	nodes       int
+}
+
+// NewCapacityBufferStandbyProcessor creates a new CapacityBufferStandbyProcessor.
+func NewCapacityBufferStandbyProcessor(client *client.CapacityBufferClient) *CapacityBufferStandbyProcessor {
+	return &CapacityBufferStandbyProcessor{
+		client:         client,
+		strategyFilter: buffersfilter.NewStatusStrategyFilter([]string{common.StandbyProvisioningStrategy}),
+		statusFilter: buffersfilter.NewStatusFilter(map[string]string{
+			common.ReadyForProvisioningCondition: common.ConditionTrue,
+			common.ProvisioningCondition:         common.ConditionTrue,
+		}),
+		podTemplateGenFilter: buffersfilter.NewPodTemplateGenerationChangedFilter(client),
+		provisioned:          map[types.NamespacedName]standbyNodes{},
+	}
+}
+
+// Process sets the standby size of node groups to match the standby buffers,
+// unschedulablePods are returned unchanged
+func (p *CapacityBufferStandbyProcessor) Process(autoscalingCtx *ca_context.AutoscalingContext, unschedulablePods []*apiv1.Pod) ([]*apiv1.Pod, error) {
+	buffers, err := p.client.ListCapacityBuffers()
+	if err != nil {
+		klog.Errorf("CapacityBufferStandbyProcessor failed to list buffers with error: %v", err.Error())
+		return unschedulablePods, nil
+	}
+	standbyBuffers, _ := p.strategyFilter.Filter(buffers)
+	_, buffers = p.statusFilter.Filter(standbyBuffers)
+	_, buffers = p.podTemplateGenFilter.Filter(buffers)
+
+	nodeGroups := standbyNodeGroups(autoscalingCtx.CloudProvider)
+	if len(nodeGroups) == 0 {
+		for _, buffer := range buffers {
+			common.UpdateBufferStatusToFailedProvisioing(buffer, "NoStandbyNodeGroup", "no node group supports standby instances")
+			p.updateBufferStatus(buffer)
+		}
+		return unschedulablePods, nil
+	}
+	templates := templateNodeInfos(autoscalingCtx, nodeGroups)
+
+	provisioned := map[types.NamespacedName]standbyNodes{}
+	bufferNodeGroups := map[*api_v1.CapacityBuffer]string{}
+	selected := map[types.NamespacedName]bool{}
+	for _, buffer := range buffers {
+		selected[bufferKey(buffer)] = true
+		nodeGroupId, nodes, ok := p.provision(autoscalingCtx, buffer, nodeGroups, templates)
+		if !ok {
+			continue
+		}
+		provisioned[bufferKey(buffer)] = standbyNodes{nodeGroupId: nodeGroupId, nodes: nodes}
+		bufferNodeGroups[buffer] = nodeGroupId
+	}
+	// Buffers filtered out until they are translated again keep their standby nodes.
+	for _, buffer := range standbyBuffers {
+		key := bufferKey(buffer)
+		if previous, found := p.provisioned[key]; found && !selected[key] {
+			provisioned[key] = previous
+		}
+	}
+	p.provisioned = provisioned
+
+	standbySizes := map[string]int{}
+	for _, standby := range provisioned {
+		standbySizes[standby.nodeGroupId] += standby.nodes
+	}
+
+	failedNodeGroups := map[string]error{}
+	for _, nodeGroup := range nodeGroups {
+		size := standbySizes[nodeGroup.Id()]
+		if currentSize, err := nodeGroup.StandbySize(); err == nil && currentSize == size {
+			continue
+		}
+		klog.V(2).Infof("Capacity buffer standby processor setting standby size of node group %s to %d", nodeGroup.Id(), size)
+		if err := nodeGroup.SetStandbySize(size); err != nil {
+			klog.Errorf("Failed to set standby size of node group %s to %d, error: %v", nodeGroup.Id(), size, err)
+			failedNodeGroups[nodeGroup.Id()] = err
+		}
+	}
+
+	for buffer, nodeGroupId := range bufferNodeGroups {
+		if err, found := failedNodeGroups[nodeGroupId]; found {
+			common.UpdateBufferStatusToFailedProvisioing(buffer, "FailedToSetStandbySize", fmt.Sprintf("failed to set standby size of node group %s with error: %v", nodeGroupId, err))
+		} else {
+			common.UpdateBufferStatusToSuccessfullyProvisioing(buffer, "StandbyCapacityRequested")
+		}
+		p.updateBufferStatus(buffer)
+	}
+	return unschedulablePods, nil
+}
+
+// CleanUp is called at CA termination
+func (p *CapacityBufferStandbyProcessor) CleanUp() {
+}
+
+// provision returns the node group chosen for the buffer and the number of
+// standby nodes needed to run its pods, updating the buffer status on failure
+func (p *CapacityBufferStandbyProcessor) provision(autoscalingCtx *ca_context.AutoscalingContext, buffer *api_v1.CapacityBuffer,
+	nodeGroups []cloudprovider.StandbyNodeGroup, templates map[string]*framework.NodeInfo) (string, int, bool) {
+	if buffer.Status.PodTemplateRef == nil || buffer.Status.Replicas == nil {
+		return "", 0, false
+	}
+	replicas := int(*buffer.Status.Replicas)
+	if replicas <= 0 {
+		return "", 0, false
+	}
+	podTemplate, err := p.client.GetPodTemplate(buffer.Namespace, buffer.Status.PodTemplateRef.Name)
+	if err != nil {
+		common.UpdateBufferStatusToFailedProvisioing(buffer, "FailedToGetPodTemplate", fmt.Sprintf("failed to get pod template with error: %v", err.Error()))
+		p.updateBufferStatus(buffer)
+		return "", 0, false
+	}
+	pods, err := makeFakePods(buffer.Name, &podTemplate.Template, replicas)
+	if err != nil {
+		common.UpdateBufferStatusToFailedProvisioing(buffer, "FailedToMakeFakePods", fmt.Sprintf("failed to create fake pods with error: %v", err.Error()))
+		p.updateBufferStatus(buffer)
+		return "", 0, false
+	}
+
+	bestNodeGroupId, bestNodes := "", 0
+	for _, nodeGroup := range nodeGroups {
+		template, found := templates[nodeGroup.Id()]
+		if !found {
+			continue
+		}
+		podsPerNode := podsFittingOnNode(autoscalingCtx, template, pods)
+		if podsPerNode == 0 {
+			continue
+		}
+		nodes := (replicas + podsPerNode - 1) / podsPerNode
+		if bestNodeGroupId == "" || nodes < bestNodes {
+			bestNodeGroupId, bestNodes = nodeGroup.Id(), nodes
+		}
+	}
+	if bestNodeGroupId == "" {
+		common.UpdateBufferStatusToFailedProvisioing(buffer, "NoStandbyNodeGroup", "buffer pods don't fit on any node group supporting standby instances")
+		p.updateBufferStatus(buffer)
+		return "", 0, false
+	}
+	return bestNodeGroupId, bestNodes, true
+}
+
+// templateNodeInfos returns the template node infos of the passed node groups,
+// as built by the TemplateNodeInfoProvider in the current loop
+func templateNodeInfos(autoscalingCtx *ca_context.AutoscalingContext, nodeGroups []cloudprovider.StandbyNodeGroup) map[string]*framework.NodeInfo {
+	templates := map[string]*framework.NodeInfo{}
+	for _, nodeGroup := range nodeGroups {
+		template, found := autoscalingCtx.TemplateNodeInfos[nodeGroup.Id()]
+		if !found {
+			klog.Warningf("CapacityBufferStandbyProcessor skipping node group %s: no template node info", nodeGroup.Id())
+			continue
+		}
+		templates[nodeGroup.Id()] = template
+	}
+	return templates
+}
+
+func bufferKey(buffer *api_v1.CapacityBuffer) types.NamespacedName {
+	return types.NamespacedName{Namespace: buffer.Namespace, Name: buffer.Name}
+}
+
+func (p *CapacityBufferStandbyProcessor) updateBufferStatus(buffer *api_v1.CapacityBuffer) {
+	_, err := p.client.UpdateCapacityBuffer(buffer)
+	if err != nil {
+		klog.Errorf("Failed to update buffer status for buffer %v, error: %v", buffer.Name, err.Error())
+	}
+}
+
+// podsFittingOnNode returns how many of the passed pods can be scheduled on a
+// single new node created from the template
+func podsFittingOnNode(autoscalingCtx *ca_context.AutoscalingContext, template *framework.NodeInfo, pods []*apiv1.Pod) int {
+	nodeInfo, err := simulator.SanitizedNodeInfo(template, "standby")
+	if err != nil {
+		klog.Errorf("CapacityBufferStandbyProcessor failed to sanitize template node info: %v", err)
+		return 0
+	}
+	autoscalingCtx.ClusterSnapshot.Fork()
+	defer autoscalingCtx.ClusterSnapshot.Revert()
+	if err := autoscalingCtx.ClusterSnapshot.AddNodeInfo(nodeInfo); err != nil {
+		klog.Errorf("CapacityBufferStandbyProcessor failed to add template node to the snapshot: %v", err)
+		return 0
+	}
+	fitting := 0
+	for _, pod := range pods {
+		if schedErr := autoscalingCtx.ClusterSnapshot.SchedulePod(pod, nodeInfo.Node().Name); schedErr != nil {
+			break
+		}
+		fitting++
+	}
+	return fitting
+}
+
+// standbyNodeGroups returns the node groups supporting standby instances, sorted by id
+func standbyNodeGroups(cloudProvider cloudprovider.CloudProvider) []cloudprovider.StandbyNodeGroup {
+	var nodeGroups []cloudprovider.StandbyNodeGroup
+	for _, nodeGroup := range cloudProvider.NodeGroups() {
+		if standbyNodeGroup, ok := nodeGroup.(cloudprovider.StandbyNodeGroup); ok {
+			nodeGroups = append(nodeGroups, standbyNodeGroup)
+		}
+	}
+	sort.Slice(nodeGroups, func(i, j int) bool {
+		return nodeGroups[i].Id() < nodeGroups[j].Id()
+	})
+	return nodeGroups
+}
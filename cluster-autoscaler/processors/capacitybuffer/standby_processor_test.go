@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitybufferpodlister
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclient "k8s.io/client-go/kubernetes/fake"
+
+	buffersfake "k8s.io/autoscaler/cluster-autoscaler/apis/capacitybuffer/client/clientset/versioned/fake"
+	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/client"
+	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/common"
+	"k8s.io/autoscaler/cluster-autoscaler/capacitybuffer/testutil"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/testsnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type testStandbyNodeGroup struct {
+	cloudprovider.NodeGroup
+	standbySize int
+	setErr      error
+}
+
+func (ng *testStandbyNodeGroup) StandbySize() (int, error) {
+	return ng.standbySize, nil
+}
+
+func (ng *testStandbyNodeGroup) SetStandbySize(size int) error {
+	if ng.setErr != nil {
+		return ng.setErr
+	}
+	ng.standbySize = size
+	return nil
+}
+
+type testStandbyCloudProvider struct {
+	*testprovider.TestCloudProvider
+	nodeGroups []cloudprovider.NodeGroup
+}
+
+func (p *testStandbyCloudProvider) NodeGroups() []cloudprovider.NodeGroup {
+	return p.nodeGroups
+}
+
+func TestStandbyProcessor(t *testing.T) {
+	standby := common.StandbyProvisioningStrategy
+	active := common.ActiveProvisioningStrategy
+	tests := []struct {
+		name                         string
+		objectsInBuffersClient       []runtime.Object
+		standbyNodeGroups            []string
+		setErr                       error
+		expectedStandbySizes         map[string]int
+		expectedBuffersProvCondition map[string]metav1.Condition
+	}{
+		{
+			name: "Buffers use the node group needing the fewest nodes",
+			objectsInBuffersClient: []runtime.Object{
+				getTestingBuffer("buffer1", "ref1", 6, 1, true, 1, standby),
+				getTestingBuffer("buffer2", "ref1", 3, 1, true, 1, standby),
+				getTestingBuffer("buffer3", "ref1", 5, 1, true, 1, active),
+				getTestingBuffer("buffer4", "ref1", 5, 1, false, 1, standby),
+			},
+			standbyNodeGroups:    []string{"ng-large", "ng-small"},
+			expectedStandbySizes: map[string]int{"ng-large": 3, "ng-small": 0},
+			expectedBuffersProvCondition: map[string]metav1.Condition{
+				"buffer1": {Type: common.ProvisioningCondition, Status: common.ConditionTrue},
+				"buffer2": {Type: common.ProvisioningCondition, Status: common.ConditionTrue},
+				"buffer3": {Type: common.ReadyForProvisioningCondition, Status: common.ConditionTrue},
+				"buffer4": {Type: common.ReadyForProvisioningCondition, Status: common.ConditionFalse},
+			},
+		},
+		{
+			name: "Pods not fitting on any standby node group",
+			objectsInBuffersClient: []runtime.Object{
+				getTestingBuffer("buffer1", "ref-huge", 1, 1, true, 1, standby),
+			},
+			standbyNodeGroups:    []string{"ng-large", "ng-small"},
+			expectedStandbySizes: map[string]int{"ng-large": 0, "ng-small": 0},
+			expectedBuffersProvCondition: map[string]metav1.Condition{
+				"buffer1": {Type: common.ProvisioningCondition, Status: common.ConditionFalse},
+			},
+		},
+		{
+			name: "No node group supports standby",
+			objectsInBuffersClient: []runtime.Object{
+				getTestingBuffer("buffer1", "ref1", 1, 1, true, 1, standby),
+			},
+			expectedStandbySizes: map[string]int{},
+			expectedBuffersProvCondition: map[string]metav1.Condition{
+				"buffer1": {Type: common.ProvisioningCondition, Status: common.ConditionFalse},
+			},
+		},
+		{
+			name: "Failing to set the standby size",
+			objectsInBuffersClient: []runtime.Object{
+				getTestingBuffer("buffer1", "ref1", 1, 1, true, 1, standby),
+			},
+			standbyNodeGroups:    []string{"ng-small"},
+			setErr:               fmt.Errorf("quota exceeded"),
+			expectedStandbySizes: map[string]int{"ng-small": 2},
+			expectedBuffersProvCondition: map[string]metav1.Condition{
+				"buffer1": {Type: common.ProvisioningCondition, Status: common.ConditionFalse},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeKubernetesClient := fakeclient.NewSimpleClientset(getTestingPodTemplateWithCpu("ref1", 1000), getTestingPodTemplateWithCpu("ref-huge", 8000))
+			fakeBuffersClient := buffersfake.NewSimpleClientset(test.objectsInBuffersClient...)
+			fakeCapacityBuffersClient, _ := client.NewCapacityBufferClientFromClients(fakeBuffersClient, fakeKubernetesClient, nil, nil, nil)
+
+			autoscalingCtx, standbyNodeGroups := newStandbyTestContext(t, test.standbyNodeGroups, test.setErr)
+
+			unschedulablePods := []*corev1.Pod{getTestingPod("Pod1")}
+			processor := NewCapacityBufferStandbyProcessor(fakeCapacityBuffersClient)
+			resUnschedulablePods, err := processor.Process(autoscalingCtx, unschedulablePods)
+			assert.NoError(t, err)
+			assert.Equal(t, unschedulablePods, resUnschedulablePods)
+
+			for id, expectedSize := range test.expectedStandbySizes {
+				assert.Equal(t, expectedSize, standbyNodeGroups[id].standbySize, id)
+			}
+			for bufferName, condition := range test.expectedBuffersProvCondition {
+				buffer, err := fakeBuffersClient.AutoscalingV1alpha1().CapacityBuffers(corev1.NamespaceDefault).Get(context.TODO(), bufferName, metav1.GetOptions{})
+				assert.NoError(t, err)
+				assert.Len(t, buffer.Status.Conditions, 1)
+				assert.Equal(t, condition.Type, buffer.Status.Conditions[0].Type, bufferName)
+				assert.Equal(t, condition.Status, buffer.Status.Conditions[0].Status, bufferName)
+			}
+		})
+	}
+}
+
+func TestStandbyProcessorKeepsSizeOfFilteredOutBuffers(t *testing.T) {
+	fakeKubernetesClient := fakeclient.NewSimpleClientset(getTestingPodTemplateWithCpu("ref1", 1000))
+	fakeBuffersClient := buffersfake.NewSimpleClientset(getTestingBuffer("buffer1", "ref1", 3, 1, true, 1, common.StandbyProvisioningStrategy))
+	fakeCapacityBuffersClient, _ := client.NewCapacityBufferClientFromClients(fakeBuffersClient, fakeKubernetesClient, nil, nil, nil)
+	autoscalingCtx, standbyNodeGroups := newStandbyTestContext(t, []string{"ng-small"}, nil)
+	processor := NewCapacityBufferStandbyProcessor(fakeCapacityBuffersClient)
+	buffers := fakeBuffersClient.AutoscalingV1alpha1().CapacityBuffers(corev1.NamespaceDefault)
+
+	_, err := processor.Process(autoscalingCtx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, standbyNodeGroups["ng-small"].standbySize)
+
+	// The buffer waits to be translated again, its standby nodes are kept.
+	buffer, err := buffers.Get(context.TODO(), "buffer1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	buffer.Status.Conditions = testutil.GetConditionNotReady()
+	_, err = buffers.UpdateStatus(context.TODO(), buffer, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		listed, err := fakeCapacityBuffersClient.ListCapacityBuffers()
+		return err == nil && len(listed) == 1 && listed[0].Status.Conditions[0].Status == metav1.ConditionFalse
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = processor.Process(autoscalingCtx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, standbyNodeGroups["ng-small"].standbySize)
+
+	// No buffer references the node group anymore.
+	assert.NoError(t, buffers.Delete(context.TODO(), "buffer1", metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool {
+		listed, err := fakeCapacityBuffersClient.ListCapacityBuffers()
+		return err == nil && len(listed) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = processor.Process(autoscalingCtx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, standbyNodeGroups["ng-small"].standbySize)
+}
+
+// newStandbyTestContext returns an autoscaling context with an active node group
+// and the passed standby node groups, each with a standby size of 2.
+func newStandbyTestContext(t *testing.T, standbyNodeGroupIds []string, setErr error) (*ca_context.AutoscalingContext, map[string]*testStandbyNodeGroup) {
+	templates := map[string]*framework.NodeInfo{
+		"ng-large":  framework.NewTestNodeInfo(BuildTestNode("ng-large-template", 4000, 16000000000)),
+		"ng-small":  framework.NewTestNodeInfo(BuildTestNode("ng-small-template", 1000, 4000000000)),
+		"ng-active": framework.NewTestNodeInfo(BuildTestNode("ng-active-template", 8000, 32000000000)),
+	}
+	provider := testprovider.NewTestCloudProviderBuilder().WithMachineTemplates(templates).Build()
+	provider.AddNodeGroup("ng-active", 0, 10, 0)
+	nodeGroups := []cloudprovider.NodeGroup{provider.GetNodeGroup("ng-active")}
+	standbyNodeGroups := map[string]*testStandbyNodeGroup{}
+	for _, id := range standbyNodeGroupIds {
+		provider.AddNodeGroup(id, 0, 10, 0)
+		standbyNodeGroups[id] = &testStandbyNodeGroup{NodeGroup: provider.GetNodeGroup(id), standbySize: 2, setErr: setErr}
+		nodeGroups = append(nodeGroups, standbyNodeGroups[id])
+	}
+	return &ca_context.AutoscalingContext{
+		CloudProvider:     &testStandbyCloudProvider{TestCloudProvider: provider, nodeGroups: nodeGroups},
+		ClusterSnapshot:   testsnapshot.NewTestSnapshotOrDie(t),
+		TemplateNodeInfos: templates,
+	}, standbyNodeGroups
+}
+
+func getTestingPodTemplateWithCpu(name string, millicpu int64) *corev1.PodTemplate {
+	podTemplate := getTestingPodTemplate(name, 1)
+	podTemplate.Template.Spec.Containers = []corev1.Container{{
+		Name: "container",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: *resource.NewMilliQuantity(millicpu, resource.DecimalSI)},
+		},
+	}}
+	return podTemplate
+}
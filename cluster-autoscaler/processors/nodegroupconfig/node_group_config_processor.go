@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
 )
 
 // NodeGroupConfigProcessor provides config values for a particular NodeGroup.
@@ -37,6 +38,12 @@ type NodeGroupConfigProcessor interface {
 	GetMaxNodeProvisionTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
 	// GetIgnoreDaemonSetsUtilization returns IgnoreDaemonSetsUtilization value that should be used for a given NodeGroup.
 	GetIgnoreDaemonSetsUtilization(nodeGroup cloudprovider.NodeGroup) (bool, error)
+	// GetMaxNodeLifetime returns MaxNodeLifetime value that should be used for a given NodeGroup.
+	GetMaxNodeLifetime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
+	// GetScaleDownMaintenanceWindows returns ScaleDownMaintenanceWindows value that should be used for a given NodeGroup.
+	GetScaleDownMaintenanceWindows(nodeGroup cloudprovider.NodeGroup) ([]schedule.Window, error)
+	// GetScaleDownBlackoutWindows returns ScaleDownBlackoutWindows value that should be used for a given NodeGroup.
+	GetScaleDownBlackoutWindows(nodeGroup cloudprovider.NodeGroup) ([]schedule.Window, error)
 	// CleanUp cleans up processor's internal structures.
 	CleanUp()
 }
@@ -120,6 +127,42 @@ func (p *DelegatingNodeGroupConfigProcessor) GetIgnoreDaemonSetsUtilization(node
 	return ngConfig.IgnoreDaemonSetsUtilization, nil
 }
 
+// GetMaxNodeLifetime returns MaxNodeLifetime value that should be used for a given NodeGroup.
+func (p *DelegatingNodeGroupConfigProcessor) GetMaxNodeLifetime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error) {
+	ngConfig, err := nodeGroup.GetOptions(p.nodeGroupDefaults)
+	if err != nil && err != cloudprovider.ErrNotImplemented {
+		return time.Duration(0), err
+	}
+	if ngConfig == nil || err == cloudprovider.ErrNotImplemented {
+		return p.nodeGroupDefaults.MaxNodeLifetime, nil
+	}
+	return ngConfig.MaxNodeLifetime, nil
+}
+
+// GetScaleDownMaintenanceWindows returns ScaleDownMaintenanceWindows value that should be used for a given NodeGroup.
+func (p *DelegatingNodeGroupConfigProcessor) GetScaleDownMaintenanceWindows(nodeGroup cloudprovider.NodeGroup) ([]schedule.Window, error) {
+	ngConfig, err := nodeGroup.GetOptions(p.nodeGroupDefaults)
+	if err != nil && err != cloudprovider.ErrNotImplemented {
+		return nil, err
+	}
+	if ngConfig == nil || err == cloudprovider.ErrNotImplemented {
+		return p.nodeGroupDefaults.ScaleDownMaintenanceWindows, nil
+	}
+	return ngConfig.ScaleDownMaintenanceWindows, nil
+}
+
+// GetScaleDownBlackoutWindows returns ScaleDownBlackoutWindows value that should be used for a given NodeGroup.
+func (p *DelegatingNodeGroupConfigProcessor) GetScaleDownBlackoutWindows(nodeGroup cloudprovider.NodeGroup) ([]schedule.Window, error) {
+	ngConfig, err := nodeGroup.GetOptions(p.nodeGroupDefaults)
+	if err != nil && err != cloudprovider.ErrNotImplemented {
+		return nil, err
+	}
+	if ngConfig == nil || err == cloudprovider.ErrNotImplemented {
+		return p.nodeGroupDefaults.ScaleDownBlackoutWindows, nil
+	}
+	return ngConfig.ScaleDownBlackoutWindows, nil
+}
+
 // CleanUp cleans up processor's internal structures.
 func (p *DelegatingNodeGroupConfigProcessor) CleanUp() {
 }
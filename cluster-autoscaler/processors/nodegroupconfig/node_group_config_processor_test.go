@@ -26,6 +26,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/mocks"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
 )
 
 // This test covers all Get* methods implemented by
@@ -41,6 +42,11 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 	var GLOBAL Want = 1
 	var NG Want = 2
 
+	globalWindow, err := schedule.ParseWindow("0 22 * * * 8h")
+	assert.NoError(t, err)
+	ngWindow, err := schedule.ParseWindow("0 0 * * 6 48h")
+	assert.NoError(t, err)
+
 	globalOpts := config.NodeGroupAutoscalingOptions{
 		ScaleDownUnneededTime:            3 * time.Minute,
 		ScaleDownUnreadyTime:             4 * time.Minute,
@@ -48,6 +54,9 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 		ScaleDownUtilizationThreshold:    0.5,
 		MaxNodeProvisionTime:             15 * time.Minute,
 		IgnoreDaemonSetsUtilization:      true,
+		MaxNodeLifetime:                  24 * time.Hour,
+		ScaleDownMaintenanceWindows:      []schedule.Window{globalWindow},
+		ScaleDownBlackoutWindows:         []schedule.Window{ngWindow},
 	}
 	ngOpts := &config.NodeGroupAutoscalingOptions{
 		ScaleDownUnneededTime:            10 * time.Minute,
@@ -56,6 +65,9 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 		ScaleDownUtilizationThreshold:    0.75,
 		MaxNodeProvisionTime:             60 * time.Minute,
 		IgnoreDaemonSetsUtilization:      false,
+		MaxNodeLifetime:                  72 * time.Hour,
+		ScaleDownMaintenanceWindows:      []schedule.Window{ngWindow},
+		ScaleDownBlackoutWindows:         []schedule.Window{globalWindow},
 	}
 
 	testUnneededTime := func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
@@ -121,6 +133,38 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 		assert.Equal(t, res, results[w])
 	}
 
+	testMaxNodeLifetime := func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
+		res, err := p.GetMaxNodeLifetime(ng)
+		assert.Equal(t, err, we)
+		results := map[Want]time.Duration{
+			NIL:    time.Duration(0),
+			GLOBAL: 24 * time.Hour,
+			NG:     72 * time.Hour,
+		}
+		assert.Equal(t, res, results[w])
+	}
+
+	testMaintenanceWindows := func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
+		res, err := p.GetScaleDownMaintenanceWindows(ng)
+		assert.Equal(t, err, we)
+		results := map[Want][]schedule.Window{
+			NIL:    nil,
+			GLOBAL: {globalWindow},
+			NG:     {ngWindow},
+		}
+		assert.Equal(t, res, results[w])
+	}
+	testBlackoutWindows := func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
+		res, err := p.GetScaleDownBlackoutWindows(ng)
+		assert.Equal(t, err, we)
+		results := map[Want][]schedule.Window{
+			NIL:    nil,
+			GLOBAL: {ngWindow},
+			NG:     {globalWindow},
+		}
+		assert.Equal(t, res, results[w])
+	}
+
 	funcs := map[string]func(*testing.T, NodeGroupConfigProcessor, cloudprovider.NodeGroup, Want, error){
 		"ScaleDownUnneededTime":            testUnneededTime,
 		"ScaleDownUnreadyTime":             testUnreadyTime,
@@ -128,6 +172,9 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 		"ScaleDownGpuUtilizationThreshold": testGpuThreshold,
 		"MaxNodeProvisionTime":             testMaxNodeProvisionTime,
 		"IgnoreDaemonSetsUtilization":      testIgnoreDSUtilization,
+		"MaxNodeLifetime":                  testMaxNodeLifetime,
+		"ScaleDownMaintenanceWindows":      testMaintenanceWindows,
+		"ScaleDownBlackoutWindows":         testBlackoutWindows,
 		"MultipleOptions": func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
 			testUnneededTime(t, p, ng, w, we)
 			testUnreadyTime(t, p, ng, w, we)
@@ -135,6 +182,9 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 			testGpuThreshold(t, p, ng, w, we)
 			testMaxNodeProvisionTime(t, p, ng, w, we)
 			testIgnoreDSUtilization(t, p, ng, w, we)
+			testMaxNodeLifetime(t, p, ng, w, we)
+			testMaintenanceWindows(t, p, ng, w, we)
+			testBlackoutWindows(t, p, ng, w, we)
 		},
 		"RepeatingTheSameCallGivesConsistentResults": func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
 			testUnneededTime(t, p, ng, w, we)
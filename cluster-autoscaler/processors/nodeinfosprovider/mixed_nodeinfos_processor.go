@@ -18,6 +18,7 @@ package nodeinfosprovider
 
 import (
 	"errors"
+	"maps"
 	"reflect"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/templatecache"
 	caerror "k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
@@ -37,6 +39,10 @@ import (
 const stabilizationDelay = 1 * time.Minute
 const maxCacheExpireTime = 87660 * time.Hour
 
+// cacheSaveInterval is how often cached templates are saved to the NodeInfoCacheStore,
+// unless the set of cached node groups changes.
+const cacheSaveInterval = 10 * time.Minute
+
 type cacheItem struct {
 	*framework.NodeInfo
 	added time.Time
@@ -47,6 +53,9 @@ type MixedTemplateNodeInfoProvider struct {
 	nodeInfoCache   map[string]cacheItem
 	ttl             time.Duration
 	forceDaemonSets bool
+	cacheRestored   bool
+	lastCacheSave   time.Time
+	savedGroups     map[string]bool
 }
 
 // NewMixedTemplateNodeInfoProvider returns a NodeInfoProvider processor building
@@ -77,6 +86,7 @@ func (p *MixedTemplateNodeInfoProvider) Process(autoscalingCtx *ca_context.Autos
 	// TODO(mwielgus): Review error policy - sometimes we may continue with partial errors.
 	result := make(map[string]*framework.NodeInfo)
 	seenGroups := make(map[string]bool)
+	p.restoreCache(autoscalingCtx.NodeInfoCacheStore)
 
 	// processNode returns information whether the nodeTemplate was generated and if there was an error.
 	processNode := func(node *apiv1.Node) (bool, string, caerror.AutoscalerError) {
@@ -154,6 +164,7 @@ func (p *MixedTemplateNodeInfoProvider) Process(autoscalingCtx *ca_context.Autos
 			delete(p.nodeInfoCache, id)
 		}
 	}
+	p.saveCache(autoscalingCtx.NodeInfoCacheStore, now)
 
 	// Last resort - unready/unschedulable nodes.
 	for _, node := range nodes {
@@ -178,6 +189,51 @@ func (p *MixedTemplateNodeInfoProvider) Process(autoscalingCtx *ca_context.Autos
 	return result, nil
 }
 
+// restoreCache fills the cache with templates saved before a restart. It is
+// only done once, templates built since then take precedence.
+func (p *MixedTemplateNodeInfoProvider) restoreCache(store templatecache.Store) {
+	if store == nil || p.nodeInfoCache == nil || p.cacheRestored {
+		return
+	}
+	p.cacheRestored = true
+	entries, err := store.Load()
+	if err != nil {
+		klog.Warningf("Failed to restore cached template node infos: %v", err)
+		return
+	}
+	for id, entry := range entries {
+		if _, found := p.nodeInfoCache[id]; !found {
+			p.nodeInfoCache[id] = cacheItem{NodeInfo: entry.NodeInfo, added: entry.Added}
+		}
+	}
+	klog.V(1).Infof("Restored %d cached template node infos", len(entries))
+}
+
+// saveCache saves cached templates, if the set of cached node groups changed
+// or they weren't saved for cacheSaveInterval.
+func (p *MixedTemplateNodeInfoProvider) saveCache(store templatecache.Store, now time.Time) {
+	if store == nil || p.nodeInfoCache == nil {
+		return
+	}
+	groups := make(map[string]bool, len(p.nodeInfoCache))
+	for id := range p.nodeInfoCache {
+		groups[id] = true
+	}
+	if maps.Equal(groups, p.savedGroups) && now.Sub(p.lastCacheSave) < cacheSaveInterval {
+		return
+	}
+	entries := make(map[string]templatecache.Entry, len(p.nodeInfoCache))
+	for id, item := range p.nodeInfoCache {
+		entries[id] = templatecache.Entry{NodeInfo: item.NodeInfo, Added: item.added}
+	}
+	// Failures aren't retried until the next interval, to avoid hammering a broken store.
+	p.lastCacheSave = now
+	p.savedGroups = groups
+	if err := store.Save(entries); err != nil {
+		klog.Warningf("Failed to save cached template node infos: %v", err)
+	}
+}
+
 func isNodeGoodTemplateCandidate(node *apiv1.Node, now time.Time) bool {
 	ready, lastTransitionTime, _ := kube_util.GetReadinessState(node)
 	stable := lastTransitionTime.Add(stabilizationDelay).Before(now)
@@ -29,6 +29,7 @@ import (
 	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/testsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/templatecache"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
@@ -302,6 +303,74 @@ func TestGetNodeInfosCacheExpired(t *testing.T) {
 
 }
 
+type fakeTemplateCacheStore struct {
+	entries map[string]templatecache.Entry
+	saves   int
+}
+
+func (s *fakeTemplateCacheStore) Load() (map[string]templatecache.Entry, error) {
+	return s.entries, nil
+}
+
+func (s *fakeTemplateCacheStore) Save(entries map[string]templatecache.Entry) error {
+	s.entries = entries
+	s.saves++
+	return nil
+}
+
+func TestGetNodeInfosPersistentCache(t *testing.T) {
+	now := time.Now()
+	ready1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(ready1, true, now.Add(-2*time.Minute))
+
+	// Cloud provider with TemplateNodeInfo not implemented.
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	provider.AddNode("ng1", ready1)
+	provider.AddNodeGroup("ng2", 0, 10, 0)
+
+	nodes := []*apiv1.Node{ready1}
+	snapshot := testsnapshot.NewTestSnapshotOrDie(t)
+	assert.NoError(t, snapshot.SetClusterState(nodes, nil, nil))
+	store := &fakeTemplateCacheStore{entries: map[string]templatecache.Entry{
+		// Saved long ago, must not be restored.
+		"ng2": {NodeInfo: framework.NewTestNodeInfo(BuildTestNode("tn", 5000, 5000)), Added: now.Add(-time.Hour)},
+	}}
+	autoscalingCtx := ca_context.AutoscalingContext{
+		CloudProvider:      provider,
+		ClusterSnapshot:    snapshot,
+		NodeInfoCacheStore: store,
+	}
+
+	ttl := 10 * time.Minute
+	processor := NewMixedTemplateNodeInfoProvider(&ttl, false)
+	res, err := processor.Process(&autoscalingCtx, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	assert.NoError(t, err)
+	assert.Contains(t, res, "ng1")
+	assert.NotContains(t, res, "ng2")
+	assert.Equal(t, 1, store.saves)
+	assert.Contains(t, store.entries, "ng1")
+
+	// The set of cached node groups didn't change, so the templates aren't saved again until cacheSaveInterval passes.
+	_, err = processor.Process(&autoscalingCtx, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, store.saves)
+	_, err = processor.Process(&autoscalingCtx, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now.Add(cacheSaveInterval+time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, store.saves)
+
+	// After a restart, ng1 is scaled to zero, but the template built from n1 is restored.
+	provider = testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 0, 10, 0)
+	autoscalingCtx.CloudProvider = provider
+	assert.NoError(t, snapshot.SetClusterState(nil, nil, nil))
+	res, err = NewMixedTemplateNodeInfoProvider(&ttl, false).Process(&autoscalingCtx, []*apiv1.Node{}, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	assert.NoError(t, err)
+	if assert.Contains(t, res, "ng1") {
+		assert.Equal(t, int64(1000), res["ng1"].Node().Status.Allocatable.Cpu().MilliValue())
+	}
+}
+
 func TestProcessHandlesTemplateNodeInfoErrors(t *testing.T) {
 	now := time.Now()
 
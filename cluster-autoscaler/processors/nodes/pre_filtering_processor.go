@@ -62,7 +62,7 @@ func (n *PreFilteringScaleDownNodeProcessor) GetScaleDownCandidates(autoscalingC
 			klog.Errorf("Error while checking node group size %s: group size not found", nodeGroup.Id())
 			continue
 		}
-		minSize := nodeGroup.MinSize()
+		minSize := autoscalingCtx.ScheduledMinCapacity.MinSize(nodeGroup)
 		if size <= minSize {
 			klog.V(1).Infof("Skipping %s - node group min size reached (current: %d, min: %d)", node.Name, size, minSize)
 			continue
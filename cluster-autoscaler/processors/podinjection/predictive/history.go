@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BucketSize is the granularity of the demand history.
+const BucketSize = 5 * time.Minute
+
+// Sample is the demand of a node group during a single bucket.
+type Sample struct {
+	// Start is the beginning of the bucket.
+	Start time.Time `json:"start"`
+	// Nodes is the highest number of nodes needed during the bucket: nodes
+	// running workload pods, plus nodes added for pending pods.
+	Nodes int `json:"nodes"`
+	// PendingGroups is the highest number of pending pod equivalence groups
+	// which triggered a scale-up of the node group during the bucket.
+	PendingGroups int `json:"pendingGroups,omitempty"`
+}
+
+// History holds demand samples of node groups, sorted by time.
+type History struct {
+	NodeGroups map[string][]Sample
+}
+
+// persistedHistory is the compact form of History saved in a Store. A week of
+// samples of a node group takes a few kilobytes this way.
+type persistedHistory struct {
+	NodeGroups map[string]persistedSamples `json:"nodeGroups"`
+}
+
+// persistedSamples holds consecutive buckets of a node group, starting at
+// Start. Buckets with no sample are stored as -1 in Nodes. PendingGroups is
+// omitted if no pending pods triggered a scale-up of the node group.
+type persistedSamples struct {
+	Start         time.Time `json:"start"`
+	Nodes         []int     `json:"nodes"`
+	PendingGroups []int     `json:"pendingGroups,omitempty"`
+}
+
+// MarshalJSON encodes the history in its compact form.
+func (h *History) MarshalJSON() ([]byte, error) {
+	persisted := persistedHistory{NodeGroups: make(map[string]persistedSamples, len(h.NodeGroups))}
+	for nodeGroup, samples := range h.NodeGroups {
+		if len(samples) == 0 {
+			continue
+		}
+		start := samples[0].Start
+		buckets := int(samples[len(samples)-1].Start.Sub(start)/BucketSize) + 1
+		ps := persistedSamples{Start: start, Nodes: make([]int, buckets)}
+		for i := range ps.Nodes {
+			ps.Nodes[i] = -1
+		}
+		for _, sample := range samples {
+			i := int(sample.Start.Sub(start) / BucketSize)
+			ps.Nodes[i] = sample.Nodes
+			if sample.PendingGroups > 0 {
+				if ps.PendingGroups == nil {
+					ps.PendingGroups = make([]int, buckets)
+				}
+				ps.PendingGroups[i] = sample.PendingGroups
+			}
+		}
+		persisted.NodeGroups[nodeGroup] = ps
+	}
+	return json.Marshal(persisted)
+}
+
+// UnmarshalJSON decodes the history from its compact form.
+func (h *History) UnmarshalJSON(data []byte) error {
+	var persisted persistedHistory
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return err
+	}
+	h.NodeGroups = make(map[string][]Sample, len(persisted.NodeGroups))
+	for nodeGroup, ps := range persisted.NodeGroups {
+		if !ps.Start.Equal(ps.Start.Truncate(BucketSize)) {
+			return fmt.Errorf("samples of node group %s start in the middle of a bucket", nodeGroup)
+		}
+		if ps.PendingGroups != nil && len(ps.PendingGroups) != len(ps.Nodes) {
+			return fmt.Errorf("node group %s has %d pending group counts for %d buckets", nodeGroup, len(ps.PendingGroups), len(ps.Nodes))
+		}
+		var samples []Sample
+		for i, nodes := range ps.Nodes {
+			if nodes < 0 {
+				continue
+			}
+			sample := Sample{Start: ps.Start.Add(time.Duration(i) * BucketSize), Nodes: nodes}
+			if ps.PendingGroups != nil {
+				sample.PendingGroups = ps.PendingGroups[i]
+			}
+			samples = append(samples, sample)
+		}
+		h.NodeGroups[nodeGroup] = samples
+	}
+	return nil
+}
+
+// NewHistory returns an empty History.
+func NewHistory() *History {
+	return &History{NodeGroups: make(map[string][]Sample)}
+}
+
+// Record records the demand of a node group at the given time, and drops
+// samples older than retention.
+func (h *History) Record(nodeGroup string, now time.Time, nodes int, retention time.Duration) {
+	h.record(nodeGroup, now, retention, func(sample *Sample) {
+		sample.Nodes = max(sample.Nodes, nodes)
+	})
+}
+
+// RecordPending records a scale-up of a node group for pending pods at the
+// given time: the nodes needed including the added ones, and the number of
+// pending pod equivalence groups which triggered the scale-up. Recording
+// demand when pods become pending, rather than when new nodes start running
+// them, keeps forecasts ahead of node provisioning time.
+func (h *History) RecordPending(nodeGroup string, now time.Time, nodes, pendingGroups int, retention time.Duration) {
+	h.record(nodeGroup, now, retention, func(sample *Sample) {
+		sample.Nodes = max(sample.Nodes, nodes)
+		sample.PendingGroups = max(sample.PendingGroups, pendingGroups)
+	})
+}
+
+func (h *History) record(nodeGroup string, now time.Time, retention time.Duration, update func(*Sample)) {
+	start := now.Truncate(BucketSize)
+	samples := h.NodeGroups[nodeGroup]
+	if n := len(samples); n == 0 || !samples[n-1].Start.Equal(start) {
+		samples = append(samples, Sample{Start: start})
+	}
+	update(&samples[len(samples)-1])
+	cutoff := sort.Search(len(samples), func(i int) bool {
+		return !samples[i].Start.Before(now.Add(-retention))
+	})
+	h.NodeGroups[nodeGroup] = samples[cutoff:]
+}
+
+// Trim drops samples older than retention.
+func (h *History) Trim(now time.Time, retention time.Duration) {
+	for nodeGroup, samples := range h.NodeGroups {
+		cutoff := sort.Search(len(samples), func(i int) bool {
+			return !samples[i].Start.Before(now.Add(-retention))
+		})
+		h.NodeGroups[nodeGroup] = samples[cutoff:]
+	}
+}
+
+// Prune drops the history of node groups which no longer exist.
+func (h *History) Prune(existing map[string]bool) {
+	for nodeGroup := range h.NodeGroups {
+		if !existing[nodeGroup] {
+			delete(h.NodeGroups, nodeGroup)
+		}
+	}
+}
+
+// observed returns the demand recorded for the bucket starting at start.
+func (h *History) observed(nodeGroup string, start time.Time) (int, bool) {
+	samples := h.NodeGroups[nodeGroup]
+	i := sort.Search(len(samples), func(i int) bool { return !samples[i].Start.Before(start) })
+	if i < len(samples) && samples[i].Start.Equal(start) {
+		return samples[i].Nodes, true
+	}
+	return 0, false
+}
+
+// seasonalAverage returns the average demand in the buckets containing t
+// shifted back by 1 to seasons seasons. Returns false if none of them was
+// recorded.
+func (h *History) seasonalAverage(nodeGroup string, t time.Time, season time.Duration, seasons int) (float64, bool) {
+	sum, count := 0, 0
+	for k := 1; k <= seasons; k++ {
+		start := t.Add(-time.Duration(k) * season).Truncate(BucketSize)
+		if nodes, found := h.observed(nodeGroup, start); found {
+			sum += nodes
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return float64(sum) / float64(count), true
+}
+
+// Forecast returns the expected demand of a node group at time t, given its
+// current demand at time now. It uses a seasonal naive model: the demand at
+// the same time of up to seasons previous seasons is averaged, and adjusted
+// by how much the current demand differs from the average at the same time
+// of these seasons, so that growth or decline since then is carried over.
+// Returns false if there is no history for the same time of previous seasons.
+func (h *History) Forecast(nodeGroup string, now, t time.Time, current int, season time.Duration, seasons int) (float64, bool) {
+	expected, found := h.seasonalAverage(nodeGroup, t, season, seasons)
+	if !found {
+		return 0, false
+	}
+	if base, found := h.seasonalAverage(nodeGroup, now, season, seasons); found {
+		expected += float64(current) - base
+	}
+	return max(expected, 0), true
+}
+
+// ForecastMax returns the highest demand forecast for buckets between now and
+// now+horizon. Returns false if no bucket could be forecast.
+func (h *History) ForecastMax(nodeGroup string, now time.Time, horizon time.Duration, current int, season time.Duration, seasons int) (float64, bool) {
+	result, found := 0.0, false
+	for t := now; !t.After(now.Add(horizon)); t = t.Add(BucketSize) {
+		if forecast, ok := h.Forecast(nodeGroup, now, t, current, season, seasons); ok {
+			result = max(result, forecast)
+			found = true
+		}
+	}
+	return result, found
+}
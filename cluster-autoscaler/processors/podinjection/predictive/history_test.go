@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	history := NewHistory()
+	history.Record("ng1", start.Add(time.Minute), 2, time.Hour)
+	history.Record("ng1", start.Add(2*time.Minute), 5, time.Hour)
+	history.Record("ng1", start.Add(3*time.Minute), 1, time.Hour)
+	history.Record("ng1", start.Add(BucketSize), 3, time.Hour)
+	assert.Equal(t, []Sample{{Start: start, Nodes: 5}, {Start: start.Add(BucketSize), Nodes: 3}}, history.NodeGroups["ng1"])
+
+	history.Record("ng1", start.Add(time.Hour+BucketSize), 4, time.Hour)
+	assert.Equal(t, []Sample{{Start: start.Add(BucketSize), Nodes: 3}, {Start: start.Add(time.Hour + BucketSize), Nodes: 4}}, history.NodeGroups["ng1"])
+
+	history.Record("ng2", start, 1, time.Hour)
+	history.Prune(map[string]bool{"ng2": true})
+	assert.NotContains(t, history.NodeGroups, "ng1")
+	assert.Contains(t, history.NodeGroups, "ng2")
+}
+
+func TestForecast(t *testing.T) {
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	retention := 7*day + BucketSize
+
+	testCases := []struct {
+		name         string
+		samples      map[time.Duration]int
+		current      int
+		wantFound    bool
+		wantForecast float64
+		wantMax      float64
+	}{
+		{
+			name:    "no history",
+			current: 2,
+		},
+		{
+			name: "single season",
+			samples: map[time.Duration]int{
+				-day:                  2,
+				-day + 10*time.Minute: 6,
+			},
+			current:      2,
+			wantFound:    true,
+			wantForecast: 6,
+			wantMax:      6,
+		},
+		{
+			name: "seasons are averaged",
+			samples: map[time.Duration]int{
+				-day:                    2,
+				-day + 10*time.Minute:   6,
+				-2 * day:                2,
+				-2*day + 10*time.Minute: 8,
+			},
+			current:      2,
+			wantFound:    true,
+			wantForecast: 7,
+			wantMax:      7,
+		},
+		{
+			name: "growth is carried over",
+			samples: map[time.Duration]int{
+				-day:                  2,
+				-day + 10*time.Minute: 6,
+			},
+			current:      5,
+			wantFound:    true,
+			wantForecast: 9,
+			wantMax:      9,
+		},
+		{
+			name: "forecast is never negative",
+			samples: map[time.Duration]int{
+				-day:                  6,
+				-day + 10*time.Minute: 1,
+			},
+			current:      2,
+			wantFound:    true,
+			wantForecast: 0,
+			wantMax:      2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			history := NewHistory()
+			for _, offset := range []time.Duration{-2 * day, -2*day + 10*time.Minute, -day, -day + 10*time.Minute} {
+				if nodes, found := tc.samples[offset]; found {
+					history.Record("ng1", now.Add(offset), nodes, retention)
+				}
+			}
+			forecast, found := history.Forecast("ng1", now, now.Add(10*time.Minute), tc.current, day, 7)
+			assert.Equal(t, tc.wantFound, found)
+			assert.InDelta(t, tc.wantForecast, forecast, 0.001)
+			forecastMax, found := history.ForecastMax("ng1", now, 15*time.Minute, tc.current, day, 7)
+			assert.Equal(t, tc.wantFound, found)
+			assert.InDelta(t, tc.wantMax, forecastMax, 0.001)
+		})
+	}
+}
+
+func TestHistoryJSON(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	history := NewHistory()
+	history.Record("ng1", start, 2, time.Hour)
+	history.Record("ng1", start.Add(3*BucketSize), 0, time.Hour)
+	history.Record("ng2", start, 1, time.Hour)
+	history.RecordPending("ng2", start, 3, 2, time.Hour)
+	history.NodeGroups["empty"] = nil
+
+	data, err := json.Marshal(history)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"nodeGroups": {
+		"ng1": {"start": "2025-01-01T12:00:00Z", "nodes": [2, -1, -1, 0]},
+		"ng2": {"start": "2025-01-01T12:00:00Z", "nodes": [3], "pendingGroups": [2]}
+	}}`, string(data))
+
+	decoded := NewHistory()
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, history.NodeGroups["ng1"], decoded.NodeGroups["ng1"])
+	assert.Equal(t, history.NodeGroups["ng2"], decoded.NodeGroups["ng2"])
+	assert.NotContains(t, decoded.NodeGroups, "empty")
+
+	// A week of samples of a node group takes a few kilobytes.
+	week := 7 * 24 * time.Hour
+	history = NewHistory()
+	for ts := start; ts.Before(start.Add(week)); ts = ts.Add(BucketSize) {
+		history.Record("ng1", ts, 100, week)
+	}
+	data, err = json.Marshal(history)
+	assert.NoError(t, err)
+	assert.Less(t, len(data), 10*1024)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"nodeGroups": {"ng1": {"start": "2025-01-01T12:01:00Z", "nodes": [1]}}}`), NewHistory()))
+}
+
+func TestTrim(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	history := NewHistory()
+	history.Record("ng1", start, 2, time.Hour)
+	history.Record("ng1", start.Add(30*time.Minute), 3, time.Hour)
+	history.Trim(start.Add(time.Hour), 45*time.Minute)
+	assert.Equal(t, []Sample{{Start: start.Add(30 * time.Minute), Nodes: 3}}, history.NodeGroups["ng1"])
+}
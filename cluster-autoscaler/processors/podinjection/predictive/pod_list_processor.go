@@ -0,0 +1,374 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/fake"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/persistence"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	"k8s.io/klog/v2"
+)
+
+// PlaceholderPodAnnotationKey is the annotation marking placeholder pods
+// injected by predictive scale-up. Its value is the id of the node group
+// the placeholder was sized for.
+const PlaceholderPodAnnotationKey = "cluster-autoscaler.kubernetes.io/predictive-scale-up-placeholder"
+
+// forecastKey identifies a forecast made for a node group and bucket.
+type forecastKey struct {
+	nodeGroup string
+	start     time.Time
+}
+
+// PodListProcessor records the demand of node groups and injects placeholder
+// pods for the demand forecast within the horizon, so that nodes are created
+// before the workload needing them is.
+type PodListProcessor struct {
+	store   Store
+	history *History
+	horizon time.Duration
+	season  time.Duration
+	seasons int
+	// keptSeasons is the number of seasons kept in the history. It is lowered
+	// if the history doesn't fit in the store.
+	keptSeasons int
+	restored    bool
+	// lastSave is the bucket during which the history was last saved.
+	lastSave time.Time
+	// forecasts are the forecasts made for buckets which haven't been
+	// observed yet, used to measure forecast accuracy.
+	forecasts map[forecastKey]float64
+	// demand is the number of nodes running workload pods in each node group,
+	// as of the last Process call.
+	demand map[string]int
+	now    func() time.Time
+}
+
+// NewPodListProcessor returns a PodListProcessor forecasting the demand within
+// horizon from up to seasons previous seasons of the given length. If store
+// is not nil, the history is restored from it and periodically saved to it.
+func NewPodListProcessor(store Store, horizon, season time.Duration, seasons int) *PodListProcessor {
+	return &PodListProcessor{
+		store:       store,
+		history:     NewHistory(),
+		horizon:     horizon,
+		season:      season,
+		seasons:     seasons,
+		keptSeasons: seasons,
+		forecasts:   make(map[forecastKey]float64),
+		now:         time.Now,
+	}
+}
+
+// Process records the current demand of node groups and appends placeholder
+// pods for the forecast demand exceeding it.
+func (p *PodListProcessor) Process(autoscalingCtx *ca_context.AutoscalingContext, unschedulablePods []*apiv1.Pod) ([]*apiv1.Pod, error) {
+	now := p.now()
+	p.restore()
+
+	nodeInfos, err := autoscalingCtx.ClusterSnapshot.ListNodeInfos()
+	if err != nil {
+		return unschedulablePods, fmt.Errorf("failed to list nodeInfos from cluster snapshot: %v", err)
+	}
+	nodeGroups := autoscalingCtx.CloudProvider.NodeGroups()
+	existing := make(map[string]bool, len(nodeGroups))
+	demand := make(map[string]int, len(nodeGroups))
+	for _, nodeGroup := range nodeGroups {
+		existing[nodeGroup.Id()] = true
+		demand[nodeGroup.Id()] = 0
+	}
+	for _, nodeInfo := range nodeInfos {
+		nodeGroup, err := autoscalingCtx.CloudProvider.NodeGroupForNode(nodeInfo.Node())
+		if err != nil || nodeGroup == nil || !existing[nodeGroup.Id()] {
+			continue
+		}
+		if runsWorkload(nodeInfo) {
+			demand[nodeGroup.Id()]++
+		}
+	}
+
+	p.demand = demand
+	retention := p.retention()
+	p.history.Prune(existing)
+	for nodeGroup, nodes := range demand {
+		p.history.Record(nodeGroup, now, nodes, retention)
+	}
+	p.observeAccuracy(now, existing)
+
+	var placeholders []*apiv1.Pod
+	for _, nodeGroup := range nodeGroups {
+		id := nodeGroup.Id()
+		p.rememberForecast(id, now, demand[id])
+		forecast, found := p.history.ForecastMax(id, now, p.horizon, demand[id], p.season, p.seasons)
+		if !found {
+			metrics.UpdatePredictiveScaleUpForecast(id, float64(demand[id]), 0)
+			continue
+		}
+		missing := min(int(math.Ceil(forecast))-demand[id], nodeGroup.MaxSize()-demand[id])
+		if missing <= 0 {
+			metrics.UpdatePredictiveScaleUpForecast(id, forecast, 0)
+			continue
+		}
+		// New nodes of the group are simulated from templates built by
+		// TemplateNodeInfoProvider, so placeholders based on them fit there.
+		template, found := autoscalingCtx.TemplateNodeInfos[id]
+		if !found {
+			klog.Warningf("Predictive scale-up has no template node for node group %s", id)
+			metrics.UpdatePredictiveScaleUpForecast(id, forecast, 0)
+			continue
+		}
+		pods := makePlaceholderPods(id, template, missing)
+		metrics.UpdatePredictiveScaleUpForecast(id, forecast, len(pods))
+		placeholders = append(placeholders, pods...)
+		klog.V(4).Infof("Predictive scale-up forecasts %.2f nodes in node group %s needing %d nodes now, injecting %d placeholder pods", forecast, id, demand[id], len(pods))
+	}
+	if len(placeholders) > 0 {
+		klog.V(2).Infof("Predictive scale-up injecting %d placeholder pods", len(placeholders))
+	}
+
+	p.save(now)
+	return append(unschedulablePods, placeholders...), nil
+}
+
+// CleanUp saves the history before CA terminates.
+func (p *PodListProcessor) CleanUp() {
+	if p.store == nil || !p.restored {
+		return
+	}
+	if err := p.store.Save(p.history); err != nil {
+		klog.Warningf("Failed to save predictive scale-up history: %v", err)
+	}
+}
+
+func (p *PodListProcessor) restore() {
+	if p.restored {
+		return
+	}
+	p.restored = true
+	if p.store == nil {
+		return
+	}
+	history, err := p.store.Load()
+	if err != nil {
+		klog.Warningf("Failed to restore predictive scale-up history, starting from scratch: %v", err)
+		return
+	}
+	p.history = history
+	klog.V(1).Infof("Restored predictive scale-up history of %d node groups", len(history.NodeGroups))
+}
+
+// RecordScaleUp records the demand of node groups scaled up for pending pods.
+// Scale-ups triggered only by placeholder pods aren't recorded, and neither
+// are nodes added for placeholders along with pending pods, so that forecasts
+// don't feed on themselves.
+func (p *PodListProcessor) RecordScaleUp(scaleUpStatus *status.ScaleUpStatus) {
+	if scaleUpStatus.Result != status.ScaleUpSuccessful || p.demand == nil {
+		return
+	}
+	pending := 0
+	equivalenceGroups := make(map[types.UID]bool)
+	for _, pod := range scaleUpStatus.PodsTriggeredScaleUp {
+		if IsPlaceholderPod(pod) {
+			continue
+		}
+		pending++
+		if controller := metav1.GetControllerOf(pod); controller != nil {
+			equivalenceGroups[controller.UID] = true
+		} else {
+			equivalenceGroups[pod.UID] = true
+		}
+	}
+	if pending == 0 {
+		return
+	}
+	now := p.now()
+	for _, info := range scaleUpStatus.ScaleUpInfos {
+		id := info.Group.Id()
+		if _, found := p.demand[id]; !found {
+			continue
+		}
+		added := int(math.Ceil(float64(info.NewSize-info.CurrentSize) * float64(pending) / float64(len(scaleUpStatus.PodsTriggeredScaleUp))))
+		p.history.RecordPending(id, now, p.demand[id]+added, len(equivalenceGroups), p.retention())
+	}
+}
+
+// retention is how long samples are kept in the history.
+func (p *PodListProcessor) retention() time.Duration {
+	return time.Duration(p.keptSeasons)*p.season + BucketSize
+}
+
+// save saves the history at most once per bucket. If the history doesn't fit
+// in the store, the oldest seasons are dropped until it does.
+func (p *PodListProcessor) save(now time.Time) {
+	bucket := now.Truncate(BucketSize)
+	if p.store == nil || p.lastSave.Equal(bucket) {
+		return
+	}
+	for {
+		err := p.store.Save(p.history)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, persistence.ErrTooLarge) {
+			klog.Warningf("Failed to save predictive scale-up history: %v", err)
+			return
+		}
+		if p.keptSeasons <= 1 {
+			klog.Errorf("Predictive scale-up history of a single season doesn't fit in the store, history won't survive restarts: %v", err)
+			return
+		}
+		p.keptSeasons--
+		p.history.Trim(now, p.retention())
+		klog.Errorf("Predictive scale-up history doesn't fit in the store, keeping only %d out of %d seasons: %v", p.keptSeasons, p.seasons, err)
+	}
+	p.lastSave = bucket
+}
+
+// rememberForecast remembers the first forecast made for the bucket at the
+// end of the horizon, to compare it with the demand observed later.
+func (p *PodListProcessor) rememberForecast(nodeGroup string, now time.Time, current int) {
+	target := now.Add(p.horizon)
+	key := forecastKey{nodeGroup: nodeGroup, start: target.Truncate(BucketSize)}
+	if _, found := p.forecasts[key]; found {
+		return
+	}
+	if forecast, found := p.history.Forecast(nodeGroup, now, target, current, p.season, p.seasons); found {
+		p.forecasts[key] = forecast
+	}
+}
+
+// observeAccuracy reports the error of forecasts made for buckets which are
+// over, so the highest demand during them is known.
+func (p *PodListProcessor) observeAccuracy(now time.Time, existing map[string]bool) {
+	for key, forecast := range p.forecasts {
+		if !existing[key.nodeGroup] {
+			delete(p.forecasts, key)
+			continue
+		}
+		if now.Before(key.start.Add(BucketSize)) {
+			continue
+		}
+		delete(p.forecasts, key)
+		if observed, found := p.history.observed(key.nodeGroup, key.start); found {
+			metrics.ObservePredictiveScaleUpForecastError(key.nodeGroup, math.Abs(forecast-float64(observed)))
+		}
+	}
+}
+
+// runsWorkload returns true if the node runs pods other than DaemonSet and
+// mirror pods.
+func runsWorkload(nodeInfo *framework.NodeInfo) bool {
+	for _, podInfo := range nodeInfo.Pods() {
+		if !pod_util.IsDaemonSetPod(podInfo.Pod) && !pod_util.IsMirrorPod(podInfo.Pod) {
+			return true
+		}
+	}
+	return false
+}
+
+// makePlaceholderPods returns count pods, each taking the whole capacity of
+// the template node left by DaemonSet and mirror pods, and only fitting on
+// nodes with the same labels and taints. The hostname label of the template
+// is left out, since it differs between nodes created from it.
+func makePlaceholderPods(nodeGroup string, template *framework.NodeInfo, count int) []*apiv1.Pod {
+	node := template.Node()
+	requests := apiv1.ResourceList{}
+	for name, allocatable := range node.Status.Allocatable {
+		if name == apiv1.ResourcePods {
+			continue
+		}
+		requests[name] = allocatable.DeepCopy()
+	}
+	for _, podInfo := range template.Pods() {
+		if !pod_util.IsDaemonSetPod(podInfo.Pod) && !pod_util.IsMirrorPod(podInfo.Pod) {
+			continue
+		}
+		for name, request := range pod_util.PodRequests(podInfo.Pod) {
+			if free, found := requests[name]; found {
+				free.Sub(request)
+				requests[name] = free
+			}
+		}
+	}
+	for name, free := range requests {
+		if free.Sign() <= 0 {
+			delete(requests, name)
+		}
+	}
+
+	nodeSelector := make(map[string]string, len(node.Labels))
+	for key, value := range node.Labels {
+		if key != apiv1.LabelHostname {
+			nodeSelector[key] = value
+		}
+	}
+	var tolerations []apiv1.Toleration
+	for _, taint := range node.Spec.Taints {
+		tolerations = append(tolerations, apiv1.Toleration{Key: taint.Key, Operator: apiv1.TolerationOpExists, Effect: taint.Effect})
+	}
+
+	pods := make([]*apiv1.Pod, 0, count)
+	for i := 1; i <= count; i++ {
+		name := fmt.Sprintf("predictive-scale-up-%s-%d", nodeGroup, i)
+		pod := &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   metav1.NamespaceSystem,
+				UID:         types.UID(name),
+				Annotations: map[string]string{PlaceholderPodAnnotationKey: nodeGroup},
+			},
+			Spec: apiv1.PodSpec{
+				NodeSelector: nodeSelector,
+				Tolerations:  tolerations,
+				Containers: []apiv1.Container{{
+					Name:      "placeholder",
+					Resources: apiv1.ResourceRequirements{Requests: copyResourceList(requests)},
+				}},
+			},
+			Status: apiv1.PodStatus{Phase: apiv1.PodPending},
+		}
+		pods = append(pods, fake.WithFakePodAnnotation(pod))
+	}
+	return pods
+}
+
+func copyResourceList(resources apiv1.ResourceList) apiv1.ResourceList {
+	result := make(apiv1.ResourceList, len(resources))
+	for name, quantity := range resources {
+		result[name] = quantity.DeepCopy()
+	}
+	return result
+}
+
+// IsPlaceholderPod returns true if the pod is a placeholder injected by
+// predictive scale-up.
+func IsPlaceholderPod(pod *apiv1.Pod) bool {
+	_, found := pod.Annotations[PlaceholderPodAnnotationKey]
+	return found
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/testsnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/fake"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/persistence"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type fakeStore struct {
+	history *History
+	saves   int
+	// maxSamples is the most samples the store accepts, if set.
+	maxSamples int
+}
+
+func (s *fakeStore) Load() (*History, error) {
+	return s.history, nil
+}
+
+func (s *fakeStore) Save(history *History) error {
+	samples := 0
+	for _, nodeGroupSamples := range history.NodeGroups {
+		samples += len(nodeGroupSamples)
+	}
+	if s.maxSamples > 0 && samples > s.maxSamples {
+		return fmt.Errorf("failed to save: %w", persistence.ErrTooLarge)
+	}
+	s.history = history
+	s.saves++
+	return nil
+}
+
+func TestPodListProcessor(t *testing.T) {
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	retention := 7*day + BucketSize
+
+	busy := BuildTestNode("busy", 4000, 8000)
+	busy.Labels = map[string]string{"pool": "ng1", apiv1.LabelHostname: "busy"}
+	busy.Spec.Taints = []apiv1.Taint{{Key: "dedicated", Value: "ng1", Effect: apiv1.TaintEffectNoSchedule}}
+	idle := BuildTestNode("idle", 4000, 8000)
+	idle.Labels = map[string]string{"pool": "ng1", apiv1.LabelHostname: "idle"}
+	other := BuildTestNode("other", 1000, 1000)
+
+	dsPod := SetDSPodSpec(BuildScheduledTestPod("ds", 500, 1000, "busy"))
+	workload := SetRSPodSpec(BuildScheduledTestPod("workload", 1000, 1000, "busy"), "rs")
+	idleDsPod := SetDSPodSpec(BuildScheduledTestPod("ds-idle", 500, 1000, "idle"))
+
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 0, 3, 2)
+	provider.AddNode("ng1", busy)
+	provider.AddNode("ng1", idle)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	provider.AddNode("ng2", other)
+
+	snapshot := testsnapshot.NewTestSnapshotOrDie(t)
+	assert.NoError(t, snapshot.AddNodeInfo(framework.NewTestNodeInfo(busy, dsPod, workload)))
+	assert.NoError(t, snapshot.AddNodeInfo(framework.NewTestNodeInfo(idle, idleDsPod)))
+	assert.NoError(t, snapshot.AddNodeInfo(framework.NewTestNodeInfo(other)))
+	template := BuildTestNode("template-node-for-ng1-1", 4000, 8000)
+	template.Labels = map[string]string{"pool": "ng1", apiv1.LabelHostname: "template-node-for-ng1-1"}
+	template.Spec.Taints = busy.Spec.Taints
+	autoscalingCtx := &ca_context.AutoscalingContext{
+		CloudProvider:   provider,
+		ClusterSnapshot: snapshot,
+		TemplateNodeInfos: map[string]*framework.NodeInfo{
+			"ng1": framework.NewTestNodeInfo(template, SetDSPodSpec(BuildScheduledTestPod("ds-template", 500, 1000, template.Name))),
+			"ng2": framework.NewTestNodeInfo(other),
+		},
+	}
+
+	// A day ago, demand of ng1 grew from 1 to 5 nodes within the horizon.
+	// The group can't grow beyond 3 nodes though.
+	history := NewHistory()
+	history.Record("ng1", now.Add(-day), 1, retention)
+	history.Record("ng1", now.Add(-day+10*time.Minute), 5, retention)
+	history.Record("ng1", now.Add(-day+15*time.Minute), 5, retention)
+	history.Record("removed", now.Add(-day), 1, retention)
+	store := &fakeStore{history: history}
+
+	processor := NewPodListProcessor(store, 15*time.Minute, day, 7)
+	processor.now = func() time.Time { return now }
+	pending := BuildTestPod("pending", 100, 100)
+	pods, err := processor.Process(autoscalingCtx, []*apiv1.Pod{pending})
+	assert.NoError(t, err)
+
+	assert.Len(t, pods, 3)
+	assert.Equal(t, pending, pods[0])
+	for _, pod := range pods[1:] {
+		assert.True(t, fake.IsFake(pod))
+		assert.True(t, IsPlaceholderPod(pod))
+		assert.Equal(t, "ng1", pod.Annotations[PlaceholderPodAnnotationKey])
+		assert.Equal(t, map[string]string{"pool": "ng1"}, pod.Spec.NodeSelector)
+		assert.Equal(t, []apiv1.Toleration{{Key: "dedicated", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule}}, pod.Spec.Tolerations)
+		requests := pod.Spec.Containers[0].Resources.Requests
+		assert.True(t, resource.MustParse("3500m").Equal(requests[apiv1.ResourceCPU]))
+		assert.True(t, resource.MustParse("7000").Equal(requests[apiv1.ResourceMemory]))
+		assert.NotContains(t, requests, apiv1.ResourcePods)
+	}
+	assert.NotEqual(t, pods[1].Name, pods[2].Name)
+
+	// The current demand is recorded, and history of removed groups dropped.
+	assert.Equal(t, 1, store.saves)
+	assert.Equal(t, []Sample{{Start: now, Nodes: 1}}, store.history.NodeGroups["ng1"][3:])
+	assert.Equal(t, []Sample{{Start: now, Nodes: 0}}, store.history.NodeGroups["ng2"])
+	assert.NotContains(t, store.history.NodeGroups, "removed")
+
+	// The history is saved at most once per bucket.
+	processor.now = func() time.Time { return now.Add(time.Minute) }
+	_, err = processor.Process(autoscalingCtx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, store.saves)
+	processor.now = func() time.Time { return now.Add(BucketSize) }
+	_, err = processor.Process(autoscalingCtx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, store.saves)
+
+	// Forecasts are checked once their bucket is over.
+	assert.Contains(t, processor.forecasts, forecastKey{nodeGroup: "ng1", start: now.Add(15 * time.Minute)})
+	processor.now = func() time.Time { return now.Add(20 * time.Minute) }
+	_, err = processor.Process(autoscalingCtx, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, processor.forecasts, forecastKey{nodeGroup: "ng1", start: now.Add(15 * time.Minute)})
+}
+
+func TestPodListProcessorDropsSeasonsNotFittingInStore(t *testing.T) {
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 0, 3, 0)
+	autoscalingCtx := &ca_context.AutoscalingContext{
+		CloudProvider:   provider,
+		ClusterSnapshot: testsnapshot.NewTestSnapshotOrDie(t),
+	}
+
+	history := NewHistory()
+	for k := 3; k >= 1; k-- {
+		history.Record("ng1", now.Add(-time.Duration(k)*day), 1, 7*day)
+	}
+	// The history of the last season and the current sample fit.
+	store := &fakeStore{history: history, maxSamples: 2}
+	processor := NewPodListProcessor(store, 15*time.Minute, day, 7)
+	processor.now = func() time.Time { return now }
+	_, err := processor.Process(autoscalingCtx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, store.saves)
+	assert.Equal(t, 1, processor.keptSeasons)
+	assert.Equal(t, []Sample{{Start: now.Add(-day), Nodes: 1}, {Start: now, Nodes: 0}}, store.history.NodeGroups["ng1"])
+
+	// Nothing is saved if a single season doesn't fit.
+	store.maxSamples = 1
+	processor.now = func() time.Time { return now.Add(BucketSize) }
+	_, err = processor.Process(autoscalingCtx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, store.saves)
+}
+
+func TestRecordScaleUp(t *testing.T) {
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	provider.AddNodeGroup("ng2", 0, 10, 0)
+	node := BuildTestNode("n1", 1000, 1000)
+	provider.AddNode("ng1", node)
+	snapshot := testsnapshot.NewTestSnapshotOrDie(t)
+	assert.NoError(t, snapshot.AddNodeInfo(framework.NewTestNodeInfo(node, SetRSPodSpec(BuildScheduledTestPod("running", 100, 100, "n1"), "rs"))))
+	autoscalingCtx := &ca_context.AutoscalingContext{
+		CloudProvider:   provider,
+		ClusterSnapshot: snapshot,
+	}
+
+	processor := NewPodListProcessor(nil, 15*time.Minute, day, 7)
+	processor.now = func() time.Time { return now }
+	_, err := processor.Process(autoscalingCtx, nil)
+	assert.NoError(t, err)
+
+	ng1, ng2 := provider.GetNodeGroup("ng1"), provider.GetNodeGroup("ng2")
+	placeholder := makePlaceholderPods("ng1", framework.NewTestNodeInfo(node), 1)[0]
+	statusProcessor := NewPlaceholderPodsScaleUpStatusProcessor(processor)
+
+	// Scale-ups triggered only by placeholders aren't recorded.
+	statusProcessor.Process(autoscalingCtx, &status.ScaleUpStatus{
+		Result:               status.ScaleUpSuccessful,
+		ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: ng1, CurrentSize: 1, NewSize: 2}},
+		PodsTriggeredScaleUp: []*apiv1.Pod{placeholder},
+	})
+	assert.Equal(t, []Sample{{Start: now, Nodes: 1}}, processor.history.NodeGroups["ng1"])
+
+	// Two replicas of a ReplicaSet and a standalone pod are two equivalence
+	// groups. Nodes added for the placeholder along with them aren't counted.
+	pods := []*apiv1.Pod{
+		SetRSPodSpec(BuildTestPod("rs-1", 100, 100), "rs"),
+		SetRSPodSpec(BuildTestPod("rs-2", 100, 100), "rs"),
+		BuildTestPod("standalone", 100, 100),
+		placeholder,
+	}
+	statusProcessor.Process(autoscalingCtx, &status.ScaleUpStatus{
+		Result: status.ScaleUpSuccessful,
+		ScaleUpInfos: []nodegroupset.ScaleUpInfo{
+			{Group: ng1, CurrentSize: 1, NewSize: 5},
+			{Group: ng2, CurrentSize: 0, NewSize: 2},
+		},
+		PodsTriggeredScaleUp: pods,
+	})
+	assert.Equal(t, []Sample{{Start: now, Nodes: 4, PendingGroups: 2}}, processor.history.NodeGroups["ng1"])
+	assert.Equal(t, []Sample{{Start: now, Nodes: 2, PendingGroups: 2}}, processor.history.NodeGroups["ng2"])
+
+	// Failed scale-ups aren't recorded.
+	processor.now = func() time.Time { return now.Add(BucketSize) }
+	statusProcessor.Process(autoscalingCtx, &status.ScaleUpStatus{
+		Result:               status.ScaleUpError,
+		ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: ng2, CurrentSize: 0, NewSize: 2}},
+		PodsTriggeredScaleUp: pods[:1],
+	})
+	assert.Len(t, processor.history.NodeGroups["ng2"], 1)
+}
+
+func TestPlaceholderPodsScaleUpStatusProcessor(t *testing.T) {
+	template := framework.NewTestNodeInfo(BuildTestNode("n1", 1000, 1000))
+	placeholder := makePlaceholderPods("ng1", template, 1)[0]
+	pod := BuildTestPod("pod", 100, 100)
+	fakePod := fake.WithFakePodAnnotation(BuildTestPod("fake", 100, 100))
+
+	scaleUpStatus := &status.ScaleUpStatus{
+		PodsTriggeredScaleUp:    []*apiv1.Pod{pod, placeholder},
+		PodsAwaitEvaluation:     []*apiv1.Pod{placeholder, fakePod},
+		PodsRemainUnschedulable: []status.NoScaleUpInfo{{Pod: placeholder}, {Pod: pod}},
+	}
+	NewPlaceholderPodsScaleUpStatusProcessor(nil).Process(nil, scaleUpStatus)
+	assert.Equal(t, []*apiv1.Pod{pod}, scaleUpStatus.PodsTriggeredScaleUp)
+	assert.Equal(t, []*apiv1.Pod{fakePod}, scaleUpStatus.PodsAwaitEvaluation)
+	assert.Equal(t, []status.NoScaleUpInfo{{Pod: pod}}, scaleUpStatus.PodsRemainUnschedulable)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/klog/v2"
+)
+
+// PlaceholderPodsScaleUpStatusProcessor is a ScaleUpStatusProcessor used for
+// recording scale-ups for pending pods in the demand history, and filtering
+// out predictive scale-up placeholder pods from scale-up status.
+type PlaceholderPodsScaleUpStatusProcessor struct {
+	podListProcessor *PodListProcessor
+}
+
+// NewPlaceholderPodsScaleUpStatusProcessor returns an instance of PlaceholderPodsScaleUpStatusProcessor
+// recording scale-ups in the history of the given PodListProcessor, unless it is nil.
+func NewPlaceholderPodsScaleUpStatusProcessor(podListProcessor *PodListProcessor) *PlaceholderPodsScaleUpStatusProcessor {
+	return &PlaceholderPodsScaleUpStatusProcessor{podListProcessor: podListProcessor}
+}
+
+// Process records the scale-up and updates scaleUpStatus to remove all
+// placeholder pods from PodsRemainUnschedulable, PodsAwaitEvaluation &
+// PodsTriggeredScaleUp.
+func (p *PlaceholderPodsScaleUpStatusProcessor) Process(_ *ca_context.AutoscalingContext, scaleUpStatus *status.ScaleUpStatus) {
+	if p.podListProcessor != nil {
+		p.podListProcessor.RecordScaleUp(scaleUpStatus)
+	}
+	scaleUpStatus.PodsRemainUnschedulable = filterPlaceholderPods(scaleUpStatus.PodsRemainUnschedulable, func(noScaleUpInfo status.NoScaleUpInfo) *apiv1.Pod { return noScaleUpInfo.Pod }, "PodsRemainUnschedulable")
+	scaleUpStatus.PodsAwaitEvaluation = filterPlaceholderPods(scaleUpStatus.PodsAwaitEvaluation, func(pod *apiv1.Pod) *apiv1.Pod { return pod }, "PodsAwaitEvaluation")
+	scaleUpStatus.PodsTriggeredScaleUp = filterPlaceholderPods(scaleUpStatus.PodsTriggeredScaleUp, func(pod *apiv1.Pod) *apiv1.Pod { return pod }, "PodsTriggeredScaleUp")
+}
+
+// filterPlaceholderPods removes placeholder pods from the input list of T using passed getPod(T).
+func filterPlaceholderPods[T any](podsWrappers []T, getPod func(T) *apiv1.Pod, resourceName string) []T {
+	filtered := make([]T, 0, len(podsWrappers))
+	removed := 0
+	for _, podsWrapper := range podsWrappers {
+		if IsPlaceholderPod(getPod(podsWrapper)) {
+			removed++
+			continue
+		}
+		filtered = append(filtered, podsWrapper)
+	}
+	if removed > 0 {
+		klog.V(4).Infof("Filtered out %d predictive scale-up placeholder pods from %s", removed, resourceName)
+	}
+	return filtered
+}
+
+// CleanUp is called at CA termination.
+func (p *PlaceholderPodsScaleUpStatusProcessor) CleanUp() {}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/autoscaler/cluster-autoscaler/utils/persistence"
+	kube_client "k8s.io/client-go/kubernetes"
+)
+
+// ConfigMapKey is the key of the ConfigMap data entry holding the demand history.
+const ConfigMapKey = "history"
+
+// Store saves and restores the demand history.
+type Store interface {
+	// Load returns the previously saved history, or an empty one if nothing was saved yet.
+	Load() (*History, error)
+	// Save replaces the previously saved history.
+	Save(history *History) error
+}
+
+// blobStore keeps the demand history in a persisted blob.
+type blobStore struct {
+	blob persistence.Blob
+}
+
+// NewFileStore returns a Store keeping the demand history in the file with the given path.
+func NewFileStore(path string) Store {
+	return &blobStore{blob: persistence.NewFileBlob(path)}
+}
+
+// NewConfigMapStore returns a Store keeping the demand history in the given ConfigMap.
+func NewConfigMapStore(client kube_client.Interface, namespace, name string) Store {
+	return &blobStore{blob: persistence.NewConfigMapBlob(client, namespace, name, ConfigMapKey)}
+}
+
+// Load returns the saved demand history.
+func (s *blobStore) Load() (*History, error) {
+	data, err := s.blob.Read()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return NewHistory(), nil
+	}
+	return decodeHistory(data)
+}
+
+// Save replaces the saved demand history.
+func (s *blobStore) Save(history *History) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return s.blob.Write(data)
+}
+
+func decodeHistory(data []byte) (*History, error) {
+	history := NewHistory()
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal demand history: %v", err)
+	}
+	if history.NodeGroups == nil {
+		history.NodeGroups = make(map[string][]Sample)
+	}
+	return history, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testHistory() *History {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	history := NewHistory()
+	history.Record("ng1", start, 2, 24*time.Hour)
+	history.Record("ng1", start.Add(BucketSize), 4, 24*time.Hour)
+	history.Record("ng2", start, 0, 24*time.Hour)
+	return history
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	store := NewFileStore(path)
+
+	history, err := store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, history.NodeGroups)
+
+	assert.NoError(t, store.Save(testHistory()))
+	history, err = store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, testHistory(), history)
+
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+	_, err = store.Load()
+	assert.Error(t, err)
+}
+
+func TestConfigMapStore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	store := NewConfigMapStore(client, "kube-system", "predictive-scale-up")
+
+	history, err := store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, history.NodeGroups)
+
+	// The first save creates the ConfigMap, the next one updates it.
+	assert.NoError(t, store.Save(NewHistory()))
+	assert.NoError(t, store.Save(testHistory()))
+	history, err = store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, testHistory(), history)
+
+	configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(context.TODO(), "predictive-scale-up", metav1.GetOptions{})
+	assert.NoError(t, err)
+	configMap.Data = map[string]string{ConfigMapKey: "{"}
+	_, err = client.CoreV1().ConfigMaps("kube-system").Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	_, err = store.Load()
+	assert.Error(t, err)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package status
 
 import (
+	"errors"
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
@@ -37,6 +38,41 @@ func NewDefaultAutoscalingStatusProcessor() AutoscalingStatusProcessor {
 	}
 }
 
+// CombinedAutoscalingStatusProcessor is a list of AutoscalingStatusProcessor
+type CombinedAutoscalingStatusProcessor struct {
+	processors []AutoscalingStatusProcessor
+}
+
+// NewCombinedAutoscalingStatusProcessor construct CombinedAutoscalingStatusProcessor.
+func NewCombinedAutoscalingStatusProcessor(processors []AutoscalingStatusProcessor) *CombinedAutoscalingStatusProcessor {
+	var autoscalingStatusProcessors []AutoscalingStatusProcessor
+	for _, processor := range processors {
+		if processor != nil {
+			autoscalingStatusProcessors = append(autoscalingStatusProcessors, processor)
+		}
+	}
+	return &CombinedAutoscalingStatusProcessor{autoscalingStatusProcessors}
+}
+
+// Process runs sub-processors sequentially in the same order of addition, and returns
+// the errors of all of them.
+func (p *CombinedAutoscalingStatusProcessor) Process(autoscalingCtx *ca_context.AutoscalingContext, csr *clusterstate.ClusterStateRegistry, now time.Time) error {
+	var errs []error
+	for _, processor := range p.processors {
+		if err := processor.Process(autoscalingCtx, csr, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *CombinedAutoscalingStatusProcessor) CleanUp() {
+	for _, processor := range p.processors {
+		processor.CleanUp()
+	}
+}
+
 // NoOpAutoscalingStatusProcessor is an AutoscalingStatusProcessor implementation useful for testing.
 type NoOpAutoscalingStatusProcessor struct{}
 
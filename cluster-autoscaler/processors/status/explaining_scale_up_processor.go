@@ -0,0 +1,332 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// ScaleUpPodCondition is the type of the pod condition set to the outcome
+	// of scale-up for the pod if pod conditions are enabled.
+	ScaleUpPodCondition apiv1.PodConditionType = "cluster-autoscaler.kubernetes.io/ScaleUp"
+	// explanationTTL is how long explanations of pods which stopped showing
+	// up in scale-up attempts are kept.
+	explanationTTL = 10 * time.Minute
+	// podConditionWriteQPS and podConditionWriteBurst limit the rate of pod
+	// status updates sent in the background to set ScaleUpPodCondition.
+	podConditionWriteQPS   = 5
+	podConditionWriteBurst = 10
+)
+
+// PodScaleUpResult is the outcome of a scale-up attempt for a pod.
+type PodScaleUpResult string
+
+const (
+	// PodTriggeredScaleUp means that the pod triggered a scale-up.
+	PodTriggeredScaleUp PodScaleUpResult = "TriggeredScaleUp"
+	// PodNotTriggerScaleUp means that no node group can help the pod.
+	PodNotTriggerScaleUp PodScaleUpResult = "NotTriggerScaleUp"
+	// PodAwaitingEvaluation means that the pod could be helped by a scale-up,
+	// but it wasn't part of the scale-up chosen in this loop.
+	PodAwaitingEvaluation PodScaleUpResult = "AwaitingEvaluation"
+)
+
+// NodeGroupOutcome is the outcome of a single node group for a pod.
+type NodeGroupOutcome string
+
+const (
+	// NodeGroupChosen - the node group was scaled up.
+	NodeGroupChosen NodeGroupOutcome = "Chosen"
+	// NodeGroupSkipped - the node group wasn't considered, e.g. because it was in backoff or at its max size.
+	NodeGroupSkipped NodeGroupOutcome = "Skipped"
+	// NodeGroupPredicateFailure - the pod wouldn't fit on a new node from the node group.
+	NodeGroupPredicateFailure NodeGroupOutcome = "PredicateFailure"
+	// NodeGroupRejected - the node group was rejected for another reason.
+	NodeGroupRejected NodeGroupOutcome = "Rejected"
+)
+
+// NodeGroupExplanation describes the outcome of a node group considered for a pod.
+type NodeGroupExplanation struct {
+	NodeGroup string           `json:"nodeGroup"`
+	Outcome   NodeGroupOutcome `json:"outcome"`
+	// Predicate is the name of the failing scheduler predicate.
+	Predicate string   `json:"predicate,omitempty"`
+	Reasons   []string `json:"reasons,omitempty"`
+	// NodeCount is the number of nodes added to a chosen node group.
+	NodeCount int `json:"nodeCount,omitempty"`
+}
+
+// PodExplanation describes the outcome of the last scale-up attempt for a pod.
+// Pods from the same equivalence group share the outcomes.
+type PodExplanation struct {
+	Namespace      string                 `json:"namespace"`
+	Name           string                 `json:"name"`
+	Result         PodScaleUpResult       `json:"result"`
+	Message        string                 `json:"message,omitempty"`
+	NodeGroups     []NodeGroupExplanation `json:"nodeGroups,omitempty"`
+	LastUpdateTime metav1.Time            `json:"lastUpdateTime"`
+}
+
+// ExplainingScaleUpStatusProcessor keeps explanations of scale-up outcomes
+// for pending pods and serves them over HTTP. Optionally, the outcome is also
+// set as the ScaleUpPodCondition of one pod of every group of equivalent pods.
+type ExplainingScaleUpStatusProcessor struct {
+	setPodConditions bool
+	writer           *kube_util.AsyncWriter
+	mutex            sync.RWMutex
+	explanations     map[types.NamespacedName]*PodExplanation
+	now              func() time.Time
+}
+
+// NewExplainingScaleUpStatusProcessor returns a new ExplainingScaleUpStatusProcessor.
+// Pod conditions are updated in the background, at a limited rate.
+func NewExplainingScaleUpStatusProcessor(setPodConditions bool) *ExplainingScaleUpStatusProcessor {
+	writer := kube_util.NewAsyncWriter(podConditionWriteQPS, podConditionWriteBurst)
+	go writer.Run(wait.NeverStop)
+	return &ExplainingScaleUpStatusProcessor{
+		setPodConditions: setPodConditions,
+		writer:           writer,
+		explanations:     make(map[types.NamespacedName]*PodExplanation),
+		now:              time.Now,
+	}
+}
+
+// Process records the outcome of the scale-up attempt for every pod in the status.
+func (p *ExplainingScaleUpStatusProcessor) Process(autoscalingCtx *ca_context.AutoscalingContext, status *ScaleUpStatus) {
+	if status == nil {
+		return
+	}
+	now := p.now()
+	var updated []*PodExplanation
+	var pods []*apiv1.Pod
+
+	if status.Result != ScaleUpSuccessful && status.Result != ScaleUpError {
+		consideredNodeGroups := nodeGroupListToMapById(status.ConsideredNodeGroups)
+		for _, noScaleUpInfo := range status.PodsRemainUnschedulable {
+			explanation := &PodExplanation{
+				Result:  PodNotTriggerScaleUp,
+				Message: ReasonsMessage(status.Result, noScaleUpInfo, consideredNodeGroups),
+			}
+			for nodeGroupId, reasons := range noScaleUpInfo.SkippedNodeGroups {
+				if nodeGroup, found := consideredNodeGroups[nodeGroupId]; !found || !nodeGroup.Exist() {
+					continue
+				}
+				explanation.NodeGroups = append(explanation.NodeGroups, NodeGroupExplanation{NodeGroup: nodeGroupId, Outcome: NodeGroupSkipped, Reasons: reasons.Reasons()})
+			}
+			for nodeGroupId, reasons := range noScaleUpInfo.RejectedNodeGroups {
+				if nodeGroup, found := consideredNodeGroups[nodeGroupId]; !found || !nodeGroup.Exist() {
+					continue
+				}
+				nodeGroupExplanation := NodeGroupExplanation{NodeGroup: nodeGroupId, Outcome: NodeGroupRejected, Reasons: reasons.Reasons()}
+				if schedulingErr, ok := reasons.(clustersnapshot.SchedulingError); ok {
+					nodeGroupExplanation.Outcome = NodeGroupPredicateFailure
+					nodeGroupExplanation.Predicate = schedulingErr.FailingPredicateName()
+				}
+				explanation.NodeGroups = append(explanation.NodeGroups, nodeGroupExplanation)
+			}
+			updated = append(updated, explanation)
+			pods = append(pods, noScaleUpInfo.Pod)
+		}
+	}
+	if len(status.ScaleUpInfos) > 0 {
+		var chosen []NodeGroupExplanation
+		for _, info := range status.ScaleUpInfos {
+			chosen = append(chosen, NodeGroupExplanation{NodeGroup: info.Group.Id(), Outcome: NodeGroupChosen, NodeCount: info.NewSize - info.CurrentSize})
+		}
+		for _, pod := range status.PodsTriggeredScaleUp {
+			updated = append(updated, &PodExplanation{Result: PodTriggeredScaleUp, Message: fmt.Sprintf("pod triggered scale-up: %v", status.ScaleUpInfos), NodeGroups: chosen})
+			pods = append(pods, pod)
+		}
+	}
+	for _, pod := range status.PodsAwaitEvaluation {
+		updated = append(updated, &PodExplanation{Result: PodAwaitingEvaluation, Message: "pod can be helped by a scale-up in a later loop"})
+		pods = append(pods, pod)
+	}
+
+	for i, explanation := range updated {
+		explanation.Namespace = pods[i].Namespace
+		explanation.Name = pods[i].Name
+		explanation.LastUpdateTime = metav1.Time{Time: now}
+		sort.Slice(explanation.NodeGroups, func(a, b int) bool {
+			return explanation.NodeGroups[a].NodeGroup < explanation.NodeGroups[b].NodeGroup
+		})
+	}
+	if p.setPodConditions {
+		for _, i := range groupRepresentatives(pods, updated) {
+			p.setPodCondition(autoscalingCtx, pods[i], updated[i])
+		}
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, explanation := range updated {
+		p.explanations[types.NamespacedName{Namespace: explanation.Namespace, Name: explanation.Name}] = explanation
+	}
+	for name, explanation := range p.explanations {
+		if now.Sub(explanation.LastUpdateTime.Time) > explanationTTL {
+			delete(p.explanations, name)
+		}
+	}
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *ExplainingScaleUpStatusProcessor) CleanUp() {
+}
+
+// Explanation returns the explanation for a given pod.
+func (p *ExplainingScaleUpStatusProcessor) Explanation(namespace, name string) (*PodExplanation, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	explanation, found := p.explanations[types.NamespacedName{Namespace: namespace, Name: name}]
+	return explanation, found
+}
+
+// Explanations returns explanations for all pods in a given namespace, or in
+// all namespaces if it's empty, sorted by namespace and name.
+func (p *ExplainingScaleUpStatusProcessor) Explanations(namespace string) []*PodExplanation {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	result := make([]*PodExplanation, 0, len(p.explanations))
+	for name, explanation := range p.explanations {
+		if namespace == "" || name.Namespace == namespace {
+			result = append(result, explanation)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// ServeHTTP writes explanations as JSON. The namespace and pod query
+// parameters limit the response to a namespace or a single pod. The namespace
+// is required if the pod is given.
+func (p *ExplainingScaleUpStatusProcessor) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get("namespace")
+	var body interface{}
+	if name := req.URL.Query().Get("pod"); name != "" {
+		if namespace == "" {
+			http.Error(w, "namespace parameter is required with pod", http.StatusBadRequest)
+			return
+		}
+		explanation, found := p.Explanation(namespace, name)
+		if !found {
+			http.Error(w, fmt.Sprintf("pod %s/%s not found", namespace, name), http.StatusNotFound)
+			return
+		}
+		body = explanation
+	} else {
+		body = p.Explanations(namespace)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		klog.Errorf("Failed to write scale-up explanations: %v", err)
+	}
+}
+
+// equivalenceKey identifies pods which share the controller and the outcome
+// of scale-up.
+type equivalenceKey struct {
+	controller types.UID
+	result     PodScaleUpResult
+	message    string
+}
+
+// groupRepresentatives returns indices of one pod, the first one by name, of
+// every group of equivalent pods. Pods without a controller form their own
+// groups.
+func groupRepresentatives(pods []*apiv1.Pod, explanations []*PodExplanation) []int {
+	var result []int
+	representatives := make(map[equivalenceKey]int)
+	for i, pod := range pods {
+		controllerRef := metav1.GetControllerOf(pod)
+		if controllerRef == nil {
+			result = append(result, i)
+			continue
+		}
+		key := equivalenceKey{controller: controllerRef.UID, result: explanations[i].Result, message: explanations[i].Message}
+		if j, found := representatives[key]; !found || pod.Name < pods[j].Name {
+			representatives[key] = i
+		}
+	}
+	for _, i := range representatives {
+		result = append(result, i)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// setPodCondition schedules an update of the ScaleUpPodCondition of the pod
+// if it changed.
+func (p *ExplainingScaleUpStatusProcessor) setPodCondition(autoscalingCtx *ca_context.AutoscalingContext, pod *apiv1.Pod, explanation *PodExplanation) {
+	condition := apiv1.PodCondition{
+		Type:               ScaleUpPodCondition,
+		Status:             apiv1.ConditionFalse,
+		Reason:             string(explanation.Result),
+		Message:            explanation.Message,
+		LastTransitionTime: explanation.LastUpdateTime,
+	}
+	switch explanation.Result {
+	case PodTriggeredScaleUp:
+		condition.Status = apiv1.ConditionTrue
+	case PodAwaitingEvaluation:
+		condition.Status = apiv1.ConditionUnknown
+	}
+	updatedPod := pod.DeepCopy()
+	found := false
+	for i, existing := range updatedPod.Status.Conditions {
+		if existing.Type != ScaleUpPodCondition {
+			continue
+		}
+		if existing.Status == condition.Status {
+			if existing.Reason == condition.Reason && existing.Message == condition.Message {
+				return
+			}
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		updatedPod.Status.Conditions[i] = condition
+		found = true
+	}
+	if !found {
+		updatedPod.Status.Conditions = append(updatedPod.Status.Conditions, condition)
+	}
+	client := autoscalingCtx.ClientSet
+	p.writer.Write(pod.Namespace+"/"+pod.Name, func() {
+		if _, err := client.CoreV1().Pods(pod.Namespace).UpdateStatus(context.TODO(), updatedPod, metav1.UpdateOptions{}); err != nil {
+			klog.Warningf("Failed to set %s condition of pod %s/%s: %v", ScaleUpPodCondition, pod.Namespace, pod.Name, err)
+		}
+	})
+}
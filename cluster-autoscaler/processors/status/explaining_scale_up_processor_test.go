@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	cp_test "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainingScaleUpStatusProcessor(t *testing.T) {
+	now := time.Now()
+	pending := BuildTestPod("pending", 100, 100)
+	triggered := BuildTestPod("triggered", 100, 100)
+	waiting := BuildTestPod("waiting", 100, 100)
+	replica1 := BuildTestPod("replica-1", 100, 100)
+	replica2 := BuildTestPod("replica-2", 100, 100)
+	for _, pod := range []*apiv1.Pod{replica1, replica2} {
+		pod.OwnerReferences = GenerateOwnerReferences("rs", "ReplicaSet", "apps/v1", "rs-uid")
+	}
+	for _, pod := range []*apiv1.Pod{pending, triggered, waiting, replica1, replica2} {
+		pod.Namespace = "default"
+	}
+	client := fake.NewSimpleClientset(pending, triggered, waiting, replica1, replica2)
+	autoscalingCtx := &ca_context.AutoscalingContext{
+		AutoscalingKubeClients: ca_context.AutoscalingKubeClients{ClientSet: client},
+	}
+
+	provider := cp_test.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("gpu", 0, 10, 1)
+	provider.AddNodeGroup("cpu", 0, 10, 1)
+	provider.AddNodeGroup("full", 0, 1, 1)
+	provider.AddNodeGroup("spot", 0, 10, 1)
+
+	p := NewExplainingScaleUpStatusProcessor(true)
+	p.now = func() time.Time { return now }
+	p.Process(autoscalingCtx, &ScaleUpStatus{
+		Result: ScaleUpNoOptionsAvailable,
+		PodsRemainUnschedulable: []NoScaleUpInfo{{
+			Pod: pending,
+			RejectedNodeGroups: map[string]Reasons{
+				"gpu":  clustersnapshot.NewFailingPredicateError(pending, "TaintToleration", []string{"node(s) had untolerated taint {gpu: true}"}, "", ""),
+				"spot": &testReason{"not enough capacity"},
+			},
+			SkippedNodeGroups: map[string]Reasons{"full": &testReason{"max node group size reached"}},
+		}},
+		PodsAwaitEvaluation: []*apiv1.Pod{waiting},
+		ConsideredNodeGroups: []cloudprovider.NodeGroup{
+			provider.GetNodeGroup("gpu"), provider.GetNodeGroup("full"), provider.GetNodeGroup("spot"),
+		},
+	})
+	p.Process(autoscalingCtx, &ScaleUpStatus{
+		Result:               ScaleUpSuccessful,
+		ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: provider.GetNodeGroup("cpu"), CurrentSize: 1, NewSize: 3, MaxSize: 10}},
+		PodsTriggeredScaleUp: []*apiv1.Pod{triggered, replica2, replica1},
+	})
+
+	explanation, found := p.Explanation("default", "pending")
+	assert.True(t, found)
+	assert.Equal(t, PodNotTriggerScaleUp, explanation.Result)
+	assert.Equal(t, []NodeGroupExplanation{
+		{NodeGroup: "full", Outcome: NodeGroupSkipped, Reasons: []string{"max node group size reached"}},
+		{NodeGroup: "gpu", Outcome: NodeGroupPredicateFailure, Predicate: "TaintToleration", Reasons: []string{"node(s) had untolerated taint {gpu: true}"}},
+		{NodeGroup: "spot", Outcome: NodeGroupRejected, Reasons: []string{"not enough capacity"}},
+	}, explanation.NodeGroups)
+
+	explanation, found = p.Explanation("default", "triggered")
+	assert.True(t, found)
+	assert.Equal(t, PodTriggeredScaleUp, explanation.Result)
+	assert.Equal(t, []NodeGroupExplanation{{NodeGroup: "cpu", Outcome: NodeGroupChosen, NodeCount: 2}}, explanation.NodeGroups)
+
+	explanation, found = p.Explanation("default", "waiting")
+	assert.True(t, found)
+	assert.Equal(t, PodAwaitingEvaluation, explanation.Result)
+	assert.Len(t, p.Explanations(""), 5)
+	assert.Len(t, p.Explanations("kube-system"), 0)
+
+	// Only one pod of the replica set gets the condition.
+	wantConditions := map[string]apiv1.ConditionStatus{"pending": apiv1.ConditionFalse, "triggered": apiv1.ConditionTrue, "waiting": apiv1.ConditionUnknown, "replica-1": apiv1.ConditionTrue}
+	assert.Eventually(t, func() bool {
+		for name, wantStatus := range wantConditions {
+			pod, err := client.CoreV1().Pods("default").Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil || len(pod.Status.Conditions) != 1 || pod.Status.Conditions[0].Type != ScaleUpPodCondition || pod.Status.Conditions[0].Status != wantStatus {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	pod, err := client.CoreV1().Pods("default").Get(context.TODO(), "replica-2", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, pod.Status.Conditions)
+
+	// Explanations of pods which no longer show up expire.
+	p.now = func() time.Time { return now.Add(explanationTTL + time.Minute) }
+	p.Process(autoscalingCtx, &ScaleUpStatus{Result: ScaleUpNotNeeded})
+	assert.Empty(t, p.Explanations(""))
+}
+
+func TestExplainingScaleUpStatusProcessorServeHTTP(t *testing.T) {
+	pod := BuildTestPod("p1", 100, 100)
+	pod.Namespace = "default"
+	p := NewExplainingScaleUpStatusProcessor(false)
+	p.Process(&ca_context.AutoscalingContext{}, &ScaleUpStatus{
+		Result:                  ScaleUpNoOptionsAvailable,
+		PodsRemainUnschedulable: []NoScaleUpInfo{{Pod: pod}},
+	})
+
+	recorder := httptest.NewRecorder()
+	p.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/scale-up-explanations?namespace=default", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var explanations []*PodExplanation
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &explanations))
+	assert.Len(t, explanations, 1)
+	assert.Equal(t, "p1", explanations[0].Name)
+
+	recorder = httptest.NewRecorder()
+	p.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/scale-up-explanations?pod=p1", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	p.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/scale-up-explanations?namespace=default&pod=p1", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var explanation PodExplanation
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &explanation))
+	assert.Equal(t, PodNotTriggerScaleUp, explanation.Result)
+
+	recorder = httptest.NewRecorder()
+	p.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/scale-up-explanations?namespace=kube-system&pod=p1", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
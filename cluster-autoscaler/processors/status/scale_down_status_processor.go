@@ -42,3 +42,33 @@ func (p *NoOpScaleDownStatusProcessor) Process(autoscalingCtx *ca_context.Autosc
 // CleanUp cleans up the processor's internal structures.
 func (p *NoOpScaleDownStatusProcessor) CleanUp() {
 }
+
+// CombinedScaleDownStatusProcessor is a list of ScaleDownStatusProcessor
+type CombinedScaleDownStatusProcessor struct {
+	processors []ScaleDownStatusProcessor
+}
+
+// NewCombinedScaleDownStatusProcessor construct CombinedScaleDownStatusProcessor.
+func NewCombinedScaleDownStatusProcessor(processors []ScaleDownStatusProcessor) *CombinedScaleDownStatusProcessor {
+	var scaleDownProcessors []ScaleDownStatusProcessor
+	for _, processor := range processors {
+		if processor != nil {
+			scaleDownProcessors = append(scaleDownProcessors, processor)
+		}
+	}
+	return &CombinedScaleDownStatusProcessor{scaleDownProcessors}
+}
+
+// Process runs sub-processors sequentially in the same order of addition
+func (p *CombinedScaleDownStatusProcessor) Process(autoscalingCtx *ca_context.AutoscalingContext, status *status.ScaleDownStatus) {
+	for _, processor := range p.processors {
+		processor.Process(autoscalingCtx, status)
+	}
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *CombinedScaleDownStatusProcessor) CleanUp() {
+	for _, processor := range p.processors {
+		processor.CleanUp()
+	}
+}
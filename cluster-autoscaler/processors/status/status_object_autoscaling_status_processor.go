@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	casclient "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned"
+	caslisters "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/listers/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+)
+
+// StatusObjectAutoscalingStatusProcessor writes the status of the cluster to a
+// ClusterAutoscalerStatus object after each autoscaling iteration.
+type StatusObjectAutoscalingStatusProcessor struct {
+	client    casclient.Interface
+	lister    caslisters.ClusterAutoscalerStatusLister
+	namespace string
+	name      string
+}
+
+// NewStatusObjectAutoscalingStatusProcessor creates a StatusObjectAutoscalingStatusProcessor
+// writing to the ClusterAutoscalerStatus object with the given namespace and name. The
+// object is read through the lister.
+func NewStatusObjectAutoscalingStatusProcessor(client casclient.Interface, lister caslisters.ClusterAutoscalerStatusLister, namespace, name string) *StatusObjectAutoscalingStatusProcessor {
+	return &StatusObjectAutoscalingStatusProcessor{
+		client:    client,
+		lister:    lister,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// Process writes the status of the cluster to the ClusterAutoscalerStatus object, if it
+// changed since it was last written.
+func (p *StatusObjectAutoscalingStatusProcessor) Process(autoscalingCtx *ca_context.AutoscalingContext, csr *clusterstate.ClusterStateRegistry, now time.Time) error {
+	status := utils.ToStatusObjectStatus(*csr.GetStatus(now), now)
+	if t, found := csr.LastClusterScaleUpTime(); found {
+		status.ClusterWide.ScaleUp.LastScaleUpTime = newTime(t)
+	}
+	if t, found := csr.LastClusterScaleDownTime(); found {
+		status.ClusterWide.ScaleDown.LastScaleDownTime = newTime(t)
+	}
+	for i := range status.NodeGroups {
+		nodeGroup := &status.NodeGroups[i]
+		if t, found := csr.LastScaleUpTime(nodeGroup.Name); found {
+			nodeGroup.ScaleUp.LastScaleUpTime = newTime(t)
+		}
+		if t, found := csr.LastScaleDownTime(nodeGroup.Name); found {
+			nodeGroup.ScaleDown.LastScaleDownTime = newTime(t)
+		}
+	}
+	_, err := utils.WriteStatusObject(p.client, p.lister, p.namespace, p.name, status)
+	return err
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *StatusObjectAutoscalingStatusProcessor) CleanUp() {
+}
+
+func newTime(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	casv1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	casfake "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned/fake"
+	caslisters "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/listers/autoscaling.x-k8s.io/v1alpha1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups/asyncnodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+func TestStatusObjectAutoscalingStatusProcessor(t *testing.T) {
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroup("ng2", 1, 10, 1)
+
+	logRecorder, _ := utils.NewStatusMapRecorder(&fake.Clientset{}, "kube-system", kube_record.NewFakeRecorder(5), false, "cluster-autoscaler-status")
+	csr := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, logRecorder,
+		backoff.NewIdBasedExponentialBackoff(5*time.Minute, 30*time.Minute, 3*time.Hour),
+		nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}),
+		asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+	now := time.Now().Truncate(time.Second)
+	csr.RegisterScaleUp(provider.GetNodeGroup("ng1"), 2, now.Add(-2*time.Minute))
+	csr.RegisterScaleDown(provider.GetNodeGroup("ng2"), "ng2-1", now.Add(-time.Minute), now)
+
+	client := casfake.NewSimpleClientset()
+	lister := caslisters.NewClusterAutoscalerStatusLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}))
+	processor := NewStatusObjectAutoscalingStatusProcessor(client, lister, "kube-system", "cluster-autoscaler-status")
+	assert.NoError(t, processor.Process(nil, csr, now))
+
+	object, err := client.AutoscalingV1alpha1().ClusterAutoscalerStatuses("kube-system").Get(context.TODO(), "cluster-autoscaler-status", metav1.GetOptions{})
+	assert.NoError(t, err)
+	status := object.Status
+	assert.Equal(t, casv1alpha1.AutoscalerRunning, status.AutoscalerState)
+	assert.Equal(t, metav1.NewTime(now), status.LastUpdateTime)
+	assert.Equal(t, newTime(now.Add(-2*time.Minute)), status.ClusterWide.ScaleUp.LastScaleUpTime)
+	assert.Equal(t, newTime(now.Add(-time.Minute)), status.ClusterWide.ScaleDown.LastScaleDownTime)
+
+	nodeGroups := make(map[string]casv1alpha1.NodeGroupStatus)
+	for _, nodeGroup := range status.NodeGroups {
+		nodeGroups[nodeGroup.Name] = nodeGroup
+	}
+	assert.Len(t, nodeGroups, 2)
+	assert.Equal(t, int32(1), nodeGroups["ng1"].Health.MinSize)
+	assert.Equal(t, int32(10), nodeGroups["ng1"].Health.MaxSize)
+	assert.Equal(t, newTime(now.Add(-2*time.Minute)), nodeGroups["ng1"].ScaleUp.LastScaleUpTime)
+	assert.Nil(t, nodeGroups["ng1"].ScaleDown.LastScaleDownTime)
+	assert.Nil(t, nodeGroups["ng2"].ScaleUp.LastScaleUpTime)
+	assert.Equal(t, newTime(now.Add(-time.Minute)), nodeGroups["ng2"].ScaleDown.LastScaleDownTime)
+}
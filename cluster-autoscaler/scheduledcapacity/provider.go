@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduledcapacity
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	klog "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigMapKey is the key of the ConfigMap data entry holding the schedules.
+const ConfigMapKey = "schedules"
+
+// Schedule raises the minimum size of the matching node groups while its window is open.
+type Schedule struct {
+	// Name identifies the schedule in logs.
+	Name string `json:"name"`
+	// NodeGroups is a list of regular expressions matched against node group ids.
+	NodeGroups []string `json:"nodeGroups"`
+	// Window is a cron expression followed by a duration, e.g. "0 8 * * 1-5 10h".
+	Window string `json:"window"`
+	// MinSize is the minimum size of the matching node groups while the window is open.
+	MinSize int `json:"minSize"`
+}
+
+type parsedSchedule struct {
+	Schedule
+	nodeGroups []*regexp.Regexp
+	window     schedule.Window
+}
+
+// parse parses a YAML list of schedules.
+func parse(data string) ([]parsedSchedule, error) {
+	var schedules []Schedule
+	if err := yaml.UnmarshalStrict([]byte(data), &schedules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schedules: %v", err)
+	}
+	var result []parsedSchedule
+	for _, s := range schedules {
+		if s.MinSize < 0 {
+			return nil, fmt.Errorf("schedule %q: minSize must not be negative", s.Name)
+		}
+		if len(s.NodeGroups) == 0 {
+			return nil, fmt.Errorf("schedule %q: at least one node group is required", s.Name)
+		}
+		window, err := schedule.ParseWindow(s.Window)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %v", s.Name, err)
+		}
+		ps := parsedSchedule{Schedule: s, window: window}
+		for _, expr := range s.NodeGroups {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("schedule %q: invalid node group expression %q: %v", s.Name, expr, err)
+			}
+			ps.nodeGroups = append(ps.nodeGroups, re)
+		}
+		result = append(result, ps)
+	}
+	return result, nil
+}
+
+func (s *parsedSchedule) matches(nodeGroupId string) bool {
+	for _, re := range s.nodeGroups {
+		if re.MatchString(nodeGroupId) {
+			return true
+		}
+	}
+	return false
+}
+
+// Provider keeps track of the scheduled minimum sizes defined in a ConfigMap.
+// A nil Provider is valid and doesn't raise the minimum size of any node group.
+type Provider struct {
+	lister    v1lister.ConfigMapNamespaceLister
+	namespace string
+	name      string
+
+	mutex     sync.Mutex
+	schedules []parsedSchedule
+	active    []parsedSchedule
+}
+
+// NewProvider returns a Provider reading schedules from the given ConfigMap
+// through the lister of its namespace.
+func NewProvider(lister v1lister.ConfigMapLister, namespace, name string) *Provider {
+	return &Provider{lister: lister.ConfigMaps(namespace), namespace: namespace, name: name}
+}
+
+// Refresh reloads the schedules and determines which of them are active at
+// the given time. If the ConfigMap can't be read or parsed, the previously
+// loaded schedules are kept.
+func (p *Provider) Refresh(now time.Time) error {
+	if p == nil {
+		return nil
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	schedules, err := p.load()
+	if err == nil {
+		p.schedules = schedules
+	}
+	p.active = nil
+	for _, s := range p.schedules {
+		if s.window.Active(now) {
+			klog.V(4).Infof("Scheduled min capacity %q is active", s.Name)
+			p.active = append(p.active, s)
+		}
+	}
+	return err
+}
+
+func (p *Provider) load() ([]parsedSchedule, error) {
+	configMap, err := p.lister.Get(p.name)
+	if kube_errors.IsNotFound(err) {
+		klog.V(4).Infof("Scheduled min capacity config map %s/%s not found", p.namespace, p.name)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config map %s/%s: %v", p.namespace, p.name, err)
+	}
+	schedules, err := parse(configMap.Data[ConfigMapKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid config map %s/%s: %v", p.namespace, p.name, err)
+	}
+	return schedules, nil
+}
+
+// ScheduledMinSize returns the highest minimum size set for the node group by
+// the active schedules, or 0 if none of them matches it.
+func (p *Provider) ScheduledMinSize(nodeGroupId string) int {
+	if p == nil {
+		return 0
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	minSize := 0
+	for i := range p.active {
+		if p.active[i].MinSize > minSize && p.active[i].matches(nodeGroupId) {
+			minSize = p.active[i].MinSize
+		}
+	}
+	return minSize
+}
+
+// MinSize returns the effective minimum size of the node group: its own
+// minimum size, raised by the active schedules, but never above its maximum size.
+func (p *Provider) MinSize(nodeGroup cloudprovider.NodeGroup) int {
+	minSize := nodeGroup.MinSize()
+	if scheduled := p.ScheduledMinSize(nodeGroup.Id()); scheduled > minSize {
+		minSize = scheduled
+		if maxSize := nodeGroup.MaxSize(); minSize > maxSize {
+			minSize = maxSize
+		}
+	}
+	return minSize
+}
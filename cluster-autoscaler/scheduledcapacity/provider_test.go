@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduledcapacity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const testSchedules = `
+- name: business-hours
+  nodeGroups: ["^web-"]
+  window: "0 8 * * 1-5 10h"
+  minSize: 5
+- name: batch
+  nodeGroups: ["^web-large$", "^batch$"]
+  window: "0 12 * * * 1h"
+  minSize: 20
+`
+
+func buildConfigMap(data string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduled-min-capacity", Namespace: "kube-system"},
+		Data:       map[string]string{ConfigMapKey: data},
+	}
+}
+
+func newConfigMapStore(configMaps ...*apiv1.ConfigMap) cache.Indexer {
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, configMap := range configMaps {
+		_ = store.Add(configMap)
+	}
+	return store
+}
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	ts, err := time.Parse(time.RFC3339, value)
+	assert.NoError(t, err)
+	return ts
+}
+
+func TestMinSize(t *testing.T) {
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("web-small", 1, 10, 1)
+	provider.AddNodeGroup("web-large", 2, 15, 2)
+	provider.AddNodeGroup("batch", 0, 30, 0)
+	provider.AddNodeGroup("other", 1, 10, 1)
+
+	testCases := []struct {
+		name string
+		now  string
+		want map[string]int
+	}{
+		{
+			name: "no active schedules",
+			now:  "2025-01-06T07:00:00Z",
+			want: map[string]int{"web-small": 1, "web-large": 2, "batch": 0, "other": 1},
+		},
+		{
+			name: "business hours",
+			now:  "2025-01-06T09:00:00Z",
+			want: map[string]int{"web-small": 5, "web-large": 5, "batch": 0, "other": 1},
+		},
+		{
+			name: "highest floor wins, capped at max size",
+			now:  "2025-01-06T12:30:00Z",
+			want: map[string]int{"web-small": 5, "web-large": 15, "batch": 20, "other": 1},
+		},
+		{
+			name: "weekend",
+			now:  "2025-01-11T09:00:00Z",
+			want: map[string]int{"web-small": 1, "web-large": 2, "batch": 0, "other": 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProvider(v1lister.NewConfigMapLister(newConfigMapStore(buildConfigMap(testSchedules))), "kube-system", "scheduled-min-capacity")
+			assert.NoError(t, p.Refresh(mustParseTime(t, tc.now)))
+			got := make(map[string]int)
+			for _, ng := range provider.NodeGroups() {
+				got[ng.Id()] = p.MinSize(ng)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNilProvider(t *testing.T) {
+	provider := testprovider.NewTestCloudProviderBuilder().Build()
+	provider.AddNodeGroup("ng", 2, 10, 2)
+	var p *Provider
+	assert.NoError(t, p.Refresh(time.Now()))
+	assert.Equal(t, 0, p.ScheduledMinSize("ng"))
+	assert.Equal(t, 2, p.MinSize(provider.GetNodeGroup("ng")))
+}
+
+func TestRefresh(t *testing.T) {
+	now := mustParseTime(t, "2025-01-06T09:00:00Z")
+	store := newConfigMapStore()
+	p := NewProvider(v1lister.NewConfigMapLister(store), "kube-system", "scheduled-min-capacity")
+
+	// A missing config map means no schedules.
+	assert.NoError(t, p.Refresh(now))
+	assert.Equal(t, 0, p.ScheduledMinSize("web-small"))
+
+	assert.NoError(t, store.Add(buildConfigMap(testSchedules)))
+	assert.NoError(t, p.Refresh(now))
+	assert.Equal(t, 5, p.ScheduledMinSize("web-small"))
+
+	// Invalid schedules are reported and the previous ones are kept.
+	assert.NoError(t, store.Update(buildConfigMap("- name: broken\n  nodeGroups: [\"web\"]\n  window: \"0 8 * *\"\n  minSize: 1\n")))
+	assert.Error(t, p.Refresh(now))
+	assert.Equal(t, 5, p.ScheduledMinSize("web-small"))
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, data := range []string{
+		"not a list",
+		"- name: a\n  nodeGroups: [\"ng\"]\n  window: \"0 8 * * * 1h\"\n  minSize: -1\n",
+		"- name: a\n  window: \"0 8 * * * 1h\"\n  minSize: 1\n",
+		"- name: a\n  nodeGroups: [\"(\"]\n  window: \"0 8 * * * 1h\"\n  minSize: 1\n",
+		"- name: a\n  nodeGroups: [\"ng\"]\n  window: \"0 8 * * *\"\n  minSize: 1\n",
+		"- name: a\n  nodeGroups: [\"ng\"]\n  window: \"0 8 * * * 1h\"\n  minSize: 1\n  unknown: true\n",
+	} {
+		_, err := parse(data)
+		assert.Error(t, err, data)
+	}
+}
@@ -91,8 +91,55 @@ const (
 	BlockedByPod
 	// UnexpectedError - node can't be removed because of an unexpected error.
 	UnexpectedError
+	// OutsideScaleDownWindow - node can't be removed because its node group is outside of a scale-down maintenance window or inside a blackout window.
+	OutsideScaleDownWindow
 )
 
+// Name returns a human readable name of the reason. It's not a String()
+// method, because reasons are reported numerically in metric labels.
+func (r UnremovableReason) Name() string {
+	switch r {
+	case NoReason:
+		return "NoReason"
+	case ScaleDownDisabledAnnotation:
+		return "ScaleDownDisabledAnnotation"
+	case ScaleDownUnreadyDisabled:
+		return "ScaleDownUnreadyDisabled"
+	case NotAutoscaled:
+		return "NotAutoscaled"
+	case NotUnneededLongEnough:
+		return "NotUnneededLongEnough"
+	case NotUnreadyLongEnough:
+		return "NotUnreadyLongEnough"
+	case NodeGroupMinSizeReached:
+		return "NodeGroupMinSizeReached"
+	case NodeGroupMaxDeletionCountReached:
+		return "NodeGroupMaxDeletionCountReached"
+	case AtomicScaleDownFailed:
+		return "AtomicScaleDownFailed"
+	case MinimalResourceLimitExceeded:
+		return "MinimalResourceLimitExceeded"
+	case CurrentlyBeingDeleted:
+		return "CurrentlyBeingDeleted"
+	case NotUnderutilized:
+		return "NotUnderutilized"
+	case NotUnneededOtherReason:
+		return "NotUnneededOtherReason"
+	case RecentlyUnremovable:
+		return "RecentlyUnremovable"
+	case NoPlaceToMovePods:
+		return "NoPlaceToMovePods"
+	case BlockedByPod:
+		return "BlockedByPod"
+	case UnexpectedError:
+		return "UnexpectedError"
+	case OutsideScaleDownWindow:
+		return "OutsideScaleDownWindow"
+	default:
+		return fmt.Sprintf("UnremovableReason(%d)", int(r))
+	}
+}
+
 // RemovalSimulator is a helper object for simulating node removal scenarios.
 type RemovalSimulator struct {
 	listers             kube_util.ListerRegistry
@@ -155,6 +202,28 @@ func (r *RemovalSimulator) SimulateNodeRemoval(
 	}, nil
 }
 
+// ConfirmNodeRemoval checks whether pods of a node found removable by another
+// RemovalSimulator, e.g. one working on a copy of the cluster snapshot, can
+// still be moved on r's snapshot. Drainability of the pods isn't checked
+// again. Like in SimulateNodeRemoval, exactly one of the return values is
+// populated.
+func (r *RemovalSimulator) ConfirmNodeRemoval(
+	removable *NodeToBeRemoved,
+	destinationMap map[string]bool,
+	timestamp time.Time,
+) (*NodeToBeRemoved, *UnremovableNode) {
+	nodeName := removable.Node.Name
+	err := r.withForkedSnapshot(func() error {
+		return r.findPlaceFor(nodeName, removable.PodsToReschedule, destinationMap, timestamp)
+	})
+	if err != nil {
+		klog.V(2).Infof("Node %s is not suitable for removal: %v", nodeName, err)
+		return nil, &UnremovableNode{Node: removable.Node, Reason: NoPlaceToMovePods}
+	}
+	klog.V(2).Infof("Node %s may be removed", nodeName)
+	return removable, nil
+}
+
 func (r *RemovalSimulator) withForkedSnapshot(f func() error) (err error) {
 	r.clusterSnapshot.Fork()
 	defer func() {
@@ -209,3 +278,9 @@ func (r *RemovalSimulator) findPlaceFor(removedNode string, pods []*apiv1.Pod, n
 func (r *RemovalSimulator) DropOldHints() {
 	r.schedulingSimulator.DropOldHints()
 }
+
+// MergeHints copies scheduling hints gathered by another RemovalSimulator, so
+// that simulations done elsewhere can speed up the ones done by r.
+func (r *RemovalSimulator) MergeHints(other *RemovalSimulator) {
+	r.schedulingSimulator.MergeHints(other.schedulingSimulator)
+}
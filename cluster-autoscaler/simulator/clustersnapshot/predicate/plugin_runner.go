@@ -89,6 +89,11 @@ func (p *SchedulerPluginRunner) RunFiltersUntilPassingNode(pod *apiv1.Pod, nodeM
 		// Run the Filter phase of the framework. Plugins retrieve the state they saved during PreFilter from CycleState, and answer whether the
 		// given Pod can be scheduled on the given Node.
 		filterStatus := p.fwHandle.Framework.RunFilterPlugins(context.TODO(), state, pod, nodeInfo.ToScheduler())
+		if filterStatus.IsSuccess() {
+			// Filter extenders are called by the scheduler only for Nodes passing all Filters, and are much more expensive to call - so call
+			// them last.
+			filterStatus = p.fwHandle.RunFilterExtenders(pod, nodeInfo.ToScheduler())
+		}
 		if filterStatus.IsSuccess() {
 			// Filter passed for all plugins, so this pod can be scheduled on this Node.
 			p.lastIndex = (p.lastIndex + i + 1) % len(nodeInfosList)
@@ -123,6 +128,9 @@ func (p *SchedulerPluginRunner) RunFiltersOnNode(pod *apiv1.Pod, nodeName string
 
 	// Run the Filter phase of the framework for the Pod and the Node and check the results. See the corresponding comments in RunFiltersUntilPassingNode() for more info.
 	filterStatus := p.fwHandle.Framework.RunFilterPlugins(context.TODO(), state, pod, nodeInfo.ToScheduler())
+	if filterStatus.IsSuccess() {
+		filterStatus = p.fwHandle.RunFilterExtenders(pod, nodeInfo.ToScheduler())
+	}
 	if !filterStatus.IsSuccess() {
 		filterName := filterStatus.Plugin()
 		filterReasons := filterStatus.Reasons()
@@ -17,15 +17,22 @@ limitations under the License.
 package predicate
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	extenderv1 "k8s.io/kube-scheduler/extender/v1"
 	"k8s.io/kubernetes/pkg/scheduler/apis/config"
 	scheduler_config_latest "k8s.io/kubernetes/pkg/scheduler/apis/config/latest"
 
@@ -350,6 +357,103 @@ func TestDebugInfo(t *testing.T) {
 	assert.Nil(t, predicateErr)
 }
 
+func TestFilterExtenders(t *testing.T) {
+	pod := BuildTestPod("p1", 100, 1000)
+	n1 := BuildTestNode("n1", 1000, 2000000)
+	n2 := BuildTestNode("n2", 1000, 2000000)
+
+	testCases := []struct {
+		name         string
+		slow         bool
+		ignorable    bool
+		wantPassing  []string
+		wantRejected bool
+	}{
+		{
+			name:         "extender rejects a node",
+			wantPassing:  []string{"n2"},
+			wantRejected: true,
+		},
+		{
+			name: "extender times out",
+			slow: true,
+		},
+		{
+			name:        "ignorable extender times out",
+			slow:        true,
+			ignorable:   true,
+			wantPassing: []string{"n1", "n2"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// A stand-in for a GPU topology extender, rejecting n1.
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if tc.slow {
+					time.Sleep(500 * time.Millisecond)
+				}
+				var args extenderv1.ExtenderArgs
+				if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				result := extenderv1.ExtenderFilterResult{Nodes: &apiv1.NodeList{}, FailedNodes: extenderv1.FailedNodesMap{}}
+				for _, node := range args.Nodes.Items {
+					if node.Name == "n1" {
+						result.FailedNodes[node.Name] = "no free GPU link"
+					} else {
+						result.Nodes.Items = append(result.Nodes.Items, node)
+					}
+				}
+				_ = json.NewEncoder(w).Encode(result)
+			}))
+			defer server.Close()
+
+			schedConfig, err := scheduler_config_latest.Default()
+			assert.NoError(t, err)
+			schedConfig.Extenders = []config.Extender{{
+				URLPrefix:   server.URL,
+				FilterVerb:  "filter",
+				Weight:      1,
+				HTTPTimeout: metav1.Duration{Duration: 100 * time.Millisecond},
+				Ignorable:   tc.ignorable,
+			}}
+			pluginRunner, snapshot, err := newTestPluginRunnerAndSnapshot(schedConfig)
+			assert.NoError(t, err)
+			assert.NoError(t, snapshot.AddNodeInfo(framework.NewTestNodeInfo(n1)))
+			assert.NoError(t, snapshot.AddNodeInfo(framework.NewTestNodeInfo(n2)))
+
+			for _, nodeName := range []string{"n1", "n2"} {
+				_, _, err := pluginRunner.RunFiltersOnNode(pod, nodeName)
+				if !assert.Equal(t, slices.Contains(tc.wantPassing, nodeName), err == nil, nodeName) || err == nil {
+					continue
+				}
+				if tc.wantRejected {
+					assert.Equal(t, clustersnapshot.FailingPredicateError, err.Type())
+					assert.Equal(t, []string{"no free GPU link"}, err.FailingPredicateReasons())
+				}
+			}
+
+			node, _, err := pluginRunner.RunFiltersUntilPassingNode(pod, func(*framework.NodeInfo) bool { return true })
+			if len(tc.wantPassing) == 0 {
+				assert.Error(t, err)
+			} else if assert.NoError(t, err) {
+				assert.Contains(t, tc.wantPassing, node.Name)
+			}
+
+			if tc.wantRejected {
+				// Decisions of the extender are cached.
+				callsBefore := calls.Load()
+				assert.Error(t, snapshot.SchedulePod(pod, "n1"))
+				assert.NoError(t, snapshot.SchedulePod(pod, "n2"))
+				assert.Equal(t, callsBefore, calls.Load())
+			}
+		})
+	}
+}
+
 func newTestPluginRunnerAndSnapshot(schedConfig *config.KubeSchedulerConfiguration) (*SchedulerPluginRunner, clustersnapshot.ClusterSnapshot, error) {
 	if schedConfig == nil {
 		defaultConfig, err := scheduler_config_latest.Default()
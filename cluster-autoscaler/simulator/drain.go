@@ -62,8 +62,9 @@ func GetPodsToMove(nodeInfo *framework.NodeInfo, deleteOptions options.NodeDelet
 			}
 		case drainability.BlockDrain:
 			return nil, nil, &drain.BlockingPod{
-				Pod:    pod,
-				Reason: status.BlockingReason,
+				Pod:          pod,
+				Reason:       status.BlockingReason,
+				BlockedUntil: status.BlockedUntil,
 			}, status.Error
 		}
 	}
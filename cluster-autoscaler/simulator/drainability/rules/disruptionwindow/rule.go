@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disruptionwindow
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/schedule"
+)
+
+// Rule is a drainability rule on how to handle pods which declare when they
+// can be disrupted.
+type Rule struct{}
+
+// New creates a new Rule.
+func New() *Rule {
+	return &Rule{}
+}
+
+// Name returns the name of the rule.
+func (r *Rule) Name() string {
+	return "DisruptionWindow"
+}
+
+// Drainable blocks drain of pods annotated with a do-not-disrupt-until
+// timestamp in the future, or with disruption windows none of which is open.
+// Pods with invalid annotations block drain as well.
+func (r *Rule) Drainable(drainCtx *drainability.DrainContext, pod *apiv1.Pod, _ *framework.NodeInfo) drainability.Status {
+	until, hasUntil := pod.Annotations[drain.PodDoNotDisruptUntilKey]
+	windowSpecs, hasWindows := pod.Annotations[drain.PodDisruptionWindowsKey]
+	if !hasUntil && !hasWindows {
+		return drainability.NewUndefinedStatus()
+	}
+
+	now := drainCtx.Timestamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+	removableAt := now
+	if hasUntil {
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(until))
+		if err != nil {
+			return drainability.NewBlockedStatus(drain.UnexpectedError, fmt.Errorf("pod %s/%s has invalid %s annotation: %v", pod.Namespace, pod.Name, drain.PodDoNotDisruptUntilKey, err))
+		}
+		if t.After(removableAt) {
+			removableAt = t
+		}
+	}
+	if hasWindows {
+		windows, err := schedule.ParseWindows(strings.Split(windowSpecs, ";"))
+		if err != nil {
+			return drainability.NewBlockedStatus(drain.UnexpectedError, fmt.Errorf("pod %s/%s has invalid %s annotation: %v", pod.Namespace, pod.Name, drain.PodDisruptionWindowsKey, err))
+		}
+		if len(windows) > 0 {
+			removableAt = schedule.NextActive(windows, removableAt)
+			if removableAt.IsZero() {
+				return drainability.NewBlockedStatus(drain.OutsideDisruptionWindow, fmt.Errorf("pod %s/%s is outside of its disruption windows, none of which opens in the foreseeable future", pod.Namespace, pod.Name))
+			}
+		}
+	}
+	if removableAt.After(now) {
+		return drainability.NewBlockedUntilStatus(drain.OutsideDisruptionWindow, removableAt, fmt.Errorf("pod %s/%s can't be disrupted until %s", pod.Namespace, pod.Name, removableAt.UTC().Format(time.RFC3339)))
+	}
+	return drainability.NewUndefinedStatus()
+}
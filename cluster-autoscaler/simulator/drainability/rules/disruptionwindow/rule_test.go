@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disruptionwindow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestDrainable(t *testing.T) {
+	// 2025-01-06 is a Monday.
+	now := time.Date(2025, time.January, 6, 12, 0, 0, 0, time.UTC)
+
+	for desc, tc := range map[string]struct {
+		annotations map[string]string
+		wantOutcome drainability.OutcomeType
+		wantReason  drain.BlockingPodReason
+		wantUntil   time.Time
+	}{
+		"no annotations": {},
+		"do-not-disrupt-until in the future": {
+			annotations: map[string]string{drain.PodDoNotDisruptUntilKey: "2025-01-06T18:30:00Z"},
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.OutsideDisruptionWindow,
+			wantUntil:   time.Date(2025, time.January, 6, 18, 30, 0, 0, time.UTC),
+		},
+		"do-not-disrupt-until in the past": {
+			annotations: map[string]string{drain.PodDoNotDisruptUntilKey: "2025-01-06T11:00:00Z"},
+		},
+		"invalid do-not-disrupt-until": {
+			annotations: map[string]string{drain.PodDoNotDisruptUntilKey: "tomorrow"},
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.UnexpectedError,
+		},
+		"inside a disruption window": {
+			annotations: map[string]string{drain.PodDisruptionWindowsKey: "0 22 * * 1-5 8h; 0 10 * * 1 4h"},
+		},
+		"outside disruption windows": {
+			annotations: map[string]string{drain.PodDisruptionWindowsKey: "0 22 * * 1-5 8h;0 8 * * 1 1h"},
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.OutsideDisruptionWindow,
+			wantUntil:   time.Date(2025, time.January, 6, 22, 0, 0, 0, time.UTC),
+		},
+		"disruption window opening after do-not-disrupt-until": {
+			annotations: map[string]string{
+				drain.PodDoNotDisruptUntilKey: "2025-01-07T07:00:00Z",
+				drain.PodDisruptionWindowsKey: "0 22 * * 1-5 8h",
+			},
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.OutsideDisruptionWindow,
+			wantUntil:   time.Date(2025, time.January, 7, 22, 0, 0, 0, time.UTC),
+		},
+		"do-not-disrupt-until inside a disruption window": {
+			annotations: map[string]string{
+				drain.PodDoNotDisruptUntilKey: "2025-01-06T23:00:00Z",
+				drain.PodDisruptionWindowsKey: "0 22 * * 1-5 8h",
+			},
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.OutsideDisruptionWindow,
+			wantUntil:   time.Date(2025, time.January, 6, 23, 0, 0, 0, time.UTC),
+		},
+		"invalid disruption windows": {
+			annotations: map[string]string{drain.PodDisruptionWindowsKey: "0 22 * * 1-5"},
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.UnexpectedError,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			pod := test.BuildTestPod("pod", 100, 100)
+			pod.Annotations = tc.annotations
+			got := New().Drainable(&drainability.DrainContext{Timestamp: now}, pod, nil)
+			assert.Equal(t, tc.wantOutcome, got.Outcome)
+			assert.Equal(t, tc.wantReason, got.BlockingReason)
+			assert.Equal(t, tc.wantUntil, got.BlockedUntil.UTC())
+			assert.Equal(t, tc.wantOutcome == drainability.BlockDrain, got.Error != nil)
+		})
+	}
+}
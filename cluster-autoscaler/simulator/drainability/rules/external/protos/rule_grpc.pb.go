@@ -0,0 +1,144 @@
+//
+//Copyright 2025 The Kubernetes Authors.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: simulator/drainability/rules/external/protos/rule.proto
+
+package protos
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DrainabilityRule_Drainable_FullMethodName = "/clusterautoscaler.drainability.v1.DrainabilityRule/Drainable"
+)
+
+// DrainabilityRuleClient is the client API for DrainabilityRule service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Interface for external drainability rules.
+type DrainabilityRuleClient interface {
+	// Drainable is invoked once per node in a removal simulation, for the pods
+	// of the node which weren't decided by the built-in rules yet.
+	Drainable(ctx context.Context, in *DrainableRequest, opts ...grpc.CallOption) (*DrainableResponse, error)
+}
+
+type drainabilityRuleClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDrainabilityRuleClient(cc grpc.ClientConnInterface) DrainabilityRuleClient {
+	return &drainabilityRuleClient{cc}
+}
+
+func (c *drainabilityRuleClient) Drainable(ctx context.Context, in *DrainableRequest, opts ...grpc.CallOption) (*DrainableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DrainableResponse)
+	err := c.cc.Invoke(ctx, DrainabilityRule_Drainable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DrainabilityRuleServer is the server API for DrainabilityRule service.
+// All implementations must embed UnimplementedDrainabilityRuleServer
+// for forward compatibility.
+//
+// Interface for external drainability rules.
+type DrainabilityRuleServer interface {
+	// Drainable is invoked once per node in a removal simulation, for the pods
+	// of the node which weren't decided by the built-in rules yet.
+	Drainable(context.Context, *DrainableRequest) (*DrainableResponse, error)
+	mustEmbedUnimplementedDrainabilityRuleServer()
+}
+
+// UnimplementedDrainabilityRuleServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDrainabilityRuleServer struct{}
+
+func (UnimplementedDrainabilityRuleServer) Drainable(context.Context, *DrainableRequest) (*DrainableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drainable not implemented")
+}
+func (UnimplementedDrainabilityRuleServer) mustEmbedUnimplementedDrainabilityRuleServer() {}
+func (UnimplementedDrainabilityRuleServer) testEmbeddedByValue()                          {}
+
+// UnsafeDrainabilityRuleServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DrainabilityRuleServer will
+// result in compilation errors.
+type UnsafeDrainabilityRuleServer interface {
+	mustEmbedUnimplementedDrainabilityRuleServer()
+}
+
+func RegisterDrainabilityRuleServer(s grpc.ServiceRegistrar, srv DrainabilityRuleServer) {
+	// If the following call pancis, it indicates UnimplementedDrainabilityRuleServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DrainabilityRule_ServiceDesc, srv)
+}
+
+func _DrainabilityRule_Drainable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DrainabilityRuleServer).Drainable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DrainabilityRule_Drainable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DrainabilityRuleServer).Drainable(ctx, req.(*DrainableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DrainabilityRule_ServiceDesc is the grpc.ServiceDesc for DrainabilityRule service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DrainabilityRule_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "clusterautoscaler.drainability.v1.DrainabilityRule",
+	HandlerType: (*DrainabilityRuleServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Drainable",
+			Handler:    _DrainabilityRule_Drainable_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "simulator/drainability/rules/external/protos/rule.proto",
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/external/protos"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	klog "k8s.io/klog/v2"
+)
+
+// Outcome is the decision of the external service.
+type Outcome string
+
+const (
+	// Drainable means that the pod can be drained.
+	Drainable Outcome = "Drainable"
+	// Blocked means that the pod blocks drain of its node.
+	Blocked Outcome = "Blocked"
+	// Skip means that the pod doesn't block drain, but shouldn't be drained itself.
+	Skip Outcome = "Skip"
+)
+
+// Rule is a drainability rule delegating the decision to an external gRPC
+// service. The service is called once per node, for all its pods which may
+// need a decision. Errors and timeouts block the drain of the node. Decisions
+// are cached per pod UID for the duration of a single simulation, identified
+// by the timestamp of the drain context.
+type Rule struct {
+	client  protos.DrainabilityRuleClient
+	timeout time.Duration
+
+	mutex     sync.Mutex
+	timestamp time.Time
+	cache     map[types.UID]drainability.Status
+}
+
+// New creates a new Rule calling the service at a given address. If caCert
+// is empty, the connection is insecure.
+func New(address string, timeout time.Duration, caCert string) (*Rule, error) {
+	creds := insecure.NewCredentials()
+	if caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caCert)
+		}
+		creds = credentials.NewClientTLSFromCert(pool, "")
+	}
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for external drainability rule %s: %v", address, err)
+	}
+	return &Rule{client: protos.NewDrainabilityRuleClient(conn), timeout: timeout, cache: make(map[types.UID]drainability.Status)}, nil
+}
+
+// Name returns the name of the rule.
+func (r *Rule) Name() string {
+	return "External"
+}
+
+// Drainable asks the external service whether the pod can be drained. Pods
+// of the same node are decided in the same call.
+func (r *Rule) Drainable(drainCtx *drainability.DrainContext, pod *apiv1.Pod, nodeInfo *framework.NodeInfo) drainability.Status {
+	r.mutex.Lock()
+	if !r.timestamp.Equal(drainCtx.Timestamp) {
+		r.timestamp = drainCtx.Timestamp
+		r.cache = make(map[types.UID]drainability.Status)
+	}
+	if status, found := r.cache[pod.UID]; found && pod.UID != "" {
+		r.mutex.Unlock()
+		return status
+	}
+	pods := []*apiv1.Pod{pod}
+	if nodeInfo != nil && pod.UID != "" {
+		for _, podInfo := range nodeInfo.Pods() {
+			other := podInfo.Pod
+			if _, found := r.cache[other.UID]; found || other.UID == "" || other.UID == pod.UID || pod_util.IsMirrorPod(other) || pod_util.IsDaemonSetPod(other) {
+				continue
+			}
+			pods = append(pods, other)
+		}
+	}
+	r.mutex.Unlock()
+
+	statuses, err := r.call(pods, nodeInfo)
+	if err != nil {
+		klog.Warningf("External drainability rule failed for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return drainability.NewBlockedStatus(drain.UnexpectedError, fmt.Errorf("external drainability rule failed for pod %s/%s: %v", pod.Namespace, pod.Name, err))
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.timestamp.Equal(drainCtx.Timestamp) {
+		for i, p := range pods {
+			if p.UID != "" {
+				r.cache[p.UID] = statuses[i]
+			}
+		}
+	}
+	return statuses[0]
+}
+
+func (r *Rule) call(pods []*apiv1.Pod, nodeInfo *framework.NodeInfo) ([]drainability.Status, error) {
+	req := &protos.DrainableRequest{}
+	if nodeInfo != nil && nodeInfo.Node() != nil {
+		node, err := json.Marshal(nodeInfo.Node())
+		if err != nil {
+			return nil, err
+		}
+		req.Node = node
+	}
+	for _, pod := range pods {
+		object, err := json.Marshal(pod)
+		if err != nil {
+			return nil, err
+		}
+		req.Pods = append(req.Pods, &protos.Pod{Namespace: pod.Namespace, Name: pod.Name, Object: object})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	resp, err := r.client.Drainable(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GetDecisions()) != len(pods) {
+		return nil, fmt.Errorf("invalid response: got %d decisions for %d pods", len(resp.GetDecisions()), len(pods))
+	}
+	statuses := make([]drainability.Status, len(pods))
+	for i, decision := range resp.GetDecisions() {
+		statuses[i] = toStatus(pods[i], decision)
+	}
+	return statuses, nil
+}
+
+func toStatus(pod *apiv1.Pod, decision *protos.Decision) drainability.Status {
+	switch Outcome(decision.GetOutcome()) {
+	case "":
+		return drainability.NewUndefinedStatus()
+	case Drainable:
+		return drainability.NewDrainableStatus()
+	case Skip:
+		return drainability.NewSkipStatus()
+	case Blocked:
+		return drainability.NewBlockedStatus(drain.BlockedByExternalRule, fmt.Errorf("pod %s/%s blocked by external drainability rule: %s", pod.Namespace, pod.Name, decision.GetReason()))
+	default:
+		return drainability.NewBlockedStatus(drain.UnexpectedError, fmt.Errorf("external drainability rule returned unknown outcome %q for pod %s/%s", decision.GetOutcome(), pod.Namespace, pod.Name))
+	}
+}
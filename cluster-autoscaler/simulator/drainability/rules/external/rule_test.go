@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/external/protos"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type fakeRuleServer struct {
+	protos.UnimplementedDrainabilityRuleServer
+	t         *testing.T
+	decisions map[string]*protos.Decision
+	delay     time.Duration
+	calls     atomic.Int32
+	pods      atomic.Int32
+}
+
+// Drainable answers with the decision for the pod name.
+func (s *fakeRuleServer) Drainable(_ context.Context, req *protos.DrainableRequest) (*protos.DrainableResponse, error) {
+	s.calls.Add(1)
+	s.pods.Add(int32(len(req.GetPods())))
+	node := &apiv1.Node{}
+	assert.NoError(s.t, json.Unmarshal(req.GetNode(), node))
+	assert.Equal(s.t, "n1", node.Name)
+	time.Sleep(s.delay)
+	resp := &protos.DrainableResponse{}
+	for _, p := range req.GetPods() {
+		pod := &apiv1.Pod{}
+		assert.NoError(s.t, json.Unmarshal(p.GetObject(), pod))
+		assert.Equal(s.t, p.GetName(), pod.Name)
+		decision, found := s.decisions[p.GetName()]
+		if !found {
+			decision = &protos.Decision{}
+		}
+		resp.Decisions = append(resp.Decisions, decision)
+	}
+	return resp, nil
+}
+
+// startServer starts a gRPC server answering with the decision for the pod
+// name. It returns the address of the server and the server itself.
+func startServer(t *testing.T, decisions map[string]*protos.Decision, delay time.Duration) (string, *fakeRuleServer) {
+	fake := &fakeRuleServer{t: t, decisions: decisions, delay: delay}
+	server := grpc.NewServer()
+	protos.RegisterDrainabilityRuleServer(server, fake)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), fake
+}
+
+func TestDrainable(t *testing.T) {
+	address, fake := startServer(t, map[string]*protos.Decision{
+		"drainable": {Outcome: "Drainable"},
+		"blocked":   {Outcome: "Blocked", Reason: "training mid-epoch"},
+		"skip":      {Outcome: "Skip"},
+		"undefined": {},
+		"unknown":   {Outcome: "Maybe"},
+	}, 0)
+	rule, err := New(address, 5*time.Second, "")
+	assert.NoError(t, err)
+	nodeInfo := framework.NewTestNodeInfo(BuildTestNode("n1", 1000, 1000))
+
+	for name, want := range map[string]drainability.Status{
+		"drainable": drainability.NewDrainableStatus(),
+		"blocked":   {Outcome: drainability.BlockDrain, BlockingReason: drain.BlockedByExternalRule},
+		"skip":      drainability.NewSkipStatus(),
+		"undefined": drainability.NewUndefinedStatus(),
+		"unknown":   {Outcome: drainability.BlockDrain, BlockingReason: drain.UnexpectedError},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pod := BuildTestPod(name, 100, 100)
+			pod.UID = types.UID(name)
+			got := rule.Drainable(&drainability.DrainContext{}, pod, nodeInfo)
+			assert.Equal(t, want.Outcome, got.Outcome)
+			assert.Equal(t, want.BlockingReason, got.BlockingReason)
+			if want.Outcome == drainability.BlockDrain {
+				assert.Error(t, got.Error)
+			}
+		})
+	}
+	assert.Equal(t, int32(5), fake.calls.Load())
+}
+
+func TestDrainableBatchesPodsOfNode(t *testing.T) {
+	address, fake := startServer(t, map[string]*protos.Decision{
+		"p1": {Outcome: "Drainable"},
+		"p2": {Outcome: "Blocked"},
+	}, 0)
+	rule, err := New(address, 5*time.Second, "")
+	assert.NoError(t, err)
+	p1 := BuildTestPod("p1", 100, 100)
+	p1.UID = "p1"
+	p2 := BuildTestPod("p2", 100, 100)
+	p2.UID = "p2"
+	ds := BuildTestPod("ds", 100, 100)
+	ds.UID = "ds"
+	ds.OwnerReferences = GenerateOwnerReferences("ds", "DaemonSet", "apps/v1", "ds-uid")
+	nodeInfo := framework.NewTestNodeInfo(BuildTestNode("n1", 1000, 1000), p1, p2, ds)
+
+	now := time.Now()
+	assert.Equal(t, drainability.DrainOk, rule.Drainable(&drainability.DrainContext{Timestamp: now}, p1, nodeInfo).Outcome)
+	assert.Equal(t, drainability.BlockDrain, rule.Drainable(&drainability.DrainContext{Timestamp: now}, p2, nodeInfo).Outcome)
+	assert.Equal(t, int32(1), fake.calls.Load())
+	// DaemonSet pods are decided by the built-in rules and not sent.
+	assert.Equal(t, int32(2), fake.pods.Load())
+}
+
+func TestDrainableCache(t *testing.T) {
+	address, fake := startServer(t, map[string]*protos.Decision{"p1": {Outcome: "Drainable"}}, 0)
+	rule, err := New(address, 5*time.Second, "")
+	assert.NoError(t, err)
+	nodeInfo := framework.NewTestNodeInfo(BuildTestNode("n1", 1000, 1000))
+	pod := BuildTestPod("p1", 100, 100)
+	pod.UID = "p1"
+
+	now := time.Now()
+	rule.Drainable(&drainability.DrainContext{Timestamp: now}, pod, nodeInfo)
+	rule.Drainable(&drainability.DrainContext{Timestamp: now}, pod, nodeInfo)
+	assert.Equal(t, int32(1), fake.calls.Load())
+	rule.Drainable(&drainability.DrainContext{Timestamp: now.Add(time.Second)}, pod, nodeInfo)
+	assert.Equal(t, int32(2), fake.calls.Load())
+}
+
+func TestDrainableTimeout(t *testing.T) {
+	address, _ := startServer(t, map[string]*protos.Decision{"p1": {Outcome: "Drainable"}}, time.Second)
+	rule, err := New(address, 10*time.Millisecond, "")
+	assert.NoError(t, err)
+	got := rule.Drainable(&drainability.DrainContext{}, BuildTestPod("p1", 100, 100), framework.NewTestNodeInfo(BuildTestNode("n1", 1000, 1000)))
+	assert.Equal(t, drainability.BlockDrain, got.Outcome)
+	assert.Equal(t, drain.UnexpectedError, got.BlockingReason)
+}
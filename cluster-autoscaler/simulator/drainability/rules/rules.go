@@ -21,6 +21,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/daemonset"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/disruptionwindow"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/localstorage"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/longterminating"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/mirror"
@@ -47,8 +48,10 @@ type Rule interface {
 	Drainable(*drainability.DrainContext, *apiv1.Pod, *framework.NodeInfo) drainability.Status
 }
 
-// Default returns the default list of Rules.
-func Default(deleteOptions options.NodeDeleteOptions) Rules {
+// Default returns the default list of Rules. Extra rules, such as external
+// ones, are evaluated after all the built-in rules, so they are only asked
+// about pods which none of the built-in rules decided on.
+func Default(deleteOptions options.NodeDeleteOptions, extraRules ...Rule) Rules {
 	var rules Rules
 	for _, r := range []struct {
 		rule Rule
@@ -64,6 +67,7 @@ func Default(deleteOptions options.NodeDeleteOptions) Rules {
 		{rule: terminal.New()},
 
 		// Blocking checks
+		{rule: disruptionwindow.New()},
 		{rule: replicated.New(deleteOptions.SkipNodesWithCustomControllerPods)},
 		{rule: system.New(deleteOptions.BspDisruptionTimeout), skip: !deleteOptions.SkipNodesWithSystemPods},
 		{rule: notsafetoevict.New()},
@@ -74,7 +78,7 @@ func Default(deleteOptions options.NodeDeleteOptions) Rules {
 			rules = append(rules, r.rule)
 		}
 	}
-	return rules
+	return append(rules, extraRules...)
 }
 
 // Rules defines operations on a collections of rules.
@@ -17,6 +17,8 @@ limitations under the License.
 package drainability
 
 import (
+	"time"
+
 	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
 )
 
@@ -52,6 +54,9 @@ type Status struct {
 	// Reason contains the reason why a pod is blocking node drain. It is
 	// set only when Outcome is BlockDrain.
 	BlockingReason drain.BlockingPodReason
+	// BlockedUntil is the time after which the pod stops blocking node
+	// drain, if known. It is set only when Outcome is BlockDrain.
+	BlockedUntil time.Time
 	// Error contains an optional error message.
 	Error error
 }
@@ -72,6 +77,16 @@ func NewBlockedStatus(reason drain.BlockingPodReason, err error) Status {
 	}
 }
 
+// NewBlockedUntilStatus returns a new Status indicating that a pod is blocked and cannot be drained until a given time.
+func NewBlockedUntilStatus(reason drain.BlockingPodReason, until time.Time, err error) Status {
+	return Status{
+		Outcome:        BlockDrain,
+		BlockingReason: reason,
+		BlockedUntil:   until,
+		Error:          err,
+	}
+}
+
 // NewSkipStatus returns a new Status indicating that a pod should be skipped when draining a node.
 func NewSkipStatus() Status {
 	return Status{
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	fwk "k8s.io/kube-scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler"
+	schedulerconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// ExtenderCacheTTL is how long the decision of a filter extender for a given Pod and Node is reused.
+// Extenders are called for every Node considered in a simulation, so they could easily be called
+// thousands of times per loop without caching.
+const ExtenderCacheTTL = time.Minute
+
+// ExtenderCallTimeout caps the timeout of a single filter extender call. The scheduler calls an extender once per
+// scheduling cycle, while a simulation calls it once per considered Node, so a slow extender could otherwise stall
+// the whole loop.
+const ExtenderCallTimeout = time.Second
+
+// TemplateNodeNamePrefix is the prefix of names of Nodes built from node group templates. Such Nodes only exist
+// in simulations.
+const TemplateNodeNamePrefix = "template-node-for-"
+
+// filterExtender calls a filter extender configured in the scheduler config, caching its decisions.
+type filterExtender struct {
+	extender schedulerframework.Extender
+	// nodeCacheCapable extenders only receive Node names, so they can't check Nodes which only exist in simulations.
+	nodeCacheCapable bool
+	ttl              time.Duration
+	now              func() time.Time
+	mutex            sync.Mutex
+	cache            map[extenderCacheKey]extenderCacheEntry
+	lastPurge        time.Time
+}
+
+// extenderCacheKey identifies a Pod and a Node checked by an extender. Simulated Nodes get a new name and UID every
+// time they're created from a template, so they are identified by the parts of the template an extender can act on
+// instead.
+type extenderCacheKey struct {
+	pod      string
+	nodeName string
+	nodeUID  types.UID
+	template string
+}
+
+type extenderCacheEntry struct {
+	// reasons why the Node was rejected, nil if it passed the extender.
+	reasons []string
+	expires time.Time
+}
+
+// newFilterExtenders builds filterExtenders for all extenders in the scheduler config implementing the filter verb.
+func newFilterExtenders(configs []schedulerconfig.Extender) ([]*filterExtender, error) {
+	var result []*filterExtender
+	for i := range configs {
+		if configs[i].FilterVerb == "" {
+			continue
+		}
+		config := configs[i]
+		if config.HTTPTimeout.Duration == 0 || config.HTTPTimeout.Duration > ExtenderCallTimeout {
+			config.HTTPTimeout.Duration = ExtenderCallTimeout
+		}
+		extender, err := scheduler.NewHTTPExtender(&config)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create scheduler extender %s: %v", config.URLPrefix, err)
+		}
+		filterExtender := newFilterExtender(extender, ExtenderCacheTTL, time.Now)
+		filterExtender.nodeCacheCapable = config.NodeCacheCapable
+		result = append(result, filterExtender)
+	}
+	return result, nil
+}
+
+func newFilterExtender(extender schedulerframework.Extender, ttl time.Duration, now func() time.Time) *filterExtender {
+	return &filterExtender{
+		extender: extender,
+		ttl:      ttl,
+		now:      now,
+		cache:    make(map[extenderCacheKey]extenderCacheEntry),
+	}
+}
+
+// filter checks if the Pod can be scheduled on the Node according to the extender. Errors of ignorable
+// extenders are logged and the Node is assumed to pass.
+func (e *filterExtender) filter(pod *apiv1.Pod, nodeInfo fwk.NodeInfo) *fwk.Status {
+	if !e.extender.IsInterested(pod) {
+		return nil
+	}
+	node := nodeInfo.Node()
+	key := extenderCacheKey{pod: podKey(pod), nodeName: node.Name, nodeUID: node.UID}
+	if isTemplateNode(node) {
+		if e.nodeCacheCapable {
+			klog.V(5).Infof("Skipping node cache capable scheduler extender %s for simulated node %s", e.extender.Name(), node.Name)
+			return nil
+		}
+		key = extenderCacheKey{pod: key.pod, template: templateKey(node)}
+	}
+	if reasons, found := e.get(key); found {
+		return e.status(reasons)
+	}
+
+	filtered, failed, failedAndUnresolvable, err := e.extender.Filter(pod, []fwk.NodeInfo{nodeInfo})
+	if err != nil {
+		if e.extender.IsIgnorable() {
+			klog.Warningf("Skipping ignorable scheduler extender %s for pod %s/%s: %v", e.extender.Name(), pod.Namespace, pod.Name, err)
+			return nil
+		}
+		return fwk.AsStatus(fmt.Errorf("scheduler extender %s failed: %v", e.extender.Name(), err)).WithPlugin(e.extender.Name())
+	}
+	var reasons []string
+	if !containsNode(filtered, node.Name) {
+		reason, found := failedAndUnresolvable[node.Name]
+		if !found {
+			reason, found = failed[node.Name]
+		}
+		if !found {
+			reason = "node rejected by scheduler extender"
+		}
+		reasons = []string{reason}
+	}
+	e.set(key, reasons)
+	return e.status(reasons)
+}
+
+func (e *filterExtender) status(reasons []string) *fwk.Status {
+	if reasons == nil {
+		return nil
+	}
+	return fwk.NewStatus(fwk.Unschedulable, reasons...).WithPlugin(e.extender.Name())
+}
+
+func (e *filterExtender) get(key extenderCacheKey) ([]string, bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	entry, found := e.cache[key]
+	if !found || e.now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.reasons, true
+}
+
+func (e *filterExtender) set(key extenderCacheKey, reasons []string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	now := e.now()
+	if now.Sub(e.lastPurge) > e.ttl {
+		for k, entry := range e.cache {
+			if now.After(entry.expires) {
+				delete(e.cache, k)
+			}
+		}
+		e.lastPurge = now
+	}
+	e.cache[key] = extenderCacheEntry{reasons: reasons, expires: now.Add(e.ttl)}
+}
+
+func containsNode(nodeInfos []fwk.NodeInfo, nodeName string) bool {
+	for _, nodeInfo := range nodeInfos {
+		if nodeInfo.Node() != nil && nodeInfo.Node().Name == nodeName {
+			return true
+		}
+	}
+	return false
+}
+
+func isTemplateNode(node *apiv1.Node) bool {
+	return strings.HasPrefix(node.Name, TemplateNodeNamePrefix)
+}
+
+// templateKey returns the labels, taints and allocatable resources of the Node, skipping the hostname label which
+// differs between copies of the same template.
+func templateKey(node *apiv1.Node) string {
+	var parts []string
+	for name, value := range node.Labels {
+		if name != apiv1.LabelHostname {
+			parts = append(parts, fmt.Sprintf("label:%s=%s", name, value))
+		}
+	}
+	for _, taint := range node.Spec.Taints {
+		parts = append(parts, fmt.Sprintf("taint:%s=%s:%s", taint.Key, taint.Value, taint.Effect))
+	}
+	for name, quantity := range node.Status.Allocatable {
+		parts = append(parts, fmt.Sprintf("allocatable:%s=%s", name, quantity.String()))
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, ",")
+}
+
+func podKey(pod *apiv1.Pod) string {
+	if pod.UID != "" {
+		return string(pod.UID)
+	}
+	return fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+}
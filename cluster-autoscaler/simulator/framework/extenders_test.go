@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	extenderv1 "k8s.io/kube-scheduler/extender/v1"
+	fwk "k8s.io/kube-scheduler/framework"
+	schedulerconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type fakeExtender struct {
+	schedulerframework.Extender
+	rejected   map[string]string
+	err        error
+	ignorable  bool
+	interested bool
+	calls      int
+}
+
+func (e *fakeExtender) Name() string                   { return "fake-extender" }
+func (e *fakeExtender) IsIgnorable() bool              { return e.ignorable }
+func (e *fakeExtender) IsInterested(_ *apiv1.Pod) bool { return e.interested }
+
+func (e *fakeExtender) Filter(_ *apiv1.Pod, nodes []fwk.NodeInfo) ([]fwk.NodeInfo, extenderv1.FailedNodesMap, extenderv1.FailedNodesMap, error) {
+	e.calls++
+	if e.err != nil {
+		return nil, nil, nil, e.err
+	}
+	var filtered []fwk.NodeInfo
+	failed := extenderv1.FailedNodesMap{}
+	for _, node := range nodes {
+		if reason, found := e.rejected[node.Node().Name]; found {
+			failed[node.Node().Name] = reason
+		} else {
+			filtered = append(filtered, node)
+		}
+	}
+	return filtered, failed, nil, nil
+}
+
+func TestFilterExtender(t *testing.T) {
+	pod := BuildTestPod("p", 100, 100)
+	n1 := NewTestNodeInfo(BuildTestNode("n1", 1000, 1000)).ToScheduler()
+	n2 := NewTestNodeInfo(BuildTestNode("n2", 1000, 1000)).ToScheduler()
+
+	for _, tc := range []struct {
+		name         string
+		extender     *fakeExtender
+		wantN1Reason string
+		wantN1Error  bool
+		wantN2Pass   bool
+		wantCalls    int
+	}{
+		{
+			name:         "rejected and passing nodes",
+			extender:     &fakeExtender{interested: true, rejected: map[string]string{"n1": "no free GPU link"}},
+			wantN1Reason: "no free GPU link",
+			wantN2Pass:   true,
+			wantCalls:    2,
+		},
+		{
+			name:       "not interested in pod",
+			extender:   &fakeExtender{rejected: map[string]string{"n1": "no free GPU link"}},
+			wantN2Pass: true,
+		},
+		{
+			name:        "error",
+			extender:    &fakeExtender{interested: true, err: fmt.Errorf("connection refused")},
+			wantN1Error: true,
+			wantCalls:   4,
+		},
+		{
+			name:       "ignorable error",
+			extender:   &fakeExtender{interested: true, ignorable: true, err: fmt.Errorf("connection refused")},
+			wantN2Pass: true,
+			wantCalls:  4,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newFilterExtender(tc.extender, time.Minute, time.Now)
+			// The second round of calls is answered from the cache, unless the extender failed.
+			for i := 0; i < 2; i++ {
+				status := e.filter(pod, n1)
+				switch {
+				case tc.wantN1Reason != "":
+					assert.True(t, status.IsRejected())
+					assert.Equal(t, []string{tc.wantN1Reason}, status.Reasons())
+					assert.Equal(t, "fake-extender", status.Plugin())
+				case tc.wantN1Error:
+					assert.False(t, status.IsSuccess())
+					assert.False(t, status.IsRejected())
+				default:
+					assert.True(t, status.IsSuccess())
+				}
+				assert.Equal(t, tc.wantN2Pass, e.filter(pod, n2).IsSuccess())
+			}
+			assert.Equal(t, tc.wantCalls, tc.extender.calls)
+		})
+	}
+}
+
+func TestFilterExtenderCacheExpiry(t *testing.T) {
+	pod := BuildTestPod("p", 100, 100)
+	node := NewTestNodeInfo(BuildTestNode("n1", 1000, 1000)).ToScheduler()
+	now := time.Now()
+	extender := &fakeExtender{interested: true}
+	e := newFilterExtender(extender, time.Minute, func() time.Time { return now })
+
+	assert.True(t, e.filter(pod, node).IsSuccess())
+	assert.True(t, e.filter(pod, node).IsSuccess())
+	assert.Equal(t, 1, extender.calls)
+
+	now = now.Add(2 * time.Minute)
+	extender.rejected = map[string]string{"n1": "no free GPU link"}
+	assert.True(t, e.filter(pod, node).IsRejected())
+	assert.Equal(t, 2, extender.calls)
+	assert.Len(t, e.cache, 1)
+}
+
+func TestFilterExtenderTemplateNodes(t *testing.T) {
+	pod := BuildTestPod("p", 100, 100)
+	templateNode := func(name, zone string) fwk.NodeInfo {
+		node := BuildTestNode(name, 1000, 1000)
+		node.Labels = map[string]string{apiv1.LabelHostname: name, apiv1.LabelTopologyZone: zone}
+		return NewTestNodeInfo(node).ToScheduler()
+	}
+	n1 := templateNode(TemplateNodeNamePrefix+"ng1-1-a", "zone-a")
+	n2 := templateNode(TemplateNodeNamePrefix+"ng1-2-b", "zone-a")
+	n3 := templateNode(TemplateNodeNamePrefix+"ng2-1-a", "zone-b")
+
+	extender := &fakeExtender{interested: true}
+	e := newFilterExtender(extender, time.Minute, time.Now)
+	assert.True(t, e.filter(pod, n1).IsSuccess())
+	// Copies of the same template share the cached decision.
+	assert.True(t, e.filter(pod, n2).IsSuccess())
+	assert.Equal(t, 1, extender.calls)
+	assert.True(t, e.filter(pod, n3).IsSuccess())
+	assert.Equal(t, 2, extender.calls)
+
+	// Node cache capable extenders can't know simulated nodes.
+	extender = &fakeExtender{interested: true, rejected: map[string]string{n1.Node().Name: "unknown node"}}
+	e = newFilterExtender(extender, time.Minute, time.Now)
+	e.nodeCacheCapable = true
+	assert.True(t, e.filter(pod, n1).IsSuccess())
+	assert.Equal(t, 0, extender.calls)
+}
+
+func TestFilterExtenderCallTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	extenders, err := newFilterExtenders([]schedulerconfig.Extender{{
+		URLPrefix:   server.URL,
+		FilterVerb:  "filter",
+		HTTPTimeout: metav1.Duration{Duration: time.Minute},
+	}})
+	assert.NoError(t, err)
+	assert.Len(t, extenders, 1)
+
+	start := time.Now()
+	status := extenders[0].filter(BuildTestPod("p", 100, 100), NewTestNodeInfo(BuildTestNode("n1", 1000, 1000)).ToScheduler())
+	assert.False(t, status.IsSuccess())
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"sync"
 
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/informers"
+	fwk "k8s.io/kube-scheduler/framework"
 	schedulerconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
 	schedulerconfiglatest "k8s.io/kubernetes/pkg/scheduler/apis/config/latest"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
@@ -38,6 +40,7 @@ var (
 type Handle struct {
 	Framework        schedulerframework.Framework
 	DelegatingLister *DelegatingSchedulerSharedLister
+	extenders        []*filterExtender
 }
 
 // NewHandle builds a framework Handle based on the provided informers and scheduler config.
@@ -76,8 +79,30 @@ func NewHandle(informerFactory informers.SharedInformerFactory, schedConfig *sch
 		return nil, fmt.Errorf("couldn't create scheduler framework; %v", err)
 	}
 
+	extenders, err := newFilterExtenders(schedConfig.Extenders)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Handle{
 		Framework:        framework,
 		DelegatingLister: sharedLister,
+		extenders:        extenders,
 	}, nil
 }
+
+// HasFilterExtenders returns true if any filter extenders are configured in the scheduler config.
+func (h *Handle) HasFilterExtenders() bool {
+	return len(h.extenders) > 0
+}
+
+// RunFilterExtenders runs the filter extenders configured in the scheduler config for the given Pod and Node. Returns nil if
+// the Node passes all of them, or the status of the first extender rejecting the Node otherwise.
+func (h *Handle) RunFilterExtenders(pod *apiv1.Pod, nodeInfo fwk.NodeInfo) *fwk.Status {
+	for _, extender := range h.extenders {
+		if status := extender.filter(pod, nodeInfo); !status.IsSuccess() {
+			return status
+		}
+	}
+	return nil
+}
@@ -61,7 +61,7 @@ func SanitizedTemplateNodeInfoFromNodeGroup(nodeGroup nodeGroupTemplateNodeInfoG
 // contains the pods that should appear on a new Node from the same node group (e.g. DaemonSet pods).
 func SanitizedTemplateNodeInfoFromNodeInfo(example *framework.NodeInfo, nodeGroupId string, daemonsets []*appsv1.DaemonSet, forceDaemonSets bool, taintConfig taints.TaintConfig) (*framework.NodeInfo, errors.AutoscalerError) {
 	randSuffix := fmt.Sprintf("%d", rand.Int63())
-	newNodeNameBase := fmt.Sprintf("template-node-for-%s", nodeGroupId)
+	newNodeNameBase := fmt.Sprintf("%s%s", framework.TemplateNodeNamePrefix, nodeGroupId)
 
 	// We need to sanitize the example before determining the DS pods, since taints are checked there, and
 	// we might need to filter some out during sanitization.
@@ -138,6 +138,11 @@ func (s *HintingSimulator) DropOldHints() {
 	s.hints.DropOld()
 }
 
+// MergeHints copies scheduling hints gathered by another HintingSimulator.
+func (s *HintingSimulator) MergeHints(other *HintingSimulator) {
+	s.hints.Merge(other.hints)
+}
+
 // ScheduleAnywhere can be passed to TrySchedulePods when there are no extra restrictions on nodes to consider.
 func ScheduleAnywhere(_ *framework.NodeInfo) bool {
 	return true
@@ -63,6 +63,14 @@ func (h *Hints) Set(hk HintKey, nodeName string) {
 	h.current[hk] = nodeName
 }
 
+// Merge copies all hints from other into the current generation of h,
+// overwriting hints already present for the same keys.
+func (h *Hints) Merge(other *Hints) {
+	for hk, nodeName := range other.current {
+		h.current[hk] = nodeName
+	}
+}
+
 // DropOld cleans up old keys. All keys are considered old if they were added
 // before the previous call to DropOld().
 func (h *Hints) DropOld() {
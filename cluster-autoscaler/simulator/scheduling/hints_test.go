@@ -55,6 +55,31 @@ func TestDropOld(t *testing.T) {
 	}
 }
 
+func TestMerge(t *testing.T) {
+	h := NewHints()
+	h.Set("a", "n1")
+	h.Set("b", "n1")
+	h.DropOld()
+	h.Set("c", "n1")
+	other := NewHints()
+	other.Set("b", "n2")
+	other.Set("d", "n2")
+
+	h.Merge(other)
+	for hk, want := range map[HintKey]string{"a": "n1", "b": "n2", "c": "n1", "d": "n2"} {
+		got, found := h.Get(hk)
+		if !found || got != want {
+			t.Errorf("Get(%v) = %v, %v; want %v, true", hk, got, found, want)
+		}
+	}
+	// Merged hints belong to the current generation.
+	h.DropOld()
+	h.DropOld()
+	if _, found := h.Get("d"); found {
+		t.Errorf("merged hint survived two generations")
+	}
+}
+
 func chain(a, b []string) []string {
 	return append(append([]string{}, a...), b...)
 }
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templatecache
+
+import (
+	"k8s.io/autoscaler/cluster-autoscaler/utils/persistence"
+	kube_client "k8s.io/client-go/kubernetes"
+)
+
+// ConfigMapKey is the key of the ConfigMap data entry holding the templates.
+const ConfigMapKey = "templates"
+
+// blobStore keeps templates in a persisted blob.
+type blobStore struct {
+	blob persistence.Blob
+}
+
+// NewFileStore returns a Store keeping templates in the file with the given path, e.g. on a persistent volume.
+func NewFileStore(path string) Store {
+	return &blobStore{blob: persistence.NewFileBlob(path)}
+}
+
+// NewConfigMapStore returns a Store keeping templates in the given ConfigMap. Note that ConfigMaps are
+// limited to 1MiB, which is enough for a few hundred node groups.
+func NewConfigMapStore(client kube_client.Interface, namespace, name string) Store {
+	return &blobStore{blob: persistence.NewConfigMapBlob(client, namespace, name, ConfigMapKey)}
+}
+
+// Load returns saved templates.
+func (s *blobStore) Load() (map[string]Entry, error) {
+	data, err := s.blob.Read()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return map[string]Entry{}, nil
+	}
+	return decode(data)
+}
+
+// Save replaces saved templates with the given ones.
+func (s *blobStore) Save(entries map[string]Entry) error {
+	data, err := encode(entries)
+	if err != nil {
+		return err
+	}
+	return s.blob.Write(data)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package templatecache persists template NodeInfos built from real nodes, so
+// that they survive restarts of the autoscaler.
+package templatecache
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+// Entry is a cached template NodeInfo of a node group.
+type Entry struct {
+	NodeInfo *framework.NodeInfo
+	// Added is the time the template was built from a real node.
+	Added time.Time
+}
+
+// Store saves and restores cached templates, keyed by node group id.
+type Store interface {
+	// Load returns previously saved templates. It returns an empty map if nothing was saved yet.
+	Load() (map[string]Entry, error)
+	// Save replaces previously saved templates with the given ones.
+	Save(entries map[string]Entry) error
+}
+
+type persistedEntry struct {
+	Node           *apiv1.Node                  `json:"node"`
+	ResourceSlices []*resourceapi.ResourceSlice `json:"resourceSlices,omitempty"`
+	Pods           []persistedPod               `json:"pods,omitempty"`
+	Added          time.Time                    `json:"added"`
+}
+
+type persistedPod struct {
+	Pod            *apiv1.Pod                   `json:"pod"`
+	ResourceClaims []*resourceapi.ResourceClaim `json:"resourceClaims,omitempty"`
+}
+
+func encode(entries map[string]Entry) ([]byte, error) {
+	persisted := make(map[string]persistedEntry, len(entries))
+	for id, entry := range entries {
+		pe := persistedEntry{
+			Node:           entry.NodeInfo.Node(),
+			ResourceSlices: entry.NodeInfo.LocalResourceSlices,
+			Added:          entry.Added,
+		}
+		for _, podInfo := range entry.NodeInfo.Pods() {
+			pe.Pods = append(pe.Pods, persistedPod{Pod: podInfo.Pod, ResourceClaims: podInfo.NeededResourceClaims})
+		}
+		persisted[id] = pe
+	}
+	return json.Marshal(persisted)
+}
+
+func decode(data []byte) (map[string]Entry, error) {
+	var persisted map[string]persistedEntry
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal templates: %v", err)
+	}
+	entries := make(map[string]Entry, len(persisted))
+	for id, pe := range persisted {
+		if pe.Node == nil {
+			return nil, fmt.Errorf("template of node group %s has no node", id)
+		}
+		var pods []*framework.PodInfo
+		for _, pp := range pe.Pods {
+			pods = append(pods, framework.NewPodInfo(pp.Pod, pp.ResourceClaims))
+		}
+		entries[id] = Entry{NodeInfo: framework.NewNodeInfo(pe.Node, pe.ResourceSlices, pods...), Added: pe.Added}
+	}
+	return entries, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templatecache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	resourceapi "k8s.io/api/resource/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testEntries() map[string]Entry {
+	node := BuildTestNode("template-node-for-ng1", 1000, 2000)
+	node.Labels = map[string]string{"pool": "gpu"}
+	dsPod := BuildScheduledTestPod("ds-pod", 100, 200, node.Name)
+	claim := &resourceapi.ResourceClaim{ObjectMeta: metav1.ObjectMeta{Name: "claim", Namespace: "default"}}
+	slice := &resourceapi.ResourceSlice{ObjectMeta: metav1.ObjectMeta{Name: "slice"}}
+	return map[string]Entry{
+		"ng1": {
+			NodeInfo: framework.NewNodeInfo(node, []*resourceapi.ResourceSlice{slice}, framework.NewPodInfo(dsPod, []*resourceapi.ResourceClaim{claim})),
+			Added:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+}
+
+func assertEntriesEqual(t *testing.T, want, got map[string]Entry) {
+	assert.Equal(t, len(want), len(got))
+	for id, wantEntry := range want {
+		gotEntry, found := got[id]
+		if !assert.True(t, found, id) {
+			continue
+		}
+		assert.True(t, wantEntry.Added.Equal(gotEntry.Added))
+		assert.Equal(t, wantEntry.NodeInfo.Node().Name, gotEntry.NodeInfo.Node().Name)
+		assert.Equal(t, wantEntry.NodeInfo.Node().Labels, gotEntry.NodeInfo.Node().Labels)
+		assert.True(t, wantEntry.NodeInfo.Node().Status.Allocatable.Cpu().Equal(*gotEntry.NodeInfo.Node().Status.Allocatable.Cpu()))
+		assert.Equal(t, wantEntry.NodeInfo.LocalResourceSlices, gotEntry.NodeInfo.LocalResourceSlices)
+		if assert.Len(t, gotEntry.NodeInfo.Pods(), len(wantEntry.NodeInfo.Pods())) {
+			for i, podInfo := range wantEntry.NodeInfo.Pods() {
+				assert.Equal(t, podInfo.Pod.Name, gotEntry.NodeInfo.Pods()[i].Pod.Name)
+				assert.Equal(t, podInfo.NeededResourceClaims, gotEntry.NodeInfo.Pods()[i].NeededResourceClaims)
+			}
+		}
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "templates.json")
+	store := NewFileStore(path)
+
+	entries, err := store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.NoError(t, store.Save(testEntries()))
+	entries, err = store.Load()
+	assert.NoError(t, err)
+	assertEntriesEqual(t, testEntries(), entries)
+
+	assert.NoError(t, store.Save(map[string]Entry{}))
+	entries, err = store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+	_, err = store.Load()
+	assert.Error(t, err)
+}
+
+func TestConfigMapStore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	store := NewConfigMapStore(client, "kube-system", "templates")
+
+	entries, err := store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// The first save creates the ConfigMap, the next one updates it.
+	assert.NoError(t, store.Save(map[string]Entry{}))
+	assert.NoError(t, store.Save(testEntries()))
+	entries, err = store.Load()
+	assert.NoError(t, err)
+	assertEntriesEqual(t, testEntries(), entries)
+
+	configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(context.TODO(), "templates", metav1.GetOptions{})
+	assert.NoError(t, err)
+	configMap.Data = map[string]string{ConfigMapKey: `{"ng1": {}}`}
+	_, err = client.CoreV1().ConfigMaps("kube-system").Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	_, err = store.Load()
+	assert.Error(t, err)
+
+	configMap.Data = map[string]string{}
+	_, err = client.CoreV1().ConfigMaps("kube-system").Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	entries, err = store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestDecodeRejectsMissingNode(t *testing.T) {
+	_, err := decode([]byte(`{"ng1": {"added": "2025-01-02T03:04:05Z"}}`))
+	assert.Error(t, err)
+	_, err = decode([]byte(`{"ng1": {"node": {"metadata": {"name": "n1"}}}}`))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing records OpenTelemetry spans of the autoscaler main loop.
+//
+// Spans are nested through the context passed to Start. The main loop keeps
+// the context of its current phase in AutoscalingContext.TraceContext, so that
+// it reaches code called through interfaces taking no context. Clients making
+// remote calls through such interfaces are bound to it with Bind. Code running
+// outside the main loop goroutine starts from context.Background() instead.
+// Unless Setup is called with an endpoint, spans are no-ops.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/autoscaler/cluster-autoscaler/version"
+)
+
+const tracerName = "k8s.io/autoscaler/cluster-autoscaler"
+
+// Attribute keys used by Cluster Autoscaler spans.
+const (
+	NodeGroupKey = attribute.Key("node_group")
+	PodCountKey  = attribute.Key("pod_count")
+	NodeCountKey = attribute.Key("node_count")
+)
+
+var (
+	mutex    sync.Mutex
+	provider *sdktrace.TracerProvider
+)
+
+// Options configure exporting spans.
+type Options struct {
+	// Endpoint is the address of the OTLP gRPC collector. Empty disables tracing.
+	Endpoint string
+	// Insecure disables TLS when connecting to the collector.
+	Insecure bool
+	// SamplingRatio is the fraction of loops traced.
+	SamplingRatio float64
+}
+
+// Setup starts exporting spans to the OTLP collector given in options, and
+// propagating trace context over W3C Trace Context headers. It is a no-op if
+// no endpoint is given.
+func Setup(ctx context.Context, options Options) error {
+	if options.Endpoint == "" {
+		return nil
+	}
+	clientOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(options.Endpoint)}
+	if options.Insecure {
+		clientOptions = append(clientOptions, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, clientOptions...)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP trace exporter: %v", err)
+	}
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SamplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "cluster-autoscaler"),
+			attribute.String("service.version", version.ClusterAutoscalerVersion),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	mutex.Lock()
+	provider = tracerProvider
+	mutex.Unlock()
+	return nil
+}
+
+// Shutdown exports the remaining spans and stops exporting.
+func Shutdown(ctx context.Context) error {
+	mutex.Lock()
+	tracerProvider := provider
+	provider = nil
+	mutex.Unlock()
+	if tracerProvider == nil {
+		return nil
+	}
+	return tracerProvider.Shutdown(ctx)
+}
+
+// Start starts a span as a child of the span in ctx, and returns a context
+// carrying the new span. A nil ctx starts a new trace.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// ContextBinder is implemented by clients making remote calls through interfaces
+// taking no context, e.g. cloud providers and expanders. WithContext returns a
+// client making its calls as children of the span in ctx.
+type ContextBinder[T any] interface {
+	WithContext(ctx context.Context) T
+}
+
+// Bind returns client bound to ctx if it implements ContextBinder, or client
+// itself otherwise. A nil ctx leaves client as is.
+func Bind[T any](ctx context.Context, client T) T {
+	if ctx == nil {
+		return client
+	}
+	if binder, ok := any(client).(ContextBinder[T]); ok {
+		return binder.WithContext(ctx)
+	}
+	return client
+}
+
+// RecordError records err on the span and marks it as failed, if err isn't nil.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracerProvider)
+	defer otel.SetTracerProvider(previousProvider)
+
+	loopCtx, loop := Start(context.Background(), "RunOnce", NodeCountKey.Int(3))
+	scaleUpCtx, scaleUp := Start(loopCtx, "ScaleUp", PodCountKey.Int(5))
+	assert.Equal(t, scaleUp.SpanContext(), trace.SpanContextFromContext(scaleUpCtx))
+	assert.Equal(t, loop.SpanContext(), trace.SpanContextFromContext(loopCtx))
+
+	_, increase := Start(scaleUpCtx, "CloudProvider.IncreaseSize", NodeGroupKey.String("ng1"))
+	RecordError(increase, errors.New("quota exceeded"))
+	increase.End()
+	scaleUp.End()
+	loop.End()
+
+	_, background := Start(nil, "Background")
+	background.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	assert.Len(t, spans, 4)
+	assert.False(t, spans["RunOnce"].Parent().IsValid())
+	assert.False(t, spans["Background"].Parent().IsValid())
+	assert.Equal(t, spans["RunOnce"].SpanContext().SpanID(), spans["ScaleUp"].Parent().SpanID())
+	assert.Equal(t, spans["ScaleUp"].SpanContext().SpanID(), spans["CloudProvider.IncreaseSize"].Parent().SpanID())
+	assert.Contains(t, spans["ScaleUp"].Attributes(), PodCountKey.Int(5))
+	assert.Contains(t, spans["CloudProvider.IncreaseSize"].Attributes(), NodeGroupKey.String("ng1"))
+	assert.Equal(t, codes.Error, spans["CloudProvider.IncreaseSize"].Status().Code)
+	assert.Equal(t, codes.Unset, spans["ScaleUp"].Status().Code)
+}
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	assert.NoError(t, Setup(context.Background(), Options{}))
+	assert.NoError(t, Shutdown(context.Background()))
+}
+
+type client interface {
+	Context() context.Context
+}
+
+type boundClient struct {
+	ctx context.Context
+}
+
+func (c *boundClient) Context() context.Context {
+	return c.ctx
+}
+
+func (c *boundClient) WithContext(ctx context.Context) client {
+	return &boundClient{ctx: ctx}
+}
+
+type unboundClient struct{}
+
+type testKey struct{}
+
+func (c *unboundClient) Context() context.Context {
+	return nil
+}
+
+func TestBind(t *testing.T) {
+	ctx := context.WithValue(context.Background(), testKey{}, "value")
+	var c client = &boundClient{}
+	assert.Equal(t, ctx, Bind(ctx, c).Context())
+	assert.Nil(t, c.Context())
+	assert.Equal(t, c, Bind(nil, c))
+
+	c = &unboundClient{}
+	assert.Equal(t, c, Bind(ctx, c))
+}
@@ -36,12 +36,21 @@ const (
 	PodSafeToEvictKey = "cluster-autoscaler.kubernetes.io/safe-to-evict"
 	// SafeToEvictLocalVolumesKey - annotation that ignores (doesn't block on) a local storage volume during node scale down
 	SafeToEvictLocalVolumesKey = "cluster-autoscaler.kubernetes.io/safe-to-evict-local-volumes"
+	// PodDisruptionWindowsKey - annotation listing windows, separated by semicolons, in which the pod can be
+	// evicted during node scale down, e.g. "0 22 * * 1-5 8h; 0 0 * * 0 24h". See schedule.ParseWindow.
+	PodDisruptionWindowsKey = "cluster-autoscaler.kubernetes.io/disruption-windows"
+	// PodDoNotDisruptUntilKey - annotation with an RFC 3339 timestamp before which the pod can't be evicted during
+	// node scale down.
+	PodDoNotDisruptUntilKey = "cluster-autoscaler.kubernetes.io/do-not-disrupt-until"
 )
 
 // BlockingPod represents a pod which is blocking the scale down of a node.
 type BlockingPod struct {
 	Pod    *apiv1.Pod
 	Reason BlockingPodReason
+	// BlockedUntil is the time after which the pod stops blocking the scale
+	// down, if known.
+	BlockedUntil time.Time
 }
 
 // BlockingPodReason represents a reason why a pod is blocking the scale down of a node.
@@ -67,6 +76,11 @@ const (
 	NotEnoughPdb
 	// UnexpectedError - pod is blocking scale down because of an unexpected error.
 	UnexpectedError
+	// BlockedByExternalRule - pod is blocking scale down because an external drainability rule blocked it.
+	BlockedByExternalRule
+	// OutsideDisruptionWindow - pod is blocking scale down because it can't be disrupted at this time, as declared by
+	// its annotations.
+	OutsideDisruptionWindow
 )
 
 func (e BlockingPodReason) String() string {
@@ -89,6 +103,10 @@ func (e BlockingPodReason) String() string {
 		return "NotEnoughPdb"
 	case UnexpectedError:
 		return "UnexpectedError"
+	case BlockedByExternalRule:
+		return "BlockedByExternalRule"
+	case OutsideDisruptionWindow:
+		return "OutsideDisruptionWindow"
 	default:
 		return fmt.Sprintf("unrecognized reason: %d", int(e))
 	}
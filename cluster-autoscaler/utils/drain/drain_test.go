@@ -172,8 +172,16 @@ func TestBlockingPodReasonFormatting(t *testing.T) {
 			want: "UnexpectedError",
 		},
 		{
-			bpr:  BlockingPodReason(9),
-			want: "unrecognized reason: 9",
+			bpr:  BlockedByExternalRule,
+			want: "BlockedByExternalRule",
+		},
+		{
+			bpr:  OutsideDisruptionWindow,
+			want: "OutsideDisruptionWindow",
+		},
+		{
+			bpr:  BlockingPodReason(11),
+			want: "unrecognized reason: 11",
 		},
 	} {
 		t.Run(tc.want, func(t *testing.T) {
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"sync"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// AsyncWriter runs API writes in the background, limited to a given rate, so
+// that they don't slow down the main loop. Only the latest write for each key
+// is kept: a write replaces the one which is still pending for the same key.
+type AsyncWriter struct {
+	limiter flowcontrol.RateLimiter
+	mutex   sync.Mutex
+	pending map[string]func()
+	queue   []string
+	wakeUp  chan struct{}
+}
+
+// NewAsyncWriter returns a new AsyncWriter running at most qps writes per
+// second, with bursts of up to burst writes. Run has to be called to process
+// the writes.
+func NewAsyncWriter(qps float32, burst int) *AsyncWriter {
+	return &AsyncWriter{
+		limiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+		pending: make(map[string]func()),
+		wakeUp:  make(chan struct{}, 1),
+	}
+}
+
+// Write schedules the write for the given key.
+func (w *AsyncWriter) Write(key string, write func()) {
+	w.mutex.Lock()
+	if _, found := w.pending[key]; !found {
+		w.queue = append(w.queue, key)
+	}
+	w.pending[key] = write
+	w.mutex.Unlock()
+	select {
+	case w.wakeUp <- struct{}{}:
+	default:
+	}
+}
+
+// Pending returns the number of writes which didn't run yet.
+func (w *AsyncWriter) Pending() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return len(w.pending)
+}
+
+// Run processes the writes in the order they were scheduled until stop is closed.
+func (w *AsyncWriter) Run(stop <-chan struct{}) {
+	for {
+		write, ok := w.pop()
+		if !ok {
+			select {
+			case <-stop:
+				return
+			case <-w.wakeUp:
+				continue
+			}
+		}
+		w.limiter.Accept()
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		write()
+	}
+}
+
+func (w *AsyncWriter) pop() (func(), bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.queue) == 0 {
+		return nil, false
+	}
+	key := w.queue[0]
+	w.queue = w.queue[1:]
+	write := w.pending[key]
+	delete(w.pending, key)
+	return write, true
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncWriter(t *testing.T) {
+	writer := NewAsyncWriter(1000, 1000)
+	var mutex sync.Mutex
+	var written []string
+	write := func(value string) func() {
+		return func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			written = append(written, value)
+		}
+	}
+
+	// Writes for the same key replace each other until they run.
+	writer.Write("a", write("a1"))
+	writer.Write("b", write("b1"))
+	writer.Write("a", write("a2"))
+	assert.Equal(t, 2, writer.Pending())
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go writer.Run(stop)
+	assert.Eventually(t, func() bool { return writer.Pending() == 0 }, time.Second, 10*time.Millisecond)
+	writer.Write("a", write("a3"))
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(written) == 3
+	}, time.Second, 10*time.Millisecond)
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, []string{"a2", "b1", "a3"}, written)
+}
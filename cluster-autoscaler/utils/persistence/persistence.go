@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package persistence keeps state of Cluster Autoscaler which should survive
+// restarts, either in a local file or in a ConfigMap.
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	apiv1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_client "k8s.io/client-go/kubernetes"
+)
+
+// MaxConfigMapDataSize is the most data a ConfigMapBlob accepts. ConfigMaps
+// are limited to 1MiB in total, some of which is taken by object metadata.
+const MaxConfigMapDataSize = 1000 * 1000
+
+// ErrTooLarge is returned when data doesn't fit in a Blob.
+var ErrTooLarge = errors.New("data too large")
+
+// Blob is a single piece of persisted data.
+type Blob interface {
+	// Read returns the saved data, or nil if nothing was saved yet.
+	Read() ([]byte, error)
+	// Write replaces the saved data.
+	Write(data []byte) error
+}
+
+// FileBlob keeps data in a local file, e.g. on a persistent volume.
+type FileBlob struct {
+	path string
+}
+
+// NewFileBlob returns a FileBlob keeping data in the file with the given path.
+func NewFileBlob(path string) *FileBlob {
+	return &FileBlob{path: path}
+}
+
+// Read returns the data saved in the file.
+func (b *FileBlob) Read() ([]byte, error) {
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", b.path, err)
+	}
+	return data, nil
+}
+
+// Write writes the data to the file. The file is replaced atomically, so a
+// crash while writing doesn't corrupt previously saved data.
+func (b *FileBlob) Write(data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", b.path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %v", b.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", b.path, err)
+	}
+	if err := os.Rename(tmp.Name(), b.path); err != nil {
+		return fmt.Errorf("failed to write %s: %v", b.path, err)
+	}
+	return nil
+}
+
+// ConfigMapBlob keeps data in a single entry of a ConfigMap.
+type ConfigMapBlob struct {
+	client    kube_client.Interface
+	namespace string
+	name      string
+	key       string
+}
+
+// NewConfigMapBlob returns a ConfigMapBlob keeping data under the given key of the given ConfigMap.
+func NewConfigMapBlob(client kube_client.Interface, namespace, name, key string) *ConfigMapBlob {
+	return &ConfigMapBlob{client: client, namespace: namespace, name: name, key: key}
+}
+
+// Read returns the data saved in the ConfigMap.
+func (b *ConfigMapBlob) Read() ([]byte, error) {
+	configMap, err := b.client.CoreV1().ConfigMaps(b.namespace).Get(context.TODO(), b.name, metav1.GetOptions{})
+	if kube_errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %v", b.namespace, b.name, err)
+	}
+	data, found := configMap.Data[b.key]
+	if !found {
+		return nil, nil
+	}
+	return []byte(data), nil
+}
+
+// Write writes the data to the ConfigMap, creating it if needed. Data larger
+// than MaxConfigMapDataSize is rejected.
+func (b *ConfigMapBlob) Write(data []byte) error {
+	if len(data) > MaxConfigMapDataSize {
+		return fmt.Errorf("failed to write ConfigMap %s/%s: %w: %d bytes exceed the limit of %d bytes", b.namespace, b.name, ErrTooLarge, len(data), MaxConfigMapDataSize)
+	}
+	configMaps := b.client.CoreV1().ConfigMaps(b.namespace)
+	configMap, err := configMaps.Get(context.TODO(), b.name, metav1.GetOptions{})
+	if kube_errors.IsNotFound(err) {
+		configMap = &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: b.namespace, Name: b.name},
+			Data:       map[string]string{b.key: string(data)},
+		}
+		if _, err := configMaps.Create(context.TODO(), configMap, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create ConfigMap %s/%s: %v", b.namespace, b.name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get ConfigMap %s/%s: %v", b.namespace, b.name, err)
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[b.key] = string(data)
+	if _, err := configMaps.Update(context.TODO(), configMap, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update ConfigMap %s/%s: %v", b.namespace, b.name, err)
+	}
+	return nil
+}
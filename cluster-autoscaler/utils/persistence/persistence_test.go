@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFileBlob(t *testing.T) {
+	dir := t.TempDir()
+	blob := NewFileBlob(filepath.Join(dir, "state.json"))
+
+	data, err := blob.Read()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	assert.NoError(t, blob.Write([]byte("first")))
+	assert.NoError(t, blob.Write([]byte("second")))
+	data, err = blob.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	// No temporary files are left behind.
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestConfigMapBlob(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	blob := NewConfigMapBlob(client, "kube-system", "state", "data")
+
+	data, err := blob.Read()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	// The first write creates the ConfigMap, the next one updates it.
+	assert.NoError(t, blob.Write([]byte("first")))
+	assert.NoError(t, blob.Write([]byte("second")))
+	data, err = blob.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	assert.ErrorIs(t, blob.Write([]byte(strings.Repeat("x", MaxConfigMapDataSize+1))), ErrTooLarge)
+	configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(context.TODO(), "state", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"data": "second"}, configMap.Data)
+
+	configMap.Data = nil
+	_, err = client.CoreV1().ConfigMaps("kube-system").Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	data, err = blob.Read()
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
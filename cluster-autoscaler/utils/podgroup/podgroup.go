@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podgroup
+
+import (
+	"strconv"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SchedulerPluginsLabel is the label assigning pods to a PodGroup of the
+	// scheduler-plugins coscheduling plugin.
+	SchedulerPluginsLabel = "scheduling.x-k8s.io/pod-group"
+	// DefaultAnnotation is the default annotation assigning pods to a pod group.
+	DefaultAnnotation = "cluster-autoscaler.kubernetes.io/pod-group"
+	// DefaultMinMemberAnnotation is the default annotation with the minimum
+	// number of pods of a pod group that have to be running at the same time.
+	DefaultMinMemberAnnotation = "cluster-autoscaler.kubernetes.io/pod-group-min-member"
+)
+
+// Detector identifies pod groups (gangs), i.e. sets of pods which are only
+// useful if all of them, or at least min-member of them, run at the same time.
+// A nil Detector doesn't detect any pod groups.
+type Detector struct {
+	annotation          string
+	minMemberAnnotation string
+}
+
+// NewDetector returns a Detector recognizing pod groups by the scheduler-plugins
+// label and a given annotation. The min-member of a pod group is read from
+// minMemberAnnotation only: PodGroup objects of scheduler-plugins aren't read,
+// so pods using the label need the annotation as well.
+func NewDetector(annotation, minMemberAnnotation string) *Detector {
+	return &Detector{annotation: annotation, minMemberAnnotation: minMemberAnnotation}
+}
+
+// PodGroup returns the namespaced name of the pod group of a pod, or an empty
+// string if the pod doesn't belong to any pod group.
+func (d *Detector) PodGroup(pod *apiv1.Pod) string {
+	if d == nil {
+		return ""
+	}
+	name := pod.Labels[SchedulerPluginsLabel]
+	if name == "" && d.annotation != "" {
+		name = pod.Annotations[d.annotation]
+	}
+	if name == "" {
+		return ""
+	}
+	return pod.Namespace + "/" + name
+}
+
+// MinMember returns the min-member of the pod group of a pod, or 0 if it isn't
+// set or invalid, in which case all pods of the pod group are required.
+func (d *Detector) MinMember(pod *apiv1.Pod) int {
+	if d == nil || d.minMemberAnnotation == "" {
+		return 0
+	}
+	minMember, err := strconv.Atoi(pod.Annotations[d.minMemberAnnotation])
+	if err != nil || minMember < 0 {
+		return 0
+	}
+	return minMember
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podgroup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestDetector(t *testing.T) {
+	detector := NewDetector(DefaultAnnotation, DefaultMinMemberAnnotation)
+
+	plain := BuildTestPod("plain", 100, 100)
+	assert.Equal(t, "", detector.PodGroup(plain))
+	assert.Equal(t, 0, detector.MinMember(plain))
+
+	labeled := BuildTestPod("labeled", 100, 100)
+	labeled.Namespace = "ns"
+	labeled.Labels = map[string]string{SchedulerPluginsLabel: "job"}
+	assert.Equal(t, "ns/job", detector.PodGroup(labeled))
+
+	annotated := BuildTestPod("annotated", 100, 100)
+	annotated.Namespace = "ns"
+	annotated.Annotations = map[string]string{DefaultAnnotation: "mpi", DefaultMinMemberAnnotation: "4"}
+	assert.Equal(t, "ns/mpi", detector.PodGroup(annotated))
+	assert.Equal(t, 4, detector.MinMember(annotated))
+
+	annotated.Annotations[DefaultMinMemberAnnotation] = "many"
+	assert.Equal(t, 0, detector.MinMember(annotated))
+
+	var disabled *Detector
+	assert.Equal(t, "", disabled.PodGroup(labeled))
+	assert.Equal(t, 0, disabled.MinMember(annotated))
+}
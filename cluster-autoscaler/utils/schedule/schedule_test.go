@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	ts, err := time.Parse(time.RFC3339, value)
+	assert.NoError(t, err)
+	return ts
+}
+
+func TestNext(t *testing.T) {
+	testCases := []struct {
+		spec string
+		from string
+		want string
+	}{
+		{spec: "* * * * *", from: "2025-01-01T10:00:30Z", want: "2025-01-01T10:01:00Z"},
+		{spec: "0 * * * *", from: "2025-01-01T10:00:00Z", want: "2025-01-01T11:00:00Z"},
+		{spec: "*/15 * * * *", from: "2025-01-01T10:16:00Z", want: "2025-01-01T10:30:00Z"},
+		{spec: "30 22 * * 1-5", from: "2025-01-03T23:00:00Z", want: "2025-01-06T22:30:00Z"},
+		{spec: "0 0 1 */3 *", from: "2025-02-10T00:00:00Z", want: "2025-04-01T00:00:00Z"},
+		{spec: "0 9 * jan,jul mon", from: "2025-01-01T00:00:00Z", want: "2025-01-06T09:00:00Z"},
+		{spec: "0 0 13 * 5", from: "2025-01-01T00:00:00Z", want: "2025-01-03T00:00:00Z"},
+		{spec: "0 0 * * 7", from: "2025-01-01T00:00:00Z", want: "2025-01-05T00:00:00Z"},
+		{spec: "@monthly", from: "2025-01-01T00:00:00Z", want: "2025-02-01T00:00:00Z"},
+		{spec: "CRON_TZ=Europe/Berlin 0 8 * * *", from: "2025-01-01T00:00:00Z", want: "2025-01-01T07:00:00Z"},
+		{spec: "0 0 30 2 *", from: "2025-01-01T00:00:00Z", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.spec, func(t *testing.T) {
+			s, err := Parse(tc.spec)
+			assert.NoError(t, err)
+			got := s.Next(mustParseTime(t, tc.from))
+			if tc.want == "" {
+				assert.True(t, got.IsZero())
+				return
+			}
+			assert.True(t, mustParseTime(t, tc.want).Equal(got), "want %s, got %s", tc.want, got)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"@never",
+		"CRON_TZ=Nowhere/Special * * * * *",
+	} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	w, err := ParseWindow("0 22 * * 1-5 8h")
+	assert.NoError(t, err)
+	assert.Equal(t, "0 22 * * 1-5 8h", w.String())
+
+	// 2025-01-06 is a Monday.
+	assert.False(t, w.Active(mustParseTime(t, "2025-01-06T21:59:59Z")))
+	assert.True(t, w.Active(mustParseTime(t, "2025-01-06T22:00:00Z")))
+	assert.True(t, w.Active(mustParseTime(t, "2025-01-07T05:59:59Z")))
+	assert.False(t, w.Active(mustParseTime(t, "2025-01-07T06:00:00Z")))
+	// Friday's window lasts until Saturday morning.
+	assert.True(t, w.Active(mustParseTime(t, "2025-01-11T03:00:00Z")))
+	assert.False(t, w.Active(mustParseTime(t, "2025-01-11T22:30:00Z")))
+
+	assert.Equal(t, mustParseTime(t, "2025-01-07T06:00:00Z"), w.End(mustParseTime(t, "2025-01-06T22:00:00Z")))
+	assert.Equal(t, mustParseTime(t, "2025-01-07T06:00:00Z"), w.End(mustParseTime(t, "2025-01-07T05:59:59Z")))
+	assert.True(t, w.End(mustParseTime(t, "2025-01-07T06:00:00Z")).IsZero())
+
+	_, err = ParseWindow("0 22 * * 1-5")
+	assert.Error(t, err)
+	_, err = ParseWindow("0 22 * * 1-5 -1h")
+	assert.Error(t, err)
+}
+
+func TestNewWindow(t *testing.T) {
+	w, err := NewWindow("0 22 * * 1-5", 8*time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, w.Active(mustParseTime(t, "2025-01-07T05:59:59Z")))
+	assert.False(t, w.Active(mustParseTime(t, "2025-01-07T06:00:00Z")))
+
+	_, err = NewWindow("0 22 * * 1-5", 0)
+	assert.Error(t, err)
+	_, err = NewWindow("0 22 * *", time.Hour)
+	assert.Error(t, err)
+}
+
+func TestAnyActive(t *testing.T) {
+	windows, err := ParseWindows([]string{"0 1 * * * 1h", "", "@weekly 24h"})
+	assert.NoError(t, err)
+	assert.Len(t, windows, 2)
+	// 2025-01-05 is a Sunday.
+	assert.True(t, AnyActive(windows, mustParseTime(t, "2025-01-05T12:00:00Z")))
+	assert.True(t, AnyActive(windows, mustParseTime(t, "2025-01-06T01:30:00Z")))
+	assert.False(t, AnyActive(windows, mustParseTime(t, "2025-01-06T12:00:00Z")))
+	assert.False(t, AnyActive(nil, mustParseTime(t, "2025-01-06T12:00:00Z")))
+
+	_, err = ParseWindows([]string{"0 1 * * * 1h", "bad"})
+	assert.Error(t, err)
+}
+
+func TestNextActive(t *testing.T) {
+	windows, err := ParseWindows([]string{"0 22 * * 1-5 8h", "0 12 * * 6 1h"})
+	assert.NoError(t, err)
+	// 2025-01-06 is a Monday.
+	now := mustParseTime(t, "2025-01-06T23:00:00Z")
+	assert.Equal(t, now, NextActive(windows, now))
+	assert.Equal(t, mustParseTime(t, "2025-01-07T22:00:00Z"), NextActive(windows, mustParseTime(t, "2025-01-07T06:00:00Z")))
+	// Friday's window is followed by Saturday's.
+	assert.Equal(t, mustParseTime(t, "2025-01-11T12:00:00Z"), NextActive(windows, mustParseTime(t, "2025-01-11T07:00:00Z")))
+	assert.True(t, NextActive(nil, now).IsZero())
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a recurring period of time, starting whenever its schedule
+// matches and lasting for a fixed duration. It's specified as a cron
+// expression followed by the duration, e.g. "0 22 * * 1-5 8h" for a window
+// from 22:00 to 06:00 starting on every weekday.
+type Window struct {
+	spec     string
+	schedule *Schedule
+	duration time.Duration
+}
+
+// ParseWindow parses a window specification.
+func ParseWindow(spec string) (Window, error) {
+	fields := strings.Fields(spec)
+	if len(fields) < 2 {
+		return Window{}, fmt.Errorf("invalid window %q: expected a cron expression followed by a duration", spec)
+	}
+	duration, err := time.ParseDuration(fields[len(fields)-1])
+	if err != nil || duration <= 0 {
+		return Window{}, fmt.Errorf("invalid window %q: invalid duration %q", spec, fields[len(fields)-1])
+	}
+	schedule, err := Parse(strings.Join(fields[:len(fields)-1], " "))
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid window %q: %v", spec, err)
+	}
+	return Window{spec: spec, schedule: schedule, duration: duration}, nil
+}
+
+// NewWindow creates a window opening whenever the cron expression matches,
+// for the given duration.
+func NewWindow(cron string, duration time.Duration) (Window, error) {
+	spec := fmt.Sprintf("%s %v", cron, duration)
+	if duration <= 0 {
+		return Window{}, fmt.Errorf("invalid window %q: duration must be positive", spec)
+	}
+	schedule, err := Parse(cron)
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid window %q: %v", spec, err)
+	}
+	return Window{spec: spec, schedule: schedule, duration: duration}, nil
+}
+
+// ParseWindows parses a list of window specifications.
+func ParseWindows(specs []string) ([]Window, error) {
+	var windows []Window
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		window, err := ParseWindow(spec)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// Active checks if the window is open at the given time.
+func (w Window) Active(t time.Time) bool {
+	start := w.schedule.Next(t.Add(-w.duration))
+	return !start.IsZero() && !start.After(t)
+}
+
+// End returns the time at which the window open at the given time closes, or
+// zero time if the window isn't open at that time.
+func (w Window) End(t time.Time) time.Time {
+	start := w.schedule.Next(t.Add(-w.duration))
+	if start.IsZero() || start.After(t) {
+		return time.Time{}
+	}
+	return start.Add(w.duration)
+}
+
+// String returns the window specification.
+func (w Window) String() string {
+	return w.spec
+}
+
+// AnyActive checks if any of the windows is open at the given time.
+func AnyActive(windows []Window, t time.Time) bool {
+	for _, w := range windows {
+		if w.Active(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStart returns the earliest time strictly after t at which the window
+// opens, or zero time if it doesn't open within the next five years.
+func (w Window) NextStart(t time.Time) time.Time {
+	return w.schedule.Next(t)
+}
+
+// NextActive returns the earliest time not before t at which any of the
+// windows is open, or zero time if none of them opens within the next five
+// years.
+func NextActive(windows []Window, t time.Time) time.Time {
+	if AnyActive(windows, t) {
+		return t
+	}
+	var next time.Time
+	for _, w := range windows {
+		if start := w.NextStart(t); !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return next
+}